@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/api"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/report"
+)
+
+// Ensure Bot can back the status/control API
+var _ api.Backend = (*Bot)(nil)
+
+// Running reports whether the bot is running
+func (b *Bot) Running() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.running
+}
+
+// Position returns the current position
+func (b *Bot) Position() *entity.Position {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.position
+}
+
+// RiskStatus returns the risk checker status
+func (b *Bot) RiskStatus() map[string]interface{} {
+	return b.risk.Status()
+}
+
+// StrategyState returns the strategy state if the strategy reports one
+func (b *Bot) StrategyState() map[string]interface{} {
+	if s, ok := b.strategy.(service.StatefulStrategy); ok {
+		return s.GetState()
+	}
+	return nil
+}
+
+// MarketSignal returns the latest market signal seen for the symbol, in any
+// format, or fetches one from the signal provider before the first arrives
+func (b *Bot) MarketSignal(ctx context.Context, symbol string) (*entity.MarketSignal, error) {
+	symbol = entity.Symbol(symbol).Normalize().String()
+	b.mu.RLock()
+	signal, ok := b.signals[symbol]
+	b.mu.RUnlock()
+	if ok {
+		return signal, nil
+	}
+	if b.signalProvider == nil {
+		return nil, api.ErrNoSignal
+	}
+
+	signal, err := b.signalProvider.GetMarketSignal(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch market signal: %w", err)
+	}
+	if signal == nil {
+		return nil, api.ErrNoSignal
+	}
+	return signal, nil
+}
+
+// Report builds the trade journal report from stored orders
+func (b *Bot) Report(ctx context.Context) (*report.Report, error) {
+	return report.FromRepository(ctx, b.orderRepo, report.Config{Fees: b.config.Fees})
+}
+
+// Halt stops trading
+func (b *Bot) Halt(reason string) {
+	b.risk.Halt(reason)
+}
+
+// Resume resumes trading and re-arms the drawdown kill-switch from current equity
+func (b *Bot) Resume() {
+	b.risk.Resume()
+	if b.drawdown != nil {
+		b.drawdown.Reset()
+	}
+}
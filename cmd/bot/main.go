@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
 	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/api"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/marketdata"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/metrics"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/notify"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/paper"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/persistence"
+	"github.com/zono819/hyperliquid-bot/internal/usecase"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/alert"
 	"github.com/zono819/hyperliquid-bot/internal/usecase/risk"
 	"github.com/zono819/hyperliquid-bot/internal/usecase/strategy"
 )
@@ -29,6 +45,10 @@ func main() {
 	configPath := flag.String("config", "config/config.yaml", "path to config file")
 	showVersion := flag.Bool("version", false, "show version")
 	dryRun := flag.Bool("dry-run", true, "run in dry-run mode (no real orders)")
+	showReport := flag.Bool("report", false, "print the trade report from stored orders and exit")
+	reportFormat := flag.String("report-format", "json", "report format: json or csv")
+	replayPath := flag.String("replay", "", "paper trade a recorded WebSocket stream (exchange.record_ws) instead of the live feed, then exit")
+	replaySpeed := flag.Float64("replay-speed", 0, "replay speed: 1 real time, 10 ten times faster, 0 without waiting")
 	flag.Parse()
 
 	if *showVersion {
@@ -47,9 +67,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Apply configured log level, format and output
+	logOutput, err := logger.OpenOutput(cfg.Log.Output, int64(cfg.Log.MaxSizeMB)<<20, cfg.Log.MaxBackups)
+	if err != nil {
+		log.Error("Failed to open log output: %v", err)
+		os.Exit(1)
+	}
+	defer logOutput.Close()
+	log = logger.NewWithFormat(logger.ParseLevel(cfg.Log.Level), logger.ParseFormat(cfg.Log.Format), logOutput)
+	logger.SetDefault(log)
+
+	if *showReport {
+		if err := writeReport(context.Background(), cfg, *reportFormat, os.Stdout); err != nil {
+			log.Error("Failed to write report: %v", err)
+			logOutput.Close()
+			os.Exit(1)
+		}
+		logOutput.Close()
+		os.Exit(0)
+	}
+
+	// Replays only ever paper trade
+	replay := replayOptions{path: *replayPath, speed: *replaySpeed}
+	if replay.path != "" {
+		if !*dryRun {
+			log.Error("Replay requires dry-run mode")
+			logOutput.Close()
+			os.Exit(1)
+		}
+		if replay.speed < 0 {
+			log.Error("Replay speed must be non-negative")
+			logOutput.Close()
+			os.Exit(1)
+		}
+		log.Info("Replaying %s at speed %g", replay.path, replay.speed)
+	}
+
 	// Override dry-run from flag
 	if *dryRun {
-		log.Info("Running in DRY-RUN mode - no real orders will be placed")
+		log.Info("Running in DRY-RUN mode - orders are paper traded against live prices")
 	} else {
 		log.Warn("Running in LIVE mode - real orders will be placed!")
 	}
@@ -58,54 +114,172 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Handle signals for graceful shutdown
+	// Handle signals for graceful shutdown; SIGUSR1 flattens all positions
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	flattenCh := make(chan os.Signal, 1)
+	signal.Notify(flattenCh, syscall.SIGUSR1)
 
 	go func() {
 		sig := <-sigCh
 		log.Info("Received signal: %v, initiating graceful shutdown...", sig)
 		cancel()
+
+		// A second signal skips the graceful shutdown
+		sig = <-sigCh
+		log.Warn("Received second signal: %v, flattening all positions", sig)
+		flattenCh <- sig
 	}()
 
 	// Run bot
-	if err := run(ctx, cfg, *dryRun, log); err != nil {
+	if err := run(ctx, cfg, *configPath, *dryRun, replay, flattenCh, log); err != nil {
 		log.Error("Bot error: %v", err)
+		logOutput.Close()
 		os.Exit(1)
 	}
 }
 
 // Bot represents the trading bot
 type Bot struct {
-	config   *config.Config
-	dryRun   bool
-	log      *logger.Logger
+	config *config.Config
+	dryRun bool
+	log    *logger.Logger
 
-	exchange *hyperliquid.HyperliquidExchange
-	strategy service.Strategy
-	risk     *risk.Checker
+	exchange       exchangeGateway
+	replayer       replayer // Re-emits a WebSocket recording through the exchange's subscriptions
+	strategy       service.Strategy
+	risk           *risk.Checker
+	orderRepo      repository.OrderRepository
+	notifiers      []notify.Notifier
+	discord        *notify.Discord
+	signalProvider gateway.MarketSignalProvider // nil unless the strategy consumes market signals
+	twap           *usecase.TWAPExecutor        // nil unless large orders are sliced
+	candles        *marketdata.Aggregator       // nil unless candles are built for the strategy
+	drawdown       *risk.DrawdownMonitor        // nil unless the drawdown kill-switch is enabled
+	blackout       *risk.EventBlackout          // nil unless event blackouts are enabled
+	events         eventSource                  // Scheduled events for the blackout
+	throttle       *tickThrottle                // nil unless strategy ticks are throttled
+	alerts         *alert.Engine                // nil unless alert rules are configured
 
-	mu       sync.RWMutex
-	running  bool
-	position *entity.Position
-	orders   []*entity.Order
+	flattenMu sync.Mutex // Serializes FlattenAll calls
+	candleMu  sync.Mutex // Hands candles to the strategy in order from ticks and flushes
+
+	mu          sync.RWMutex
+	running     bool
+	startEquity float64 // Account equity at start; drawdown is measured on it plus PnL
+	position    *entity.Position
+	openFees    float64 // Fees paid on the open position's entries, charged to trades as it closes
+	orders      *usecase.OrderManager
+	signals     map[string]*entity.MarketSignal // Latest market signal by symbol
+	books       map[string]*entity.OrderBook    // Latest order book by symbol
+	now         func() time.Time
+	retryDelay  time.Duration // Wait before retrying an order after a transient failure
+
+	rateLimitedUntil time.Time     // Orders are not placed before this after a rate limit
+	rateLimitBackoff time.Duration // Current pause, doubled on each consecutive rate limit
+
+	startedAt   time.Time // Feed staleness is measured from here until the first tick
+	feedStale   bool      // The watchdog has reported the feed stale
+	feedAlertAt time.Time // Last stale report, repeated once per threshold
+
+	// Written on every tick, so kept apart from mu to let ticks run under its read lock
+	tickMu   sync.Mutex
+	lastTick time.Time                       // Receive time of the latest tick
+	tickers  map[string]*entity.Ticker       // Latest streamed ticker by symbol
+	states   map[string]*service.MarketState // Market state reused across ticks, by symbol
+}
+
+// eventSource supplies scheduled high-impact economic events
+type eventSource interface {
+	UpcomingEvents() []*entity.EconomicEvent
+}
+
+// backgroundCollector is implemented by event sources that poll in the background
+type backgroundCollector interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// exchangeGateway is the exchange interface the bot trades through
+type exchangeGateway interface {
+	gateway.ExchangeGateway
+	gateway.AccountGateway
 }
 
-func run(ctx context.Context, cfg *config.Config, dryRun bool, log *logger.Logger) error {
+func run(ctx context.Context, cfg *config.Config, configPath string, dryRun bool, replay replayOptions, flatten <-chan os.Signal, log *logger.Logger) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	log.Info("Starting %s in %s mode", cfg.App.Name, cfg.App.Environment)
 	log.Info("Strategy: %s, Symbol: %s", cfg.Strategy.Name, cfg.Strategy.Symbol)
 
+	// Register configured assets before data source clients resolve symbols
+	entity.SetAssets(entity.DefaultAssetRegistry().With(cfg.DataSources.Assets, cfg.DataSources.ExchangeAliases))
+
 	// Create bot
-	bot, err := newBot(cfg, dryRun, log)
+	bot, err := newBot(cfg, dryRun, replay.path != "", log)
 	if err != nil {
 		return fmt.Errorf("failed to create bot: %w", err)
 	}
 
+	// Start metrics server
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		if err := metrics.RegisterRiskStatus(bot.risk.Status); err != nil {
+			return fmt.Errorf("failed to register risk metrics: %w", err)
+		}
+		metricsServer = startMetricsServer(cfg.Metrics.Port, log)
+	}
+
+	// Start status/control API server
+	var apiServer *api.Server
+	if cfg.API.Enabled {
+		apiServer = api.NewServer(bot, cfg.API.Port, cfg.API.Token, log)
+		apiServer.Start()
+	}
+
 	// Start bot
 	if err := bot.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start bot: %w", err)
 	}
 
+	// Feed the recording through the bot, then shut down
+	if replay.path != "" {
+		go func() {
+			defer cancel()
+			n, err := bot.Replay(ctx, replay)
+			if err != nil && ctx.Err() == nil {
+				log.Error("Replay failed after %d messages: %v", n, err)
+				return
+			}
+			log.Info("Replayed %d messages", n)
+		}()
+	}
+
+	// Flatten on SIGUSR1, or on a second shutdown signal and then exit at once
+	go func() {
+		for sig := range flatten {
+			flattenCtx, flattenCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := bot.FlattenAll(flattenCtx); err != nil {
+				log.Error("Flatten failed: %v", err)
+			}
+			flattenCancel()
+			if sig != syscall.SIGUSR1 {
+				log.Warn("Exiting without graceful shutdown")
+				os.Exit(1)
+			}
+		}
+	}()
+
+	// Watch config file for reloadable changes
+	if cfg.App.HotReload {
+		watcher := config.NewWatcher(configPath, cfg, log)
+		watcher.OnReload(bot.Reconfigure)
+		if err := watcher.Start(ctx); err != nil {
+			log.Warn("Config hot-reload disabled: %v", err)
+		}
+	}
+
 	// Wait for context cancellation
 	<-ctx.Done()
 
@@ -114,6 +288,17 @@ func run(ctx context.Context, cfg *config.Config, dryRun bool, log *logger.Logge
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			log.Error("Metrics server shutdown error: %v", err)
+		}
+	}
+	if apiServer != nil {
+		if err := apiServer.Shutdown(shutdownCtx); err != nil {
+			log.Error("API server shutdown error: %v", err)
+		}
+	}
+
 	if err := bot.Stop(shutdownCtx); err != nil {
 		log.Error("Shutdown error: %v", err)
 	}
@@ -122,37 +307,165 @@ func run(ctx context.Context, cfg *config.Config, dryRun bool, log *logger.Logge
 	return nil
 }
 
-func newBot(cfg *config.Config, dryRun bool, log *logger.Logger) (*Bot, error) {
+// startMetricsServer serves Prometheus metrics on /metrics
+func startMetricsServer(port int, log *logger.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Info("Metrics server listening on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("Metrics server error: %v", err)
+		}
+	}()
+
+	return srv
+}
+
+// newBot creates the bot; an offline bot takes its market data from Replay
+func newBot(cfg *config.Config, dryRun, offline bool, log *logger.Logger) (*Bot, error) {
 	// Create exchange gateway
 	exchangeCfg := &hyperliquid.ExchangeConfig{
-		BaseURL:   cfg.Exchange.BaseURL,
-		WSURL:     cfg.Exchange.WSURL,
-		APIKey:    cfg.Exchange.APIKey,
-		APISecret: cfg.Exchange.APISecret,
-		Testnet:   cfg.Exchange.Testnet,
+		BaseURL:      cfg.Exchange.BaseURL,
+		WSURL:        cfg.Exchange.WSURL,
+		APIKey:       cfg.Exchange.APIKey,
+		APISecret:    cfg.Exchange.APISecret,
+		Testnet:      cfg.Exchange.Testnet,
+		VaultAddress: cfg.Exchange.VaultAddress,
+		RecordPath:   cfg.Exchange.RecordWS,
+		Offline:      offline,
+		BalanceTTL:   cfg.Exchange.BalanceTTL,
+	}
+	market := hyperliquid.NewHyperliquidExchange(exchangeCfg, log)
+	var exchange exchangeGateway = market
+	if dryRun {
+		// Simulate fills against live market data
+		exchange = paper.NewExchange(exchange, paperConfig(cfg))
+		log.Info("Paper trading with balance %.2f", cfg.Paper.InitialBalance)
 	}
-	exchange := hyperliquid.NewHyperliquidExchange(exchangeCfg, log)
 
 	// Create strategy
-	strat := strategy.NewMeanReversionStrategy()
+	strat, err := strategy.NewFactory().Create(cfg.Strategy.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create strategy: %w", err)
+	}
+	if loggable, ok := strat.(service.LoggableStrategy); ok {
+		loggable.SetLogger(log.WithField("strategy", cfg.Strategy.Name))
+	}
 
 	// Create risk checker
-	riskCfg := &risk.Config{
-		MaxPositionSize:    cfg.Risk.MaxPositionSize,
-		MaxDailyLoss:       cfg.Risk.MaxDrawdown,
-		MaxConsecutiveLoss: 3,
-		CooldownDuration:   5 * time.Minute,
+	riskChecker := risk.NewChecker(riskConfig(cfg))
+	if sized, ok := strat.(service.SizedStrategy); ok {
+		sized.SetPositionSizer(riskChecker)
+	}
+
+	// Create order repository
+	var orderRepo repository.OrderRepository = persistence.NewMemoryOrderRepository()
+	if cfg.Storage.SQLitePath != "" {
+		sqliteRepo, err := persistence.NewSQLiteOrderRepository(cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open order store: %w", err)
+		}
+		orderRepo = sqliteRepo
+		log.Info("Storing order history in %s", cfg.Storage.SQLitePath)
+	}
+
+	// Create notifiers
+	var notifiers []notify.Notifier
+	if cfg.Notify.Telegram.Enabled {
+		notifiers = append(notifiers, notify.NewTelegram(cfg.Notify.Telegram.BotToken, cfg.Notify.Telegram.ChatID))
+	}
+	var discord *notify.Discord
+	if cfg.Notify.Discord.Enabled {
+		discord = notify.NewDiscord(cfg.Notify.Discord.WebhookURL)
+		notifiers = append(notifiers, discord)
+	}
+
+	// Create macro and market signal providers
+	macroProvider := newMacroProvider(cfg)
+	signalProvider := newSignalProvider(cfg, macroProvider, log)
+
+	// Alert on market signal conditions
+	var alerts *alert.Engine
+	if len(cfg.Alerts.Rules) > 0 {
+		if signalProvider == nil {
+			log.Warn("Alert rules need market signals (the ai_signal strategy, alone or in an ensemble, with a data source); disabled")
+		} else {
+			alerts = alert.NewEngine(cfg.Alerts.Rules, cfg.Alerts.Debounce)
+		}
+	}
+
+	// Kill-switch on drawdown from peak equity
+	var drawdown *risk.DrawdownMonitor
+	if cfg.Risk.MaxDrawdown > 0 {
+		drawdown = risk.NewDrawdownMonitor(cfg.Risk.MaxDrawdown)
 	}
-	riskChecker := risk.NewChecker(riskCfg)
 
-	return &Bot{
+	// Block entries around high-impact economic releases
+	var blackout *risk.EventBlackout
+	var events eventSource
+	if cfg.Risk.EventBlackoutBefore > 0 || cfg.Risk.EventBlackoutAfter > 0 {
+		if macroProvider == nil || !cfg.DataSources.TradingEconomics.Enabled {
+			log.Warn("Event blackout needs trading_economics enabled for the event calendar; disabled")
+		} else {
+			blackout = risk.NewEventBlackout(cfg.Risk.EventBlackoutBefore, cfg.Risk.EventBlackoutAfter)
+			events = macroProvider
+		}
+	}
+
+	// Build candles from ticks for strategies that trade on them
+	var candles *marketdata.Aggregator
+	if _, ok := strat.(service.CandleStrategy); ok && cfg.Strategy.CandleInterval > 0 {
+		candles = marketdata.NewAggregator(cfg.Strategy.CandleInterval)
+	}
+
+	bot := &Bot{
 		config:   cfg,
 		dryRun:   dryRun,
 		log:      log,
 		exchange: exchange,
+		replayer: market,
 		strategy: strat,
 		risk:     riskChecker,
-	}, nil
+
+		orderRepo: orderRepo,
+		notifiers: notifiers,
+		discord:   discord,
+		signals:   make(map[string]*entity.MarketSignal),
+		books:     make(map[string]*entity.OrderBook),
+		tickers:   make(map[string]*entity.Ticker),
+		orders:    usecase.NewOrderManager(),
+		now:       time.Now,
+
+		retryDelay: placeRetryDelay,
+
+		signalProvider: signalProvider,
+		candles:        candles,
+		drawdown:       drawdown,
+		blackout:       blackout,
+		events:         events,
+		throttle:       newTickThrottle(cfg.Strategy.TickInterval, cfg.Strategy.TickMinMoveBps),
+		alerts:         alerts,
+	}
+
+	// Slice large orders over time, pausing slices while orders are rate limited
+	if cfg.Orders.TWAPThreshold > 0 {
+		bot.twap = usecase.NewTWAPExecutor(rateLimitedGateway{ExchangeGateway: exchange, bot: bot}, cfg.Orders.TWAPSlices, cfg.Orders.TWAPDuration)
+	}
+
+	riskChecker.OnHalt(func(reason string) {
+		bot.notify(func(ctx context.Context, n notify.Notifier) error {
+			return notify.NotifyHalt(ctx, n, reason)
+		})
+	})
+
+	return bot, nil
 }
 
 // Start starts the bot
@@ -163,6 +476,7 @@ func (b *Bot) Start(ctx context.Context) error {
 		return fmt.Errorf("bot already running")
 	}
 	b.running = true
+	b.startedAt = b.now()
 	b.mu.Unlock()
 
 	// Initialize strategy
@@ -170,16 +484,81 @@ func (b *Bot) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to init strategy: %w", err)
 	}
 
+	// Start market signal collection
+	if err := b.startSignals(ctx); err != nil {
+		return err
+	}
+
+	// Collect the event calendar (a no-op if the signal provider already started it)
+	if collector, ok := b.events.(backgroundCollector); ok {
+		if err := collector.Start(ctx); err != nil {
+			b.log.Warn("Failed to start event calendar: %v", err)
+		}
+	}
+
 	// Connect to exchange
 	if err := b.exchange.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect exchange: %w", err)
 	}
 
+	// Read the starting equity for the daily loss limit, sizing and drawdown
+	equity, err := b.exchange.GetAccountValue(ctx)
+	if err != nil {
+		b.log.Warn("Failed to read account equity: %v", err)
+	} else {
+		b.risk.UpdateEquity(equity)
+	}
+
+	// Measure drawdown from the starting equity; without it the kill-switch
+	// cannot work, so refuse to trade unprotected
+	if b.drawdown != nil {
+		if err != nil {
+			return fmt.Errorf("failed to read starting equity for the drawdown kill-switch: %w", err)
+		}
+		b.mu.Lock()
+		b.startEquity = equity
+		b.mu.Unlock()
+		b.drawdown.Update(equity)
+	}
+
+	// Subscribe to order updates
+	if err := b.exchange.SubscribeOrders(ctx, b.onOrderUpdate); err != nil {
+		return fmt.Errorf("failed to subscribe orders: %w", err)
+	}
+
 	// Subscribe to market data
 	symbol := b.config.Strategy.Symbol
 	if err := b.exchange.SubscribeTicker(ctx, symbol, b.onTicker); err != nil {
 		return fmt.Errorf("failed to subscribe ticker: %w", err)
 	}
+	if err := b.exchange.SubscribeOrderBook(ctx, symbol, b.onOrderBook); err != nil {
+		return fmt.Errorf("failed to subscribe order book: %w", err)
+	}
+
+	// Cancel limit orders that rest too long
+	if timeout := b.config.Orders.Timeout; timeout > 0 {
+		go b.runOrderTimeouts(ctx, timeout)
+	}
+
+	// Correct the fill-tracked position against the exchange
+	if interval := b.config.Orders.ReconcileInterval; interval > 0 {
+		go b.runPositionReconcile(ctx, interval)
+	}
+
+	// Keep the equity used by risk limits and sizing current
+	if interval := b.config.Exchange.BalanceTTL; interval > 0 {
+		go b.runEquityRefresh(ctx, interval)
+	}
+
+	// Close candles on time when no tick arrives after an interval ends
+	if b.candles != nil {
+		go b.runCandleFlush(ctx)
+	}
+
+	// Watch for a silently dead market data feed
+	if staleAfter := b.config.Health.StaleAfter; staleAfter > 0 {
+		go b.runWatchdog(ctx, staleAfter)
+	}
 
 	b.log.Info("Bot started, subscribed to %s", symbol)
 	return nil
@@ -195,51 +574,155 @@ func (b *Bot) Stop(ctx context.Context) error {
 	b.running = false
 	b.mu.Unlock()
 
+	// Stop slicing TWAP orders
+	if b.twap != nil {
+		b.twap.Stop()
+	}
+
 	// Stop strategy
 	if err := b.strategy.Stop(ctx); err != nil {
 		b.log.Error("Failed to stop strategy: %v", err)
 	}
 
-	// Cancel all orders if not in dry-run
-	if !b.dryRun {
-		if err := b.exchange.CancelAllOrders(ctx, b.config.Strategy.Symbol); err != nil {
-			b.log.Error("Failed to cancel orders: %v", err)
+	// Stop market signal collection
+	if b.signalProvider != nil {
+		if err := b.signalProvider.Stop(ctx); err != nil {
+			b.log.Error("Failed to stop signal provider: %v", err)
 		}
 	}
+	if collector, ok := b.events.(backgroundCollector); ok {
+		collector.Stop(ctx)
+	}
+
+	// Cancel all open orders (simulated orders in dry-run)
+	if err := b.exchange.CancelAllOrders(ctx, b.config.Strategy.Symbol); err != nil {
+		b.log.Error("Failed to cancel orders: %v", err)
+	}
 
 	// Disconnect from exchange
 	if err := b.exchange.Disconnect(ctx); err != nil {
 		b.log.Error("Failed to disconnect: %v", err)
 	}
 
+	// Flush queued notifications
+	if b.discord != nil {
+		b.discord.Close()
+	}
+
+	// Close order store
+	if closer, ok := b.orderRepo.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			b.log.Error("Failed to close order store: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// FlattenAll halts trading, cancels resting orders and market-closes the
+// position with a reduce-only order. The halt keeps the running pipeline
+// from re-entering, so it is safe to call at any time.
+func (b *Bot) FlattenAll(ctx context.Context) error {
+	return b.flatten(ctx, "flatten all")
+}
+
+// flatten halts trading with reason, cancels all orders and closes the position
+func (b *Bot) flatten(ctx context.Context, reason string) error {
+	b.flattenMu.Lock()
+	defer b.flattenMu.Unlock()
+
+	b.log.Warn("Flattening all positions")
+	b.risk.Halt(reason)
+	return b.closeAll(ctx)
+}
+
+// closeAll cancels all orders and market-closes the position with a reduce-only order
+func (b *Bot) closeAll(ctx context.Context) error {
+	symbol := b.config.Strategy.Symbol
+	var errs []error
+	if err := b.exchange.CancelAllOrders(ctx, symbol); err != nil {
+		errs = append(errs, fmt.Errorf("cancel orders: %w", err))
+	}
+
+	position, err := b.exchange.GetPosition(ctx, symbol)
+	if err != nil {
+		b.log.Warn("Failed to fetch position, closing the tracked one: %v", err)
+		position = b.Position()
+	}
+	if position != nil {
+		if size := signedPositionSize(position); size != 0 {
+			side := entity.SideSell
+			if size < 0 {
+				side = entity.SideBuy
+			}
+			if _, err := b.exchange.PlaceOrder(ctx, &entity.Order{
+				Symbol:     symbol,
+				Side:       side,
+				Type:       entity.OrderTypeMarket,
+				Quantity:   math.Abs(size),
+				ReduceOnly: true,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("close position: %w", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // onTicker handles incoming ticker data - the main pipeline
 func (b *Bot) onTicker(ticker *entity.Ticker) {
 	b.mu.RLock()
-	if !b.running {
-		b.mu.RUnlock()
-		return
-	}
+	running := b.running
 	position := b.position
-	orders := b.orders
+	marketSignal := b.signals[ticker.Symbol]
+	orderBook := b.books[ticker.Symbol]
 	b.mu.RUnlock()
+	if !running {
+		return
+	}
+
+	b.tickMu.Lock()
+	b.lastTick = b.now()
+	b.tickers[ticker.Symbol] = ticker
+	b.tickMu.Unlock()
 
 	ctx := context.Background()
+	ticker = withBookQuotes(ticker, orderBook)
 
-	// === PIPELINE STEP 1: Market Data → Strategy ===
-	state := &service.MarketState{
-		Ticker:   ticker,
-		Position: position,
-		Orders:   orders,
+	positionSize := 0.0
+	if position != nil {
+		positionSize = position.Size
 	}
-
-	signals, err := b.strategy.OnTick(ctx, state)
-	if err != nil {
-		b.log.Error("Strategy error: %v", err)
+	metrics.PositionSize.WithLabelValues(ticker.Symbol).Set(positionSize)
+	b.risk.UpdatePrice(ticker.Symbol, ticker.LastPrice)
+	if b.checkDrawdown(ctx, position, ticker.LastPrice) {
 		return
 	}
+	b.checkBlackout(ctx, position)
+
+	// === PIPELINE STEP 1: Market Data → Strategy ===
+	// Throttled ticks skip OnTick but still build candles
+	var signals []*service.Signal
+	if b.throttle == nil || b.throttle.Allow(ticker.Symbol, ticker.LastPrice, b.now()) {
+		state := b.marketState(ticker.Symbol)
+		*state = service.MarketState{
+			Ticker:       ticker,
+			OrderBook:    orderBook,
+			Position:     position,
+			Orders:       b.orders.OpenOrders(),
+			MarketSignal: marketSignal,
+			Equity:       b.risk.Equity(),
+		}
+
+		var err error
+		signals, err = b.strategy.OnTick(ctx, state)
+		if err != nil {
+			b.log.Error("Strategy error: %v", err)
+			return
+		}
+	}
+	signals = append(signals, b.onCandles(ctx, ticker)...)
 
 	if len(signals) == 0 {
 		return
@@ -247,104 +730,927 @@ func (b *Bot) onTicker(ticker *entity.Ticker) {
 
 	// === PIPELINE STEP 2: Strategy Signal → Risk Check ===
 	for _, sig := range signals {
-		b.processSignal(ctx, sig)
+		b.processSignal(ctx, sig, ticker)
+	}
+}
+
+// marketState returns the market state reused for symbol's ticks. Ticks of a
+// symbol arrive sequentially from its subscription, so the state is never
+// filled while a strategy still reads it.
+func (b *Bot) marketState(symbol string) *service.MarketState {
+	b.tickMu.Lock()
+	defer b.tickMu.Unlock()
+	if b.states == nil {
+		b.states = make(map[string]*service.MarketState)
+	}
+	state, ok := b.states[symbol]
+	if !ok {
+		state = &service.MarketState{}
+		b.states[symbol] = state
+	}
+	return state
+}
+
+// checkDrawdown marks equity to price and trips the kill-switch when the
+// drawdown from peak exceeds MaxDrawdown: trading halts until resumed, all
+// orders are canceled and the position is closed. Reports whether it tripped.
+func (b *Bot) checkDrawdown(ctx context.Context, position *entity.Position, price float64) bool {
+	if b.drawdown == nil {
+		return false
+	}
+
+	b.mu.RLock()
+	equity := b.startEquity
+	b.mu.RUnlock()
+	if position != nil {
+		equity += position.RealizedPnL - position.Fees + position.Size*(price-position.EntryPrice)
+	}
+
+	drawdown, trip := b.drawdown.Update(equity)
+	if !trip {
+		return false
+	}
+
+	b.mu.RLock()
+	limit := b.config.Risk.MaxDrawdown
+	b.mu.RUnlock()
+	reason := fmt.Sprintf("max drawdown exceeded: %.2f%% from peak (limit %.2f%%)",
+		drawdown*100, limit*100)
+	b.log.Error("Kill-switch: %s", reason)
+	flattenCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := b.flatten(flattenCtx, reason); err != nil {
+		b.log.Error("Kill-switch flatten failed: %v", err)
+	}
+	b.notify(func(ctx context.Context, n notify.Notifier) error {
+		return n.Notify(ctx, notify.LevelError, "Kill-switch tripped", reason+"; orders canceled and position closed, resume manually")
+	})
+	return true
+}
+
+// checkBlackout refreshes the event calendar and, with flatten_before_events,
+// closes the position once when the blackout window of an event opens
+func (b *Bot) checkBlackout(ctx context.Context, position *entity.Position) {
+	if b.blackout == nil {
+		return
+	}
+	if b.events != nil {
+		b.blackout.SetEvents(b.events.UpcomingEvents())
+	}
+	b.mu.RLock()
+	flattenBeforeEvents := b.config.Risk.FlattenBeforeEvents
+	b.mu.RUnlock()
+	if !flattenBeforeEvents {
+		return
+	}
+	event := b.blackout.FlattenDue(b.now())
+	if event == nil || position == nil || position.Size == 0 {
+		return
+	}
+
+	b.log.Warn("Closing position ahead of %s at %s", event.Event, event.Date.Format(time.RFC3339))
+	flattenCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := b.closeAll(flattenCtx); err != nil {
+		b.log.Error("Pre-event flatten failed: %v", err)
+	}
+	b.notify(func(ctx context.Context, n notify.Notifier) error {
+		return n.Notify(ctx, notify.LevelWarn, "Position closed ahead of event", event.Event+" at "+event.Date.Format(time.RFC3339))
+	})
+}
+
+// opensPosition reports whether a signal opens or adds to the position
+func (b *Bot) opensPosition(sig *service.Signal) bool {
+	size := 0.0
+	if position := b.Position(); position != nil {
+		size = position.Size
+	}
+	return size == 0 || (size > 0) == (sig.Side == entity.SideBuy)
+}
+
+// onCandles feeds the ticker into the candle aggregator and hands each
+// closed candle to the strategy, returning the signals it produces
+func (b *Bot) onCandles(ctx context.Context, ticker *entity.Ticker) []*service.Signal {
+	if b.candles == nil {
+		return nil
+	}
+	b.candleMu.Lock()
+	defer b.candleMu.Unlock()
+	return b.candleSignals(ctx, b.candles.Add(ticker))
+}
+
+// runCandleFlush closes candles at each interval boundary until the context
+// is done or the bot stops, so a quiet feed still closes bars on time
+func (b *Bot) runCandleFlush(ctx context.Context) {
+	interval := b.candles.Interval()
+	for {
+		now := b.now()
+		timer := time.NewTimer(now.Truncate(interval).Add(interval).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if !b.Running() {
+				return
+			}
+			b.flushCandles(ctx)
+		}
+	}
+}
+
+// flushCandles hands candles whose interval ended to the strategy and runs
+// the resulting signals through the pipeline at the latest streamed price
+func (b *Bot) flushCandles(ctx context.Context) {
+	b.candleMu.Lock()
+	signals := b.candleSignals(ctx, b.candles.Flush(b.now()))
+	b.candleMu.Unlock()
+
+	for _, sig := range signals {
+		ticker, err := b.latestTicker(sig.Symbol)
+		if err != nil {
+			b.log.Warn("Dropping candle signal: %v", err)
+			continue
+		}
+		b.processSignal(ctx, sig, ticker)
+	}
+}
+
+// candleSignals hands closed candles to a candle strategy and returns the
+// signals it produces. b.candleMu must be held.
+func (b *Bot) candleSignals(ctx context.Context, candles []entity.Candle) []*service.Signal {
+	candleStrategy, ok := b.strategy.(service.CandleStrategy)
+	if !ok {
+		return nil
+	}
+
+	var signals []*service.Signal
+	for _, candle := range candles {
+		sigs, err := candleStrategy.OnCandle(ctx, candle)
+		if err != nil {
+			b.log.Error("Strategy candle error: %v", err)
+			continue
+		}
+		signals = append(signals, sigs...)
+	}
+	return signals
+}
+
+// withBookQuotes returns a copy of the ticker with bid/ask and sizes taken from
+// the order book top, since the mids feed only carries a single price
+func withBookQuotes(ticker *entity.Ticker, book *entity.OrderBook) *entity.Ticker {
+	if book == nil || len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return ticker
+	}
+	quoted := *ticker
+	quoted.BidPrice, quoted.BidSize = book.BestBid()
+	quoted.AskPrice, quoted.AskSize = book.BestAsk()
+	return &quoted
+}
+
+// onOrderBook caches the latest order book for the next tick
+func (b *Bot) onOrderBook(book *entity.OrderBook) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.books[book.Symbol] = book
+}
+
+// paperConfig builds the paper exchange configuration from app config
+func paperConfig(cfg *config.Config) paper.Config {
+	return paper.Config{
+		InitialBalance: cfg.Paper.InitialBalance,
+		SlippageBps:    cfg.Paper.SlippageBps,
+		Fees:           cfg.Fees,
+
+		Latency:           cfg.Paper.Latency,
+		SlippageJitterBps: cfg.Paper.SlippageJitterBps,
+		Seed:              cfg.Paper.Seed,
+	}
+}
+
+// riskConfig builds the risk checker configuration from app config
+func riskConfig(cfg *config.Config) *risk.Config {
+	return &risk.Config{
+		MaxPositionSize:    cfg.Risk.MaxPositionSize,
+		SymbolLimits:       cfg.Risk.SymbolLimits,
+		MaxLeverage:        cfg.Risk.MaxLeverage,
+		DailyResetHour:     cfg.Risk.DailyResetHour,
+		ResetStatsDaily:    cfg.Risk.ResetStatsDaily,
+		MaxSpreadBps:       cfg.Risk.MaxSpreadBps,
+		MinTopSize:         cfg.Risk.MinTopSize,
+		MaxPositions:       cfg.Risk.MaxPositions,
+		MaxDailyLossPct:    cfg.Risk.DailyLossLimit,
+		MaxConsecutiveLoss: 3,
+		CooldownDuration:   5 * time.Minute,
+	}
+}
+
+// Reconfigure applies the reloadable settings of a new config to the running bot
+func (b *Bot) Reconfigure(cfg *config.Config) {
+	b.risk.Reconfigure(riskConfig(cfg))
+	if b.drawdown != nil {
+		b.drawdown.SetMaxDrawdown(cfg.Risk.MaxDrawdown)
+	}
+	if b.blackout != nil {
+		b.blackout.SetWindow(cfg.Risk.EventBlackoutBefore, cfg.Risk.EventBlackoutAfter)
+	}
+
+	if reconfigurable, ok := b.strategy.(service.ReconfigurableStrategy); ok {
+		if err := reconfigurable.Reconfigure(context.Background(), cfg.Strategy.Params); err != nil {
+			b.log.Error("Failed to reconfigure strategy: %v", err)
+		}
 	}
+
+	// Only the reloadable sections change; the rest of the config is fixed
+	// for the life of the bot and read without the lock
+	b.mu.Lock()
+	b.config.Strategy.Params = cfg.Strategy.Params
+	b.config.Risk = cfg.Risk
+	b.config.Log = cfg.Log
+	b.mu.Unlock()
+
+	b.log.SetLevel(logger.ParseLevel(cfg.Log.Level))
+	b.log.Info("Applied reloaded config")
 }
 
 // processSignal processes a trading signal through risk check and execution
-func (b *Bot) processSignal(ctx context.Context, sig *service.Signal) {
+func (b *Bot) processSignal(ctx context.Context, sig *service.Signal, ticker *entity.Ticker) {
 	b.log.Info("Signal: %s %s @ %.2f x %.4f - %s",
 		sig.Side, sig.Symbol, sig.Price, sig.Quantity, sig.Reason)
+	metrics.SignalsGenerated.WithLabelValues(sig.Symbol, string(sig.Side)).Inc()
 
 	// Risk check: can we trade?
 	check := b.risk.CanTrade()
 	if !check.Allowed {
 		b.log.Warn("Risk check failed: %s", check.Reason)
+		metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectRisk).Inc()
+		b.notify(func(ctx context.Context, n notify.Notifier) error {
+			return n.Notify(ctx, notify.LevelWarn, "Risk check failed", check.Reason)
+		})
+		return
+	}
+
+	// Risk check: no new entries around high-impact events
+	if b.blackout != nil && b.opensPosition(sig) {
+		if event := b.blackout.Active(b.now()); event != nil {
+			b.log.Warn("Entry blocked by event blackout: %s at %s", event.Event, event.Date.Format(time.RFC3339))
+			metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectBlackout).Inc()
+			return
+		}
+	}
+
+	// Risk check: spread and top-of-book liquidity, for entries only so a
+	// thin market never blocks a stop-loss or exit
+	if b.opensPosition(sig) {
+		if liquidityCheck := b.risk.CheckLiquidity(ticker, sig.Side); !liquidityCheck.Allowed {
+			b.log.Warn("Liquidity check failed: %s", liquidityCheck.Reason)
+			metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectLiquidity).Inc()
+			return
+		}
+	}
+
+	// Exchange minimums: entries the exchange would reject are skipped or rounded up
+	if b.opensPosition(sig) && !b.meetsMinimums(ctx, sig) {
+		metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectMinimum).Inc()
 		return
 	}
 
 	// Risk check: position size
-	sizeCheck := b.risk.CheckPositionSize(sig.Quantity)
+	sizeCheck := b.risk.CheckSymbolPositionSize(sig.Symbol, sig.Side, sig.Quantity)
 	if !sizeCheck.Allowed {
 		b.log.Warn("Position size check failed: %s", sizeCheck.Reason)
+		metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectSize).Inc()
+		b.notify(func(ctx context.Context, n notify.Notifier) error {
+			return n.Notify(ctx, notify.LevelWarn, "Position size check failed", sizeCheck.Reason)
+		})
 		return
 	}
 
+	// Risk check: number of symbols in a position
+	positionsCheck := b.risk.CheckOpenPositions(sig.Symbol)
+	if !positionsCheck.Allowed {
+		b.log.Warn("Open positions check failed: %s", positionsCheck.Reason)
+		metrics.OrdersRejected.WithLabelValues(sig.Symbol, metrics.RejectPositions).Inc()
+		return
+	}
+
+	// Risk check: leverage across all open positions
+	equity, err := b.exchange.GetAccountValue(ctx)
+	if err == nil {
+		b.risk.UpdateEquity(equity)
+	}
+	if err != nil && b.dryRun {
+		b.log.Warn("[DRY-RUN] Skipping leverage check: %v", err)
+	} else {
+		// Orders that reduce exposure pass without equity; others are
+		// rejected rather than measured against an equity of zero
+		leverageCheck := b.risk.CheckExposure(sig.Symbol, sig.Side, sig.Quantity, sig.Price, equity)
+		title, reason := "Leverage check failed", metrics.RejectLeverage
+		if err != nil && !leverageCheck.Allowed {
+			leverageCheck.Reason = fmt.Sprintf("account equity unavailable: %v", err)
+			title, reason = "Equity unavailable", metrics.RejectEquity
+		}
+		if !leverageCheck.Allowed {
+			b.log.Warn("%s: %s", title, leverageCheck.Reason)
+			metrics.OrdersRejected.WithLabelValues(sig.Symbol, reason).Inc()
+			b.notify(func(ctx context.Context, n notify.Notifier) error {
+				return n.Notify(ctx, notify.LevelWarn, title, leverageCheck.Reason)
+			})
+			return
+		}
+	}
+
 	// === PIPELINE STEP 3: Risk Approved → Execute Order ===
 	b.executeOrder(ctx, sig)
 }
 
-// executeOrder executes an order (or simulates in dry-run mode)
+// executeOrder places an order on the exchange (the paper exchange in dry-run mode)
 func (b *Bot) executeOrder(ctx context.Context, sig *service.Signal) {
+	// Only a signal placed before can already be on the exchange
+	reused := sig.ClientOrderID != ""
+	if !reused {
+		sig.ClientOrderID = newClientOrderID()
+	}
 	order := &entity.Order{
-		Symbol:   sig.Symbol,
-		Side:     sig.Side,
-		Type:     entity.OrderTypeLimit,
-		Price:    sig.Price,
-		Quantity: sig.Quantity,
+		Symbol:        sig.Symbol,
+		Side:          sig.Side,
+		Type:          entity.OrderTypeLimit,
+		Price:         sig.Price,
+		Quantity:      sig.Quantity,
+		ReduceOnly:    sig.ReduceOnly,
+		ClientOrderID: sig.ClientOrderID,
+		CreatedAt:     time.Now(),
+	}
+	if sig.PostOnly {
+		order.TimeInForce = entity.TimeInForcePostOnly
+	}
+	if sig.Market {
+		order.Type = entity.OrderTypeMarket
+		order.TimeInForce = entity.TimeInForceIOC
+	}
+
+	if err := b.checkSlippage(order); err != nil {
+		b.log.Warn("Slippage check failed: %v", err)
+		metrics.OrdersRejected.WithLabelValues(order.Symbol, metrics.RejectSlippage).Inc()
+		return
 	}
 
+	// === Place order (simulated by the paper exchange in dry-run mode) ===
+	mode := "LIVE"
 	if b.dryRun {
-		// === DRY-RUN MODE: Simulate order ===
-		b.log.Info("[DRY-RUN] Would place order: %s %s @ %.2f x %.4f",
-			order.Side, order.Symbol, order.Price, order.Quantity)
-
-		// Simulate filled order notification
-		order.Status = entity.OrderStatusFilled
-		order.FilledQty = order.Quantity
-		b.strategy.OnOrderUpdate(ctx, order)
+		mode = "PAPER"
+	}
+	b.log.Info("[%s] Placing order: %s %s @ %.2f x %.4f",
+		mode, order.Side, order.Symbol, order.Price, order.Quantity)
+
+	if b.twap != nil && order.Quantity > b.config.Orders.TWAPThreshold {
+		go b.executeTWAP(ctx, order)
 		return
 	}
 
-	// === LIVE MODE: Place real order ===
-	b.log.Info("[LIVE] Placing order: %s %s @ %.2f x %.4f",
-		order.Side, order.Symbol, order.Price, order.Quantity)
+	if reused {
+		if existing := b.findPlaced(ctx, order); existing != nil {
+			b.log.Warn("Order %s already placed as %s, not placing again", order.ClientOrderID, existing.ID)
+			return
+		}
+	}
+
+	if wait := b.rateLimitWait(); wait > 0 {
+		b.log.Warn("Rate limited by the exchange, not placing orders for %s", wait.Round(time.Second))
+		metrics.OrdersRejected.WithLabelValues(order.Symbol, metrics.RejectRateLimit).Inc()
+		return
+	}
 
-	result, err := b.exchange.PlaceOrder(ctx, order)
+	result, err := b.placeOrder(ctx, order)
+	if errors.Is(err, hyperliquid.ErrRateLimited) {
+		b.log.Warn("Order rate limited, pausing orders for %s: %v", b.backOffRateLimit(), err)
+		metrics.OrdersRejected.WithLabelValues(order.Symbol, metrics.RejectRateLimit).Inc()
+		return
+	}
 	if err != nil {
 		b.log.Error("Failed to place order: %v", err)
-		b.risk.RecordTrade(-0.001) // Record as small loss for consecutive tracking
+		metrics.OrdersRejected.WithLabelValues(order.Symbol, metrics.RejectExchange).Inc()
+		b.risk.RecordFailure()
 		return
 	}
+	b.resetRateLimit()
 
 	b.log.Info("Order placed: ID=%s, Status=%s", result.ID, result.Status)
+	b.saveOrder(ctx, result)
+	metrics.OrdersPlaced.WithLabelValues(order.Symbol, string(order.Side)).Inc()
+	b.notify(func(ctx context.Context, n notify.Notifier) error {
+		return notify.NotifyOrder(ctx, n, result)
+	})
 }
 
-// onOrderUpdate handles order status updates
-func (b *Bot) onOrderUpdate(order *entity.Order) {
-	b.mu.Lock()
-	// Update orders list
-	found := false
-	for i, o := range b.orders {
-		if o.ID == order.ID {
-			b.orders[i] = order
-			found = true
-			break
+// Order placement retries and rate limit backoff
+const (
+	placeAttempts       = 2
+	placeRetryDelay     = 500 * time.Millisecond
+	minRateLimitBackoff = 5 * time.Second
+	maxRateLimitBackoff = 2 * time.Minute
+)
+
+// placeOrder places an order, retrying once after a transient failure under
+// the same client order ID. Orders the exchange rejected, rate limited or
+// refused to authenticate are not retried.
+func (b *Bot) placeOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	var err error
+	for attempt := 0; attempt < placeAttempts; attempt++ {
+		if attempt > 0 {
+			b.log.Warn("Failed to place order %s, retrying: %v", order.ClientOrderID, err)
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(b.retryDelay):
+			}
+		}
+
+		var result *entity.Order
+		if result, err = b.exchange.PlaceOrder(ctx, order); err == nil {
+			return result, nil
+		}
+		// A timed-out request may still have reached the exchange
+		if existing := b.findPlaced(ctx, order); existing != nil {
+			b.log.Warn("Order %s placed despite error: %v", order.ClientOrderID, err)
+			return existing, nil
+		}
+		if ctx.Err() != nil || !retryablePlaceError(err) {
+			return nil, err
 		}
 	}
-	if !found && order.Status == entity.OrderStatusOpen {
-		b.orders = append(b.orders, order)
+	return nil, err
+}
+
+// retryablePlaceError reports whether placing an order again may succeed
+func retryablePlaceError(err error) bool {
+	for _, class := range []error{hyperliquid.ErrInvalidOrder, hyperliquid.ErrInsufficientBalance, hyperliquid.ErrAuth, hyperliquid.ErrRateLimited} {
+		if errors.Is(err, class) {
+			return false
+		}
 	}
+	return true
+}
+
+// rateLimitWait returns how long order placement is still paused after a rate limit
+func (b *Bot) rateLimitWait() time.Duration {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.rateLimitedUntil.Sub(b.now())
+}
+
+// backOffRateLimit pauses order placement, doubling the pause on each
+// consecutive rate limit up to maxRateLimitBackoff, and returns the pause
+func (b *Bot) backOffRateLimit() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rateLimitBackoff = min(max(2*b.rateLimitBackoff, minRateLimitBackoff), maxRateLimitBackoff)
+	b.rateLimitedUntil = b.now().Add(b.rateLimitBackoff)
+	return b.rateLimitBackoff
+}
+
+// resetRateLimit clears the rate limit pause after an order is placed
+func (b *Bot) resetRateLimit() {
+	b.mu.Lock()
+	b.rateLimitBackoff = 0
 	b.mu.Unlock()
+}
+
+// rateLimitedGateway places orders under the bot's rate limit pause, for
+// orders placed outside executeOrder such as TWAP slices. An order waits out
+// the pause; a rate limited order extends it.
+type rateLimitedGateway struct {
+	gateway.ExchangeGateway
+	bot *Bot
+}
+
+// PlaceOrder places the order once the rate limit pause is over
+func (g rateLimitedGateway) PlaceOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	if wait := g.bot.rateLimitWait(); wait > 0 {
+		g.bot.log.Warn("Rate limited by the exchange, holding %s order for %s", order.Symbol, wait.Round(time.Second))
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	result, err := g.ExchangeGateway.PlaceOrder(ctx, order)
+	if errors.Is(err, hyperliquid.ErrRateLimited) {
+		g.bot.log.Warn("Order rate limited, pausing orders for %s: %v", g.bot.backOffRateLimit(), err)
+		metrics.OrdersRejected.WithLabelValues(order.Symbol, metrics.RejectRateLimit).Inc()
+	} else if err == nil {
+		g.bot.resetRateLimit()
+	}
+	return result, err
+}
+
+// newClientOrderID returns a random client order ID in Hyperliquid's cloid
+// format (0x followed by 16 hex-encoded bytes)
+func newClientOrderID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return "0x" + hex.EncodeToString(id[:])
+}
+
+// findPlaced returns the order already placed with the order's client order ID,
+// from the order history or the exchange's open orders, or nil if there is none
+func (b *Bot) findPlaced(ctx context.Context, order *entity.Order) *entity.Order {
+	if existing, err := b.orderRepo.GetByClientOrderID(ctx, order.ClientOrderID); err == nil {
+		return existing
+	}
+	open, err := b.exchange.GetOpenOrders(ctx, order.Symbol)
+	if err != nil {
+		b.log.Warn("Failed to check open orders for %s: %v", order.ClientOrderID, err)
+		return nil
+	}
+	for _, o := range open {
+		if o.ClientOrderID == order.ClientOrderID {
+			return o
+		}
+	}
+	return nil
+}
+
+// latestTicker returns the last streamed ticker of a symbol, quoted from its
+// latest order book when one is cached
+func (b *Bot) latestTicker(symbol string) (*entity.Ticker, error) {
+	b.tickMu.Lock()
+	ticker := b.tickers[symbol]
+	b.tickMu.Unlock()
+	if ticker == nil {
+		return nil, fmt.Errorf("no ticker for %s yet", symbol)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return withBookQuotes(ticker, b.books[symbol]), nil
+}
+
+// checkSlippage rejects a market or IOC order when the current best price on
+// its side deviates from the order's reference price by more than
+// MaxSlippageBps. Resting limit orders are not checked.
+func (b *Bot) checkSlippage(order *entity.Order) error {
+	maxBps := b.config.Orders.MaxSlippageBps
+	takes := order.Type == entity.OrderTypeMarket || order.TimeInForce == entity.TimeInForceIOC
+	if maxBps <= 0 || !takes || order.Price <= 0 {
+		return nil
+	}
+
+	ticker, err := b.latestTicker(order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	// Buys take the ask and sells the bid; fall back to the last price
+	best := ticker.LastPrice
+	if order.Side == entity.SideBuy && ticker.AskPrice > 0 {
+		best = ticker.AskPrice
+	} else if order.Side == entity.SideSell && ticker.BidPrice > 0 {
+		best = ticker.BidPrice
+	}
+	if best <= 0 {
+		return fmt.Errorf("no price for %s", order.Symbol)
+	}
+
+	deviation := math.Abs(best-order.Price) / order.Price * 10000
+	if deviation > maxBps {
+		return fmt.Errorf("%s %s best price %.2f is %.1f bps from %.2f (max %.1f)",
+			order.Side, order.Symbol, best, deviation, order.Price, maxBps)
+	}
+	return nil
+}
 
-	// Notify strategy
+// meetsMinimums checks a signal against the exchange's minimum order size and
+// notional. Below them it is skipped or, under orders.below_minimum: round_up,
+// raised to the minimum. Without limits the exchange has the final say.
+func (b *Bot) meetsMinimums(ctx context.Context, sig *service.Signal) bool {
+	limiter, ok := b.exchange.(gateway.LimitsGateway)
+	if !ok {
+		return true
+	}
+	limits, err := limiter.OrderLimits(ctx, sig.Symbol)
+	if err != nil {
+		b.log.Warn("Skipping minimum order check for %s: %v", sig.Symbol, err)
+		return true
+	}
+
+	reason := limits.Check(sig.Quantity, sig.Price)
+	if reason == "" {
+		return true
+	}
+	if b.config.Orders.BelowMinimum != config.BelowMinimumRoundUp {
+		b.log.Warn("Skipping %s %s below the exchange minimum: %s", sig.Side, sig.Symbol, reason)
+		return false
+	}
+	quantity := limits.MinQuantity(sig.Price)
+	b.log.Info("Rounding %s %s up from %g to the exchange minimum %g: %s", sig.Side, sig.Symbol, sig.Quantity, quantity, reason)
+	sig.Quantity = quantity
+	return true
+}
+
+// executeTWAP places a large order as TWAP slices; the executor stops when the bot stops
+func (b *Bot) executeTWAP(ctx context.Context, order *entity.Order) {
+	b.log.Info("Slicing %s %s x %.4f into %d orders over %s",
+		order.Side, order.Symbol, order.Quantity, b.config.Orders.TWAPSlices, b.config.Orders.TWAPDuration)
+
+	result, err := b.twap.Execute(ctx, order)
+	for range result.Orders {
+		metrics.OrdersPlaced.WithLabelValues(order.Symbol, string(order.Side)).Inc()
+	}
+	if err != nil {
+		b.log.Warn("TWAP %s %s stopped with %.4f of %.4f filled: %v",
+			order.Side, order.Symbol, result.Filled, result.Target, err)
+		return
+	}
+	b.log.Info("TWAP %s %s done: %.4f of %.4f filled @ %.2f",
+		order.Side, order.Symbol, result.Filled, result.Target, result.AvgPrice)
+}
+
+// notify sends a notification to every configured notifier in the background
+// so the pipeline is never blocked
+func (b *Bot) notify(send func(ctx context.Context, n notify.Notifier) error) {
+	for _, n := range b.notifiers {
+		go func(n notify.Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := send(ctx, n); err != nil {
+				b.log.Warn("Failed to send notification: %v", err)
+			}
+		}(n)
+	}
+}
+
+// saveOrder stores the latest state of an order in the repository
+func (b *Bot) saveOrder(ctx context.Context, order *entity.Order) {
+	err := b.orderRepo.Update(ctx, order)
+	if errors.Is(err, repository.ErrNotFound) {
+		err = b.orderRepo.Create(ctx, order)
+	}
+	if err != nil {
+		b.log.Warn("Failed to store order %s: %v", order.ID, err)
+	}
+}
+
+// onOrderUpdate handles order status updates
+func (b *Bot) onOrderUpdate(order *entity.Order) {
+	// Skip duplicate and late updates so fills are only counted once
+	filled, price, ok := b.orders.UpdateFill(order, b.now())
+	if !ok {
+		return
+	}
+
+	// Persist and notify strategy
 	ctx := context.Background()
+	b.saveOrder(ctx, order)
 	b.strategy.OnOrderUpdate(ctx, order)
 
-	// Track PnL for risk management
+	// Partial fills arrive as open updates with a growing FilledQty
+	if filled > 0 {
+		b.applyFill(ctx, order, filled, price)
+	}
+
 	if order.Status == entity.OrderStatusFilled {
-		// Calculate PnL if this closes a position
-		b.mu.RLock()
-		pos := b.position
-		b.mu.RUnlock()
-
-		if pos != nil && pos.Size > 0 {
-			pnl := (order.Price - pos.EntryPrice) * order.FilledQty
-			if pos.Side == entity.SideSell {
-				pnl = -pnl
+		metrics.OrdersFilled.WithLabelValues(order.Symbol, string(order.Side)).Inc()
+		b.notify(func(ctx context.Context, n notify.Notifier) error {
+			return notify.NotifyFill(ctx, n, order)
+		})
+	}
+}
+
+// applyFill nets qty filled at price into the position, recording PnL net of
+// fees if it reduces it. A closing trade is charged its share of the fill's
+// fee and of the fees paid on the entries it closes.
+func (b *Bot) applyFill(ctx context.Context, order *entity.Order, qty, price float64) {
+	b.risk.RecordFill(order.Symbol, order.Side, qty)
+	fee := b.fillFee(order, qty, price)
+
+	b.mu.Lock()
+	prev := entity.Position{Symbol: order.Symbol}
+	if b.position != nil {
+		prev = *b.position
+	}
+	position := prev
+	position.ApplyFill(order.Side, qty, price)
+	position.Fees += fee
+	position.UpdatedAt = b.now()
+	b.position = &position
+
+	closed, entryFees := 0.0, 0.0
+	if prev.Size != 0 && prev.Side != order.Side {
+		closed = math.Min(qty, math.Abs(prev.Size))
+		entryFees = b.openFees * closed / math.Abs(prev.Size)
+		b.openFees -= entryFees
+	}
+	b.openFees += fee * (qty - closed) / qty
+	b.mu.Unlock()
+
+	if closed > 0 {
+		pnl := entity.GrossPnL(prev.Side, prev.EntryPrice, price, closed) - entryFees - fee*closed/qty
+		b.risk.RecordTrade(pnl)
+		b.log.Info("Trade closed: PnL=%.4f", pnl)
+	}
+
+	b.updateStrategyPosition(ctx, position)
+}
+
+// fillFee returns the fee of qty filled on order: its share of the fee the
+// exchange reported, or the fee model's estimate when none was
+func (b *Bot) fillFee(order *entity.Order, qty, price float64) float64 {
+	if order.Fee != 0 && order.FilledQty > 0 {
+		return order.Fee * qty / order.FilledQty
+	}
+	return b.config.Fees.Fee(price, qty, entity.OrderLiquidity(order))
+}
+
+// updateStrategyPosition passes a copy of the position to the strategy,
+// resetting its per-trade state when the position is flat
+func (b *Bot) updateStrategyPosition(ctx context.Context, position entity.Position) {
+	if err := b.strategy.OnPositionUpdate(ctx, &position); err != nil {
+		b.log.Error("Strategy position update error: %v", err)
+	}
+	if position.Size != 0 {
+		return
+	}
+	if err := b.strategy.Reset(ctx); err != nil {
+		b.log.Error("Strategy reset error: %v", err)
+	}
+}
+
+// runEquityRefresh re-reads the account equity for the risk checker until ctx is done
+func (b *Bot) runEquityRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !b.Running() {
+				return
+			}
+			equity, err := b.exchange.GetAccountValue(ctx)
+			if err != nil {
+				b.log.Warn("Failed to refresh account equity: %v", err)
+				continue
 			}
-			b.risk.RecordTrade(pnl)
-			b.log.Info("Trade closed: PnL=%.4f", pnl)
+			b.risk.UpdateEquity(equity)
 		}
 	}
 }
+
+// runPositionReconcile periodically reconciles the position until the context is done or the bot stops
+func (b *Bot) runPositionReconcile(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !b.Running() {
+				return
+			}
+			b.reconcilePosition(ctx)
+		}
+	}
+}
+
+// reconcilePosition overwrites the fill-tracked position with the exchange's
+// when they disagree, e.g. after missed order updates or a reconnect
+func (b *Bot) reconcilePosition(ctx context.Context) {
+	symbol := b.config.Strategy.Symbol
+	remote, err := b.exchange.GetPosition(ctx, symbol)
+	if err != nil {
+		b.log.Warn("Failed to reconcile position: %v", err)
+		return
+	}
+
+	actual := entity.Position{Symbol: symbol}
+	if remote != nil {
+		actual = *remote
+		actual.Size = signedPositionSize(remote)
+	}
+
+	b.mu.Lock()
+	local := entity.Position{Symbol: symbol}
+	if b.position != nil {
+		local = *b.position
+	}
+	if math.Abs(local.Size-actual.Size) < positionTolerance {
+		b.mu.Unlock()
+		return
+	}
+	// Keep the PnL realized and fees paid from fills; the exchange owns size and entry
+	actual.RealizedPnL = local.RealizedPnL
+	actual.Fees = local.Fees
+	actual.UpdatedAt = b.now()
+	b.position = &actual
+	if actual.Size == 0 {
+		b.openFees = 0
+	}
+	b.mu.Unlock()
+
+	b.log.Warn("Position mismatch for %s: tracked %.6f, exchange %.6f; using exchange", symbol, local.Size, actual.Size)
+	b.risk.SetPosition(symbol, actual.Size)
+	b.updateStrategyPosition(ctx, actual)
+}
+
+// positionTolerance is the size difference treated as equal when reconciling
+const positionTolerance = 1e-9
+
+// signedPositionSize returns the position size as positive for longs and
+// negative for shorts, whichever convention the exchange reports in
+func signedPositionSize(p *entity.Position) float64 {
+	if p.Side == entity.SideSell && p.Size > 0 {
+		return -p.Size
+	}
+	return p.Size
+}
+
+// runOrderTimeouts runs the stale order check until the context is done or the bot stops
+func (b *Bot) runOrderTimeouts(ctx context.Context, timeout time.Duration) {
+	interval := timeout / 2
+	if interval > 5*time.Second {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !b.Running() {
+				return
+			}
+			b.cancelStaleOrders(ctx)
+		}
+	}
+}
+
+// cancelStaleOrders cancels orders open longer than the configured timeout and,
+// when repricing is enabled, re-places the unfilled remainder at the current price
+func (b *Bot) cancelStaleOrders(ctx context.Context) {
+	timeout := b.config.Orders.Timeout
+	if timeout <= 0 {
+		return
+	}
+
+	now := b.now()
+	for _, order := range b.orders.Stale(now, timeout) {
+		b.log.Info("Canceling stale order %s (open longer than %s)", order.ID, timeout)
+		if err := b.exchange.CancelOrder(ctx, order.ID); err != nil {
+			b.log.Error("Failed to cancel stale order %s: %v", order.ID, err)
+			continue
+		}
+
+		// The exchange may not echo the cancel; make sure the strategy hears about it
+		if b.orders.IsOpen(order.ID) {
+			canceled := *order
+			canceled.Status = entity.OrderStatusCanceled
+			canceled.UpdatedAt = now
+			b.onOrderUpdate(&canceled)
+		}
+
+		if b.config.Orders.Reprice {
+			b.repriceOrder(ctx, order)
+		}
+	}
+}
+
+// repriceOrder re-submits the unfilled remainder of a canceled order at the current price
+func (b *Bot) repriceOrder(ctx context.Context, order *entity.Order) {
+	remaining := order.Quantity - order.FilledQty
+	if remaining <= 0 {
+		return
+	}
+	ticker, err := b.latestTicker(order.Symbol)
+	if err != nil {
+		b.log.Error("Failed to reprice order %s: %v", order.ID, err)
+		return
+	}
+
+	// Rest at the near touch when quotes are known, otherwise at the last price
+	price := ticker.LastPrice
+	if order.Side == entity.SideBuy && ticker.BidPrice > 0 {
+		price = ticker.BidPrice
+	} else if order.Side == entity.SideSell && ticker.AskPrice > 0 {
+		price = ticker.AskPrice
+	}
+
+	b.processSignal(ctx, &service.Signal{
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		Price:      price,
+		Quantity:   remaining,
+		Reason:     fmt.Sprintf("Reprice stale order %s", order.ID),
+		PostOnly:   order.TimeInForce == entity.TimeInForcePostOnly,
+		ReduceOnly: order.ReduceOnly,
+	}, ticker)
+}
@@ -0,0 +1,979 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/marketdata"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/paper"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/persistence"
+	"github.com/zono819/hyperliquid-bot/internal/usecase"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/risk"
+)
+
+// fakeMarket is a live market data source that lets tests push prices
+type fakeMarket struct {
+	gateway.ExchangeGateway
+	last     map[string]float64
+	handlers []func(*entity.Ticker)
+	limits   entity.OrderLimits
+}
+
+func (m *fakeMarket) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (m *fakeMarket) OrderLimits(ctx context.Context, symbol string) (entity.OrderLimits, error) {
+	return m.limits, nil
+}
+
+func (m *fakeMarket) SubscribeTicker(ctx context.Context, symbol string, handler func(*entity.Ticker)) error {
+	m.handlers = append(m.handlers, handler)
+	return nil
+}
+
+func (m *fakeMarket) GetTicker(ctx context.Context, symbol string) (*entity.Ticker, error) {
+	return &entity.Ticker{Symbol: symbol, LastPrice: m.last[symbol]}, nil
+}
+
+func (m *fakeMarket) tick(symbol string, price float64) {
+	m.last[symbol] = price
+	for _, h := range m.handlers {
+		h(&entity.Ticker{Symbol: symbol, LastPrice: price})
+	}
+}
+
+// newPaperBot builds a running bot trading on a paper exchange over a fake market
+func newPaperBot(t *testing.T, cfg *config.Config) (*Bot, *fakeMarket, *recordingStrategy) {
+	t.Helper()
+	market := &fakeMarket{last: make(map[string]float64)}
+	exchange := paper.NewExchange(market, paper.Config{InitialBalance: 100000})
+	strat := &recordingStrategy{}
+	bot := &Bot{
+		config:    cfg,
+		dryRun:    true,
+		log:       logger.New(logger.LevelError, nil),
+		exchange:  exchange,
+		strategy:  strat,
+		risk:      risk.NewChecker(risk.DefaultConfig()),
+		orderRepo: persistence.NewMemoryOrderRepository(),
+		signals:   make(map[string]*entity.MarketSignal),
+		books:     make(map[string]*entity.OrderBook),
+		tickers:   make(map[string]*entity.Ticker),
+		orders:    usecase.NewOrderManager(),
+		now:       time.Now,
+		running:   true,
+	}
+
+	ctx := context.Background()
+	exchange.SubscribeOrders(ctx, bot.onOrderUpdate)
+	// Cache streamed tickers as onTicker does, without running the pipeline
+	exchange.SubscribeTicker(ctx, "BTC", func(ticker *entity.Ticker) {
+		bot.tickMu.Lock()
+		bot.tickers[ticker.Symbol] = ticker
+		bot.tickMu.Unlock()
+	})
+	return bot, market, strat
+}
+
+func TestBot_InjectsLatestOrderBook(t *testing.T) {
+	strat := &recordingStrategy{}
+	bot := &Bot{
+		config:   &config.Config{},
+		log:      logger.New(logger.LevelError, nil),
+		strategy: strat,
+		risk:     risk.NewChecker(risk.DefaultConfig()),
+		orders:   usecase.NewOrderManager(),
+		signals:  make(map[string]*entity.MarketSignal),
+		books:    make(map[string]*entity.OrderBook),
+		tickers:  make(map[string]*entity.Ticker),
+		now:      time.Now,
+		running:  true,
+	}
+
+	bot.onOrderBook(&entity.OrderBook{Symbol: "BTC"})
+	latest := &entity.OrderBook{
+		Symbol: "BTC",
+		Bids:   []entity.OrderBookLevel{{Price: 49999, Size: 2}},
+		Asks:   []entity.OrderBookLevel{{Price: 50001, Size: 3}},
+	}
+	bot.onOrderBook(latest)
+	bot.onOrderBook(&entity.OrderBook{Symbol: "ETH"})
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+
+	if len(strat.states) != 1 || strat.states[0].OrderBook != latest {
+		t.Fatalf("Expected latest BTC order book in market state, got %+v", strat.states)
+	}
+	// The mids feed carries no quotes, so bid/ask come from the book
+	ticker := strat.states[0].Ticker
+	if ticker.BidPrice != 49999 || ticker.AskPrice != 50001 || ticker.BidSize != 2 || ticker.AskSize != 3 {
+		t.Errorf("Expected ticker quotes from the book top, got %+v", ticker)
+	}
+}
+
+func TestBot_CancelsStaleOrders(t *testing.T) {
+	cfg := &config.Config{Orders: config.OrdersConfig{Timeout: time.Minute}}
+	bot, market, strat := newPaperBot(t, cfg)
+	ctx := context.Background()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bot.now = func() time.Time { return start }
+	market.tick("BTC", 50000)
+
+	old, err := bot.exchange.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 49000, Quantity: 0.1,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder failed: %v", err)
+	}
+	bot.now = func() time.Time { return start.Add(50 * time.Second) }
+	fresh, _ := bot.exchange.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 48000, Quantity: 0.1,
+	})
+
+	bot.now = func() time.Time { return start.Add(61 * time.Second) }
+	bot.cancelStaleOrders(ctx)
+
+	if got, _ := bot.exchange.GetOrder(ctx, old.ID); got.Status != entity.OrderStatusCanceled {
+		t.Errorf("Expected order older than the timeout to be canceled, got %s", got.Status)
+	}
+	if got, _ := bot.exchange.GetOrder(ctx, fresh.ID); got.Status != entity.OrderStatusOpen {
+		t.Errorf("Expected order within the timeout to stay open, got %s", got.Status)
+	}
+
+	last := strat.orders[len(strat.orders)-1]
+	if last.ID != old.ID || last.Status != entity.OrderStatusCanceled {
+		t.Errorf("Expected strategy to see the cancel, got %+v", last)
+	}
+	if open, _ := bot.exchange.GetOpenOrders(ctx, "BTC"); len(open) != 1 {
+		t.Errorf("Expected no reprice without reprice enabled, got %d open orders", len(open))
+	}
+}
+
+func TestBot_RepricesStaleOrders(t *testing.T) {
+	cfg := &config.Config{Orders: config.OrdersConfig{Timeout: time.Minute, Reprice: true}}
+	bot, market, _ := newPaperBot(t, cfg)
+	ctx := context.Background()
+
+	start := time.Now()
+	bot.now = func() time.Time { return start }
+	market.tick("BTC", 50000)
+	stale, _ := bot.exchange.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeLimit, Price: 51000, Quantity: 0.1,
+		TimeInForce: entity.TimeInForcePostOnly,
+	})
+
+	market.tick("BTC", 49500)
+	bot.onOrderBook(&entity.OrderBook{
+		Symbol: "BTC",
+		Bids:   []entity.OrderBookLevel{{Price: 49499, Size: 1}},
+		Asks:   []entity.OrderBookLevel{{Price: 49501, Size: 1}},
+	})
+	bot.now = func() time.Time { return start.Add(2 * time.Minute) }
+	bot.cancelStaleOrders(ctx)
+
+	open, _ := bot.exchange.GetOpenOrders(ctx, "BTC")
+	if len(open) != 1 || open[0].ID == stale.ID {
+		t.Fatalf("Expected stale order replaced by a new one, got %+v", open)
+	}
+	if open[0].Price != 49501 || open[0].Quantity != 0.1 || open[0].TimeInForce != entity.TimeInForcePostOnly {
+		t.Errorf("Expected post-only reprice at the current ask, got %+v", open[0])
+	}
+}
+
+func TestBot_FillsUpdatePosition(t *testing.T) {
+	bot, market, strat := newPaperBot(t, &config.Config{})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	bot.exchange.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.2})
+
+	pos := bot.Position()
+	if pos == nil || pos.Size != 0.2 || pos.EntryPrice != 50000 || pos.Side != entity.SideBuy {
+		t.Fatalf("Expected 0.2 long at 50000 after buy fill, got %+v", pos)
+	}
+	if strat.resets != 0 {
+		t.Errorf("Expected no strategy reset while the position is open, got %d", strat.resets)
+	}
+
+	market.tick("BTC", 51000)
+	bot.exchange.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.2})
+	if pos := bot.Position(); pos.Size != 0 || pos.RealizedPnL != 200 {
+		t.Errorf("Expected flat position with 200 realized, got %+v", pos)
+	}
+	if strat.resets != 1 {
+		t.Errorf("Expected the strategy reset once the position went flat, got %d", strat.resets)
+	}
+}
+
+func TestBot_PartialFillLeavesResidualPosition(t *testing.T) {
+	bot, _, _ := newPaperBot(t, &config.Config{})
+	strat := &positionRecorder{recordingStrategy: &recordingStrategy{}}
+	bot.strategy = strat
+
+	bot.onOrderUpdate(&entity.Order{ID: "buy", Symbol: "BTC", Side: entity.SideBuy, Price: 50000,
+		Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled})
+
+	// The sell fills in two steps; only the new quantity is applied each time
+	sell := func(filled float64, status entity.OrderStatus) {
+		bot.onOrderUpdate(&entity.Order{ID: "sell", Symbol: "BTC", Side: entity.SideSell, Price: 51000,
+			Quantity: 0.6, FilledQty: filled, Status: status})
+	}
+	sell(0, entity.OrderStatusOpen)
+	sell(0.4, entity.OrderStatusOpen)
+	sell(0.4, entity.OrderStatusOpen) // duplicate
+
+	pos := bot.Position()
+	if !approxEqual(pos.Size, 0.6) || pos.EntryPrice != 50000 || pos.Side != entity.SideBuy {
+		t.Fatalf("Expected 0.6 long at 50000 after partial sell, got %+v", pos)
+	}
+	if !approxEqual(pos.RealizedPnL, 400) {
+		t.Errorf("Expected 400 realized on 0.4 sold, got %f", pos.RealizedPnL)
+	}
+	if got := bot.risk.Status()["daily_pnl"]; !approxEqual(got.(float64), 400) {
+		t.Errorf("Expected risk daily PnL 400, got %v", got)
+	}
+
+	sell(0.5, entity.OrderStatusCanceled)
+	if pos := bot.Position(); !approxEqual(pos.Size, 0.5) || !approxEqual(pos.RealizedPnL, 500) {
+		t.Errorf("Expected 0.5 long with 500 realized after fill before cancel, got %+v", pos)
+	}
+
+	if len(strat.positions) != 3 {
+		t.Fatalf("Expected a strategy position update per fill, got %d", len(strat.positions))
+	}
+	if last := strat.positions[2]; !approxEqual(last.Size, 0.5) {
+		t.Errorf("Expected strategy to see 0.5 long, got %+v", last)
+	}
+}
+
+func TestBot_TradePnLNetOfFees(t *testing.T) {
+	t.Run("reported by the exchange", func(t *testing.T) {
+		bot, _, _ := newPaperBot(t, &config.Config{})
+		bot.onOrderUpdate(&entity.Order{ID: "buy", Symbol: "BTC", Side: entity.SideBuy, Price: 50000,
+			Quantity: 1, FilledQty: 1, Fee: 22.5, Status: entity.OrderStatusFilled})
+		bot.onOrderUpdate(&entity.Order{ID: "sell", Symbol: "BTC", Side: entity.SideSell, Price: 51000,
+			Quantity: 1, FilledQty: 1, Fee: 22.95, Status: entity.OrderStatusFilled})
+
+		if got := bot.risk.Status()["daily_pnl"]; !approxEqual(got.(float64), 1000-22.5-22.95) {
+			t.Errorf("Expected risk daily PnL net of both fees, got %v", got)
+		}
+		if pos := bot.Position(); !approxEqual(pos.Fees, 45.45) {
+			t.Errorf("Expected 45.45 fees on the position, got %f", pos.Fees)
+		}
+	})
+
+	t.Run("estimated by the fee model", func(t *testing.T) {
+		bot, _, _ := newPaperBot(t, &config.Config{Fees: entity.FeeModel{MakerBps: 1.5, TakerBps: 4.5}})
+		bot.onOrderUpdate(&entity.Order{ID: "buy", Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket,
+			Price: 50000, Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled})
+		bot.onOrderUpdate(&entity.Order{ID: "sell", Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket,
+			Price: 51000, Quantity: 0.5, FilledQty: 0.5, Status: entity.OrderStatusFilled})
+
+		// Half the entry's 22.5 taker fee and all of the 11.475 exit fee
+		if got := bot.risk.Status()["daily_pnl"]; !approxEqual(got.(float64), 500-11.25-11.475) {
+			t.Errorf("Expected risk daily PnL net of estimated fees, got %v", got)
+		}
+	})
+}
+
+func TestBot_ReconcilesPositionWithExchange(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}})
+	strat := &positionRecorder{recordingStrategy: &recordingStrategy{}}
+	bot.strategy = strat
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	bot.exchange.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.3})
+
+	// In sync: nothing changes
+	bot.reconcilePosition(ctx)
+	if len(strat.positions) != 1 {
+		t.Fatalf("Expected no update when in sync, got %d", len(strat.positions))
+	}
+
+	// A missed fill leaves the tracked position stale
+	bot.mu.Lock()
+	bot.position = &entity.Position{Symbol: "BTC", Side: entity.SideSell, Size: -0.1, EntryPrice: 50000, RealizedPnL: 7}
+	bot.mu.Unlock()
+
+	bot.reconcilePosition(ctx)
+	pos := bot.Position()
+	if !approxEqual(pos.Size, -0.3) || pos.Side != entity.SideSell || pos.RealizedPnL != 7 {
+		t.Fatalf("Expected exchange 0.3 short with realized PnL kept, got %+v", pos)
+	}
+	if len(strat.positions) != 2 || !approxEqual(strat.positions[1].Size, -0.3) {
+		t.Errorf("Expected strategy notified of reconciled position, got %+v", strat.positions)
+	}
+}
+
+// positionRecorder is a recordingStrategy that also records position updates
+type positionRecorder struct {
+	*recordingStrategy
+	positions []entity.Position
+}
+
+func (s *positionRecorder) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	s.positions = append(s.positions, *position)
+	return nil
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestBot_FlattenAll(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	bot.exchange.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.3})
+	bot.exchange.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 45000, Quantity: 0.1})
+
+	// Flatten while the pipeline keeps ticking
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+		}
+	}()
+	if err := bot.FlattenAll(ctx); err != nil {
+		t.Fatalf("FlattenAll failed: %v", err)
+	}
+	<-done
+
+	if pos, _ := bot.exchange.GetPosition(ctx, "BTC"); pos != nil {
+		t.Errorf("Expected flat exchange position, got %+v", pos)
+	}
+	if open, _ := bot.exchange.GetOpenOrders(ctx, "BTC"); len(open) != 0 {
+		t.Errorf("Expected resting orders canceled, got %d", len(open))
+	}
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0) {
+		t.Errorf("Expected flat tracked position, got %+v", pos)
+	}
+	if halted := bot.risk.Status()["halted"]; halted != true {
+		t.Error("Expected trading halted after flatten")
+	}
+
+	// Nothing left to close
+	if err := bot.FlattenAll(ctx); err != nil {
+		t.Errorf("Expected flatten of a flat book to succeed, got %v", err)
+	}
+}
+
+func TestBot_RoutesLargeOrdersThroughTWAP(t *testing.T) {
+	cfg := &config.Config{Orders: config.OrdersConfig{TWAPThreshold: 0.5, TWAPSlices: 2, TWAPDuration: 20 * time.Millisecond}}
+	bot, market, _ := newPaperBot(t, cfg)
+	bot.twap = usecase.NewTWAPExecutor(rateLimitedGateway{ExchangeGateway: bot.exchange, bot: bot}, cfg.Orders.TWAPSlices, cfg.Orders.TWAPDuration)
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50100, Quantity: 1})
+
+	// Slices are placed in the background
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if pos := bot.Position(); pos != nil && approxEqual(pos.Size, 1) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 1) {
+		t.Fatalf("Expected full 1 BTC filled across slices, got %+v", pos)
+	}
+	if filled := len(bot.orders.Snapshot()); filled != 2 {
+		t.Errorf("Expected 2 child orders, got %d", filled)
+	}
+
+	// Small orders are placed directly
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideSell, Price: 49900, Quantity: 0.2})
+	if pos := bot.Position(); !approxEqual(pos.Size, 0.8) {
+		t.Errorf("Expected direct fill of the small order, got %+v", pos)
+	}
+}
+
+func TestBot_TWAPSlicesRespectRateLimit(t *testing.T) {
+	ctx := context.Background()
+	order := func() *entity.Order {
+		return &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 1}
+	}
+
+	t.Run("slices wait out the pause", func(t *testing.T) {
+		bot, market, _ := newPaperBot(t, &config.Config{})
+		market.tick("BTC", 50000)
+		twap := usecase.NewTWAPExecutor(rateLimitedGateway{ExchangeGateway: bot.exchange, bot: bot}, 2, 0)
+		bot.rateLimitedUntil = time.Now().Add(50 * time.Millisecond)
+
+		began := time.Now()
+		result, err := twap.Execute(ctx, order())
+		if err != nil || !approxEqual(result.Filled, 1) {
+			t.Fatalf("Expected the slices filled after the pause, got %+v, %v", result, err)
+		}
+		if elapsed := time.Since(began); elapsed < 50*time.Millisecond {
+			t.Errorf("Expected the first slice to wait out the pause, placed after %s", elapsed)
+		}
+	})
+
+	t.Run("a rate limited slice pauses orders", func(t *testing.T) {
+		bot, market, _ := newPaperBot(t, &config.Config{})
+		market.tick("BTC", 50000)
+		failing := &failingExchange{exchangeGateway: bot.exchange, errs: []error{hyperliquid.ErrRateLimited}}
+		twap := usecase.NewTWAPExecutor(rateLimitedGateway{ExchangeGateway: failing, bot: bot}, 2, 0)
+
+		if _, err := twap.Execute(ctx, order()); !errors.Is(err, hyperliquid.ErrRateLimited) {
+			t.Fatalf("Expected the TWAP to stop on the rate limit, got %v", err)
+		}
+		if wait := bot.rateLimitWait(); wait <= 0 {
+			t.Error("Expected the rate limited slice to pause order placement")
+		}
+	})
+}
+
+func TestBot_SlippageCheck(t *testing.T) {
+	cfg := &config.Config{Orders: config.OrdersConfig{MaxSlippageBps: 50}}
+	bot, market, _ := newPaperBot(t, cfg)
+	ctx := context.Background()
+
+	// 1% above the signal price exceeds the 50 bps tolerance
+	market.tick("BTC", 50500)
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true})
+	if pos := bot.Position(); pos != nil {
+		t.Fatalf("Expected market order to be skipped on excessive deviation, got %+v", pos)
+	}
+
+	// Resting limit orders are not checked
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1})
+	if open := bot.orders.OpenOrders(); len(open) != 1 {
+		t.Errorf("Expected limit order to be placed regardless of deviation, got %d open", len(open))
+	}
+
+	// 0.2% is within tolerance, priced from the streamed ticker: the live
+	// exchange has no ticker endpoint
+	bot.exchange = noTickerExchange{bot.exchange}
+	market.tick("BTC", 50100)
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true})
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.1) {
+		t.Errorf("Expected market order within tolerance to fill, got %+v", pos)
+	}
+}
+
+// noTickerExchange fails ticker requests, like the live exchange
+type noTickerExchange struct {
+	exchangeGateway
+}
+
+func (noTickerExchange) GetTicker(ctx context.Context, symbol string) (*entity.Ticker, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestBot_PlaceOrderErrors(t *testing.T) {
+	ctx := context.Background()
+	setup := func(errs ...error) (*Bot, *failingExchange) {
+		bot, market, _ := newPaperBot(t, &config.Config{})
+		market.tick("BTC", 50000)
+		failing := &failingExchange{exchangeGateway: bot.exchange, errs: errs}
+		bot.exchange = failing
+		return bot, failing
+	}
+	buy := func() *service.Signal {
+		return &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 49000, Quantity: 0.1}
+	}
+
+	t.Run("rejections are not retried", func(t *testing.T) {
+		for _, class := range []error{hyperliquid.ErrInvalidOrder, hyperliquid.ErrInsufficientBalance} {
+			bot, failing := setup(&hyperliquid.APIError{Message: "rejected", Kind: class})
+			bot.executeOrder(ctx, buy())
+			if failing.calls != 1 {
+				t.Errorf("Expected %v not to be retried, got %d attempts", class, failing.calls)
+			}
+		}
+	})
+
+	t.Run("failures are not recorded as trades", func(t *testing.T) {
+		bot, _ := setup(&hyperliquid.APIError{Message: "rejected", Kind: hyperliquid.ErrInvalidOrder})
+		bot.executeOrder(ctx, buy())
+		status := bot.risk.Status()
+		if status["win_rate"].(float64) != 0 || status["losses"].(int) != 0 || status["daily_pnl"].(float64) != 0 {
+			t.Errorf("Expected trade stats unchanged by a failed placement, got %+v", status)
+		}
+		if status["consecutive_loss"].(int) != 1 {
+			t.Errorf("Expected the failure to count towards the cooldown, got %v", status["consecutive_loss"])
+		}
+	})
+
+	t.Run("transient failures are retried once", func(t *testing.T) {
+		bot, failing := setup(errors.New("connection reset"))
+		bot.executeOrder(ctx, buy())
+		if failing.calls != 2 {
+			t.Errorf("Expected a retry, got %d attempts", failing.calls)
+		}
+		if open, _ := bot.exchange.GetOpenOrders(ctx, "BTC"); len(open) != 1 {
+			t.Errorf("Expected the retried order to rest, got %d open orders", len(open))
+		}
+	})
+
+	t.Run("rate limits pause placement", func(t *testing.T) {
+		bot, failing := setup(hyperliquid.ErrRateLimited, hyperliquid.ErrRateLimited)
+		now := time.Now()
+		bot.now = func() time.Time { return now }
+
+		bot.executeOrder(ctx, buy())
+		bot.executeOrder(ctx, buy())
+		if failing.calls != 1 {
+			t.Fatalf("Expected no orders during the pause, got %d attempts", failing.calls)
+		}
+
+		// The pause doubles on a consecutive rate limit and clears on success
+		now = now.Add(minRateLimitBackoff)
+		bot.executeOrder(ctx, buy())
+		if wait := bot.rateLimitWait(); wait != 2*minRateLimitBackoff {
+			t.Errorf("Expected the pause to double, got %s", wait)
+		}
+		now = now.Add(2 * minRateLimitBackoff)
+		bot.executeOrder(ctx, buy())
+		if failing.calls != 3 || bot.rateLimitBackoff != 0 {
+			t.Errorf("Expected the order placed after the pause, got %d attempts (backoff %s)", failing.calls, bot.rateLimitBackoff)
+		}
+	})
+}
+
+// failingExchange fails order placement with errs in turn, then places orders normally
+type failingExchange struct {
+	exchangeGateway
+	errs  []error
+	calls int
+}
+
+func (e *failingExchange) PlaceOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	e.calls++
+	if len(e.errs) > 0 {
+		err := e.errs[0]
+		e.errs = e.errs[1:]
+		return nil, err
+	}
+	return e.exchangeGateway.PlaceOrder(ctx, order)
+}
+
+func TestBot_PlacesSignalOnceByClientOrderID(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{})
+	ctx := context.Background()
+	market.tick("BTC", 50000)
+
+	// A resting limit order re-placed after a timeout keeps its client order ID
+	sig := &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 49000, Quantity: 0.1}
+	bot.executeOrder(ctx, sig)
+	if sig.ClientOrderID == "" {
+		t.Fatal("Expected a client order ID to be assigned to the signal")
+	}
+	bot.executeOrder(ctx, sig)
+
+	if open := bot.orders.OpenOrders(); len(open) != 1 {
+		t.Fatalf("Expected one logical order, got %d open", len(open))
+	}
+	exchangeOpen, _ := bot.exchange.GetOpenOrders(ctx, "BTC")
+	if len(exchangeOpen) != 1 || exchangeOpen[0].ClientOrderID != sig.ClientOrderID {
+		t.Errorf("Expected one exchange order with cloid %s, got %+v", sig.ClientOrderID, exchangeOpen)
+	}
+
+	// A new signal gets its own ID
+	other := &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 48000, Quantity: 0.1}
+	bot.executeOrder(ctx, other)
+	if other.ClientOrderID == sig.ClientOrderID || len(bot.orders.OpenOrders()) != 2 {
+		t.Errorf("Expected a distinct second order, got cloid %s", other.ClientOrderID)
+	}
+}
+
+// openOrdersCounter counts open order lookups on the exchange
+type openOrdersCounter struct {
+	exchangeGateway
+	calls int
+}
+
+func (e *openOrdersCounter) GetOpenOrders(ctx context.Context, symbol string) ([]*entity.Order, error) {
+	e.calls++
+	return e.exchangeGateway.GetOpenOrders(ctx, symbol)
+}
+
+func TestBot_ChecksPlacedOnlyForReusedClientOrderIDs(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{})
+	counter := &openOrdersCounter{exchangeGateway: bot.exchange}
+	bot.exchange = counter
+	ctx := context.Background()
+	market.tick("BTC", 50000)
+
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 49000, Quantity: 0.1})
+	if counter.calls != 0 {
+		t.Errorf("Expected a new client order ID placed without an open order lookup, got %d", counter.calls)
+	}
+
+	// Unknown to the repository, so the exchange is asked
+	bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 48000, Quantity: 0.1, ClientOrderID: newClientOrderID()})
+	if counter.calls != 1 {
+		t.Errorf("Expected a reused client order ID checked on the exchange, got %d lookups", counter.calls)
+	}
+}
+
+// candleRecorder records candles handed to a candle strategy
+type candleRecorder struct {
+	*recordingStrategy
+	candles []entity.Candle
+}
+
+func (s *candleRecorder) OnCandle(ctx context.Context, candle entity.Candle) ([]*service.Signal, error) {
+	s.candles = append(s.candles, candle)
+	return nil, nil
+}
+
+func TestBot_HandsClosedCandlesToStrategy(t *testing.T) {
+	strat := &candleRecorder{recordingStrategy: &recordingStrategy{}}
+	bot := &Bot{
+		config:   &config.Config{},
+		log:      logger.New(logger.LevelError, nil),
+		strategy: strat,
+		risk:     risk.NewChecker(risk.DefaultConfig()),
+		orders:   usecase.NewOrderManager(),
+		signals:  make(map[string]*entity.MarketSignal),
+		books:    make(map[string]*entity.OrderBook),
+		tickers:  make(map[string]*entity.Ticker),
+		candles:  marketdata.NewAggregator(time.Minute),
+		now:      time.Now,
+		running:  true,
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, price := range []float64{100, 102, 99, 101} {
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: price, Timestamp: start.Add(time.Duration(i*15) * time.Second)})
+	}
+	if len(strat.candles) != 0 {
+		t.Fatalf("Expected no candle before the interval closes, got %+v", strat.candles)
+	}
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 103, Timestamp: start.Add(time.Minute)})
+	if len(strat.candles) != 1 {
+		t.Fatalf("Expected one closed candle, got %d", len(strat.candles))
+	}
+	if c := strat.candles[0]; c.Open != 100 || c.High != 102 || c.Low != 99 || c.Close != 101 {
+		t.Errorf("Unexpected candle: %+v", c)
+	}
+	if len(strat.states) != 5 {
+		t.Errorf("Expected every tick still passed to OnTick, got %d", len(strat.states))
+	}
+}
+
+func TestBot_FlushesCandlesWithoutTicks(t *testing.T) {
+	strat := &candleRecorder{recordingStrategy: &recordingStrategy{}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	bot := &Bot{
+		config:   &config.Config{},
+		log:      logger.New(logger.LevelError, nil),
+		strategy: strat,
+		risk:     risk.NewChecker(risk.DefaultConfig()),
+		orders:   usecase.NewOrderManager(),
+		signals:  make(map[string]*entity.MarketSignal),
+		books:    make(map[string]*entity.OrderBook),
+		tickers:  make(map[string]*entity.Ticker),
+		candles:  marketdata.NewAggregator(time.Minute),
+		now:      func() time.Time { return now },
+		running:  true,
+	}
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 100, Timestamp: start})
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 101, Timestamp: start.Add(30 * time.Second)})
+
+	now = start.Add(59 * time.Second)
+	bot.flushCandles(context.Background())
+	if len(strat.candles) != 0 {
+		t.Fatalf("Expected no candle before the interval ends, got %+v", strat.candles)
+	}
+
+	now = start.Add(time.Minute)
+	bot.flushCandles(context.Background())
+	if len(strat.candles) != 1 || strat.candles[0].Close != 101 {
+		t.Fatalf("Expected the quiet bar closed by the flush, got %+v", strat.candles)
+	}
+}
+
+func TestBot_DrawdownKillSwitch(t *testing.T) {
+	t.Run("realized and unrealized PnL", func(t *testing.T) {
+		cfg := &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}, Risk: config.RiskConfig{MaxDrawdown: 0.05}}
+		bot, market, _ := newPaperBot(t, cfg)
+		bot.drawdown = risk.NewDrawdownMonitor(cfg.Risk.MaxDrawdown)
+		bot.startEquity = 100000
+		ctx := context.Background()
+		buy := &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 1, Market: true}
+
+		// A losing round trip, then a second long that keeps falling
+		market.tick("BTC", 50000)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+		bot.executeOrder(ctx, buy)
+		market.tick("BTC", 49000)
+		bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideSell, Price: 49000, Quantity: 1, Market: true})
+		bot.executeOrder(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 49000, Quantity: 1, Market: true})
+		market.tick("BTC", 46000)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 46000})
+		if status := bot.risk.Status(); status["halted"] == true {
+			t.Fatalf("Expected no trip at 4%% drawdown, got %+v", status)
+		}
+
+		// 1000 realized + 4500 unrealized = 5.5% from the 100000 peak
+		market.tick("BTC", 44500)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 44500})
+		if status := bot.risk.Status(); status["halted"] != true {
+			t.Fatalf("Expected kill-switch to halt trading, got %+v", status)
+		}
+		if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0) {
+			t.Fatalf("Expected position flattened, got %+v", pos)
+		}
+
+		// Latched: signals are rejected and still after further ticks
+		placed := len(bot.orders.Snapshot())
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 45000})
+		bot.processSignal(ctx, buy, &entity.Ticker{Symbol: "BTC", LastPrice: 45000})
+		if got := len(bot.orders.Snapshot()); got != placed {
+			t.Errorf("Expected no orders after the kill-switch, got %d more", got-placed)
+		}
+
+		bot.Resume()
+		if status := bot.risk.Status(); status["halted"] == true {
+			t.Error("Expected manual resume to lift the halt")
+		}
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 45000})
+		if status := bot.risk.Status(); status["halted"] == true {
+			t.Error("Expected kill-switch re-armed from current equity after resume")
+		}
+	})
+
+	t.Run("net of fees", func(t *testing.T) {
+		cfg := &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}, Risk: config.RiskConfig{MaxDrawdown: 0.05}}
+		bot, market, _ := newPaperBot(t, cfg)
+		bot.drawdown = risk.NewDrawdownMonitor(cfg.Risk.MaxDrawdown)
+		bot.startEquity = 100000
+
+		market.tick("BTC", 50000)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+		bot.onOrderUpdate(&entity.Order{ID: "buy", Symbol: "BTC", Side: entity.SideBuy, Price: 50000,
+			Quantity: 1, FilledQty: 1, Fee: 1500, Status: entity.OrderStatusFilled})
+
+		// 4000 unrealized alone is 4%, the 1500 fee takes it to 5.5%
+		market.tick("BTC", 46000)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 46000})
+		if status := bot.risk.Status(); status["halted"] != true {
+			t.Fatalf("Expected fees to count towards the drawdown, got %+v", status)
+		}
+	})
+}
+
+func TestBot_StartNeedsEquityForDrawdown(t *testing.T) {
+	cfg := &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}, Risk: config.RiskConfig{MaxDrawdown: 0.05}}
+	bot, _, _ := newPaperBot(t, cfg)
+	bot.running = false
+	bot.drawdown = risk.NewDrawdownMonitor(cfg.Risk.MaxDrawdown)
+	errUnavailable := errors.New("info unavailable")
+	bot.exchange = &equityExchange{exchangeGateway: bot.exchange, err: errUnavailable}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := bot.Start(ctx); !errors.Is(err, errUnavailable) {
+		t.Fatalf("Expected start to fail without starting equity, got %v", err)
+	}
+	if bot.drawdown == nil {
+		t.Error("Expected the kill-switch to stay configured")
+	}
+}
+
+func TestBot_ReconfigureDrawdown(t *testing.T) {
+	cfg := &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}, Risk: config.RiskConfig{MaxDrawdown: 0.05}}
+	bot, market, _ := newPaperBot(t, cfg)
+	bot.drawdown = risk.NewDrawdownMonitor(cfg.Risk.MaxDrawdown)
+	bot.startEquity = 100000
+
+	next := *cfg
+	next.Risk.MaxDrawdown = 0.2
+	bot.Reconfigure(&next)
+	if bot.config.Risk.MaxDrawdown != 0.2 {
+		t.Errorf("Expected reloaded max drawdown in the bot config, got %v", bot.config.Risk.MaxDrawdown)
+	}
+
+	// 10% down trips the old 5% limit but not the reloaded 20%
+	market.tick("BTC", 50000)
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	bot.executeOrder(context.Background(), &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 2, Market: true})
+	market.tick("BTC", 45000)
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 45000})
+	if status := bot.risk.Status(); status["halted"] == true {
+		t.Fatalf("Expected the reloaded threshold to apply, got %+v", status)
+	}
+}
+
+// staticEvents is an event source with a fixed calendar
+type staticEvents []*entity.EconomicEvent
+
+func (e staticEvents) UpcomingEvents() []*entity.EconomicEvent { return e }
+
+func TestBot_FeedsEquityToRiskAndStrategy(t *testing.T) {
+	bot, market, strat := newPaperBot(t, &config.Config{})
+	ctx := context.Background()
+	market.tick("BTC", 50000)
+
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true},
+		&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	if equity := bot.risk.Equity(); equity <= 0 {
+		t.Fatalf("Expected the signal's equity read to reach the risk checker, got %v", equity)
+	}
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	if len(strat.states) == 0 || strat.states[len(strat.states)-1].Equity != bot.risk.Equity() {
+		t.Errorf("Expected the market state to carry equity %v, got %+v", bot.risk.Equity(), strat.states)
+	}
+}
+
+func TestBot_OrderMinimums(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{})
+	market.limits = entity.OrderLimits{MinNotional: 10, MinSize: 0.00001}
+	ctx := context.Background()
+	ticker := &entity.Ticker{Symbol: "BTC", LastPrice: 50000}
+	market.tick("BTC", 50000)
+
+	// $5 is below the $10 minimum and is skipped
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.0001, Market: true}, ticker)
+	if pos := bot.Position(); pos != nil && pos.Size != 0 {
+		t.Fatalf("Expected sub-minimum entry to be skipped, got %+v", pos)
+	}
+
+	// $10.50 clears it
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.00021, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.00021) {
+		t.Fatalf("Expected near-minimum entry to fill, got %+v", pos)
+	}
+}
+
+func TestBot_OrderMinimumsRoundUp(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{Orders: config.OrdersConfig{BelowMinimum: config.BelowMinimumRoundUp}})
+	market.limits = entity.OrderLimits{MinNotional: 10, MinSize: 0.00001}
+	ctx := context.Background()
+	ticker := &entity.Ticker{Symbol: "BTC", LastPrice: 30000}
+	market.tick("BTC", 30000)
+
+	// $10 at 30000 is 0.000333..., rounded up to the next 0.00001
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 30000, Quantity: 0.0001, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.00034) {
+		t.Fatalf("Expected entry rounded up to 0.00034, got %+v", pos)
+	}
+}
+
+func TestBot_LiquidityCheckSkipsExits(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{})
+	cfg := risk.DefaultConfig()
+	cfg.MaxSpreadBps = 10
+	bot.risk = risk.NewChecker(cfg)
+	ctx := context.Background()
+	tight := &entity.Ticker{Symbol: "BTC", LastPrice: 50000, BidPrice: 49999, AskPrice: 50001}
+	wide := &entity.Ticker{Symbol: "BTC", LastPrice: 50000, BidPrice: 49900, AskPrice: 50100} // 40 bps
+	market.tick("BTC", 50000)
+
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, tight)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.1) {
+		t.Fatalf("Expected entry in a tight market, got %+v", pos)
+	}
+
+	// Adding to the position is blocked by the wide spread, closing it is not
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, wide)
+	if pos := bot.Position(); !approxEqual(pos.Size, 0.1) {
+		t.Fatalf("Expected entry blocked by the wide spread, got %+v", pos)
+	}
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideSell, Price: 50000, Quantity: 0.1, Market: true, ReduceOnly: true}, wide)
+	if pos := bot.Position(); !approxEqual(pos.Size, 0) {
+		t.Errorf("Expected the exit to go through despite the wide spread, got %+v", pos)
+	}
+}
+
+func TestBot_LeverageCheckWithoutEquity(t *testing.T) {
+	bot, market, _ := newPaperBot(t, &config.Config{})
+	cfg := risk.DefaultConfig()
+	cfg.MaxLeverage = 3
+	bot.risk = risk.NewChecker(cfg)
+	bot.dryRun = false
+	exchange := &equityExchange{exchangeGateway: bot.exchange}
+	bot.exchange = exchange
+	ctx := context.Background()
+	ticker := &entity.Ticker{Symbol: "BTC", LastPrice: 50000}
+	market.tick("BTC", 50000)
+
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.1) {
+		t.Fatalf("Expected entry with equity available, got %+v", pos)
+	}
+
+	// Live, an entry is rejected without equity while an exit still goes through
+	exchange.err = errors.New("clearinghouse unavailable")
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, ticker)
+	if pos := bot.Position(); !approxEqual(pos.Size, 0.1) {
+		t.Fatalf("Expected entry rejected without equity, got %+v", pos)
+	}
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideSell, Price: 50000, Quantity: 0.1, Market: true, ReduceOnly: true}, ticker)
+	if pos := bot.Position(); !approxEqual(pos.Size, 0) {
+		t.Errorf("Expected the exit to go through without equity, got %+v", pos)
+	}
+}
+
+// equityExchange fails account value reads with err when set
+type equityExchange struct {
+	exchangeGateway
+	err error
+}
+
+func (e *equityExchange) GetAccountValue(ctx context.Context) (float64, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	return e.exchangeGateway.GetAccountValue(ctx)
+}
+
+func TestBot_EventBlackout(t *testing.T) {
+	cfg := &config.Config{Strategy: config.StrategyConfig{Symbol: "BTC"}, Risk: config.RiskConfig{FlattenBeforeEvents: true}}
+	bot, market, _ := newPaperBot(t, cfg)
+	now := time.Date(2025, 1, 15, 13, 20, 0, 0, time.UTC)
+	bot.now = func() time.Time { return now }
+	bot.blackout = risk.NewEventBlackout(30*time.Minute, 30*time.Minute)
+	bot.events = staticEvents{{Event: "CPI", Date: now.Add(10 * time.Minute), Importance: "high"}}
+	ctx := context.Background()
+	ticker := &entity.Ticker{Symbol: "BTC", LastPrice: 50000}
+	market.tick("BTC", 50000)
+
+	// Open a long before the calendar is loaded, then the next tick closes it
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.1) {
+		t.Fatalf("Expected entry before the blackout is known, got %+v", pos)
+	}
+	bot.onTicker(ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0) {
+		t.Fatalf("Expected position closed ahead of the event, got %+v", pos)
+	}
+	if status := bot.risk.Status(); status["halted"] == true {
+		t.Error("Expected pre-event flatten not to halt trading")
+	}
+
+	// The event is 10 minutes out, inside the 30 minute window
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0) {
+		t.Fatalf("Expected entry blocked inside the blackout, got %+v", pos)
+	}
+
+	// Entries resume once the window has passed
+	now = now.Add(41 * time.Minute)
+	bot.processSignal(ctx, &service.Signal{Symbol: "BTC", Side: entity.SideBuy, Price: 50000, Quantity: 0.1, Market: true}, ticker)
+	if pos := bot.Position(); pos == nil || !approxEqual(pos.Size, 0.1) {
+		t.Errorf("Expected entry after the blackout, got %+v", pos)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// replayOptions replays a WebSocket recording in place of the live feed
+type replayOptions struct {
+	path  string  // Recording written with exchange.record_ws (empty = live feed)
+	speed float64 // Scales the recorded gaps: 1 is real time, 0 does not wait
+}
+
+// replayer is implemented by exchanges that re-emit a WebSocket recording
+// through their subscription handlers
+type replayer interface {
+	ReplayFile(ctx context.Context, path string, speed float64) (int, error)
+}
+
+// Replay feeds the recording through the started bot's subscriptions, as if
+// it were the live feed, and returns the number of messages replayed
+func (b *Bot) Replay(ctx context.Context, opts replayOptions) (int, error) {
+	if b.replayer == nil {
+		return 0, fmt.Errorf("exchange does not replay recordings")
+	}
+	n, err := b.replayer.ReplayFile(ctx, opts.path, opts.speed)
+	if err != nil {
+		return n, fmt.Errorf("replay %s: %w", opts.path, err)
+	}
+	return n, nil
+}
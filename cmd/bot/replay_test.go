@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+func TestBot_ReplaysRecording(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	yaml := `
+exchange:
+  api_key: "0x0000000000000000000000000000000000000001"
+  api_secret: "0x0000000000000000000000000000000000000000000000000000000000000001"
+strategy:
+  name: mean_reversion
+  symbol: BTC
+`
+	if err := os.WriteFile(configPath, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	recordingPath := filepath.Join(dir, "ws.jsonl")
+	recorder, err := hyperliquid.OpenRecorder(recordingPath)
+	if err != nil {
+		t.Fatalf("OpenRecorder failed: %v", err)
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, mid := range []string{"50000", "50010", "49990"} {
+		recorder.Record([]byte(`{"channel":"allMids","data":{"mids":{"BTC":"`+mid+`"}}}`), start.Add(time.Duration(i)*time.Second))
+	}
+	recorder.Close()
+
+	bot, err := newBot(cfg, true, true, logger.New(logger.LevelError, nil))
+	if err != nil {
+		t.Fatalf("newBot failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := bot.Start(ctx); err != nil {
+		t.Fatalf("Expected an offline bot to start without the WebSocket, got %v", err)
+	}
+	defer bot.Stop(context.Background())
+
+	n, err := bot.Replay(ctx, replayOptions{path: recordingPath})
+	if err != nil || n != 3 {
+		t.Fatalf("Expected 3 messages replayed, got %d, %v", n, err)
+	}
+	bot.tickMu.Lock()
+	ticker := bot.tickers["BTC"]
+	bot.tickMu.Unlock()
+	if ticker == nil || ticker.LastPrice != 49990 {
+		t.Errorf("Expected the last recorded BTC mid to reach the bot, got %+v", ticker)
+	}
+
+	if _, err := bot.Replay(ctx, replayOptions{path: filepath.Join(dir, "missing.jsonl")}); err == nil {
+		t.Error("Expected a missing recording to fail")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/persistence"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/report"
+)
+
+// writeReport builds the trade report from the order store and writes it in the given format
+func writeReport(ctx context.Context, cfg *config.Config, format string, w io.Writer) error {
+	if cfg.Storage.SQLitePath == "" {
+		return fmt.Errorf("report requires storage.sqlite_path (orders are not persisted in memory mode)")
+	}
+
+	repo, err := persistence.NewSQLiteOrderRepository(cfg.Storage.SQLitePath)
+	if err != nil {
+		return fmt.Errorf("failed to open order store: %w", err)
+	}
+	defer repo.Close()
+
+	rep, err := report.FromRepository(ctx, repo, report.Config{Fees: cfg.Fees})
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		return rep.WriteJSON(w)
+	case "csv":
+		return rep.WriteCSV(w)
+	default:
+		return fmt.Errorf("unknown report format: %s", format)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/persistence"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/report"
+)
+
+func TestWriteReport_AppliesConfiguredFees(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.db")
+	repo, err := persistence.NewSQLiteOrderRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteOrderRepository failed: %v", err)
+	}
+	t0 := time.Now()
+	for _, o := range []*entity.Order{
+		{ID: "1", Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Price: 100, Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled, CreatedAt: t0, UpdatedAt: t0},
+		{ID: "2", Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Price: 110, Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled, CreatedAt: t0, UpdatedAt: t0.Add(time.Second)},
+	} {
+		if err := repo.Create(context.Background(), o); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	repo.Close()
+
+	cfg := &config.Config{Fees: entity.FeeModel{MakerBps: 1, TakerBps: 10}}
+	cfg.Storage.SQLitePath = path
+
+	var buf bytes.Buffer
+	if err := writeReport(context.Background(), cfg, "json", &buf); err != nil {
+		t.Fatalf("writeReport failed: %v", err)
+	}
+	var rep report.Report
+	if err := json.Unmarshal(buf.Bytes(), &rep); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	// Both fills are taker: (100 + 110) * 10bps
+	if math.Abs(rep.Total.Fees-0.21) > 1e-9 {
+		t.Errorf("Expected fees from the configured model (0.21), got %.4f", rep.Total.Fees)
+	}
+	if math.Abs(rep.Total.NetPnL-9.79) > 1e-9 {
+		t.Errorf("Expected net PnL 9.79, got %.4f", rep.Total.NetPnL)
+	}
+}
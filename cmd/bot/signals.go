@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/macro"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/notify"
+	signalprovider "github.com/zono819/hyperliquid-bot/internal/infrastructure/signal"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/strategy"
+)
+
+// breakerConfig builds the data source circuit breaker configuration
+func breakerConfig(cfg *config.Config) *httputil.BreakerConfig {
+	cb := cfg.DataSources.CircuitBreaker
+	return &httputil.BreakerConfig{
+		FailureThreshold: cb.FailureThreshold,
+		Window:           cb.Window,
+		Cooldown:         cb.Cooldown,
+	}
+}
+
+// apiKey returns the key of an enabled data source
+func apiKey(enabled bool, key string) string {
+	if !enabled {
+		return ""
+	}
+	return key
+}
+
+// macroConfig builds the macro provider configuration from app config
+func macroConfig(cfg *config.Config) macro.Config {
+	ds := cfg.DataSources
+	return macro.Config{
+		FedWatchAPIKey:         apiKey(ds.FedWatch.Enabled, ds.FedWatch.APIKey),
+		TradingEconomicsAPIKey: apiKey(ds.TradingEconomics.Enabled, ds.TradingEconomics.APIKey),
+		MaxAttempts:            cfg.Macro.MaxAttempts,
+		Breaker:                breakerConfig(cfg),
+		RefreshInterval:        cfg.Macro.RefreshInterval,
+		DXYIndicator:           cfg.Macro.DXYIndicator,
+	}
+}
+
+// newMacroProvider creates the macro provider, or nil if no macro source is enabled
+func newMacroProvider(cfg *config.Config) *macro.Provider {
+	mc := macroConfig(cfg)
+	if mc.FedWatchAPIKey == "" && mc.TradingEconomicsAPIKey == "" {
+		return nil
+	}
+	return macro.NewProvider(mc)
+}
+
+// signalConfig builds the signal provider configuration from app config,
+// sharing the macro provider (may be nil)
+func signalConfig(cfg *config.Config, macroProvider *macro.Provider) signalprovider.Config {
+	ds := cfg.DataSources
+	symbols := ds.Symbols
+	if len(symbols) == 0 {
+		symbols = []string{cfg.Strategy.Symbol}
+	}
+
+	maxAge := signalprovider.DefaultMaxAgeConfig()
+	if v := cfg.Signals.MaxAge.CoinGlass; v > 0 {
+		maxAge.CoinGlass = v
+	}
+	if v := cfg.Signals.MaxAge.WhaleAlert; v > 0 {
+		maxAge.WhaleAlert = v
+	}
+	if v := cfg.Signals.MaxAge.LunarCrush; v > 0 {
+		maxAge.LunarCrush = v
+	}
+	if v := cfg.Signals.MaxAge.Macro; v > 0 {
+		maxAge.Macro = v
+	}
+	if v := cfg.Signals.MaxAge.SentimentAPI; v > 0 {
+		maxAge.SentimentAPI = v
+	}
+	if v := cfg.Signals.MaxAge.FearGreed; v > 0 {
+		maxAge.FearGreed = v
+	}
+
+	cascade := signalprovider.DefaultCascadeConfig()
+	if v := cfg.Signals.LiquidationCascade.Window; v > 0 {
+		cascade.Window = v
+	}
+	if v := cfg.Signals.LiquidationCascade.MinValue; v > 0 {
+		cascade.MinValue = v
+	}
+
+	return signalprovider.Config{
+		CoinGlassAPIKey:    apiKey(ds.CoinGlass.Enabled, ds.CoinGlass.APIKey),
+		CoinGlassAggregate: ds.CoinGlass.Aggregate,
+		WhaleAlertAPIKey:   apiKey(ds.WhaleAlert.Enabled, ds.WhaleAlert.APIKey),
+		WhaleMinValue:      ds.WhaleAlert.MinValue,
+		WhaleBlockchains:   ds.WhaleAlert.Blockchains,
+		WhaleChainMinValue: ds.WhaleAlert.MinValueByChain,
+		LunarCrushAPIKey:   apiKey(ds.LunarCrush.Enabled, ds.LunarCrush.APIKey),
+		SentimentAPIURL:    apiKey(ds.SentimentAPI.Enabled, ds.SentimentAPI.URL),
+		SentimentAPIKey:    ds.SentimentAPI.APIKey,
+		FearGreed:          ds.FearGreed.Enabled,
+		Symbols:            symbols,
+		Weights:            cfg.Signals.Weights,
+		FetchTimeout:       cfg.Signals.FetchTimeout,
+		MaxAttempts:        cfg.Signals.MaxAttempts,
+		MaxAge:             &maxAge,
+		Cascade:            &cascade,
+		MaxCachedEvents:    cfg.Signals.MaxCachedEvents,
+		SentimentFloor:     cfg.Signals.SentimentFloor,
+		Breaker:            breakerConfig(cfg),
+		MacroProvider:      macroProvider,
+	}
+}
+
+// usesMarketSignals reports whether the configured strategy, or a child of
+// an ensemble, is ai_signal, the strategy that consumes market signals
+func usesMarketSignals(cfg *config.Config) bool {
+	for _, name := range strategy.StrategyNames(cfg.Strategy.Name, cfg.Strategy.Params) {
+		if name == "ai_signal" {
+			return true
+		}
+	}
+	return false
+}
+
+// newSignalProvider creates the market signal provider for the ai_signal strategy,
+// or nil if no strategy consumes market signals or no data source is enabled
+func newSignalProvider(cfg *config.Config, macroProvider *macro.Provider, log *logger.Logger) gateway.MarketSignalProvider {
+	if !usesMarketSignals(cfg) {
+		return nil
+	}
+	ds := cfg.DataSources
+	if !ds.CoinGlass.Enabled && !ds.WhaleAlert.Enabled && !ds.LunarCrush.Enabled &&
+		!ds.SentimentAPI.Enabled && !ds.FearGreed.Enabled && !ds.FedWatch.Enabled && !ds.TradingEconomics.Enabled {
+		log.Warn("ai_signal strategy has no data sources enabled; it will not receive market signals")
+		return nil
+	}
+	return signalprovider.NewProvider(signalConfig(cfg, macroProvider), log.WithField("component", "signal"))
+}
+
+// startSignals subscribes to the signal provider and starts it
+func (b *Bot) startSignals(ctx context.Context) error {
+	if b.signalProvider == nil {
+		return nil
+	}
+	if err := b.signalProvider.SubscribeSignals(ctx, b.onMarketSignal); err != nil {
+		return fmt.Errorf("failed to subscribe signals: %w", err)
+	}
+	if err := b.signalProvider.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start signal provider: %w", err)
+	}
+	return nil
+}
+
+// onMarketSignal caches the latest market signal for its symbol and sends
+// the alerts it fires
+func (b *Bot) onMarketSignal(signal *entity.MarketSignal) {
+	if signal == nil {
+		return
+	}
+	b.mu.Lock()
+	b.signals[signal.Symbol] = signal
+	b.mu.Unlock()
+
+	if b.alerts == nil {
+		return
+	}
+	for _, a := range b.alerts.Evaluate(signal) {
+		title := "Alert"
+		if a.Rule.Name != "" {
+			title += ": " + a.Rule.Name
+		}
+		message := a.Message
+		b.notify(func(ctx context.Context, n notify.Notifier) error {
+			return n.Notify(ctx, notify.LevelWarn, title, message)
+		})
+	}
+}
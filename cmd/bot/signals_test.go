@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/api"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/notify"
+	"github.com/zono819/hyperliquid-bot/internal/usecase"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/alert"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/risk"
+)
+
+// fakeSignalProvider emits market signals on demand and serves fetched on request
+type fakeSignalProvider struct {
+	mu       sync.Mutex
+	started  bool
+	handlers []func(*entity.MarketSignal)
+	fetched  *entity.MarketSignal
+	fetches  []string
+}
+
+var _ gateway.MarketSignalProvider = (*fakeSignalProvider)(nil)
+
+func (f *fakeSignalProvider) Start(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.started = true
+	return nil
+}
+
+func (f *fakeSignalProvider) Stop(ctx context.Context) error { return nil }
+
+func (f *fakeSignalProvider) GetMarketSignal(ctx context.Context, symbol string) (*entity.MarketSignal, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetches = append(f.fetches, symbol)
+	return f.fetched, nil
+}
+
+func (f *fakeSignalProvider) SubscribeSignals(ctx context.Context, handler func(*entity.MarketSignal)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers = append(f.handlers, handler)
+	return nil
+}
+
+func (f *fakeSignalProvider) emit(signal *entity.MarketSignal) {
+	f.mu.Lock()
+	handlers := f.handlers
+	f.mu.Unlock()
+	for _, h := range handlers {
+		h(signal)
+	}
+}
+
+// recordingStrategy records a copy of the market state of each tick, every
+// order update and the number of resets
+type recordingStrategy struct {
+	states []*service.MarketState
+	orders []*entity.Order
+	resets int
+}
+
+func (s *recordingStrategy) Name() string { return "recording" }
+func (s *recordingStrategy) Init(ctx context.Context, config map[string]interface{}) error {
+	return nil
+}
+func (s *recordingStrategy) OnTick(ctx context.Context, state *service.MarketState) ([]*service.Signal, error) {
+	recorded := *state
+	s.states = append(s.states, &recorded)
+	return nil, nil
+}
+func (s *recordingStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	s.orders = append(s.orders, order)
+	return nil
+}
+func (s *recordingStrategy) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	return nil
+}
+func (s *recordingStrategy) Reset(ctx context.Context) error {
+	s.resets++
+	return nil
+}
+func (s *recordingStrategy) Stop(ctx context.Context) error { return nil }
+
+func TestBot_InjectsMarketSignal(t *testing.T) {
+	provider := &fakeSignalProvider{}
+	strat := &recordingStrategy{}
+	bot := &Bot{
+		config:         &config.Config{},
+		log:            logger.New(logger.LevelError, nil),
+		strategy:       strat,
+		risk:           risk.NewChecker(risk.DefaultConfig()),
+		signalProvider: provider,
+		orders:         usecase.NewOrderManager(),
+		signals:        make(map[string]*entity.MarketSignal),
+		tickers:        make(map[string]*entity.Ticker),
+		now:            time.Now,
+		running:        true,
+	}
+
+	if err := bot.startSignals(context.Background()); err != nil {
+		t.Fatalf("startSignals failed: %v", err)
+	}
+	if !provider.started {
+		t.Fatal("Expected signal provider to be started")
+	}
+
+	// No signal yet
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+
+	signal := &entity.MarketSignal{Symbol: "BTC", Bias: entity.SignalBiasBullish}
+	provider.emit(signal)
+	provider.emit(&entity.MarketSignal{Symbol: "ETH"})
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50100})
+
+	if len(strat.states) != 2 {
+		t.Fatalf("Expected 2 ticks, got %d", len(strat.states))
+	}
+	if strat.states[0].MarketSignal != nil {
+		t.Error("Expected no market signal before the provider emitted one")
+	}
+	if strat.states[1].MarketSignal != signal {
+		t.Errorf("Expected latest BTC signal to be injected, got %+v", strat.states[1].MarketSignal)
+	}
+
+	got, err := bot.MarketSignal(context.Background(), "BTC")
+	if err != nil || got != signal {
+		t.Errorf("Expected cached signal from API accessor, got %v, %v", got, err)
+	}
+}
+
+func TestBot_MarketSignalLookup(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeSignalProvider{}
+	bot := &Bot{signals: make(map[string]*entity.MarketSignal)}
+
+	// Without a provider only cached signals are served
+	if _, err := bot.MarketSignal(ctx, "BTC"); !errors.Is(err, api.ErrNoSignal) {
+		t.Errorf("Expected no signal without a provider, got %v", err)
+	}
+
+	// Before the first signal arrives it is fetched, by the normalized symbol
+	bot.signalProvider = provider
+	if _, err := bot.MarketSignal(ctx, "kPEPE-PERP"); !errors.Is(err, api.ErrNoSignal) {
+		t.Errorf("Expected no signal when the provider has none, got %v", err)
+	}
+	provider.fetched = &entity.MarketSignal{Symbol: "kPEPE", Bias: entity.SignalBiasBearish}
+	got, err := bot.MarketSignal(ctx, "kPEPE-PERP")
+	if err != nil || got != provider.fetched {
+		t.Errorf("Expected the fetched signal, got %v, %v", got, err)
+	}
+	if len(provider.fetches) != 2 || provider.fetches[1] != "kPEPE" {
+		t.Errorf("Expected fetches for kPEPE, got %v", provider.fetches)
+	}
+
+	// Cached signals are served without a fetch
+	bot.onMarketSignal(&entity.MarketSignal{Symbol: "BTC"})
+	if got, err := bot.MarketSignal(ctx, "btc"); err != nil || got.Symbol != "BTC" || len(provider.fetches) != 2 {
+		t.Errorf("Expected the cached BTC signal, got %v, %v after %d fetches", got, err, len(provider.fetches))
+	}
+}
+
+// chanNotifier forwards notifications to a channel
+type chanNotifier chan string
+
+func (c chanNotifier) Notify(ctx context.Context, level notify.Level, title, body string) error {
+	c <- title + " - " + body
+	return nil
+}
+
+func TestBot_MarketSignalAlerts(t *testing.T) {
+	sent := make(chanNotifier, 4)
+	bot := &Bot{
+		log:       logger.New(logger.LevelError, nil),
+		notifiers: []notify.Notifier{sent},
+		signals:   make(map[string]*entity.MarketSignal),
+		alerts: alert.NewEngine([]entity.AlertRule{
+			{Name: "bearish sentiment", Field: "sentiment_score", Op: "<", Threshold: -0.2, Message: "{symbol} sentiment {value}"},
+		}, time.Minute),
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, score := range []float64{0.1, -0.5, -0.6} {
+		bot.onMarketSignal(&entity.MarketSignal{
+			Symbol:          "BTC",
+			Timestamp:       start.Add(time.Duration(i) * time.Hour),
+			SocialSentiment: &entity.SocialSentiment{SentimentScore: score},
+		})
+	}
+
+	select {
+	case got := <-sent:
+		if got != "Alert: bearish sentiment - BTC sentiment -0.5" {
+			t.Errorf("Unexpected alert %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an alert when sentiment flipped bearish")
+	}
+	select {
+	case got := <-sent:
+		t.Errorf("Expected one alert while sentiment stays bearish, also got %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNewSignalProvider(t *testing.T) {
+	log := logger.New(logger.LevelError, nil)
+
+	cfg := &config.Config{}
+	cfg.Strategy.Name = "mean_reversion"
+	cfg.DataSources.LunarCrush.Enabled = true
+	cfg.DataSources.LunarCrush.APIKey = "key"
+	if p := newSignalProvider(cfg, nil, log); p != nil {
+		t.Error("Expected no signal provider for a strategy that ignores market signals")
+	}
+
+	cfg.Strategy.Name = "ai_signal"
+	if p := newSignalProvider(cfg, nil, log); p == nil {
+		t.Error("Expected signal provider for ai_signal with an enabled data source")
+	}
+
+	cfg.Strategy.Name = "ensemble"
+	cfg.Strategy.Params = map[string]interface{}{"strategies": []interface{}{
+		map[string]interface{}{"name": "mean_reversion"},
+		map[string]interface{}{"name": "ai_signal"},
+	}}
+	if p := newSignalProvider(cfg, nil, log); p == nil {
+		t.Error("Expected signal provider for an ensemble with an ai_signal child")
+	}
+
+	cfg.DataSources.LunarCrush.Enabled = false
+	if p := newSignalProvider(cfg, nil, log); p != nil {
+		t.Error("Expected no signal provider without enabled data sources")
+	}
+}
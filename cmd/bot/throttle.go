@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// tickThrottle limits how often the strategy sees ticks of a symbol: a tick
+// passes once Interval has elapsed since the last passed tick, or sooner when
+// the price moved at least MinMoveBps from it. Ticks in between are dropped;
+// the next passed tick carries the latest state.
+type tickThrottle struct {
+	interval time.Duration
+	minMove  float64 // Fractional price move that bypasses the interval (0 = never)
+
+	mu   sync.Mutex
+	last map[string]tickMark // Last passed tick by symbol
+}
+
+// tickMark records when and at what price a tick last passed
+type tickMark struct {
+	at    time.Time
+	price float64
+}
+
+// newTickThrottle creates a throttle, or returns nil when interval disables it
+func newTickThrottle(interval time.Duration, minMoveBps float64) *tickThrottle {
+	if interval <= 0 {
+		return nil
+	}
+	return &tickThrottle{
+		interval: interval,
+		minMove:  minMoveBps / 10000,
+		last:     make(map[string]tickMark),
+	}
+}
+
+// Allow reports whether a tick should reach the strategy, recording it if so
+func (t *tickThrottle) Allow(symbol string, price float64, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, ok := t.last[symbol]
+	if ok && now.Sub(last.at) < t.interval && !t.moved(last.price, price) {
+		return false
+	}
+	t.last[symbol] = tickMark{at: now, price: price}
+	return true
+}
+
+// moved reports whether price moved at least minMove from last
+func (t *tickThrottle) moved(last, price float64) bool {
+	return t.minMove > 0 && last > 0 && math.Abs(price-last)/last >= t.minMove
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+)
+
+func TestBot_ThrottlesTickBurst(t *testing.T) {
+	bot, market, strat := newPaperBot(t, &config.Config{})
+	tick := func(price float64) {
+		market.tick("BTC", price)
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: price})
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bot.now = func() time.Time { return now }
+	bot.throttle = newTickThrottle(500*time.Millisecond, 0)
+
+	// 100 ticks within 100ms of each other
+	for i := 0; i < 100; i++ {
+		tick(50000 + float64(i))
+		now = now.Add(time.Millisecond)
+	}
+	if len(strat.states) != 1 {
+		t.Fatalf("Expected the burst to reach the strategy once, got %d", len(strat.states))
+	}
+
+	// The first tick after the window carries the latest price
+	now = now.Add(500 * time.Millisecond)
+	tick(50200)
+	if len(strat.states) != 2 || strat.states[1].Ticker.LastPrice != 50200 {
+		t.Errorf("Expected the next window to see the latest tick, got %d states", len(strat.states))
+	}
+}
+
+func TestTickThrottle(t *testing.T) {
+	if newTickThrottle(0, 10) != nil {
+		t.Fatal("Expected no throttle without an interval")
+	}
+
+	th := newTickThrottle(time.Second, 10)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	steps := []struct {
+		symbol string
+		offset time.Duration
+		price  float64
+		want   bool
+	}{
+		{"BTC", 0, 50000, true},
+		{"BTC", 100 * time.Millisecond, 50040, false}, // 8bps: inside the window
+		{"ETH", 100 * time.Millisecond, 3000, true},   // Symbols are throttled separately
+		{"BTC", 200 * time.Millisecond, 50050, true},  // 10bps move bypasses the window
+		{"BTC", 300 * time.Millisecond, 50050, false},
+		{"BTC", 1200 * time.Millisecond, 50050, true}, // Window elapsed since the last pass
+	}
+	for i, s := range steps {
+		if got := th.Allow(s.symbol, s.price, start.Add(s.offset)); got != s.want {
+			t.Errorf("Step %d (%s @ %.0f): Allow = %v, want %v", i, s.symbol, s.price, got, s.want)
+		}
+	}
+}
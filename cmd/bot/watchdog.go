@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/api"
+)
+
+// Health reports the market data feed as healthy while the stream is
+// connected and a tick arrived within health.stale_after
+func (b *Bot) Health() api.Health {
+	stream, _ := b.exchange.(gateway.StreamGateway)
+
+	b.mu.RLock()
+	running, since := b.running, b.feedSinceLocked()
+	b.mu.RUnlock()
+
+	health := api.Health{Connected: stream == nil || stream.Connected(), LastTick: b.lastTickAt()}
+	switch {
+	case !running:
+		health.Reason = "bot not running"
+	case !health.Connected:
+		health.Reason = "websocket disconnected"
+	case b.now().Sub(since) > b.config.Health.StaleAfter:
+		health.Reason = fmt.Sprintf("no tick for %s", b.now().Sub(since).Round(time.Second))
+	default:
+		health.Healthy = true
+	}
+	return health
+}
+
+// feedSinceLocked returns the time the feed was last known live: the latest
+// tick, or the start before the first one. b.mu must be held.
+func (b *Bot) feedSinceLocked() time.Time {
+	if last := b.lastTickAt(); !last.IsZero() {
+		return last
+	}
+	return b.startedAt
+}
+
+// lastTickAt returns the receive time of the latest tick, zero before the first
+func (b *Bot) lastTickAt() time.Time {
+	b.tickMu.Lock()
+	defer b.tickMu.Unlock()
+	return b.lastTick
+}
+
+// runWatchdog checks the market data feed until ctx is done
+func (b *Bot) runWatchdog(ctx context.Context, staleAfter time.Duration) {
+	interval := staleAfter / 2
+	if interval > 5*time.Second {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !b.Running() {
+				return
+			}
+			b.checkFeed(ctx)
+		}
+	}
+}
+
+// checkFeed warns when no tick has arrived within health.stale_after and,
+// when health.reconnect is set, reconnects the stream. A still-stale feed is
+// retried once per threshold; recovery is logged once.
+func (b *Bot) checkFeed(ctx context.Context) {
+	staleAfter := b.config.Health.StaleAfter
+	now := b.now()
+
+	b.mu.Lock()
+	since := b.feedSinceLocked()
+	stale := now.Sub(since) > staleAfter
+	wasStale := b.feedStale
+	retry := stale && (!wasStale || now.Sub(b.feedAlertAt) >= staleAfter)
+	b.feedStale = stale
+	if retry {
+		b.feedAlertAt = now
+	}
+	b.mu.Unlock()
+
+	if !stale && wasStale {
+		b.log.Info("Market data feed recovered")
+	}
+	if !retry {
+		return
+	}
+
+	b.log.Warn("No tick for %s; market data feed may be dead", now.Sub(since).Round(time.Second))
+	if !b.config.Health.Reconnect {
+		return
+	}
+	stream, ok := b.exchange.(gateway.StreamGateway)
+	if !ok {
+		return
+	}
+	b.log.Info("Reconnecting market data stream")
+	if err := stream.Reconnect(ctx); err != nil {
+		b.log.Error("Failed to reconnect market data stream: %v", err)
+	}
+}
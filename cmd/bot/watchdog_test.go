@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/config"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/paper"
+)
+
+// streamMarket is a fakeMarket with a stream connection that can drop
+type streamMarket struct {
+	*fakeMarket
+	connected  bool
+	reconnects int
+}
+
+func (m *streamMarket) Connected() bool { return m.connected }
+
+func (m *streamMarket) Reconnect(ctx context.Context) error {
+	m.reconnects++
+	m.connected = true
+	return nil
+}
+
+// newWatchedBot returns a running paper bot on a stream market, with its
+// clock stopped at the start
+func newWatchedBot(t *testing.T, health config.HealthConfig) (*Bot, *streamMarket, *service.FakeClock) {
+	t.Helper()
+	cfg := &config.Config{Health: health}
+	bot, market, _ := newPaperBot(t, cfg)
+	stream := &streamMarket{fakeMarket: market, connected: true}
+	bot.exchange = paper.NewExchange(stream, paper.Config{InitialBalance: 100000})
+
+	clock := service.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	bot.now = clock.Now
+	bot.startedAt = clock.Now()
+	return bot, stream, clock
+}
+
+func TestBot_Health(t *testing.T) {
+	bot, stream, clock := newWatchedBot(t, config.HealthConfig{StaleAfter: time.Minute})
+
+	if h := bot.Health(); !h.Healthy || !h.LastTick.IsZero() {
+		t.Errorf("Expected a just-started bot to be healthy, got %+v", h)
+	}
+
+	clock.Advance(30 * time.Second)
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	clock.Advance(45 * time.Second)
+	if h := bot.Health(); !h.Healthy || !h.LastTick.Equal(clock.Now().Add(-45*time.Second)) {
+		t.Errorf("Expected healthy within the threshold of the last tick, got %+v", h)
+	}
+
+	clock.Advance(30 * time.Second)
+	if h := bot.Health(); h.Healthy || !strings.Contains(h.Reason, "no tick for 1m15s") {
+		t.Errorf("Expected a stale feed to be unhealthy, got %+v", h)
+	}
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	stream.connected = false
+	if h := bot.Health(); h.Healthy || h.Connected || h.Reason != "websocket disconnected" {
+		t.Errorf("Expected a disconnected stream to be unhealthy, got %+v", h)
+	}
+}
+
+func TestBot_WatchdogReconnects(t *testing.T) {
+	bot, stream, clock := newWatchedBot(t, config.HealthConfig{StaleAfter: time.Minute, Reconnect: true})
+	ctx := context.Background()
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	clock.Advance(30 * time.Second)
+	bot.checkFeed(ctx)
+	if stream.reconnects != 0 {
+		t.Fatalf("Expected no reconnect while ticks are fresh, got %d", stream.reconnects)
+	}
+
+	clock.Advance(45 * time.Second)
+	bot.checkFeed(ctx)
+	// Still stale on the next checks, but within the retry interval
+	clock.Advance(5 * time.Second)
+	bot.checkFeed(ctx)
+	if stream.reconnects != 1 {
+		t.Fatalf("Expected one reconnect when the feed went stale, got %d", stream.reconnects)
+	}
+
+	clock.Advance(time.Minute)
+	bot.checkFeed(ctx)
+	if stream.reconnects != 2 {
+		t.Errorf("Expected a retry once per threshold while stale, got %d", stream.reconnects)
+	}
+
+	bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+	bot.checkFeed(ctx)
+	if bot.feedStale {
+		t.Error("Expected a tick to clear the stale state")
+	}
+}
+
+func TestBot_WatchdogWithoutReconnect(t *testing.T) {
+	bot, stream, clock := newWatchedBot(t, config.HealthConfig{StaleAfter: time.Minute})
+
+	clock.Advance(2 * time.Minute)
+	bot.checkFeed(context.Background())
+	if !bot.feedStale || stream.reconnects != 0 {
+		t.Errorf("Expected a stale feed to be reported without reconnecting, got stale=%v reconnects=%d", bot.feedStale, stream.reconnects)
+	}
+}
+
+func TestBot_TickStampsWithoutWriteLock(t *testing.T) {
+	bot, _, clock := newWatchedBot(t, config.HealthConfig{StaleAfter: time.Minute})
+
+	// A tick only needs the read lock, so it is not held up by readers such as Health
+	bot.mu.RLock()
+	done := make(chan struct{})
+	go func() {
+		bot.onTicker(&entity.Ticker{Symbol: "BTC", LastPrice: 50000})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the tick to run while the bot state is read locked")
+	}
+	bot.mu.RUnlock()
+
+	if h := bot.Health(); !h.LastTick.Equal(clock.Now()) {
+		t.Errorf("Expected the tick stamped, got %+v", h)
+	}
+}
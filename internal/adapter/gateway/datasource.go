@@ -33,6 +33,32 @@ type DataSourceGateway interface {
 	SubscribeWhaleAlerts(ctx context.Context, handler func(*entity.WhaleAlert)) error
 }
 
+// SentimentProvider supplies social sentiment for symbols
+type SentimentProvider interface {
+	// GetSentiment retrieves current sentiment for a symbol
+	GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error)
+
+	// SubscribeSentiment subscribes to sentiment updates for a symbol
+	SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error
+}
+
+// SentimentGateway defines social sentiment data source interface
+type SentimentGateway interface {
+	SentimentProvider
+
+	// Connect establishes connection to data source
+	Connect(ctx context.Context) error
+
+	// Disconnect closes connection
+	Disconnect(ctx context.Context) error
+}
+
+// FearGreedGateway defines the market-wide fear & greed index source
+type FearGreedGateway interface {
+	// GetIndex retrieves the latest index value
+	GetIndex(ctx context.Context) (*entity.FearGreedIndex, error)
+}
+
 // MarketSignalProvider aggregates multiple data sources for trading signals
 type MarketSignalProvider interface {
 	// Start starts all data source connections
@@ -47,3 +47,29 @@ type ExchangeGateway interface {
 	// SubscribeOrders subscribes to order updates
 	SubscribeOrders(ctx context.Context, handler func(*entity.Order)) error
 }
+
+// AccountGateway is implemented by exchanges that report account equity
+type AccountGateway interface {
+	// GetAccountValue returns the account equity in USD
+	GetAccountValue(ctx context.Context) (float64, error)
+
+	// GetBalance returns the account equity and margin usage
+	GetBalance(ctx context.Context) (*entity.AccountBalance, error)
+}
+
+// StreamGateway is implemented by exchanges whose market data arrives over a
+// stream connection that can silently drop
+type StreamGateway interface {
+	// Connected reports whether the stream is connected
+	Connected() bool
+
+	// Reconnect re-establishes the stream and restores its subscriptions
+	Reconnect(ctx context.Context) error
+}
+
+// LimitsGateway is implemented by exchanges that reject orders below a
+// minimum size or notional
+type LimitsGateway interface {
+	// OrderLimits returns the minimum order requirements for a symbol
+	OrderLimits(ctx context.Context, symbol string) (entity.OrderLimits, error)
+}
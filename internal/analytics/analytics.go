@@ -0,0 +1,141 @@
+// Package analytics computes risk-adjusted performance metrics over returns
+// and equity series. All functions are pure and return 0 when a metric is
+// undefined (too few points, zero deviation or no drawdown).
+package analytics
+
+import (
+	"math"
+)
+
+// epsilon treats deviations below it as zero, so float noise in a constant
+// series does not produce huge ratios
+const epsilon = 1e-12
+
+// Returns converts an equity curve into per-period fractional returns,
+// starting from initial. Periods starting from non-positive equity are skipped.
+func Returns(initial float64, equity []float64) []float64 {
+	returns := make([]float64, 0, len(equity))
+	prev := initial
+	for _, eq := range equity {
+		if prev > 0 {
+			returns = append(returns, (eq-prev)/prev)
+		}
+		prev = eq
+	}
+	return returns
+}
+
+// Sharpe returns the Sharpe ratio of per-period returns: mean excess return
+// over its standard deviation. riskFreeRate is annual and periodsPerYear
+// annualizes the ratio; with periodsPerYear <= 0 nothing is annualized and
+// riskFreeRate is taken per period.
+func Sharpe(returns []float64, riskFreeRate, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	rf := periodRate(riskFreeRate, periodsPerYear)
+	sd := stdDev(returns)
+	if sd < epsilon {
+		return 0
+	}
+	return annualize((mean(returns)-rf)/sd, periodsPerYear)
+}
+
+// Sortino returns the Sortino ratio: like Sharpe, but dividing by the
+// downside deviation, so only returns below the risk-free rate count as risk
+func Sortino(returns []float64, riskFreeRate, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	rf := periodRate(riskFreeRate, periodsPerYear)
+	var downside float64
+	for _, r := range returns {
+		if d := r - rf; d < 0 {
+			downside += d * d
+		}
+	}
+	dd := math.Sqrt(downside / float64(len(returns)))
+	if dd < epsilon {
+		return 0
+	}
+	return annualize((mean(returns)-rf)/dd, periodsPerYear)
+}
+
+// MaxDrawdown returns the largest fractional decline of equity from its
+// running peak, starting the peak at initial (0-1)
+func MaxDrawdown(initial float64, equity []float64) float64 {
+	peak := initial
+	maxDD := 0.0
+	for _, eq := range equity {
+		peak = math.Max(peak, eq)
+		if peak > 0 {
+			maxDD = math.Max(maxDD, (peak-eq)/peak)
+		}
+	}
+	return maxDD
+}
+
+// Calmar returns the annualized compound return over the equity curve
+// divided by its max drawdown. It needs periodsPerYear to annualize.
+func Calmar(initial float64, equity []float64, periodsPerYear float64) float64 {
+	if initial <= 0 || len(equity) == 0 || periodsPerYear <= 0 {
+		return 0
+	}
+	dd := MaxDrawdown(initial, equity)
+	final := equity[len(equity)-1]
+	if dd == 0 || final <= 0 {
+		return 0
+	}
+	years := float64(len(equity)) / periodsPerYear
+	cagr := math.Pow(final/initial, 1/years) - 1
+	return cagr / dd
+}
+
+// RollingVolatility returns the standard deviation of each window of returns,
+// annualized by periodsPerYear when positive. Element i covers
+// returns[i : i+window]; the result is empty when there are fewer returns than window.
+func RollingVolatility(returns []float64, window int, periodsPerYear float64) []float64 {
+	if window < 2 || len(returns) < window {
+		return nil
+	}
+	vols := make([]float64, 0, len(returns)-window+1)
+	for i := 0; i+window <= len(returns); i++ {
+		vols = append(vols, annualize(stdDev(returns[i:i+window]), periodsPerYear))
+	}
+	return vols
+}
+
+// periodRate converts an annual rate to a per-period rate
+func periodRate(annual, periodsPerYear float64) float64 {
+	if periodsPerYear <= 0 {
+		return annual
+	}
+	return annual / periodsPerYear
+}
+
+// annualize scales a per-period ratio or deviation by sqrt(periodsPerYear)
+func annualize(v, periodsPerYear float64) float64 {
+	if periodsPerYear <= 0 {
+		return v
+	}
+	return v * math.Sqrt(periodsPerYear)
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stdDev returns the population standard deviation of values
+func stdDev(values []float64) float64 {
+	m := mean(values)
+	var variance float64
+	for _, v := range values {
+		variance += (v - m) * (v - m)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestReturns(t *testing.T) {
+	got := Returns(100, []float64{110, 99, 99})
+	want := []float64{0.1, -0.1, 0}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d returns, got %v", len(want), got)
+	}
+	for i := range want {
+		if !approx(got[i], want[i]) {
+			t.Errorf("Return %d: expected %f, got %f", i, want[i], got[i])
+		}
+	}
+	if got := Returns(0, []float64{0, 10}); len(got) != 0 {
+		t.Errorf("Expected periods from zero equity skipped, got %v", got)
+	}
+}
+
+func TestSharpe(t *testing.T) {
+	// Mean 0.01, population standard deviation 0.02
+	returns := []float64{0.03, -0.01, 0.03, -0.01}
+	if got := Sharpe(returns, 0, 0); !approx(got, 0.5) {
+		t.Errorf("Expected Sharpe 0.5, got %f", got)
+	}
+	if got := Sharpe(returns, 0, 252); !approx(got, 0.5*math.Sqrt(252)) {
+		t.Errorf("Expected annualized Sharpe, got %f", got)
+	}
+	// 2.52 annual over 252 periods = 0.01 per period: no excess return
+	if got := Sharpe(returns, 2.52, 252); !approx(got, 0) {
+		t.Errorf("Expected zero Sharpe at the risk-free rate, got %f", got)
+	}
+}
+
+func TestSortino(t *testing.T) {
+	// Mean 0.01; downside deviation sqrt((0.01^2+0.01^2)/4) = 0.01/sqrt(2)
+	returns := []float64{0.03, -0.01, 0.03, -0.01}
+	if got := Sortino(returns, 0, 0); !approx(got, math.Sqrt2) {
+		t.Errorf("Expected Sortino sqrt(2), got %f", got)
+	}
+	if got := Sortino([]float64{0.01, 0.02, 0.03}, 0, 0); got != 0 {
+		t.Errorf("Expected zero Sortino without downside, got %f", got)
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	if got := MaxDrawdown(1000, []float64{1200, 900, 1100, 600, 1300}); !approx(got, 0.5) {
+		t.Errorf("Expected max drawdown 0.5, got %f", got)
+	}
+	// The initial equity counts as the first peak
+	if got := MaxDrawdown(1000, []float64{800}); !approx(got, 0.2) {
+		t.Errorf("Expected drawdown from the initial equity, got %f", got)
+	}
+}
+
+func TestCalmar(t *testing.T) {
+	// Doubling over one year with a 20% drawdown
+	equity := []float64{1200, 960, 1500, 2000}
+	if got := Calmar(1000, equity, 4); !approx(got, 1/0.2) {
+		t.Errorf("Expected Calmar 5, got %f", got)
+	}
+	if got := Calmar(1000, equity, 0); got != 0 {
+		t.Errorf("Expected zero Calmar without an annualization factor, got %f", got)
+	}
+}
+
+func TestRollingVolatility(t *testing.T) {
+	vols := RollingVolatility([]float64{0.01, 0.03, 0.01, 0.01, 0.01}, 2, 0)
+	want := []float64{0.01, 0.01, 0, 0}
+	if len(vols) != len(want) {
+		t.Fatalf("Expected %d windows, got %v", len(want), vols)
+	}
+	for i := range want {
+		if !approx(vols[i], want[i]) {
+			t.Errorf("Window %d: expected %f, got %f", i, want[i], vols[i])
+		}
+	}
+	if got := RollingVolatility([]float64{0.01, 0.03}, 2, 4); !approx(got[0], 0.02) {
+		t.Errorf("Expected annualized volatility 0.02, got %v", got)
+	}
+	if got := RollingVolatility([]float64{0.01}, 2, 0); got != nil {
+		t.Errorf("Expected no windows for a short series, got %v", got)
+	}
+}
+
+func TestEmptyAndConstantSeries(t *testing.T) {
+	constant := []float64{0.01, 0.01, 0.01, 0.01}
+	flat := []float64{1000, 1000, 1000}
+
+	tests := []struct {
+		name string
+		got  float64
+	}{
+		{"sharpe empty", Sharpe(nil, 0, 252)},
+		{"sharpe constant", Sharpe(constant, 0, 252)},
+		{"sortino empty", Sortino(nil, 0, 252)},
+		{"sortino constant", Sortino(constant, 0, 252)},
+		{"max drawdown empty", MaxDrawdown(1000, nil)},
+		{"max drawdown flat", MaxDrawdown(1000, flat)},
+		{"calmar empty", Calmar(1000, nil, 252)},
+		{"calmar flat", Calmar(1000, flat, 252)},
+	}
+	for _, tt := range tests {
+		if tt.got != 0 || math.IsNaN(tt.got) {
+			t.Errorf("%s: expected 0, got %f", tt.name, tt.got)
+		}
+	}
+
+	if got := RollingVolatility(nil, 3, 0); got != nil {
+		t.Errorf("Expected no volatility for an empty series, got %v", got)
+	}
+	for _, v := range RollingVolatility(constant, 2, 0) {
+		if !approx(v, 0) {
+			t.Errorf("Expected zero volatility for a constant series, got %f", v)
+		}
+	}
+	if got := Returns(1000, nil); len(got) != 0 {
+		t.Errorf("Expected no returns for an empty curve, got %v", got)
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+// AccountBalance is a margin account's equity and margin usage in USD
+type AccountBalance struct {
+	Equity       float64 // Account value: collateral plus unrealized PnL
+	MarginUsed   float64 // Margin held by open positions
+	Notional     float64 // Total absolute position notional
+	Withdrawable float64 // Equity not held as margin
+}
+
+// Leverage returns position notional over equity; 0 without equity
+func (b *AccountBalance) Leverage() float64 {
+	if b == nil || b.Equity <= 0 {
+		return 0
+	}
+	return b.Notional / b.Equity
+}
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AlertRule is a condition on a market signal field that raises an alert when
+// it starts to hold, e.g. funding_rate > 0.0005
+type AlertRule struct {
+	Name      string        `yaml:"name"`
+	Symbol    string        `yaml:"symbol"` // Empty = every symbol
+	Field     string        `yaml:"field"`  // One of AlertFields
+	Op        string        `yaml:"op"`     // >, >=, <, <=, == or !=
+	Threshold float64       `yaml:"threshold"`
+	Message   string        `yaml:"message"`  // {name}, {symbol}, {field}, {op}, {threshold} and {value} are replaced; empty = default
+	Debounce  time.Duration `yaml:"debounce"` // Minimum time between alerts of this rule per symbol (0 = engine default)
+}
+
+// biasValue maps a bias to -1 (bearish), 0 (neutral) or 1 (bullish) for comparison
+func biasValue(b SignalBias) (float64, bool) {
+	switch b {
+	case SignalBiasBullish:
+		return 1, true
+	case SignalBiasBearish:
+		return -1, true
+	case SignalBiasNeutral:
+		return 0, true
+	}
+	return 0, false
+}
+
+// AlertFields are the market signal fields rules can test, by name. Each
+// returns the value and whether the signal carries it. Biases compare as -1
+// (bearish), 0 (neutral) and 1 (bullish); rates and probabilities are
+// fractions, changes are percentages.
+var AlertFields = map[string]func(s *MarketSignal) (float64, bool){
+	"funding_rate": func(s *MarketSignal) (float64, bool) {
+		if s.FundingRate == nil {
+			return 0, false
+		}
+		return s.FundingRate.Rate, true
+	},
+	"long_short_ratio": func(s *MarketSignal) (float64, bool) {
+		if s.LongShortRatio == nil {
+			return 0, false
+		}
+		return s.LongShortRatio.LongShortRatio, true
+	},
+	"open_interest": func(s *MarketSignal) (float64, bool) {
+		if s.OpenInterest == nil {
+			return 0, false
+		}
+		return s.OpenInterest.OpenInterest, true
+	},
+	"oi_change_24h": func(s *MarketSignal) (float64, bool) {
+		if s.OpenInterest == nil {
+			return 0, false
+		}
+		return s.OpenInterest.Change24h, true
+	},
+	"price_change_24h": func(s *MarketSignal) (float64, bool) {
+		return s.PriceChange24h, s.PriceChange24h != 0
+	},
+	"sentiment_score": func(s *MarketSignal) (float64, bool) {
+		if s.SocialSentiment == nil {
+			return 0, false
+		}
+		return s.SocialSentiment.SentimentScore, true
+	},
+	"social_volume": func(s *MarketSignal) (float64, bool) {
+		if s.SocialSentiment == nil {
+			return 0, false
+		}
+		return float64(s.SocialSentiment.SocialVolume), true
+	},
+	"sentiment_momentum": func(s *MarketSignal) (float64, bool) {
+		if s.SentimentTrend == nil {
+			return 0, false
+		}
+		return s.SentimentTrend.Change(), true
+	},
+	"fear_greed": func(s *MarketSignal) (float64, bool) {
+		if s.FearGreed == nil {
+			return 0, false
+		}
+		return float64(s.FearGreed.Value), true
+	},
+	"fed_cut_prob": func(s *MarketSignal) (float64, bool) {
+		return s.FedCutProb, s.FedCutProb > 0 || s.FedHikeProb > 0
+	},
+	"fed_hike_prob": func(s *MarketSignal) (float64, bool) {
+		return s.FedHikeProb, s.FedCutProb > 0 || s.FedHikeProb > 0
+	},
+	"macro_bias": func(s *MarketSignal) (float64, bool) {
+		return biasValue(s.MacroBias)
+	},
+	"macro_strength": func(s *MarketSignal) (float64, bool) {
+		return s.MacroStrength, s.MacroBias != ""
+	},
+	"bias": func(s *MarketSignal) (float64, bool) {
+		return biasValue(s.Bias)
+	},
+	"strength": func(s *MarketSignal) (float64, bool) {
+		return s.Strength, true
+	},
+	"confidence": func(s *MarketSignal) (float64, bool) {
+		return s.Confidence, true
+	},
+}
+
+// alertOps are the comparison operators rules can use
+var alertOps = map[string]func(v, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// Validate reports whether the rule names a known field and operator
+func (r AlertRule) Validate() error {
+	if _, ok := AlertFields[r.Field]; !ok {
+		fields := make([]string, 0, len(AlertFields))
+		for name := range AlertFields {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+		return fmt.Errorf("unknown field %q (one of %s)", r.Field, strings.Join(fields, ", "))
+	}
+	if _, ok := alertOps[r.Op]; !ok {
+		return fmt.Errorf("unknown op %q (one of >, >=, <, <=, ==, !=)", r.Op)
+	}
+	if r.Debounce < 0 {
+		return fmt.Errorf("debounce must be non-negative")
+	}
+	return nil
+}
+
+// Applies reports whether the rule covers the signal's symbol
+func (r AlertRule) Applies(signal *MarketSignal) bool {
+	return r.Symbol == "" || Symbol(r.Symbol).key() == Symbol(signal.Symbol).key()
+}
+
+// Evaluate returns the field value and whether the condition holds; ok is
+// false when the signal lacks the field or the rule is invalid
+func (r AlertRule) Evaluate(signal *MarketSignal) (value float64, met, ok bool) {
+	field, fieldOK := AlertFields[r.Field]
+	op, opOK := alertOps[r.Op]
+	if !fieldOK || !opOK {
+		return 0, false, false
+	}
+	if value, ok = field(signal); !ok {
+		return 0, false, false
+	}
+	return value, op(value, r.Threshold), true
+}
+
+// Format renders the rule's message for a symbol and field value
+func (r AlertRule) Format(symbol string, value float64) string {
+	message := r.Message
+	if message == "" {
+		message = "{symbol} {field} is {value} ({op} {threshold})"
+	}
+	return strings.NewReplacer(
+		"{name}", r.Name,
+		"{symbol}", symbol,
+		"{field}", r.Field,
+		"{op}", r.Op,
+		"{threshold}", strconv.FormatFloat(r.Threshold, 'g', -1, 64),
+		"{value}", strconv.FormatFloat(value, 'g', 6, 64),
+	).Replace(message)
+}
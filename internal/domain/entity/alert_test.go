@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAlertRule_Validate(t *testing.T) {
+	if err := (AlertRule{Field: "funding_rate", Op: ">="}).Validate(); err != nil {
+		t.Errorf("Expected valid rule, got %v", err)
+	}
+	if err := (AlertRule{Field: "funding", Op: ">"}).Validate(); err == nil || !strings.Contains(err.Error(), "funding_rate") {
+		t.Errorf("Expected unknown field error listing the fields, got %v", err)
+	}
+	if err := (AlertRule{Field: "funding_rate", Op: "=>"}).Validate(); err == nil {
+		t.Error("Expected unknown op error")
+	}
+}
+
+func TestAlertRule_Evaluate(t *testing.T) {
+	signal := &MarketSignal{
+		Symbol:          "BTC",
+		SocialSentiment: &SocialSentiment{SentimentScore: -0.4},
+		MacroBias:       SignalBiasBearish,
+	}
+
+	if v, met, ok := (AlertRule{Field: "sentiment_score", Op: "<", Threshold: -0.2}).Evaluate(signal); !ok || !met || v != -0.4 {
+		t.Errorf("Expected bearish sentiment to meet the rule, got %v %v %v", v, met, ok)
+	}
+	if v, met, ok := (AlertRule{Field: "macro_bias", Op: "==", Threshold: -1}).Evaluate(signal); !ok || !met || v != -1 {
+		t.Errorf("Expected bearish macro bias as -1, got %v %v %v", v, met, ok)
+	}
+	if _, _, ok := (AlertRule{Field: "funding_rate", Op: ">", Threshold: 0}).Evaluate(signal); ok {
+		t.Error("Expected a missing field not to evaluate")
+	}
+}
+
+func TestAlertRule_Format(t *testing.T) {
+	rule := AlertRule{Name: "funding", Field: "funding_rate", Op: ">", Threshold: 0.0005}
+	if got := rule.Format("BTC", 0.0007); got != "BTC funding_rate is 0.0007 (> 0.0005)" {
+		t.Errorf("Unexpected default message %q", got)
+	}
+	rule.Message = "{name}: {symbol} at {value}"
+	if got := rule.Format("BTC", 0.0007); got != "funding: BTC at 0.0007" {
+		t.Errorf("Unexpected templated message %q", got)
+	}
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Asset maps a base trading symbol to the names data providers use for it
+type Asset struct {
+	Symbol     string `yaml:"symbol"`     // Base symbol, e.g. "AVAX"
+	Blockchain string `yaml:"blockchain"` // Whale Alert blockchain, e.g. "avalanche"; empty = not tracked
+	Topic      string `yaml:"topic"`      // LunarCrush topic, e.g. "avalanche"; empty = lower-case symbol
+}
+
+// AssetRegistry resolves symbols, blockchains, social topics and exchange
+// owner names. It is immutable; use With to derive an overridden copy.
+type AssetRegistry struct {
+	assets       map[string]Asset  // upper-case symbol -> asset
+	byChain      map[string]string // blockchain -> symbol
+	ownerAliases map[string]string // lower-case owner alias -> canonical exchange
+	exchanges    map[string]bool   // canonical exchange names
+}
+
+// defaultAssets are the assets known without configuration
+var defaultAssets = []Asset{
+	{Symbol: "BTC", Blockchain: "bitcoin", Topic: "bitcoin"},
+	{Symbol: "ETH", Blockchain: "ethereum", Topic: "ethereum"},
+	{Symbol: "SOL", Blockchain: "solana", Topic: "solana"},
+	{Symbol: "TRX", Blockchain: "tron", Topic: "tron"},
+	{Symbol: "XRP", Blockchain: "ripple", Topic: "xrp"},
+	{Symbol: "DOGE", Blockchain: "dogecoin", Topic: "dogecoin"},
+	{Symbol: "LTC", Blockchain: "litecoin", Topic: "litecoin"},
+	{Symbol: "ADA", Blockchain: "cardano", Topic: "cardano"},
+	{Symbol: "AVAX", Topic: "avalanche"},
+	{Symbol: "DOT", Topic: "polkadot"},
+	{Symbol: "LINK", Topic: "chainlink"},
+	{Symbol: "MATIC", Topic: "polygon"},
+}
+
+// defaultExchanges maps canonical exchange owner names to the aliases Whale Alert reports
+var defaultExchanges = map[string][]string{
+	"binance":  {"Binance"},
+	"coinbase": {"Coinbase"},
+	"kraken":   {"Kraken"},
+	"bitfinex": {"Bitfinex"},
+	"bybit":    {"Bybit"},
+	"okx":      {"OKX", "OKEx"},
+	"huobi":    {"Huobi", "HTX"},
+	"kucoin":   {"KuCoin"},
+	"gate.io":  {"Gate.io"},
+}
+
+// DefaultAssetRegistry returns a registry of the built-in assets and exchanges
+func DefaultAssetRegistry() *AssetRegistry {
+	r := &AssetRegistry{
+		assets:       make(map[string]Asset),
+		byChain:      make(map[string]string),
+		ownerAliases: make(map[string]string),
+		exchanges:    make(map[string]bool),
+	}
+	return r.With(defaultAssets, defaultExchanges)
+}
+
+// With returns a copy of the registry with assets replacing entries of the same
+// symbol and exchanges adding owner aliases (canonical name -> aliases)
+func (r *AssetRegistry) With(assets []Asset, exchanges map[string][]string) *AssetRegistry {
+	next := &AssetRegistry{
+		assets:       make(map[string]Asset, len(r.assets)+len(assets)),
+		byChain:      make(map[string]string, len(r.byChain)+len(assets)),
+		ownerAliases: make(map[string]string, len(r.ownerAliases)),
+		exchanges:    make(map[string]bool, len(r.exchanges)+len(exchanges)),
+	}
+	for symbol, a := range r.assets {
+		next.assets[symbol] = a
+	}
+	for chain, symbol := range r.byChain {
+		next.byChain[chain] = symbol
+	}
+	for alias, name := range r.ownerAliases {
+		next.ownerAliases[alias] = name
+	}
+	for name := range r.exchanges {
+		next.exchanges[name] = true
+	}
+
+	for _, a := range assets {
+		a.Symbol = Symbol(a.Symbol).key()
+		if old, ok := next.assets[a.Symbol]; ok && old.Blockchain != "" {
+			delete(next.byChain, strings.ToLower(old.Blockchain))
+		}
+		next.assets[a.Symbol] = a
+		if a.Blockchain != "" {
+			next.byChain[strings.ToLower(a.Blockchain)] = a.Symbol
+		}
+	}
+	for name, aliases := range exchanges {
+		name = strings.ToLower(name)
+		next.exchanges[name] = true
+		next.ownerAliases[name] = name
+		for _, alias := range aliases {
+			next.ownerAliases[strings.ToLower(alias)] = name
+		}
+	}
+	return next
+}
+
+// SymbolForBlockchain returns the symbol tracked for a Whale Alert blockchain, or "" if none
+func (r *AssetRegistry) SymbolForBlockchain(blockchain string) string {
+	return r.byChain[strings.ToLower(blockchain)]
+}
+
+// Blockchain returns the Whale Alert blockchain tracked for a symbol, or "" if none
+func (r *AssetRegistry) Blockchain(symbol string) string {
+	return strings.ToLower(r.assets[Symbol(symbol).key()].Blockchain)
+}
+
+// Topic returns the LunarCrush topic for a symbol, defaulting to the lower-case symbol
+func (r *AssetRegistry) Topic(symbol string) string {
+	base := Symbol(symbol).Base()
+	if a, ok := r.assets[Symbol(symbol).key()]; ok && a.Topic != "" {
+		return a.Topic
+	}
+	return strings.ToLower(base)
+}
+
+// NormalizeOwner maps an exchange alias to its canonical name; other owners are
+// returned unchanged and an empty owner is "unknown"
+func (r *AssetRegistry) NormalizeOwner(owner string) string {
+	if owner == "" {
+		return "unknown"
+	}
+	if name, ok := r.ownerAliases[strings.ToLower(owner)]; ok {
+		return name
+	}
+	return owner
+}
+
+// IsExchange reports whether an owner is a known exchange
+func (r *AssetRegistry) IsExchange(owner string) bool {
+	return r.exchanges[r.NormalizeOwner(owner)]
+}
+
+// registry is the process-wide registry, replaced at startup from configuration
+var registry atomic.Pointer[AssetRegistry]
+
+func init() {
+	registry.Store(DefaultAssetRegistry())
+}
+
+// Assets returns the process-wide asset registry
+func Assets() *AssetRegistry {
+	return registry.Load()
+}
+
+// SetAssets replaces the process-wide asset registry; nil restores the defaults
+func SetAssets(r *AssetRegistry) {
+	if r == nil {
+		r = DefaultAssetRegistry()
+	}
+	registry.Store(r)
+}
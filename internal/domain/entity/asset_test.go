@@ -0,0 +1,61 @@
+package entity
+
+import "testing"
+
+func TestAssetRegistry_Defaults(t *testing.T) {
+	r := DefaultAssetRegistry()
+
+	if got := r.SymbolForBlockchain("bitcoin"); got != "BTC" {
+		t.Errorf("Expected bitcoin -> BTC, got %q", got)
+	}
+	if got := r.SymbolForBlockchain("avalanche"); got != "" {
+		t.Errorf("Expected avalanche to be unmapped by default, got %q", got)
+	}
+	if got := r.Topic("matic"); got != "polygon" {
+		t.Errorf("Expected MATIC topic polygon, got %q", got)
+	}
+	if got := r.Topic("PEPE"); got != "pepe" {
+		t.Errorf("Expected unknown symbols to use the lower-case symbol, got %q", got)
+	}
+	if got := r.NormalizeOwner("OKEx"); got != "okx" {
+		t.Errorf("Expected OKEx -> okx, got %q", got)
+	}
+	if got := r.NormalizeOwner(""); got != "unknown" {
+		t.Errorf("Expected empty owner -> unknown, got %q", got)
+	}
+	if !r.IsExchange("Binance") || r.IsExchange("unknown") {
+		t.Error("Expected Binance to be an exchange and unknown not")
+	}
+}
+
+func TestAssetRegistry_With(t *testing.T) {
+	base := DefaultAssetRegistry()
+	r := base.With(
+		[]Asset{
+			{Symbol: "avax", Blockchain: "avalanche", Topic: "avalanche"},
+			{Symbol: "TRX", Blockchain: "tron-mainnet"}, // Replaces the default entry
+		},
+		map[string][]string{"MEXC": {"MEXC Global"}},
+	)
+
+	if got := r.SymbolForBlockchain("avalanche"); got != "AVAX" {
+		t.Errorf("Expected avalanche -> AVAX, got %q", got)
+	}
+	if got := r.SymbolForBlockchain("tron"); got != "" {
+		t.Errorf("Expected the replaced tron mapping to be dropped, got %q", got)
+	}
+	if got := r.SymbolForBlockchain("tron-mainnet"); got != "TRX" {
+		t.Errorf("Expected tron-mainnet -> TRX, got %q", got)
+	}
+	if got := r.Topic("TRX"); got != "trx" {
+		t.Errorf("Expected replaced TRX without a topic to use the symbol, got %q", got)
+	}
+	if got := r.NormalizeOwner("MEXC Global"); got != "mexc" || !r.IsExchange("MEXC Global") {
+		t.Errorf("Expected MEXC Global to be the mexc exchange, got %q", got)
+	}
+
+	// The base registry is unchanged
+	if base.SymbolForBlockchain("avalanche") != "" || base.IsExchange("mexc") {
+		t.Error("Expected With to leave the base registry unchanged")
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+// Liquidity indicates whether a fill added (maker) or removed (taker) liquidity
+type Liquidity string
+
+const (
+	LiquidityMaker Liquidity = "maker"
+	LiquidityTaker Liquidity = "taker"
+)
+
+// FeeModel holds maker and taker fee rates in basis points of notional
+type FeeModel struct {
+	MakerBps float64 `yaml:"maker_bps"`
+	TakerBps float64 `yaml:"taker_bps"`
+}
+
+// DefaultFeeModel returns the Hyperliquid base tier fees (0.015% maker, 0.045% taker)
+func DefaultFeeModel() FeeModel {
+	return FeeModel{
+		MakerBps: 1.5,
+		TakerBps: 4.5,
+	}
+}
+
+// Fee returns the fee charged for a fill of qty at price
+func (m FeeModel) Fee(price, qty float64, liquidity Liquidity) float64 {
+	bps := m.TakerBps
+	if liquidity == LiquidityMaker {
+		bps = m.MakerBps
+	}
+	return price * qty * bps / 10000
+}
+
+// OrderLiquidity returns the liquidity an order is expected to take.
+// Only post-only orders are guaranteed makers; market, IOC and plain limit
+// orders are conservatively charged as takers.
+func OrderLiquidity(order *Order) Liquidity {
+	if order.Type == OrderTypeLimit && order.TimeInForce == TimeInForcePostOnly {
+		return LiquidityMaker
+	}
+	return LiquidityTaker
+}
+
+// GrossPnL returns the PnL of closing qty of a position on side opened at entry and closed at exit
+func GrossPnL(side Side, entry, exit, qty float64) float64 {
+	pnl := (exit - entry) * qty
+	if side == SideSell {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// NetPnL returns GrossPnL minus the entry and exit fees on the closed quantity
+func (m FeeModel) NetPnL(side Side, entry, exit, qty float64, entryLiquidity, exitLiquidity Liquidity) float64 {
+	return GrossPnL(side, entry, exit, qty) - m.Fee(entry, qty, entryLiquidity) - m.Fee(exit, qty, exitLiquidity)
+}
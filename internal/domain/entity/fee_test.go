@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeeModel_Fee(t *testing.T) {
+	m := FeeModel{MakerBps: 1.5, TakerBps: 4.5}
+
+	if got := m.Fee(50000, 0.1, LiquidityMaker); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("Expected maker fee 0.75, got %f", got)
+	}
+	if got := m.Fee(50000, 0.1, LiquidityTaker); math.Abs(got-2.25) > 1e-9 {
+		t.Errorf("Expected taker fee 2.25, got %f", got)
+	}
+}
+
+func TestFeeModel_BreakEvenIsNetLoss(t *testing.T) {
+	m := DefaultFeeModel()
+
+	for _, side := range []Side{SideBuy, SideSell} {
+		if gross := GrossPnL(side, 50000, 50000, 1); gross != 0 {
+			t.Errorf("%s: expected zero gross PnL, got %f", side, gross)
+		}
+		net := m.NetPnL(side, 50000, 50000, 1, LiquidityTaker, LiquidityTaker)
+		if net >= 0 {
+			t.Errorf("%s: expected break-even trade to be a net loss, got %f", side, net)
+		}
+		if math.Abs(net+45) > 1e-9 {
+			t.Errorf("%s: expected net loss of 45 (2 x 4.5bps), got %f", side, net)
+		}
+	}
+}
+
+func TestFeeModel_NetPnLDirection(t *testing.T) {
+	m := FeeModel{}
+
+	if got := m.NetPnL(SideBuy, 100, 110, 2, LiquidityTaker, LiquidityTaker); got != 20 {
+		t.Errorf("Expected long profit of 20, got %f", got)
+	}
+	if got := m.NetPnL(SideSell, 100, 110, 2, LiquidityTaker, LiquidityTaker); got != -20 {
+		t.Errorf("Expected short loss of 20, got %f", got)
+	}
+}
+
+func TestOrderLiquidity(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  Liquidity
+	}{
+		{"post-only limit", Order{Type: OrderTypeLimit, TimeInForce: TimeInForcePostOnly}, LiquidityMaker},
+		{"gtc limit", Order{Type: OrderTypeLimit, TimeInForce: TimeInForceGTC}, LiquidityTaker},
+		{"ioc limit", Order{Type: OrderTypeLimit, TimeInForce: TimeInForceIOC}, LiquidityTaker},
+		{"market", Order{Type: OrderTypeMarket}, LiquidityTaker},
+	}
+	for _, tt := range tests {
+		if got := OrderLiquidity(&tt.order); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+// OrderLimits are an exchange's minimum order requirements for a symbol
+type OrderLimits struct {
+	MinNotional float64 // Minimum order value in USD (0 = none)
+	MinSize     float64 // Size increment and smallest size; sizes are rounded to it (0 = none)
+}
+
+// roundSize rounds a size to the nearest MinSize increment, as the exchange does
+func (l OrderLimits) roundSize(size float64) float64 {
+	if l.MinSize <= 0 {
+		return size
+	}
+	return math.Round(size/l.MinSize) * l.MinSize
+}
+
+// Check returns why an order of quantity at price falls below the limits,
+// or an empty string when it meets them
+func (l OrderLimits) Check(quantity, price float64) string {
+	size := l.roundSize(quantity)
+	if l.MinSize > 0 && size < l.MinSize {
+		return fmt.Sprintf("size %g is below the minimum %g", quantity, l.MinSize)
+	}
+	if l.MinNotional > 0 && size*price < l.MinNotional {
+		return fmt.Sprintf("notional $%.2f (%g at %g) is below the minimum $%.2f", size*price, size, price, l.MinNotional)
+	}
+	return ""
+}
+
+// MinQuantity returns the smallest quantity that meets the limits at price,
+// rounded up to a MinSize increment
+func (l OrderLimits) MinQuantity(price float64) float64 {
+	quantity := l.MinSize
+	if l.MinNotional > 0 && price > 0 {
+		quantity = math.Max(quantity, l.MinNotional/price)
+	}
+	if l.MinSize > 0 {
+		// Tolerate float error so an exact multiple is not bumped a step
+		quantity = math.Ceil(quantity/l.MinSize-1e-9) * l.MinSize
+	}
+	return quantity
+}
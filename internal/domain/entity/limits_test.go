@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestOrderLimits_Check(t *testing.T) {
+	limits := OrderLimits{MinNotional: 10, MinSize: 0.001}
+
+	tests := []struct {
+		name     string
+		quantity float64
+		price    float64
+		reason   string
+	}{
+		{"meets both", 0.01, 2000, ""},
+		{"just above notional", 0.006, 2000, ""},
+		{"below notional", 0.004, 2000, "notional"},
+		{"rounds below size", 0.0004, 100000, "size"},
+		{"rounds to a valid size", 0.0996, 200, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := limits.Check(tt.quantity, tt.price)
+			if tt.reason == "" && reason != "" {
+				t.Errorf("Expected no reason, got %q", reason)
+			}
+			if tt.reason != "" && !strings.Contains(reason, tt.reason) {
+				t.Errorf("Expected reason mentioning %q, got %q", tt.reason, reason)
+			}
+		})
+	}
+
+	if reason := (OrderLimits{}).Check(0.0000001, 1); reason != "" {
+		t.Errorf("Expected no limits to pass everything, got %q", reason)
+	}
+}
+
+func TestOrderLimits_MinQuantity(t *testing.T) {
+	limits := OrderLimits{MinNotional: 10, MinSize: 0.001}
+
+	if got := limits.MinQuantity(3000); math.Abs(got-0.004) > 1e-12 {
+		t.Errorf("Expected $10 at 3000 to round up to 0.004, got %v", got)
+	}
+	if got := limits.MinQuantity(2000); math.Abs(got-0.005) > 1e-12 {
+		t.Errorf("Expected an exact multiple to stay 0.005, got %v", got)
+	}
+	if got := limits.MinQuantity(1000000); math.Abs(got-0.001) > 1e-12 {
+		t.Errorf("Expected the size increment to bound a high price, got %v", got)
+	}
+	if reason := limits.Check(limits.MinQuantity(3000), 3000); reason != "" {
+		t.Errorf("Expected the minimum quantity to pass the check, got %q", reason)
+	}
+}
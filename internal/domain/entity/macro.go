@@ -2,6 +2,9 @@ package entity
 
 import "time"
 
+// DXYChangeThreshold is the relative change in the US Dollar Index that counts as a move
+const DXYChangeThreshold = 0.005
+
 // FOMCMeeting represents an FOMC meeting with rate probabilities
 type FOMCMeeting struct {
 	MeetingDate     time.Time              `json:"meeting_date"`
@@ -66,6 +69,7 @@ type MacroSignal struct {
 	GDP          *EconomicIndicator `json:"gdp,omitempty"`
 	Unemployment *EconomicIndicator `json:"unemployment,omitempty"`
 	PCE          *EconomicIndicator `json:"pce,omitempty"` // Fed's preferred inflation measure
+	DXY          *EconomicIndicator `json:"dxy,omitempty"` // US Dollar Index
 
 	// Upcoming events
 	UpcomingEvents []*EconomicEvent `json:"upcoming_events,omitempty"`
@@ -76,65 +80,40 @@ type MacroSignal struct {
 	Confidence float64    `json:"confidence"`
 }
 
+// ImminentEventWindow is how far ahead a high-impact event lowers macro confidence
+const ImminentEventWindow = 24 * time.Hour
+
+// imminentEventDiscount scales confidence while a high-impact event is imminent,
+// since its release can override every other input
+const imminentEventDiscount = 0.5
+
+// macroFactor scores one macro source, reporting false when the source is unavailable
+type macroFactor func(m *MacroSignal) (bullish, bearish float64, ok bool)
+
+// macroFactors are the sources AnalyzeMacroSignal considers; confidence is the
+// share of them with data
+var macroFactors = []macroFactor{
+	fedWatchFactor,
+	cpiFactor,
+	pceFactor,
+	gdpFactor,
+	unemploymentFactor,
+	dxyFactor,
+}
+
 // AnalyzeMacroSignal analyzes macro data and sets bias/strength
 func (m *MacroSignal) AnalyzeMacroSignal() {
 	var bullishScore, bearishScore float64
 	var dataPoints int
 
-	// Analyze FedWatch data
-	if m.FedWatch != nil && m.FedWatch.NextMeeting != nil {
-		dataPoints++
-		meeting := m.FedWatch.NextMeeting
-
-		// Rate cuts are generally bullish for risk assets
-		if meeting.CutProb > 0.5 {
-			bullishScore += 0.3 * meeting.CutProb
-		}
-		// Rate hikes are bearish
-		if meeting.HikeProb > 0.3 {
-			bearishScore += 0.3 * meeting.HikeProb
-		}
-	}
-
-	// Analyze CPI (inflation)
-	if m.CPI != nil {
-		dataPoints++
-		// Higher than expected inflation = bearish (more rate hikes expected)
-		if m.CPI.Value > m.CPI.Forecast && m.CPI.Forecast > 0 {
-			bearishScore += 0.2
-		}
-		// Lower than expected = bullish
-		if m.CPI.Value < m.CPI.Forecast && m.CPI.Forecast > 0 {
-			bullishScore += 0.2
-		}
-	}
-
-	// Analyze GDP
-	if m.GDP != nil {
-		dataPoints++
-		// Strong GDP = bullish
-		if m.GDP.Value > m.GDP.Previous {
-			bullishScore += 0.15
-		}
-		// Weak GDP = bearish
-		if m.GDP.Value < m.GDP.Previous {
-			bearishScore += 0.15
+	for _, factor := range macroFactors {
+		bullish, bearish, ok := factor(m)
+		if !ok {
+			continue
 		}
-	}
-
-	// Analyze Unemployment
-	if m.Unemployment != nil {
 		dataPoints++
-		// Rising unemployment = bearish for economy but could be bullish for rates
-		if m.Unemployment.Value > m.Unemployment.Previous {
-			// Mixed signal - weak economy but potential rate cuts
-			bullishScore += 0.1  // Rate cut expectations
-			bearishScore += 0.1 // Economic weakness
-		}
-		// Falling unemployment = strong economy
-		if m.Unemployment.Value < m.Unemployment.Previous {
-			bullishScore += 0.1
-		}
+		bullishScore += bullish
+		bearishScore += bearish
 	}
 
 	// Calculate final signal
@@ -157,8 +136,141 @@ func (m *MacroSignal) AnalyzeMacroSignal() {
 		m.Strength = 0
 	}
 
-	// Confidence based on data availability (4 possible data points)
-	m.Confidence = float64(dataPoints) / 4.0
+	// Confidence based on data availability, lowered ahead of a high-impact release
+	m.Confidence = float64(dataPoints) / float64(len(macroFactors))
+	if m.ImminentEvent() != nil {
+		m.Confidence *= imminentEventDiscount
+	}
+}
+
+// ImminentEvent returns the first high-impact event within ImminentEventWindow
+// of the signal's timestamp, or nil if none
+func (m *MacroSignal) ImminentEvent() *EconomicEvent {
+	if m.Timestamp.IsZero() {
+		return nil
+	}
+	for _, e := range m.UpcomingEvents {
+		if e.Importance != "high" || e.Date.Before(m.Timestamp) {
+			continue
+		}
+		if e.Date.Sub(m.Timestamp) < ImminentEventWindow {
+			return e
+		}
+	}
+	return nil
+}
+
+// fedWatchFactor scores rate expectations for the next FOMC meeting
+func fedWatchFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	if m.FedWatch == nil || m.FedWatch.NextMeeting == nil {
+		return 0, 0, false
+	}
+	meeting := m.FedWatch.NextMeeting
+
+	// Rate cuts are generally bullish for risk assets
+	if meeting.CutProb > 0.5 {
+		bullish += 0.3 * meeting.CutProb
+	}
+	// Rate hikes are bearish
+	if meeting.HikeProb > 0.3 {
+		bearish += 0.3 * meeting.HikeProb
+	}
+	return bullish, bearish, true
+}
+
+// cpiFactor scores CPI inflation against its forecast
+func cpiFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	if m.CPI == nil {
+		return 0, 0, false
+	}
+	// Higher than expected inflation = bearish (more rate hikes expected)
+	if m.CPI.Value > m.CPI.Forecast && m.CPI.Forecast > 0 {
+		bearish += 0.2
+	}
+	// Lower than expected = bullish
+	if m.CPI.Value < m.CPI.Forecast && m.CPI.Forecast > 0 {
+		bullish += 0.2
+	}
+	return bullish, bearish, true
+}
+
+// pceFactor scores core PCE inflation like CPI, against its forecast or,
+// when no forecast is published, its previous reading
+func pceFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	if m.PCE == nil {
+		return 0, 0, false
+	}
+	expected := m.PCE.Forecast
+	if expected <= 0 {
+		expected = m.PCE.Previous
+	}
+	if expected > 0 && m.PCE.Value > expected {
+		bearish += 0.2
+	}
+	if expected > 0 && m.PCE.Value < expected {
+		bullish += 0.2
+	}
+	return bullish, bearish, true
+}
+
+// gdpFactor scores GDP growth against the previous reading
+func gdpFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	if m.GDP == nil {
+		return 0, 0, false
+	}
+	// Strong GDP = bullish
+	if m.GDP.Value > m.GDP.Previous {
+		bullish += 0.15
+	}
+	// Weak GDP = bearish
+	if m.GDP.Value < m.GDP.Previous {
+		bearish += 0.15
+	}
+	return bullish, bearish, true
+}
+
+// unemploymentFactor scores the unemployment rate against the previous reading
+func unemploymentFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	if m.Unemployment == nil {
+		return 0, 0, false
+	}
+	// Rising unemployment = bearish for economy but could be bullish for rates
+	if m.Unemployment.Value > m.Unemployment.Previous {
+		// Mixed signal - weak economy but potential rate cuts
+		bullish += 0.1 // Rate cut expectations
+		bearish += 0.1 // Economic weakness
+	}
+	// Falling unemployment = strong economy
+	if m.Unemployment.Value < m.Unemployment.Previous {
+		bullish += 0.1
+	}
+	return bullish, bearish, true
+}
+
+// dxyFactor scores the dollar index move
+func dxyFactor(m *MacroSignal) (bullish, bearish float64, ok bool) {
+	change, ok := m.DXYChange()
+	if !ok {
+		return 0, 0, false
+	}
+	// A rising dollar is a headwind for crypto
+	if change > DXYChangeThreshold {
+		bearish += 0.15
+	}
+	// A falling dollar is a tailwind
+	if change < -DXYChangeThreshold {
+		bullish += 0.15
+	}
+	return bullish, bearish, true
+}
+
+// DXYChange returns the relative change of the dollar index from its previous
+// value, or false when there is no previous value to compare against
+func (m *MacroSignal) DXYChange() (float64, bool) {
+	if m.DXY == nil || m.DXY.Previous <= 0 {
+		return 0, false
+	}
+	return (m.DXY.Value - m.DXY.Previous) / m.DXY.Previous, true
 }
 
 // GetFedBias returns the market bias based on Fed policy expectations
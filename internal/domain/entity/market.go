@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math"
 	"time"
 )
 
@@ -34,6 +35,15 @@ func (t *Ticker) MidPrice() float64 {
 	return (t.BidPrice + t.AskPrice) / 2
 }
 
+// Microprice returns the size-weighted mid, which leans toward the side with less
+// size (where price is more likely to move). Falls back to MidPrice without sizes.
+func (t *Ticker) Microprice() float64 {
+	if t.BidSize+t.AskSize == 0 {
+		return t.MidPrice()
+	}
+	return (t.BidPrice*t.AskSize + t.AskPrice*t.BidSize) / (t.BidSize + t.AskSize)
+}
+
 // OrderBookLevel represents a single level in order book
 type OrderBookLevel struct {
 	Price float64
@@ -64,6 +74,72 @@ func (ob *OrderBook) BestAsk() (float64, float64) {
 	return ob.Asks[0].Price, ob.Asks[0].Size
 }
 
+// depth sums size and price*size over the top levels (levels <= 0 = all)
+func depth(side []OrderBookLevel, levels int) (size, notional float64) {
+	if levels <= 0 || levels > len(side) {
+		levels = len(side)
+	}
+	for _, l := range side[:levels] {
+		size += l.Size
+		notional += l.Price * l.Size
+	}
+	return size, notional
+}
+
+// Imbalance returns (bidVol-askVol)/(bidVol+askVol) over the top levels of each side,
+// from -1 (all asks) to 1 (all bids). Returns 0 for an empty book.
+func (ob *OrderBook) Imbalance(levels int) float64 {
+	bidVol, _ := depth(ob.Bids, levels)
+	askVol, _ := depth(ob.Asks, levels)
+	if bidVol+askVol == 0 {
+		return 0
+	}
+	return (bidVol - askVol) / (bidVol + askVol)
+}
+
+// WeightedMidPrice returns the mid of the size-weighted bid and ask prices over the
+// top levels, with each side weighted by the opposite side's volume so the price
+// leans toward the thinner side. Returns 0 unless both sides have size.
+func (ob *OrderBook) WeightedMidPrice(levels int) float64 {
+	bidVol, bidNotional := depth(ob.Bids, levels)
+	askVol, askNotional := depth(ob.Asks, levels)
+	if bidVol == 0 || askVol == 0 {
+		return 0
+	}
+	bidPrice := bidNotional / bidVol
+	askPrice := askNotional / askVol
+	return (bidPrice*askVol + askPrice*bidVol) / (bidVol + askVol)
+}
+
+// Microprice returns the size-weighted mid from the best bid and ask.
+// Returns 0 unless both sides have size.
+func (ob *OrderBook) Microprice() float64 {
+	return ob.WeightedMidPrice(1)
+}
+
+// Sweep walks the side of the book an order of side would take, best level
+// first, and returns the size available up to size and its volume-weighted
+// price. filled is less than size when the book is too thin.
+func (ob *OrderBook) Sweep(side Side, size float64) (filled, avgPrice float64) {
+	levels := ob.Asks
+	if side == SideSell {
+		levels = ob.Bids
+	}
+	var notional float64
+	for _, l := range levels {
+		if filled >= size {
+			break
+		}
+		take := math.Min(l.Size, size-filled)
+		filled += take
+		notional += take * l.Price
+	}
+	if filled == 0 {
+		return 0, 0
+	}
+	return filled, notional / filled
+}
+
 // Candle represents OHLCV candle data
 type Candle struct {
 	Symbol    string
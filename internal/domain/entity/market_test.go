@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+// testBook builds a book with the given sizes at 1-dollar steps around 100
+func testBook(bidSizes, askSizes []float64) *OrderBook {
+	ob := &OrderBook{Symbol: "BTC"}
+	for i, size := range bidSizes {
+		ob.Bids = append(ob.Bids, OrderBookLevel{Price: 99 - float64(i), Size: size})
+	}
+	for i, size := range askSizes {
+		ob.Asks = append(ob.Asks, OrderBookLevel{Price: 101 + float64(i), Size: size})
+	}
+	return ob
+}
+
+func TestOrderBook_Imbalance(t *testing.T) {
+	tests := []struct {
+		name   string
+		book   *OrderBook
+		levels int
+		want   float64
+	}{
+		{"balanced", testBook([]float64{1, 2, 3}, []float64{3, 2, 1}), 3, 0},
+		{"bid heavy", testBook([]float64{3, 3}, []float64{1, 1}), 2, 0.5},
+		{"ask heavy", testBook([]float64{1, 1}, []float64{3, 3}), 2, -0.5},
+		{"top levels only", testBook([]float64{1, 100}, []float64{1, 1}), 1, 0},
+		{"all levels", testBook([]float64{1, 3}, []float64{1, 1}), 0, 1.0 / 3.0},
+		{"one-sided", testBook([]float64{2}, nil), 5, 1},
+		{"empty", &OrderBook{}, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.book.Imbalance(tt.levels); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Expected imbalance %.4f, got %.4f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderBook_WeightedMidPrice(t *testing.T) {
+	if got := testBook([]float64{1}, []float64{1}).WeightedMidPrice(1); got != 100 {
+		t.Errorf("Expected balanced book to weight to the mid, got %f", got)
+	}
+
+	// Heavy bids push the price toward the ask
+	if got := testBook([]float64{3}, []float64{1}).WeightedMidPrice(1); math.Abs(got-100.5) > 1e-9 {
+		t.Errorf("Expected 100.5 for bid-heavy book, got %f", got)
+	}
+	if got := testBook([]float64{1}, []float64{3}).WeightedMidPrice(1); math.Abs(got-99.5) > 1e-9 {
+		t.Errorf("Expected 99.5 for ask-heavy book, got %f", got)
+	}
+
+	if got := testBook([]float64{1}, nil).WeightedMidPrice(1); got != 0 {
+		t.Errorf("Expected 0 for one-sided book, got %f", got)
+	}
+}
+
+func TestMicroprice_SkewsTowardThinSide(t *testing.T) {
+	// Heavier bids pull the microprice above the mid, toward the ask
+	bidHeavy := &Ticker{BidPrice: 99, AskPrice: 101, BidSize: 9, AskSize: 1}
+	if got := bidHeavy.Microprice(); math.Abs(got-100.8) > 1e-9 {
+		t.Errorf("Expected bid-heavy microprice 100.8, got %f", got)
+	}
+	askHeavy := &Ticker{BidPrice: 99, AskPrice: 101, BidSize: 1, AskSize: 9}
+	if got := askHeavy.Microprice(); math.Abs(got-99.2) > 1e-9 {
+		t.Errorf("Expected ask-heavy microprice 99.2, got %f", got)
+	}
+	if got := (&Ticker{BidPrice: 99, AskPrice: 101}).Microprice(); got != 100 {
+		t.Errorf("Expected mid price without sizes, got %f", got)
+	}
+
+	book := testBook([]float64{9, 100}, []float64{1, 100})
+	if got := book.Microprice(); math.Abs(got-100.8) > 1e-9 {
+		t.Errorf("Expected book microprice from top level only, got %f", got)
+	}
+}
+
+func TestOrderBook_Sweep(t *testing.T) {
+	book := testBook([]float64{2, 2}, []float64{1, 2, 3})
+
+	// Buys walk the asks: 1@101 + 2@102 + 1@103
+	filled, avg := book.Sweep(SideBuy, 4)
+	if filled != 4 || math.Abs(avg-(101+2*102+103)/4.0) > 1e-9 {
+		t.Errorf("Expected 4 filled at the weighted ask, got %f @ %f", filled, avg)
+	}
+	if filled, avg := book.Sweep(SideBuy, 0.5); filled != 0.5 || avg != 101 {
+		t.Errorf("Expected small buy at the best ask, got %f @ %f", filled, avg)
+	}
+
+	// Sells beyond the bids fill partially
+	filled, avg = book.Sweep(SideSell, 10)
+	if filled != 4 || math.Abs(avg-98.5) > 1e-9 {
+		t.Errorf("Expected partial sell of 4 at 98.5, got %f @ %f", filled, avg)
+	}
+	if filled, avg := (&OrderBook{}).Sweep(SideBuy, 1); filled != 0 || avg != 0 {
+		t.Errorf("Expected nothing filled on an empty book, got %f @ %f", filled, avg)
+	}
+}
@@ -20,6 +20,15 @@ const (
 	OrderTypeMarket OrderType = "market"
 )
 
+// TimeInForce represents how long a limit order stays on the book
+type TimeInForce string
+
+const (
+	TimeInForceGTC      TimeInForce = "gtc"       // Good til canceled (default)
+	TimeInForceIOC      TimeInForce = "ioc"       // Immediate or cancel
+	TimeInForcePostOnly TimeInForce = "post_only" // Rejected instead of taking liquidity
+)
+
 // OrderStatus represents order status
 type OrderStatus string
 
@@ -41,9 +50,13 @@ type Order struct {
 	Quantity      float64
 	FilledQty     float64
 	Status        OrderStatus
+	TimeInForce   TimeInForce
+	ReduceOnly    bool // Only reduces an existing position, never opens or flips one
 	ClientOrderID string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	Fee float64 // Fees paid on the filled quantity so far in USD; negative for rebates (0 = unknown)
 }
 
 // IsFilled returns true if order is completely filled
@@ -1,20 +1,23 @@
 package entity
 
 import (
+	"math"
 	"time"
 )
 
 // Position represents a trading position (exchange-agnostic)
 type Position struct {
-	Symbol        string
-	Side          Side
-	Size          float64
-	EntryPrice    float64
-	MarkPrice     float64
-	Leverage      float64
-	UnrealizedPnL float64
-	RealizedPnL   float64
-	UpdatedAt     time.Time
+	Symbol        string    `json:"symbol"`
+	Side          Side      `json:"side"`
+	Size          float64   `json:"size"`
+	EntryPrice    float64   `json:"entry_price"`
+	MarkPrice     float64   `json:"mark_price"`
+	Leverage      float64   `json:"leverage"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Fees float64 `json:"fees"` // Fees paid on fills, accumulated alongside the gross RealizedPnL
 }
 
 // IsLong returns true if position is long
@@ -31,3 +34,44 @@ func (p *Position) IsShort() bool {
 func (p *Position) Value() float64 {
 	return p.Size * p.MarkPrice
 }
+
+// ApplyFill nets a fill into the position and returns the gross PnL realized by
+// the part of the fill that reduces it. Size is signed: positive long, negative short.
+// A fill larger than the position flips it at the fill price.
+func (p *Position) ApplyFill(side Side, qty, price float64) float64 {
+	signed := qty
+	if side == SideSell {
+		signed = -qty
+	}
+
+	var realized float64
+	switch {
+	case p.Size == 0 || (p.Size > 0) == (signed > 0):
+		// Opening or adding: average the entry price
+		total := math.Abs(p.Size) + qty
+		p.EntryPrice = (p.EntryPrice*math.Abs(p.Size) + price*qty) / total
+		p.Size += signed
+	default:
+		closing := math.Min(qty, math.Abs(p.Size))
+		realized = GrossPnL(p.Side, p.EntryPrice, price, closing)
+		p.RealizedPnL += realized
+		p.Size += signed
+		if math.Abs(p.Size) < 1e-12 {
+			p.Size = 0
+			p.EntryPrice = 0
+		} else if (p.Size > 0) != (p.Side == SideBuy) {
+			// Flipped: the remainder opens at the fill price
+			p.EntryPrice = price
+		}
+	}
+
+	switch {
+	case p.Size > 0:
+		p.Side = SideBuy
+	case p.Size < 0:
+		p.Side = SideSell
+	default:
+		p.Side = ""
+	}
+	return realized
+}
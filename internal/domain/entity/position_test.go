@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPosition_ApplyFill(t *testing.T) {
+	p := &Position{Symbol: "BTC"}
+
+	p.ApplyFill(SideBuy, 1, 100)
+	p.ApplyFill(SideBuy, 1, 110)
+	if p.Size != 2 || p.EntryPrice != 105 || p.Side != SideBuy {
+		t.Fatalf("Expected 2 long at 105, got %+v", p)
+	}
+
+	if realized := p.ApplyFill(SideSell, 0.5, 115); math.Abs(realized-5) > 1e-9 {
+		t.Errorf("Expected realized 5 on partial close, got %f", realized)
+	}
+	if p.Size != 1.5 || p.EntryPrice != 105 {
+		t.Errorf("Expected 1.5 long at 105 after partial close, got %+v", p)
+	}
+
+	// Flip short: close 1.5 and open 0.5 at the fill price
+	if realized := p.ApplyFill(SideSell, 2, 100); math.Abs(realized+7.5) > 1e-9 {
+		t.Errorf("Expected realized -7.5 on flip, got %f", realized)
+	}
+	if p.Size != -0.5 || p.EntryPrice != 100 || p.Side != SideSell {
+		t.Errorf("Expected 0.5 short at 100 after flip, got %+v", p)
+	}
+	if math.Abs(p.RealizedPnL+2.5) > 1e-9 {
+		t.Errorf("Expected cumulative realized -2.5, got %f", p.RealizedPnL)
+	}
+
+	p.ApplyFill(SideBuy, 0.5, 90)
+	if p.Size != 0 || p.Side != "" || p.EntryPrice != 0 {
+		t.Errorf("Expected flat position, got %+v", p)
+	}
+}
@@ -0,0 +1,103 @@
+package entity
+
+import "math"
+
+// SentimentMomentumThreshold is the sentiment change over the history window
+// below which the trend is treated as flat
+const SentimentMomentumThreshold = 0.1
+
+// SentimentTrend summarizes how a symbol's sentiment moved over its recent history
+type SentimentTrend struct {
+	Momentum   float64 `json:"momentum"`   // Least-squares slope of SentimentScore per point
+	Divergence float64 `json:"divergence"` // Momentum when price moved the other way, else 0; positive = sentiment rising as price falls
+	Points     int     `json:"points"`     // History points the trend was fit over
+}
+
+// AnalyzeSentimentTrend fits the sentiment score and price of the last n
+// points of history (oldest first; n <= 0 = all). It returns nil for fewer
+// than two points. Points without a price are left out of the price fit.
+func AnalyzeSentimentTrend(history []*SocialSentiment, n int) *SentimentTrend {
+	if n > 0 && len(history) > n {
+		history = history[len(history)-n:]
+	}
+	scores := make([]float64, 0, len(history))
+	var prices, priceX []float64
+	for _, s := range history {
+		if s == nil {
+			continue
+		}
+		if s.Price > 0 {
+			prices = append(prices, s.Price)
+			priceX = append(priceX, float64(len(scores)))
+		}
+		scores = append(scores, s.SentimentScore)
+	}
+	if len(scores) < 2 {
+		return nil
+	}
+
+	trend := &SentimentTrend{Momentum: slope(nil, scores), Points: len(scores)}
+	if len(prices) >= 2 {
+		if priceSlope := slope(priceX, prices); trend.Momentum*priceSlope < 0 {
+			trend.Divergence = trend.Momentum
+		}
+	}
+	return trend
+}
+
+// Change returns the sentiment change the momentum implies across the window
+func (t *SentimentTrend) Change() float64 {
+	if t == nil || t.Points < 2 {
+		return 0
+	}
+	return t.Momentum * float64(t.Points-1)
+}
+
+// Bias returns the direction of the trend and its strength (0-1), neutral
+// when the change is below SentimentMomentumThreshold
+func (t *SentimentTrend) Bias() (SignalBias, float64) {
+	return changeBias(t.Change())
+}
+
+// DivergenceBias returns the direction of a divergence from price and its
+// strength (0-1), read like Bias: sentiment rising as price falls is bullish,
+// since price tends to follow sentiment
+func (t *SentimentTrend) DivergenceBias() (SignalBias, float64) {
+	if t == nil || t.Points < 2 {
+		return SignalBiasNeutral, 0
+	}
+	return changeBias(t.Divergence * float64(t.Points-1))
+}
+
+// changeBias returns the bias of a sentiment change across the window
+func changeBias(change float64) (SignalBias, float64) {
+	if math.Abs(change) < SentimentMomentumThreshold {
+		return SignalBiasNeutral, 0
+	}
+	strength := math.Min(math.Abs(change), 1)
+	if change > 0 {
+		return SignalBiasBullish, strength
+	}
+	return SignalBiasBearish, strength
+}
+
+// slope returns the least-squares slope of ys over xs (nil xs = 0, 1, 2, ...)
+func slope(xs, ys []float64) float64 {
+	n := float64(len(ys))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range ys {
+		x := float64(i)
+		if xs != nil {
+			x = xs[i]
+		}
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+// sentimentSeries builds a history from scores and prices (0 = no price)
+func sentimentSeries(scores, prices []float64) []*SocialSentiment {
+	history := make([]*SocialSentiment, len(scores))
+	for i, score := range scores {
+		history[i] = &SocialSentiment{SentimentScore: score}
+		if prices != nil {
+			history[i].Price = prices[i]
+		}
+	}
+	return history
+}
+
+func TestAnalyzeSentimentTrend(t *testing.T) {
+	rising := AnalyzeSentimentTrend(sentimentSeries([]float64{-0.2, -0.1, 0, 0.1, 0.2}, nil), 0)
+	if rising == nil || math.Abs(rising.Momentum-0.1) > 1e-9 || rising.Points != 5 {
+		t.Fatalf("Expected rising sentiment to have momentum 0.1 over 5 points, got %+v", rising)
+	}
+	if bias, strength := rising.Bias(); bias != SignalBiasBullish || math.Abs(strength-0.4) > 1e-9 {
+		t.Errorf("Expected bullish trend of strength 0.4, got %s (%.2f)", bias, strength)
+	}
+
+	flat := AnalyzeSentimentTrend(sentimentSeries([]float64{0.3, 0.3, 0.3, 0.3}, nil), 0)
+	if flat == nil || math.Abs(flat.Momentum) > 1e-9 {
+		t.Fatalf("Expected flat sentiment to have ~0 momentum, got %+v", flat)
+	}
+	if bias, _ := flat.Bias(); bias != SignalBiasNeutral {
+		t.Errorf("Expected flat trend to be neutral, got %s", bias)
+	}
+
+	// Only the last n points are fit
+	recent := AnalyzeSentimentTrend(sentimentSeries([]float64{0.9, -0.5, 0, 0, 0}, nil), 3)
+	if recent == nil || recent.Points != 3 || math.Abs(recent.Momentum) > 1e-9 {
+		t.Errorf("Expected the last 3 flat points, got %+v", recent)
+	}
+
+	if AnalyzeSentimentTrend(sentimentSeries([]float64{0.5}, nil), 0) != nil {
+		t.Error("Expected nil trend for a single point")
+	}
+}
+
+func TestAnalyzeSentimentTrend_Divergence(t *testing.T) {
+	scores := []float64{0, 0.1, 0.2, 0.3}
+	falling := AnalyzeSentimentTrend(sentimentSeries(scores, []float64{100, 98, 97, 95}), 0)
+	if falling.Divergence <= 0 || falling.Divergence != falling.Momentum {
+		t.Errorf("Expected sentiment rising as price falls to diverge, got %+v", falling)
+	}
+
+	rising := AnalyzeSentimentTrend(sentimentSeries(scores, []float64{100, 101, 103, 104}), 0)
+	if rising.Divergence != 0 {
+		t.Errorf("Expected no divergence when price agrees, got %+v", rising)
+	}
+
+	unpriced := AnalyzeSentimentTrend(sentimentSeries(scores, []float64{0, 0, 0, 95}), 0)
+	if unpriced.Divergence != 0 {
+		t.Errorf("Expected no divergence without two prices, got %+v", unpriced)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_SentimentMomentum(t *testing.T) {
+	analyze := func(trend *SentimentTrend) *MarketSignal {
+		signal := &MarketSignal{
+			Symbol:          "BTC",
+			SocialSentiment: &SocialSentiment{SentimentScore: 0},
+			SentimentTrend:  trend,
+		}
+		signal.AnalyzeSignal()
+		return signal
+	}
+
+	if s := analyze(&SentimentTrend{Momentum: 0.05, Points: 9}); s.Bias != SignalBiasBullish || s.Confidence != 1.0/8.0 {
+		t.Errorf("Expected rising sentiment to be bullish without adding a data point, got %s (confidence %.3f)", s.Bias, s.Confidence)
+	}
+	if s := analyze(&SentimentTrend{Momentum: -0.05, Points: 9}); s.Bias != SignalBiasBearish {
+		t.Errorf("Expected falling sentiment to be bearish, got %s", s.Bias)
+	}
+	if s := analyze(&SentimentTrend{Momentum: 0.001, Points: 9}); s.Bias != SignalBiasNeutral {
+		t.Errorf("Expected a flat trend to be neutral, got %s", s.Bias)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_SentimentDivergence(t *testing.T) {
+	analyze := func(trend *SentimentTrend) *MarketSignal {
+		signal := &MarketSignal{
+			Symbol:          "BTC",
+			FundingRate:     &FundingRate{Rate: ExtremeFundingRate},
+			SocialSentiment: &SocialSentiment{SentimentScore: 0},
+			SentimentTrend:  trend,
+		}
+		signal.AnalyzeSignal()
+		return signal
+	}
+
+	// Sentiment rising as price falls adds to the bullish side on top of the momentum
+	agrees := analyze(&SentimentTrend{Momentum: 0.05, Points: 9})
+	diverges := analyze(&SentimentTrend{Momentum: 0.05, Divergence: 0.05, Points: 9})
+	if diverges.Bias != SignalBiasBearish || diverges.Strength >= agrees.Strength {
+		t.Errorf("Expected the divergence to weaken the bearish funding read, got %.3f vs %.3f", diverges.Strength, agrees.Strength)
+	}
+	if diverges.Confidence != agrees.Confidence {
+		t.Errorf("Expected the divergence to add no data point, got %.3f vs %.3f", diverges.Confidence, agrees.Confidence)
+	}
+
+	if bias, strength := (&SentimentTrend{Momentum: -0.05, Divergence: -0.05, Points: 9}).DivergenceBias(); bias != SignalBiasBearish || strength != 0.4 {
+		t.Errorf("Expected sentiment falling as price rises to be bearish 0.4, got %s %.2f", bias, strength)
+	}
+	if bias, _ := (*SentimentTrend)(nil).DivergenceBias(); bias != SignalBiasNeutral {
+		t.Errorf("Expected no trend to be neutral, got %s", bias)
+	}
+}
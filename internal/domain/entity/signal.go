@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // Liquidation represents a liquidation event
 type Liquidation struct {
@@ -13,6 +16,25 @@ type Liquidation struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// LiquidationCascade flags a burst of accelerating one-sided liquidations.
+// Forced selling that flushes longs tends to mark a local bottom (and a short
+// squeeze a local top), so Direction is the contrarian bias.
+type LiquidationCascade struct {
+	Side      string        `json:"side"`      // Liquidated side: "long" or "short"
+	Direction SignalBias    `json:"direction"` // Bullish after long liquidations, bearish after short
+	ValueUSD  float64       `json:"value_usd"` // Liquidated on Side within Window
+	Magnitude float64       `json:"magnitude"` // ValueUSD relative to the detection threshold (>= 1)
+	Window    time.Duration `json:"window"`
+}
+
+// Strength returns the cascade strength (0 to 1); twice the threshold is full strength
+func (c *LiquidationCascade) Strength() float64 {
+	if c == nil {
+		return 0
+	}
+	return math.Min(c.Magnitude/2, 1)
+}
+
 // OpenInterest represents open interest data
 type OpenInterest struct {
 	Symbol      string    `json:"symbol"`
@@ -22,14 +44,79 @@ type OpenInterest struct {
 	Timestamp   time.Time `json:"timestamp"`
 }
 
+// Open interest trend thresholds (24h percentage changes)
+const (
+	// OIChangeThreshold is the open interest change that counts as rising or falling
+	OIChangeThreshold = 2.0
+	// OIPriceMoveThreshold is the price change that counts as a move when reading OI
+	OIPriceMoveThreshold = 1.0
+)
+
+// OITrend interprets the open interest change against the price change
+type OITrend string
+
+const (
+	OITrendNone      OITrend = ""           // No meaningful OI change, or no meaningful price move
+	OITrendNoPrice   OITrend = "no_price"   // OI rising, but no price change to read it against
+	OITrendConfirmed OITrend = "confirmed"  // OI rising with price: new longs confirm the uptrend
+	OITrendNewShorts OITrend = "new_shorts" // OI rising as price falls: new shorts press the downtrend
+	OITrendUnwinding OITrend = "unwinding"  // OI falling: positions closing, the move lacks conviction
+)
+
+// Trend classifies the 24h OI change given the 24h price change (percentage,
+// 0 = unknown)
+func (o *OpenInterest) Trend(priceChange float64) OITrend {
+	switch {
+	case o.Change24h <= -OIChangeThreshold:
+		return OITrendUnwinding
+	case o.Change24h < OIChangeThreshold:
+		return OITrendNone
+	case priceChange == 0:
+		return OITrendNoPrice
+	case priceChange >= OIPriceMoveThreshold:
+		return OITrendConfirmed
+	case priceChange <= -OIPriceMoveThreshold:
+		return OITrendNewShorts
+	}
+	return OITrendNone
+}
+
 // FundingRate represents funding rate data
 type FundingRate struct {
-	Symbol          string    `json:"symbol"`
-	Rate            float64   `json:"rate"`
-	PredictedRate   float64   `json:"predicted_rate"`
-	NextFundingTime time.Time `json:"next_funding_time"`
-	Exchange        string    `json:"exchange"`
-	Timestamp       time.Time `json:"timestamp"`
+	Symbol          string        `json:"symbol"`
+	Rate            float64       `json:"rate"`
+	PredictedRate   float64       `json:"predicted_rate"`
+	NextFundingTime time.Time     `json:"next_funding_time"`
+	Exchange        string        `json:"exchange"`
+	Timestamp       time.Time     `json:"timestamp"`
+	Exchanges       []FundingRate `json:"exchanges,omitempty"` // per-exchange breakdown of an aggregate
+}
+
+// Funding rate thresholds (per 8h interval)
+const (
+	// NeutralFundingRate is the magnitude below which funding carries no bias
+	NeutralFundingRate = 0.0001
+	// ExtremeFundingRate is the magnitude at which the funding bias reaches full strength
+	ExtremeFundingRate = 0.001
+)
+
+// Bias returns the contrarian funding bias and its strength (0 to 1).
+// Very positive funding means crowded longs paying shorts, so the bias is to be
+// short the perp and collect funding; very negative funding is the reverse.
+// Strength is proportional to the rate and saturates at ExtremeFundingRate.
+func (f *FundingRate) Bias() (SignalBias, float64) {
+	if f == nil {
+		return SignalBiasNeutral, 0
+	}
+	magnitude := math.Abs(f.Rate)
+	if magnitude <= NeutralFundingRate {
+		return SignalBiasNeutral, 0
+	}
+	strength := math.Min(magnitude/ExtremeFundingRate, 1)
+	if f.Rate > 0 {
+		return SignalBiasBearish, strength
+	}
+	return SignalBiasBullish, strength
 }
 
 // LongShortRatio represents long/short position ratio
@@ -40,6 +127,7 @@ type LongShortRatio struct {
 	LongShortRatio float64  `json:"long_short_ratio"`
 	Exchange      string    `json:"exchange"`
 	Timestamp     time.Time `json:"timestamp"`
+	Exchanges     []LongShortRatio `json:"exchanges,omitempty"` // per-exchange breakdown of an aggregate
 }
 
 // WhaleAlert represents a large transaction alert
@@ -67,16 +155,10 @@ const (
 	WhaleAlertUnknown         WhaleAlertType = "unknown"
 )
 
-// GetAlertType determines the type of whale alert
+// GetAlertType determines the type of whale alert; exchanges come from the asset registry
 func (w *WhaleAlert) GetAlertType() WhaleAlertType {
-	exchanges := map[string]bool{
-		"binance": true, "coinbase": true, "kraken": true,
-		"bitfinex": true, "bybit": true, "okx": true,
-		"huobi": true, "kucoin": true, "gate.io": true,
-	}
-
-	fromIsExchange := exchanges[w.FromOwner]
-	toIsExchange := exchanges[w.ToOwner]
+	fromIsExchange := Assets().IsExchange(w.FromOwner)
+	toIsExchange := Assets().IsExchange(w.ToOwner)
 
 	switch {
 	case !fromIsExchange && toIsExchange:
@@ -104,10 +186,24 @@ type SocialSentiment struct {
 	Contributors      int64                      `json:"contributors"` // Unique contributors
 	GalaxyScore       float64                    `json:"galaxy_score,omitempty"` // LunarCrush proprietary
 	AltRank           int                        `json:"alt_rank,omitempty"` // LunarCrush proprietary
+	PriceChange24h    float64                    `json:"price_change_24h,omitempty"` // Percentage, when the source reports price
+	Price             float64                    `json:"price,omitempty"` // Asset price at Timestamp, when the source reports it
 	PlatformBreakdown map[string]PlatformMetrics `json:"platform_breakdown,omitempty"`
 	Timestamp         time.Time                  `json:"timestamp"`
 }
 
+// SentimentVolumeFloor is the social volume below which sentiment is noise
+// and contributes nothing to the signal (0 = no floor)
+type SentimentVolumeFloor struct {
+	MinInteractions int64 `yaml:"min_interactions"`
+	MinPosts        int64 `yaml:"min_posts"`
+}
+
+// Meets reports whether the sentiment has enough interactions and posts to count
+func (f SentimentVolumeFloor) Meets(s *SocialSentiment) bool {
+	return s != nil && s.Interactions >= f.MinInteractions && s.SocialVolume >= f.MinPosts
+}
+
 // PlatformMetrics represents sentiment metrics for a specific platform
 type PlatformMetrics struct {
 	Positive int `json:"positive"`
@@ -115,6 +211,36 @@ type PlatformMetrics struct {
 	Negative int `json:"negative"`
 }
 
+// Fear & greed index thresholds outside of which the contrarian bias applies
+const (
+	ExtremeFearIndex  = 25
+	ExtremeGreedIndex = 75
+)
+
+// FearGreedIndex represents the crypto fear & greed index (0 = extreme fear, 100 = extreme greed)
+type FearGreedIndex struct {
+	Value          int       `json:"value"`
+	Classification string    `json:"classification"` // e.g. "Extreme Fear", "Greed"
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// Bias returns the contrarian bias of the index and its strength (0 to 1).
+// Extreme fear is bullish and extreme greed bearish; in between it is neutral.
+// Strength is the distance from 50, so an index of 0 or 100 has strength 1.
+func (f *FearGreedIndex) Bias() (SignalBias, float64) {
+	if f == nil {
+		return SignalBiasNeutral, 0
+	}
+	strength := math.Min(math.Abs(float64(f.Value)-50)/50, 1)
+	switch {
+	case f.Value <= ExtremeFearIndex:
+		return SignalBiasBullish, strength
+	case f.Value >= ExtremeGreedIndex:
+		return SignalBiasBearish, strength
+	}
+	return SignalBiasNeutral, 0
+}
+
 // TrendingTopic represents a trending social topic
 type TrendingTopic struct {
 	Topic        string    `json:"topic"`
@@ -135,12 +261,16 @@ type MarketSignal struct {
 	FundingRate      *FundingRate    `json:"funding_rate,omitempty"`
 	LongShortRatio   *LongShortRatio `json:"long_short_ratio,omitempty"`
 	RecentLiquidations []*Liquidation `json:"recent_liquidations,omitempty"`
+	LiquidationCascade *LiquidationCascade `json:"liquidation_cascade,omitempty"`
+	PriceChange24h     float64             `json:"price_change_24h,omitempty"` // Percentage (0 = unknown), read against OI
 
 	// Whale activity
 	RecentWhaleAlerts []*WhaleAlert `json:"recent_whale_alerts,omitempty"`
 
 	// Social sentiment
 	SocialSentiment *SocialSentiment `json:"social_sentiment,omitempty"`
+	FearGreed       *FearGreedIndex  `json:"fear_greed,omitempty"`
+	SentimentTrend  *SentimentTrend  `json:"sentiment_trend,omitempty"` // From sentiment history
 
 	// Macro indicators (imported from macro package to avoid circular import)
 	MacroBias       SignalBias `json:"macro_bias,omitempty"`
@@ -164,18 +294,63 @@ const (
 	SignalBiasNeutral SignalBias = "neutral"
 )
 
-// AnalyzeSignal analyzes the market signal and sets bias, strength, confidence
+// SignalWeights controls how much each data source contributes to the signal
+type SignalWeights struct {
+	FundingRate    float64 `yaml:"funding_rate"`
+	LongShortRatio float64 `yaml:"long_short_ratio"`
+	WhaleFlow      float64 `yaml:"whale_flow"`
+	Liquidation    float64 `yaml:"liquidation"`
+	Sentiment      float64 `yaml:"sentiment"`
+	FedPolicy      float64 `yaml:"fed_policy"`
+	FearGreed      float64 `yaml:"fear_greed"`
+	OpenInterest   float64 `yaml:"open_interest"`
+
+	SentimentMomentum   float64 `yaml:"sentiment_momentum"`
+	SentimentDivergence float64 `yaml:"sentiment_divergence"`
+}
+
+// DefaultSignalWeights returns the default data source weights
+func DefaultSignalWeights() SignalWeights {
+	return SignalWeights{
+		FundingRate:    0.3,
+		LongShortRatio: 0.2,
+		WhaleFlow:      0.3,
+		Liquidation:    0.2,
+		Sentiment:      0.25,
+		FedPolicy:      0.2,
+		FearGreed:      0.15,
+		OpenInterest:   0.15,
+
+		SentimentMomentum:   0.1,
+		SentimentDivergence: 0.1,
+	}
+}
+
+// AnalyzeSignal analyzes the market signal with default weights
 func (s *MarketSignal) AnalyzeSignal() {
+	s.AnalyzeSignalWithWeights(DefaultSignalWeights())
+}
+
+// AnalyzeSignalWithWeights analyzes the market signal and sets bias, strength, confidence
+func (s *MarketSignal) AnalyzeSignalWithWeights(w SignalWeights) {
+	s.AnalyzeSignalWith(w, SentimentVolumeFloor{})
+}
+
+// AnalyzeSignalWith analyzes the market signal with weights, ignoring
+// sentiment whose social volume is below floor
+func (s *MarketSignal) AnalyzeSignalWith(w SignalWeights, floor SentimentVolumeFloor) {
 	var bullishScore, bearishScore float64
 	var dataPoints int
 
 	// Analyze funding rate
 	if s.FundingRate != nil {
 		dataPoints++
-		if s.FundingRate.Rate > 0.0001 { // High positive = bearish (shorts pay longs)
-			bearishScore += 0.3
-		} else if s.FundingRate.Rate < -0.0001 { // Negative = bullish
-			bullishScore += 0.3
+		// Fade crowded positioning, weighted by how extreme the rate is
+		switch bias, strength := s.FundingRate.Bias(); bias {
+		case SignalBiasBearish:
+			bearishScore += w.FundingRate * strength
+		case SignalBiasBullish:
+			bullishScore += w.FundingRate * strength
 		}
 	}
 
@@ -183,28 +358,39 @@ func (s *MarketSignal) AnalyzeSignal() {
 	if s.LongShortRatio != nil {
 		dataPoints++
 		if s.LongShortRatio.LongShortRatio > 1.5 { // Too many longs = bearish
-			bearishScore += 0.2
+			bearishScore += w.LongShortRatio
 		} else if s.LongShortRatio.LongShortRatio < 0.7 { // Too many shorts = bullish
-			bullishScore += 0.2
+			bullishScore += w.LongShortRatio
 		}
 	}
 
 	// Analyze whale alerts
 	if len(s.RecentWhaleAlerts) > 0 {
 		dataPoints++
-		var inflowValue, outflowValue float64
-		for _, alert := range s.RecentWhaleAlerts {
-			switch alert.GetAlertType() {
-			case WhaleAlertExchangeInflow:
-				inflowValue += alert.AmountUSD
-			case WhaleAlertExchangeOutflow:
-				outflowValue += alert.AmountUSD
-			}
-		}
+		inflowValue, outflowValue, _ := NetExchangeFlow(s.RecentWhaleAlerts)
 		if inflowValue > outflowValue*1.5 {
-			bearishScore += 0.3
+			bearishScore += w.WhaleFlow
 		} else if outflowValue > inflowValue*1.5 {
-			bullishScore += 0.3
+			bullishScore += w.WhaleFlow
+		}
+	}
+
+	// Analyze open interest against price: rising OI adds conviction to the
+	// move, falling OI means positions are unwinding. Rising OI without a
+	// price change (no LunarCrush) is skipped rather than read as flat.
+	unwinding := false
+	if s.OpenInterest != nil {
+		trend := s.OpenInterest.Trend(s.PriceChange24h)
+		if trend != OITrendNoPrice {
+			dataPoints++
+		}
+		switch trend {
+		case OITrendConfirmed:
+			bullishScore += w.OpenInterest
+		case OITrendNewShorts:
+			bearishScore += w.OpenInterest
+		case OITrendUnwinding:
+			unwinding = true
 		}
 	}
 
@@ -219,22 +405,42 @@ func (s *MarketSignal) AnalyzeSignal() {
 				shortLiqValue += liq.Value
 			}
 		}
-		// Cascade liquidations often continue
-		if longLiqValue > shortLiqValue*2 {
-			bearishScore += 0.2
+		if c := s.LiquidationCascade; c != nil {
+			// A detected cascade exhausts the liquidated side, so fade it
+			switch c.Direction {
+			case SignalBiasBullish:
+				bullishScore += w.Liquidation * c.Strength()
+			case SignalBiasBearish:
+				bearishScore += w.Liquidation * c.Strength()
+			}
+		} else if longLiqValue > shortLiqValue*2 { // Otherwise liquidations often continue
+			bearishScore += w.Liquidation
 		} else if shortLiqValue > longLiqValue*2 {
-			bullishScore += 0.2
+			bullishScore += w.Liquidation
 		}
 	}
 
 	// Analyze social sentiment
-	if s.SocialSentiment != nil {
+	if s.SocialSentiment != nil && floor.Meets(s.SocialSentiment) {
 		dataPoints++
 		score := s.SocialSentiment.SentimentScore // -1 to 1
 		if score > 0.2 {
-			bullishScore += 0.25 * score
+			bullishScore += w.Sentiment * score
 		} else if score < -0.2 {
-			bearishScore += 0.25 * (-score)
+			bearishScore += w.Sentiment * (-score)
+		}
+		// The trend refines the same source, so it adds no data point
+		switch bias, strength := s.SentimentTrend.Bias(); bias {
+		case SignalBiasBullish:
+			bullishScore += w.SentimentMomentum * strength
+		case SignalBiasBearish:
+			bearishScore += w.SentimentMomentum * strength
+		}
+		switch bias, strength := s.SentimentTrend.DivergenceBias(); bias {
+		case SignalBiasBullish:
+			bullishScore += w.SentimentDivergence * strength
+		case SignalBiasBearish:
+			bearishScore += w.SentimentDivergence * strength
 		}
 	}
 
@@ -243,11 +449,22 @@ func (s *MarketSignal) AnalyzeSignal() {
 		dataPoints++
 		// Rate cuts are bullish for risk assets (crypto)
 		if s.FedCutProb > 0.5 {
-			bullishScore += 0.2 * s.FedCutProb
+			bullishScore += w.FedPolicy * s.FedCutProb
 		}
 		// Rate hikes are bearish
 		if s.FedHikeProb > 0.3 {
-			bearishScore += 0.2 * s.FedHikeProb
+			bearishScore += w.FedPolicy * s.FedHikeProb
+		}
+	}
+
+	// Analyze fear & greed index (contrarian)
+	if s.FearGreed != nil {
+		dataPoints++
+		switch bias, strength := s.FearGreed.Bias(); bias {
+		case SignalBiasBullish:
+			bullishScore += w.FearGreed * strength
+		case SignalBiasBearish:
+			bearishScore += w.FearGreed * strength
 		}
 	}
 
@@ -271,6 +488,11 @@ func (s *MarketSignal) AnalyzeSignal() {
 		s.Strength = 0
 	}
 
-	// Confidence based on data availability (6 possible data sources)
-	s.Confidence = float64(dataPoints) / 6.0
+	// Unwinding positions weaken whatever bias the other sources give
+	if unwinding {
+		s.Strength *= 1 - math.Min(w.OpenInterest, 1)
+	}
+
+	// Confidence based on data availability (8 possible data sources)
+	s.Confidence = float64(dataPoints) / 8.0
 }
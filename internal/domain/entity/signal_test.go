@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -105,6 +106,204 @@ func TestMarketSignal_AnalyzeSignal_Neutral(t *testing.T) {
 		signal.Bias, signal.Strength, signal.Confidence)
 }
 
+func TestMarketSignal_AnalyzeSignalWithWeights(t *testing.T) {
+	newSignal := func() *MarketSignal {
+		return &MarketSignal{
+			Symbol:    "BTC",
+			Timestamp: time.Now(),
+			// High positive funding rate = bearish
+			FundingRate: &FundingRate{
+				Rate: 0.001,
+			},
+			// Bullish sentiment
+			SocialSentiment: &SocialSentiment{
+				SentimentScore: 0.5,
+			},
+		}
+	}
+
+	// Default weights: funding (0.3) outweighs sentiment (0.25 * 0.5)
+	defaultSignal := newSignal()
+	defaultSignal.AnalyzeSignal()
+	if defaultSignal.Bias != SignalBiasBearish {
+		t.Errorf("Expected bearish bias with default weights, got %s", defaultSignal.Bias)
+	}
+
+	// Custom weights: sentiment dominates
+	weights := DefaultSignalWeights()
+	weights.FundingRate = 0.05
+	weights.Sentiment = 1.0
+
+	customSignal := newSignal()
+	customSignal.AnalyzeSignalWithWeights(weights)
+	if customSignal.Bias != SignalBiasBullish {
+		t.Errorf("Expected bullish bias with custom weights, got %s", customSignal.Bias)
+	}
+	if customSignal.Confidence != defaultSignal.Confidence {
+		t.Errorf("Expected weights not to affect confidence, got %.2f vs %.2f",
+			customSignal.Confidence, defaultSignal.Confidence)
+	}
+
+	t.Logf("Default: Bias=%s, Strength=%.2f | Custom: Bias=%s, Strength=%.2f",
+		defaultSignal.Bias, defaultSignal.Strength, customSignal.Bias, customSignal.Strength)
+}
+
+func TestMarketSignal_AnalyzeSignal_OpenInterest(t *testing.T) {
+	tests := []struct {
+		name        string
+		oiChange    float64
+		priceChange float64
+		trend       OITrend
+		bias        SignalBias
+	}{
+		{"rising OI with rising price", 5, 3, OITrendConfirmed, SignalBiasBullish},
+		{"rising OI with falling price", 5, -3, OITrendNewShorts, SignalBiasBearish},
+		{"rising OI with flat price", 5, 0.2, OITrendNone, SignalBiasNeutral},
+		{"rising OI without price", 5, 0, OITrendNoPrice, SignalBiasNeutral},
+		{"falling OI", -5, 3, OITrendUnwinding, SignalBiasNeutral},
+		{"flat OI", 0.5, 3, OITrendNone, SignalBiasNeutral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal := &MarketSignal{
+				Symbol:         "BTC",
+				OpenInterest:   &OpenInterest{OpenInterest: 1e9, Change24h: tt.oiChange},
+				PriceChange24h: tt.priceChange,
+			}
+			if got := signal.OpenInterest.Trend(tt.priceChange); got != tt.trend {
+				t.Errorf("Trend() = %q, want %q", got, tt.trend)
+			}
+			signal.AnalyzeSignal()
+			if signal.Bias != tt.bias {
+				t.Errorf("Expected %s bias, got %s", tt.bias, signal.Bias)
+			}
+			if tt.bias != SignalBiasNeutral && signal.Strength != 1 {
+				t.Errorf("Expected OI alone to give full strength, got %.2f", signal.Strength)
+			}
+		})
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_OIWithoutPriceSkipped(t *testing.T) {
+	signal := &MarketSignal{
+		Symbol:       "BTC",
+		FundingRate:  &FundingRate{Rate: -ExtremeFundingRate},
+		OpenInterest: &OpenInterest{OpenInterest: 1e9, Change24h: 5},
+	}
+	signal.AnalyzeSignal()
+
+	// Rising OI with no price change is not a data point, so it does not dilute confidence
+	if signal.Confidence != 1.0/8 {
+		t.Errorf("Expected only funding counted, got confidence %.3f", signal.Confidence)
+	}
+
+	signal.PriceChange24h = 3
+	signal.AnalyzeSignal()
+	if signal.Confidence != 2.0/8 {
+		t.Errorf("Expected OI counted with a price change, got confidence %.3f", signal.Confidence)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_OIUnwindingWeakens(t *testing.T) {
+	base := func() *MarketSignal {
+		return &MarketSignal{
+			Symbol:         "BTC",
+			FundingRate:    &FundingRate{Rate: -ExtremeFundingRate},
+			LongShortRatio: &LongShortRatio{LongShortRatio: 1.6},
+		}
+	}
+	without := base()
+	without.AnalyzeSignal()
+
+	with := base()
+	with.OpenInterest = &OpenInterest{OpenInterest: 1e9, Change24h: -5}
+	with.AnalyzeSignal()
+
+	if with.Bias != without.Bias {
+		t.Errorf("Expected unwinding to keep the %s bias, got %s", without.Bias, with.Bias)
+	}
+	want := without.Strength * (1 - DefaultSignalWeights().OpenInterest)
+	if math.Abs(with.Strength-want) > 1e-9 {
+		t.Errorf("Expected strength weakened to %.3f, got %.3f", want, with.Strength)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_LiquidationCascade(t *testing.T) {
+	signal := &MarketSignal{
+		Symbol: "BTC",
+		RecentLiquidations: []*Liquidation{
+			{Side: "long", Value: 25_000_000},
+			{Side: "short", Value: 1_000_000},
+		},
+	}
+	signal.AnalyzeSignal()
+	if signal.Bias != SignalBiasBearish {
+		t.Fatalf("Expected one-sided long liquidations to be bearish, got %s", signal.Bias)
+	}
+
+	// A detected cascade is faded instead, scaled by its strength
+	signal.LiquidationCascade = &LiquidationCascade{Side: "long", Direction: SignalBiasBullish, Magnitude: 1}
+	signal.AnalyzeSignal()
+	if signal.Bias != SignalBiasBullish {
+		t.Errorf("Expected a long liquidation cascade to be bullish, got %s", signal.Bias)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_SentimentVolumeFloor(t *testing.T) {
+	floor := SentimentVolumeFloor{MinInteractions: 10000, MinPosts: 50}
+	analyze := func(posts, interactions int64) *MarketSignal {
+		signal := &MarketSignal{
+			Symbol:          "BTC",
+			SocialSentiment: &SocialSentiment{SentimentScore: 0.8, SocialVolume: posts, Interactions: interactions},
+		}
+		signal.AnalyzeSignalWith(DefaultSignalWeights(), floor)
+		return signal
+	}
+
+	if s := analyze(4, 120); s.Bias != SignalBiasNeutral || s.Confidence != 0 {
+		t.Errorf("Expected high score at tiny volume to be neutral, got %s (confidence %.2f)", s.Bias, s.Confidence)
+	}
+	if s := analyze(5000, 9999); s.Bias != SignalBiasNeutral {
+		t.Errorf("Expected interactions below the floor to be neutral, got %s", s.Bias)
+	}
+	if s := analyze(5000, 200000); s.Bias != SignalBiasBullish || s.Strength != 1 {
+		t.Errorf("Expected high score at high volume to be bullish, got %s (%.2f)", s.Bias, s.Strength)
+	}
+}
+
+func TestMarketSignal_AnalyzeSignal_FedPolicy(t *testing.T) {
+	// Rate cut expectations alone produce a bullish signal
+	signal := &MarketSignal{
+		Symbol:     "BTC",
+		Timestamp:  time.Now(),
+		FedCutProb: 0.8,
+	}
+	signal.AnalyzeSignal()
+
+	if signal.Bias != SignalBiasBullish {
+		t.Errorf("Expected bullish bias from rate cut expectations, got %s", signal.Bias)
+	}
+	if signal.Confidence != 1.0/8.0 {
+		t.Errorf("Expected Fed to count as one of 8 data sources, got confidence %.3f", signal.Confidence)
+	}
+
+	// Zero Fed weight removes its contribution to the score
+	weights := DefaultSignalWeights()
+	weights.FedPolicy = 0
+
+	unweighted := &MarketSignal{
+		Symbol:     "BTC",
+		Timestamp:  time.Now(),
+		FedCutProb: 0.8,
+	}
+	unweighted.AnalyzeSignalWithWeights(weights)
+
+	if unweighted.Bias != SignalBiasNeutral {
+		t.Errorf("Expected neutral bias with zero Fed weight, got %s", unweighted.Bias)
+	}
+}
+
 func TestWhaleAlert_GetAlertType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -181,6 +380,84 @@ func TestMacroSignal_AnalyzeMacroSignal(t *testing.T) {
 			signal.Bias, signal.Strength, signal.Confidence)
 	})
 
+	t.Run("Rising dollar is bearish", func(t *testing.T) {
+		signal := &MacroSignal{
+			Timestamp: time.Now(),
+			DXY:       &EconomicIndicator{Value: 105.0, Previous: 103.0},
+		}
+
+		signal.AnalyzeMacroSignal()
+
+		if signal.Bias != SignalBiasBearish || signal.Strength != 1 {
+			t.Errorf("Expected fully bearish bias from a rising DXY, got %s (%.2f)", signal.Bias, signal.Strength)
+		}
+		if want := 1 / float64(len(macroFactors)); signal.Confidence != want {
+			t.Errorf("Expected DXY to count as one of %d data points (%.2f), got %.2f", len(macroFactors), want, signal.Confidence)
+		}
+
+		// A move within the threshold adds nothing
+		signal.DXY = &EconomicIndicator{Value: 103.2, Previous: 103.0}
+		signal.AnalyzeMacroSignal()
+		if signal.Bias != SignalBiasNeutral {
+			t.Errorf("Expected neutral bias for a small DXY move, got %s", signal.Bias)
+		}
+	})
+
+	t.Run("PCE counts as a data point", func(t *testing.T) {
+		signal := &MacroSignal{
+			Timestamp: time.Now(),
+			PCE:       &EconomicIndicator{Value: 2.4, Previous: 2.8},
+		}
+
+		signal.AnalyzeMacroSignal()
+
+		if signal.Bias != SignalBiasBullish || signal.Strength != 1 {
+			t.Errorf("Expected cooling PCE to be bullish, got %s (%.2f)", signal.Bias, signal.Strength)
+		}
+		if want := 1 / float64(len(macroFactors)); signal.Confidence != want {
+			t.Errorf("Expected PCE to count as a data point (%.2f), got %.2f", want, signal.Confidence)
+		}
+
+		// A forecast takes precedence over the previous reading
+		signal.PCE = &EconomicIndicator{Value: 2.4, Previous: 2.8, Forecast: 2.2}
+		signal.AnalyzeMacroSignal()
+		if signal.Bias != SignalBiasBearish {
+			t.Errorf("Expected PCE above forecast to be bearish, got %s", signal.Bias)
+		}
+	})
+
+	t.Run("Imminent high-impact event lowers confidence", func(t *testing.T) {
+		now := time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+		signal := &MacroSignal{
+			Timestamp: now,
+			CPI:       &EconomicIndicator{Value: 2.5, Forecast: 3.0},
+			PCE:       &EconomicIndicator{Value: 2.4, Previous: 2.8},
+			UpcomingEvents: []*EconomicEvent{
+				{Event: "Retail Sales", Importance: "medium", Date: now.Add(2 * time.Hour)},
+				{Event: "FOMC Decision", Importance: "high", Date: now.Add(3 * 24 * time.Hour)},
+			},
+		}
+
+		signal.AnalyzeMacroSignal()
+		full := signal.Confidence
+		if want := 2 / float64(len(macroFactors)); full != want {
+			t.Fatalf("Expected confidence %.2f without an imminent event, got %.2f", want, full)
+		}
+
+		signal.UpcomingEvents = append(signal.UpcomingEvents,
+			&EconomicEvent{Event: "CPI", Importance: "high", Date: now.Add(12 * time.Hour)})
+		signal.AnalyzeMacroSignal()
+		if signal.ImminentEvent() == nil || signal.ImminentEvent().Event != "CPI" {
+			t.Errorf("Expected CPI as the imminent event, got %+v", signal.ImminentEvent())
+		}
+		if signal.Confidence != full*imminentEventDiscount {
+			t.Errorf("Expected confidence discounted to %.2f, got %.2f", full*imminentEventDiscount, signal.Confidence)
+		}
+		if signal.Bias != SignalBiasBullish {
+			t.Errorf("Expected the event not to change the bias, got %s", signal.Bias)
+		}
+	})
+
 	t.Run("Bearish macro (rate hike expected)", func(t *testing.T) {
 		signal := &MacroSignal{
 			Timestamp: time.Now(),
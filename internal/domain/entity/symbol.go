@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"strings"
+)
+
+// Symbol is a trading symbol in any accepted format: plain ("BTC"), perp
+// ("BTC-PERP"), pair ("BTC/USDC") or concatenated ("BTCUSDC"). All formats of
+// an asset share the same Base; venue methods format it for each data source.
+type Symbol string
+
+// quoteSuffixes are stripped to find the base asset, checked in order and
+// matched case-insensitively
+var quoteSuffixes = []string{"-PERP", "/USDC", "/USDT", "/USD", "-USDC", "-USDT", "-USD", "USDC", "USDT"}
+
+// Base returns the base asset, e.g. "BTC" for "btc/usdc". A lower-case base
+// is upper-cased; a mixed-case one is kept as written, since venue coin names
+// are case-sensitive (Hyperliquid's "kPEPE").
+func (s Symbol) Base() string {
+	base := strings.TrimSpace(string(s))
+	upper := strings.ToUpper(base)
+	for _, suffix := range quoteSuffixes {
+		if strings.HasSuffix(upper, suffix) && len(base) > len(suffix) {
+			base, upper = base[:len(base)-len(suffix)], upper[:len(upper)-len(suffix)]
+			break
+		}
+	}
+	if base == strings.ToLower(base) {
+		return upper
+	}
+	return base
+}
+
+// key returns the case-insensitive lookup key of the base asset
+func (s Symbol) key() string {
+	return strings.ToUpper(s.Base())
+}
+
+// IsPerp reports whether the symbol names a perpetual: plain, "-PERP" and
+// concatenated symbols are perps, "BASE/QUOTE" pairs are spot
+func (s Symbol) IsPerp() bool {
+	return !strings.Contains(string(s), "/")
+}
+
+// Normalize returns the canonical form used as the symbol key across the bot: the base asset
+func (s Symbol) Normalize() Symbol {
+	return Symbol(s.Base())
+}
+
+// String returns the symbol as written
+func (s Symbol) String() string {
+	return string(s)
+}
+
+// Hyperliquid returns the Hyperliquid perp coin name. The exchange client
+// trades perps only, so pair formats map to the perp of their base.
+func (s Symbol) Hyperliquid() string {
+	return s.Base()
+}
+
+// CoinGlass returns the CoinGlass symbol (the upper-case base asset)
+func (s Symbol) CoinGlass() string {
+	return s.key()
+}
+
+// LunarCrushTopic returns the LunarCrush topic from the asset registry
+func (s Symbol) LunarCrushTopic() string {
+	return Assets().Topic(s.Base())
+}
+
+// WhaleAlertBlockchain returns the Whale Alert blockchain from the asset registry, or "" if untracked
+func (s Symbol) WhaleAlertBlockchain() string {
+	return Assets().Blockchain(s.Base())
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSymbol_Formats(t *testing.T) {
+	tests := []struct {
+		symbol string
+		base   string
+		perp   bool
+	}{
+		{"BTC", "BTC", true},
+		{"btc", "BTC", true},
+		{" BTC ", "BTC", true},
+		{"BTC/USDC", "BTC", false},
+		{"btc/usdc", "BTC", false},
+		{"ETH/USDT", "ETH", false},
+		{"ETH-PERP", "ETH", true},
+		{"eth-perp", "ETH", true},
+		{"XRPUSDC", "XRP", true},
+		{"SOLUSDT", "SOL", true},
+		{"AVAX-USD", "AVAX", true},
+		{"USDC", "USDC", true},   // A bare quote asset is its own base
+		{"kPEPE", "kPEPE", true}, // Hyperliquid coin names are case-sensitive
+		{"kSHIB-PERP", "kSHIB", true},
+		{"kPEPE/usdc", "kPEPE", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			s := Symbol(tt.symbol)
+			if got := s.Base(); got != tt.base {
+				t.Errorf("Base() = %s, want %s", got, tt.base)
+			}
+			if got := s.Normalize(); got != Symbol(tt.base) {
+				t.Errorf("Normalize() = %s, want %s", got, tt.base)
+			}
+			if got := s.IsPerp(); got != tt.perp {
+				t.Errorf("IsPerp() = %v, want %v", got, tt.perp)
+			}
+			if got := s.Hyperliquid(); got != tt.base {
+				t.Errorf("Hyperliquid() = %s, want %s", got, tt.base)
+			}
+			if got := s.CoinGlass(); got != strings.ToUpper(tt.base) {
+				t.Errorf("CoinGlass() = %s, want %s", got, strings.ToUpper(tt.base))
+			}
+		})
+	}
+}
+
+func TestSymbol_RegistryVenues(t *testing.T) {
+	for _, symbol := range []Symbol{"BTC", "BTC-PERP", "BTC/USDC", "BTCUSDC"} {
+		if got := symbol.WhaleAlertBlockchain(); got != "bitcoin" {
+			t.Errorf("%s: WhaleAlertBlockchain() = %s, want bitcoin", symbol, got)
+		}
+		if got := symbol.LunarCrushTopic(); got != "bitcoin" {
+			t.Errorf("%s: LunarCrushTopic() = %s, want bitcoin", symbol, got)
+		}
+	}
+	if got := Symbol("NEW-PERP").LunarCrushTopic(); got != "new" {
+		t.Errorf("Expected unknown topics to default to the lower-case base, got %s", got)
+	}
+	if got := Symbol("NEW-PERP").WhaleAlertBlockchain(); got != "" {
+		t.Errorf("Expected untracked symbols to have no blockchain, got %s", got)
+	}
+}
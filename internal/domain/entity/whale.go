@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// Default whale clustering settings: at least 3 same-direction exchange flows
+// within a 10 minute bucket count as a coordinated movement
+const (
+	DefaultWhaleClusterBucket = 10 * time.Minute
+	DefaultWhaleClusterSize   = 3
+)
+
+// NetExchangeFlow sums exchange inflows and outflows in USD. net is inflow
+// minus outflow, so a positive net (coins moving onto exchanges) is bearish.
+// Wallet-to-wallet and exchange-to-exchange transfers are ignored.
+func NetExchangeFlow(alerts []*WhaleAlert) (inflowUSD, outflowUSD, net float64) {
+	for _, a := range alerts {
+		switch a.GetAlertType() {
+		case WhaleAlertExchangeInflow:
+			inflowUSD += a.AmountUSD
+		case WhaleAlertExchangeOutflow:
+			outflowUSD += a.AmountUSD
+		}
+	}
+	return inflowUSD, outflowUSD, inflowUSD - outflowUSD
+}
+
+// WhaleCluster is a group of same-direction exchange flows within one time bucket
+type WhaleCluster struct {
+	Type      WhaleAlertType `json:"type"`  // WhaleAlertExchangeInflow or WhaleAlertExchangeOutflow
+	Start     time.Time      `json:"start"` // Bucket start
+	AmountUSD float64        `json:"amount_usd"`
+	Alerts    []*WhaleAlert  `json:"alerts"`
+}
+
+// ClusterWhaleAlerts groups exchange inflows and outflows into buckets of the
+// given width and returns the buckets holding at least minSize alerts of the
+// same direction, oldest first
+func ClusterWhaleAlerts(alerts []*WhaleAlert, bucket time.Duration, minSize int) []WhaleCluster {
+	if bucket <= 0 {
+		return nil
+	}
+
+	type key struct {
+		typ   WhaleAlertType
+		start time.Time
+	}
+	groups := make(map[key]*WhaleCluster)
+	for _, a := range alerts {
+		typ := a.GetAlertType()
+		if typ != WhaleAlertExchangeInflow && typ != WhaleAlertExchangeOutflow {
+			continue
+		}
+		k := key{typ, a.Timestamp.Truncate(bucket)}
+		c := groups[k]
+		if c == nil {
+			c = &WhaleCluster{Type: typ, Start: k.start}
+			groups[k] = c
+		}
+		c.AmountUSD += a.AmountUSD
+		c.Alerts = append(c.Alerts, a)
+	}
+
+	clusters := make([]WhaleCluster, 0, len(groups))
+	for _, c := range groups {
+		if len(c.Alerts) >= minSize {
+			clusters = append(clusters, *c)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if !clusters[i].Start.Equal(clusters[j].Start) {
+			return clusters[i].Start.Before(clusters[j].Start)
+		}
+		return clusters[i].Type < clusters[j].Type
+	})
+	return clusters
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNetExchangeFlow(t *testing.T) {
+	alerts := []*WhaleAlert{
+		{FromOwner: "unknown", ToOwner: "binance", AmountUSD: 30_000_000},  // Inflow
+		{FromOwner: "unknown", ToOwner: "coinbase", AmountUSD: 20_000_000}, // Inflow
+		{FromOwner: "kraken", ToOwner: "unknown", AmountUSD: 15_000_000},   // Outflow
+		{FromOwner: "unknown", ToOwner: "unknown", AmountUSD: 90_000_000},  // Wallet transfer
+		{FromOwner: "binance", ToOwner: "okx", AmountUSD: 40_000_000},      // Exchange to exchange
+	}
+
+	inflow, outflow, net := NetExchangeFlow(alerts)
+	if inflow != 50_000_000 || outflow != 15_000_000 || net != 35_000_000 {
+		t.Errorf("Expected $50M in / $15M out / $35M net, got %.0f / %.0f / %.0f", inflow, outflow, net)
+	}
+
+	if inflow, outflow, net := NetExchangeFlow(nil); inflow != 0 || outflow != 0 || net != 0 {
+		t.Errorf("Expected zero flow without alerts, got %.0f / %.0f / %.0f", inflow, outflow, net)
+	}
+}
+
+func TestClusterWhaleAlerts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	inflow := func(offset time.Duration, usd float64) *WhaleAlert {
+		return &WhaleAlert{FromOwner: "unknown", ToOwner: "binance", AmountUSD: usd, Timestamp: start.Add(offset)}
+	}
+	outflow := func(offset time.Duration, usd float64) *WhaleAlert {
+		return &WhaleAlert{FromOwner: "binance", ToOwner: "unknown", AmountUSD: usd, Timestamp: start.Add(offset)}
+	}
+
+	alerts := []*WhaleAlert{
+		// Three deposits within the same 10 minute bucket
+		inflow(time.Minute, 10_000_000),
+		inflow(4*time.Minute, 20_000_000),
+		inflow(9*time.Minute, 30_000_000),
+		// A single withdrawal in that bucket, and deposits split across buckets
+		outflow(5*time.Minute, 50_000_000),
+		inflow(15*time.Minute, 10_000_000),
+		inflow(25*time.Minute, 10_000_000),
+		{FromOwner: "unknown", ToOwner: "unknown", AmountUSD: 90_000_000, Timestamp: start.Add(2 * time.Minute)},
+	}
+
+	clusters := ClusterWhaleAlerts(alerts, 10*time.Minute, 3)
+	if len(clusters) != 1 {
+		t.Fatalf("Expected one cluster, got %+v", clusters)
+	}
+	c := clusters[0]
+	if c.Type != WhaleAlertExchangeInflow || !c.Start.Equal(start) || len(c.Alerts) != 3 || c.AmountUSD != 60_000_000 {
+		t.Errorf("Expected 3 inflows worth $60M from %s, got %+v", start, c)
+	}
+
+	// Smaller clusters come back oldest first
+	clusters = ClusterWhaleAlerts(alerts, 10*time.Minute, 1)
+	if len(clusters) != 4 {
+		t.Fatalf("Expected 4 clusters, got %d", len(clusters))
+	}
+	if clusters[0].Type != WhaleAlertExchangeInflow || clusters[1].Type != WhaleAlertExchangeOutflow || !clusters[3].Start.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("Unexpected cluster order: %+v", clusters)
+	}
+
+	if clusters := ClusterWhaleAlerts(alerts, 0, 1); clusters != nil {
+		t.Errorf("Expected no clusters without a bucket width, got %+v", clusters)
+	}
+}
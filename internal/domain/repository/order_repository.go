@@ -2,10 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
 )
 
+// Common repository errors
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+)
+
 // OrderRepository defines order data access interface
 type OrderRepository interface {
 	// Create creates a new order
@@ -17,7 +24,7 @@ type OrderRepository interface {
 	// GetByClientOrderID retrieves order by client order ID
 	GetByClientOrderID(ctx context.Context, clientOrderID string) (*entity.Order, error)
 
-	// List retrieves orders with filters
+	// List retrieves orders with filters, most recent first
 	List(ctx context.Context, filter OrderFilter) ([]*entity.Order, error)
 
 	// Update updates order
@@ -32,5 +39,5 @@ type OrderFilter struct {
 	Symbol string
 	Status entity.OrderStatus
 	Side   entity.Side
-	Limit  int
+	Limit  int // Maximum number of orders (0 = no limit)
 }
@@ -13,15 +13,32 @@ type Signal struct {
 	Price    float64
 	Quantity float64
 	Reason   string
+	PostOnly bool // Place as a post-only limit order (maker fees)
+	Market   bool // Take liquidity with a market order; Price is the expected fill
+
+	ReduceOnly bool // Only reduce the position; never open or flip one
+
+	ClientOrderID string // Assigned on first placement; re-placing the signal reuses it
+}
+
+// QuantityForNotional converts a USD notional to a quantity in base units at
+// price, for strategies that size orders in USD; 0 when price is not positive
+func QuantityForNotional(notional, price float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	return notional / price
 }
 
-// MarketState represents current market state for strategy
+// MarketState represents current market state for strategy.
+// Callers may reuse it between ticks, so strategies must not retain it after OnTick returns.
 type MarketState struct {
 	Ticker       *entity.Ticker
 	OrderBook    *entity.OrderBook
 	Position     *entity.Position
 	Orders       []*entity.Order
 	MarketSignal *entity.MarketSignal // Aggregated market signal from all data sources
+	Equity       float64              // Account equity in USD (0 = unknown)
 }
 
 // Strategy defines trading strategy interface
@@ -41,10 +58,73 @@ type Strategy interface {
 	// OnPositionUpdate is called when position changes
 	OnPositionUpdate(ctx context.Context, position *entity.Position) error
 
+	// Reset clears per-trade state such as entry and trailing-stop tracking;
+	// it is called when the position goes flat
+	Reset(ctx context.Context) error
+
 	// Stop stops the strategy
 	Stop(ctx context.Context) error
 }
 
+// StatefulStrategy is implemented by strategies that can report their internal state
+type StatefulStrategy interface {
+	Strategy
+
+	// GetState returns a snapshot of the strategy state for monitoring
+	GetState() map[string]interface{}
+}
+
+// ReconfigurableStrategy is implemented by strategies that accept new parameters while running
+type ReconfigurableStrategy interface {
+	Strategy
+
+	// Reconfigure applies new parameters, keeping market history and position state
+	Reconfigure(ctx context.Context, config map[string]interface{}) error
+}
+
+// CandleStrategy is implemented by strategies that trade on closed candles
+type CandleStrategy interface {
+	Strategy
+
+	// OnCandle is called with each closed candle built from the tick stream
+	OnCandle(ctx context.Context, candle entity.Candle) ([]*Signal, error)
+}
+
+// PositionSizer sizes entries by volatility
+type PositionSizer interface {
+	// SizePosition returns the size at which a stop some ATRs away loses
+	// riskPerTrade of equity, or 0 if it cannot be sized
+	SizePosition(equity, price, atr, riskPerTrade float64) float64
+}
+
+// SizedStrategy is implemented by strategies that can size entries by volatility
+type SizedStrategy interface {
+	Strategy
+
+	// SetPositionSizer sets the sizer used for volatility-scaled entries
+	SetPositionSizer(sizer PositionSizer)
+}
+
+// Logger is the logging interface strategies use for diagnostics
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// NopLogger discards all log output
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+
+// LoggableStrategy is implemented by strategies that accept a logger
+type LoggableStrategy interface {
+	Strategy
+
+	// SetLogger sets the logger used for per-tick diagnostics
+	SetLogger(log Logger)
+}
+
 // StrategyFactory creates strategy instances
 type StrategyFactory interface {
 	// Create creates a new strategy instance by name
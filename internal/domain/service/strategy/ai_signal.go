@@ -27,6 +27,13 @@ type AISignalConfig struct {
 	TrailingStop      bool    `yaml:"trailing_stop"`         // Enable trailing stop
 	TrailingPercent   float64 `yaml:"trailing_percent"`      // Trailing stop %
 
+	MaxHoldTime time.Duration `yaml:"max_hold_time"` // Close the position after this long (0 = disabled)
+
+	// Signal reversal exit: an opposing signal stronger than the threshold
+	// with at least the minimum confidence closes the position
+	ReversalStrengthThreshold float64 `yaml:"reversal_strength_threshold"`
+	ReversalMinConfidence     float64 `yaml:"reversal_min_confidence"`
+
 	// Risk management
 	MaxDrawdown       float64 `yaml:"max_drawdown"`          // Max drawdown before stopping
 	CooldownPeriod    time.Duration `yaml:"cooldown_period"` // Cooldown after loss
@@ -55,6 +62,8 @@ func DefaultAISignalConfig() AISignalConfig {
 		WeightWhale:        0.20,
 		WeightSentiment:    0.25,
 		WeightMacro:        0.25,
+
+		ReversalStrengthThreshold: 0.5,
 	}
 }
 
@@ -66,19 +75,44 @@ type AISignalStrategy struct {
 	running       bool
 	entryPrice    float64
 	highestPrice  float64   // For trailing stop
+	entryTime     time.Time // When the open position was entered, for max_hold_time
 	lastSignal    *entity.MarketSignal
 	lastTradeTime time.Time
 	totalPnL      float64
 	peakEquity    float64
+	log           service.Logger
+	clock         service.Clock
 }
 
 // NewAISignalStrategy creates a new AI signal strategy
 func NewAISignalStrategy() *AISignalStrategy {
 	return &AISignalStrategy{
 		config: DefaultAISignalConfig(),
+		log:    service.NopLogger,
+		clock:  service.RealClock,
 	}
 }
 
+// SetLogger sets the logger used for per-tick diagnostics
+func (s *AISignalStrategy) SetLogger(log service.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if log == nil {
+		log = service.NopLogger
+	}
+	s.log = log
+}
+
+// SetClock sets the clock used for the loss cooldown; nil restores the real clock
+func (s *AISignalStrategy) SetClock(clock service.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clock == nil {
+		clock = service.RealClock
+	}
+	s.clock = clock
+}
+
 // Name returns strategy name
 func (s *AISignalStrategy) Name() string {
 	return "ai_signal"
@@ -89,24 +123,64 @@ func (s *AISignalStrategy) Init(ctx context.Context, config map[string]interface
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.applyConfig(config); err != nil {
+		return err
+	}
+	s.running = true
+	return nil
+}
+
+// Reconfigure applies new parameters without resetting price history or position state
+func (s *AISignalStrategy) Reconfigure(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.applyConfig(config)
+}
+
+// applyConfig parses config over the current settings; nothing changes on error
+func (s *AISignalStrategy) applyConfig(config map[string]interface{}) error {
+	cfg := s.config
+
 	// Parse config
 	if v, ok := config["max_position_size"].(float64); ok {
-		s.config.MaxPositionSize = v
+		cfg.MaxPositionSize = v
 	}
 	if v, ok := config["min_signal_strength"].(float64); ok {
-		s.config.MinSignalStrength = v
+		cfg.MinSignalStrength = v
 	}
 	if v, ok := config["min_confidence"].(float64); ok {
-		s.config.MinConfidence = v
+		cfg.MinConfidence = v
 	}
 	if v, ok := config["take_profit_percent"].(float64); ok {
-		s.config.TakeProfitPercent = v
+		cfg.TakeProfitPercent = v
 	}
 	if v, ok := config["stop_loss_percent"].(float64); ok {
-		s.config.StopLossPercent = v
+		cfg.StopLossPercent = v
+	}
+	if v, ok := config["max_hold_time"].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid max_hold_time: %w", err)
+		}
+		if d < 0 {
+			return fmt.Errorf("max_hold_time must be non-negative")
+		}
+		cfg.MaxHoldTime = d
+	}
+	if v, ok := config["reversal_strength_threshold"].(float64); ok {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("reversal_strength_threshold must be between 0 and 1")
+		}
+		cfg.ReversalStrengthThreshold = v
+	}
+	if v, ok := config["reversal_min_confidence"].(float64); ok {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("reversal_min_confidence must be between 0 and 1")
+		}
+		cfg.ReversalMinConfidence = v
 	}
 
-	s.running = true
+	s.config = cfg
 	return nil
 }
 
@@ -126,18 +200,15 @@ func (s *AISignalStrategy) OnTick(ctx context.Context, state *service.MarketStat
 		s.lastSignal = state.MarketSignal
 	}
 
-	// Check cooldown
-	if time.Since(s.lastTradeTime) < s.config.CooldownPeriod && s.totalPnL < 0 {
-		return nil, nil
-	}
-
 	currentPrice := state.Ticker.LastPrice
 	hasPosition := state.Position != nil && state.Position.Size != 0
 
 	if hasPosition {
-		// Manage existing position
+		// Manage existing position; the cooldown never blocks exits
 		exitSignals := s.managePosition(state, currentPrice)
 		signals = append(signals, exitSignals...)
+	} else if s.inCooldown() {
+		s.log.Debug("ai_signal %s: no entry, in cooldown after loss (total PnL %.2f)", state.Ticker.Symbol, s.totalPnL)
 	} else {
 		// Look for entry opportunities
 		entrySignal := s.evaluateEntry(state, currentPrice)
@@ -149,25 +220,37 @@ func (s *AISignalStrategy) OnTick(ctx context.Context, state *service.MarketStat
 	return signals, nil
 }
 
+// inCooldown reports whether new entries are paused after a loss
+func (s *AISignalStrategy) inCooldown() bool {
+	return s.totalPnL < 0 && s.clock.Now().Sub(s.lastTradeTime) < s.config.CooldownPeriod
+}
+
 // evaluateEntry evaluates entry opportunity based on aggregated signals
 func (s *AISignalStrategy) evaluateEntry(state *service.MarketState, currentPrice float64) *service.Signal {
+	symbol := state.Ticker.Symbol
 	if s.lastSignal == nil {
+		s.log.Debug("ai_signal %s: no entry, no market signal yet", symbol)
 		return nil
 	}
 
 	signal := s.lastSignal
+	s.log.Debug("ai_signal %s: bias=%s strength=%.2f confidence=%.2f",
+		symbol, signal.Bias, signal.Strength, signal.Confidence)
 
 	// Check minimum thresholds
 	if signal.Strength < s.config.MinSignalStrength {
+		s.log.Debug("ai_signal %s: no entry, strength %.2f < min %.2f", symbol, signal.Strength, s.config.MinSignalStrength)
 		return nil
 	}
 	if signal.Confidence < s.config.MinConfidence {
+		s.log.Debug("ai_signal %s: no entry, confidence %.2f < min %.2f", symbol, signal.Confidence, s.config.MinConfidence)
 		return nil
 	}
 
 	// Determine position size based on signal strength and confidence
 	positionSize := s.calculatePositionSize(signal)
 	if positionSize <= 0 {
+		s.log.Debug("ai_signal %s: no entry, position size %.2f below step %.2f", symbol, positionSize, s.config.PositionSizeStep)
 		return nil
 	}
 
@@ -183,10 +266,11 @@ func (s *AISignalStrategy) evaluateEntry(state *service.MarketState, currentPric
 		side = entity.SideSell
 		reason = s.buildEntryReason(signal, "SHORT")
 	default:
+		s.log.Debug("ai_signal %s: no entry, bias %s", symbol, signal.Bias)
 		return nil
 	}
 
-	quantity := positionSize / currentPrice
+	quantity := service.QuantityForNotional(positionSize, currentPrice)
 
 	return &service.Signal{
 		Symbol:   state.Ticker.Symbol,
@@ -235,18 +319,14 @@ func (s *AISignalStrategy) buildEntryReason(signal *entity.MarketSignal, directi
 	}
 
 	if len(signal.RecentWhaleAlerts) > 0 {
-		var inflow, outflow float64
-		for _, a := range signal.RecentWhaleAlerts {
-			switch a.GetAlertType() {
-			case entity.WhaleAlertExchangeInflow:
-				inflow += a.AmountUSD
-			case entity.WhaleAlertExchangeOutflow:
-				outflow += a.AmountUSD
-			}
-		}
+		inflow, outflow, _ := entity.NetExchangeFlow(signal.RecentWhaleAlerts)
 		reasons = append(reasons, fmt.Sprintf("Whale: $%.0fM in / $%.0fM out", inflow/1e6, outflow/1e6))
 	}
 
+	if c := signal.LiquidationCascade; c != nil {
+		reasons = append(reasons, fmt.Sprintf("Liquidation cascade: $%.0fM %ss in %s (%s)", c.ValueUSD/1e6, c.Side, c.Window, c.Direction))
+	}
+
 	if signal.SocialSentiment != nil {
 		sentimentStr := "neutral"
 		if signal.SocialSentiment.SentimentScore > 0.2 {
@@ -279,6 +359,10 @@ func (s *AISignalStrategy) managePosition(state *service.MarketState, currentPri
 
 	isLong := position.Size > 0
 	entryPrice := position.EntryPrice
+	now := s.clock.Now()
+	if s.entryTime.IsZero() {
+		s.entryTime = now
+	}
 
 	// Update highest price for trailing stop
 	if isLong && currentPrice > s.highestPrice {
@@ -325,23 +409,43 @@ func (s *AISignalStrategy) managePosition(state *service.MarketState, currentPri
 		}
 	}
 
-	// Check signal reversal
-	if s.lastSignal != nil {
-		if isLong && s.lastSignal.Bias == entity.SignalBiasBearish && s.lastSignal.Strength > 0.5 {
-			signals = append(signals, s.createExitSignal(state, position, currentPrice,
-				"Signal Reversal: Strong bearish signal detected"))
-			return signals
-		}
-		if !isLong && s.lastSignal.Bias == entity.SignalBiasBullish && s.lastSignal.Strength > 0.5 {
-			signals = append(signals, s.createExitSignal(state, position, currentPrice,
-				"Signal Reversal: Strong bullish signal detected"))
-			return signals
-		}
+	// Check max hold time
+	if held := now.Sub(s.entryTime); s.config.MaxHoldTime > 0 && held >= s.config.MaxHoldTime {
+		signals = append(signals, s.createExitSignal(state, position, currentPrice,
+			fmt.Sprintf("Timeout: held %s, max %s", held.Round(time.Second), s.config.MaxHoldTime)))
+		return signals
+	}
+
+	s.log.Debug("ai_signal %s: holding, PnL %.2f%% (TP %.2f%% / SL -%.2f%%), best %.2f",
+		state.Ticker.Symbol, pnlPercent*100, s.config.TakeProfitPercent*100, s.config.StopLossPercent*100, s.highestPrice)
+
+	// Check signal reversal against this tick's signal, falling back to the
+	// last one only when the tick carries none
+	signal := state.MarketSignal
+	if signal == nil {
+		signal = s.lastSignal
+	}
+	opposing := entity.SignalBiasBearish
+	if !isLong {
+		opposing = entity.SignalBiasBullish
+	}
+	if s.reverses(signal, opposing) {
+		signals = append(signals, s.createExitSignal(state, position, currentPrice,
+			fmt.Sprintf("Signal Reversal: Strong %s signal detected", opposing)))
+		return signals
 	}
 
 	return signals
 }
 
+// reverses reports whether signal has the opposing bias with enough strength
+// and confidence to exit on
+func (s *AISignalStrategy) reverses(signal *entity.MarketSignal, opposing entity.SignalBias) bool {
+	return signal != nil && signal.Bias == opposing &&
+		signal.Strength > s.config.ReversalStrengthThreshold &&
+		signal.Confidence >= s.config.ReversalMinConfidence
+}
+
 // createExitSignal creates an exit signal
 func (s *AISignalStrategy) createExitSignal(state *service.MarketState, position *entity.Position, price float64, reason string) *service.Signal {
 	var side entity.Side
@@ -352,11 +456,12 @@ func (s *AISignalStrategy) createExitSignal(state *service.MarketState, position
 	}
 
 	return &service.Signal{
-		Symbol:   state.Ticker.Symbol,
-		Side:     side,
-		Price:    price,
-		Quantity: math.Abs(position.Size),
-		Reason:   "EXIT: " + reason,
+		Symbol:     state.Ticker.Symbol,
+		Side:       side,
+		Price:      price,
+		Quantity:   math.Abs(position.Size),
+		Reason:     "EXIT: " + reason,
+		ReduceOnly: true,
 	}
 }
 
@@ -366,7 +471,7 @@ func (s *AISignalStrategy) OnOrderUpdate(ctx context.Context, order *entity.Orde
 	defer s.mu.Unlock()
 
 	if order.Status == entity.OrderStatusFilled {
-		s.lastTradeTime = time.Now()
+		s.lastTradeTime = s.clock.Now()
 
 		// Track PnL for drawdown calculation
 		if order.Side == entity.SideSell && s.entryPrice > 0 {
@@ -386,18 +491,36 @@ func (s *AISignalStrategy) OnPositionUpdate(ctx context.Context, position *entit
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if position.Size != 0 {
-		s.entryPrice = position.EntryPrice
+	if position == nil || position.Size == 0 {
+		s.resetTradeState()
+		return nil
+	}
+	s.entryPrice = position.EntryPrice
+	// Seed the trailing stop and hold timer on entry only; later updates keep them
+	if s.highestPrice == 0 {
 		s.highestPrice = position.EntryPrice
-	} else {
-		// Position closed
-		s.entryPrice = 0
-		s.highestPrice = 0
 	}
+	if s.entryTime.IsZero() {
+		s.entryTime = s.clock.Now()
+	}
+	return nil
+}
 
+// Reset clears the entry and trailing-stop tracking of the last trade
+func (s *AISignalStrategy) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetTradeState()
 	return nil
 }
 
+// resetTradeState clears per-trade state. s.mu must be held.
+func (s *AISignalStrategy) resetTradeState() {
+	s.entryPrice = 0
+	s.highestPrice = 0
+	s.entryTime = time.Time{}
+}
+
 // Stop stops the strategy
 func (s *AISignalStrategy) Stop(ctx context.Context) error {
 	s.mu.Lock()
@@ -423,3 +546,24 @@ func (s *AISignalStrategy) GetStats() map[string]interface{} {
 		"running":        s.running,
 	}
 }
+
+// GetState returns a snapshot of the strategy state
+func (s *AISignalStrategy) GetState() map[string]interface{} {
+	state := s.GetStats()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state["entry_price"] = s.entryPrice
+	state["highest_price"] = s.highestPrice
+	if !s.entryTime.IsZero() {
+		state["entry_time"] = s.entryTime
+	}
+	if !s.lastTradeTime.IsZero() {
+		state["last_trade_time"] = s.lastTradeTime
+	}
+	if s.lastSignal != nil {
+		state["last_signal"] = s.lastSignal
+	}
+	return state
+}
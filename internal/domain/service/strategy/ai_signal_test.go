@@ -2,6 +2,8 @@ package strategy
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -191,6 +193,60 @@ func TestAISignalStrategy_OnTick_WeakSignalNoEntry(t *testing.T) {
 	}
 }
 
+func TestAISignalStrategy_OnTick_LiquidationCascadeEntry(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	s.Init(ctx, nil)
+
+	// The cascade is already scored into bias and strength by the signal
+	// provider, so the strategy trades the signal as is
+	cascade := &entity.LiquidationCascade{
+		Side:      "long",
+		Direction: entity.SignalBiasBullish,
+		ValueUSD:  25_000_000,
+		Magnitude: 2.5,
+		Window:    5 * time.Minute,
+	}
+	state := func(bias entity.SignalBias, strength float64, c *entity.LiquidationCascade) *service.MarketState {
+		return &service.MarketState{
+			Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: 50000.0, Timestamp: time.Now()},
+			MarketSignal: &entity.MarketSignal{
+				Symbol:             "BTC",
+				Timestamp:          time.Now(),
+				Bias:               bias,
+				Strength:           strength,
+				Confidence:         0.7,
+				LiquidationCascade: c,
+			},
+		}
+	}
+
+	signals, err := s.OnTick(ctx, state(entity.SignalBiasBullish, 0.6, cascade))
+	if err != nil {
+		t.Fatalf("OnTick failed: %v", err)
+	}
+	if len(signals) != 1 || signals[0].Side != entity.SideBuy {
+		t.Fatalf("Expected a long entry, got %+v", signals)
+	}
+	if !strings.Contains(signals[0].Reason, "Liquidation cascade") {
+		t.Errorf("Expected the cascade in the entry reason, got %q", signals[0].Reason)
+	}
+
+	other := NewAISignalStrategy()
+	other.Init(ctx, nil)
+	plain, _ := other.OnTick(ctx, state(entity.SignalBiasBullish, 0.6, nil))
+	if len(plain) != 1 || plain[0].Quantity != signals[0].Quantity {
+		t.Errorf("Expected the cascade not to be weighted again, got %+v vs %+v", signals, plain)
+	}
+
+	// A weak bearish read stays below the entry threshold
+	s = NewAISignalStrategy()
+	s.Init(ctx, nil)
+	if signals, _ := s.OnTick(ctx, state(entity.SignalBiasBearish, 0.1, cascade)); len(signals) != 0 {
+		t.Errorf("Expected no entry for a weak signal with a cascade, got %+v", signals)
+	}
+}
+
 func TestAISignalStrategy_TakeProfit(t *testing.T) {
 	s := NewAISignalStrategy()
 	ctx := context.Background()
@@ -365,3 +421,254 @@ func TestAISignalStrategy_BuildEntryReason(t *testing.T) {
 
 	t.Logf("Entry reason:\n%s", reason)
 }
+
+// recordingLogger captures debug messages
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(msg, args...))
+}
+
+func TestAISignalStrategy_DebugLogging(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	s.Init(ctx, nil)
+	log := &recordingLogger{}
+	s.SetLogger(log)
+
+	state := &service.MarketState{
+		Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: 50000.0, Timestamp: time.Now()},
+		MarketSignal: &entity.MarketSignal{
+			Symbol:     "BTC",
+			Bias:       entity.SignalBiasBullish,
+			Strength:   0.2,
+			Confidence: 0.8,
+		},
+	}
+	if _, err := s.OnTick(ctx, state); err != nil {
+		t.Fatalf("OnTick failed: %v", err)
+	}
+
+	found := false
+	for _, line := range log.lines {
+		if strings.Contains(line, "strength 0.20 < min 0.30") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected debug output explaining the rejected entry, got %v", log.lines)
+	}
+}
+
+func TestAISignalStrategy_CooldownAfterLoss(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	s.Init(ctx, nil)
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.SetClock(clock)
+
+	// Close a long at a loss
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 50000.0, Side: entity.SideBuy})
+	s.OnOrderUpdate(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Price: 49000.0, Quantity: 0.01, Status: entity.OrderStatusFilled})
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC"})
+
+	state := &service.MarketState{
+		Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: 50000.0, Timestamp: clock.Now()},
+		MarketSignal: &entity.MarketSignal{
+			Symbol:         "BTC",
+			Bias:           entity.SignalBiasBullish,
+			Strength:       0.6,
+			Confidence:     0.7,
+			FundingRate:    &entity.FundingRate{Rate: -0.0003},
+			LongShortRatio: &entity.LongShortRatio{LongShortRatio: 0.6},
+		},
+	}
+
+	clock.Advance(29 * time.Minute)
+	if signals, _ := s.OnTick(ctx, state); len(signals) != 0 {
+		t.Fatalf("Expected no entry during the cooldown, got %d signals", len(signals))
+	}
+
+	clock.Advance(time.Minute)
+	if signals, _ := s.OnTick(ctx, state); len(signals) == 0 {
+		t.Error("Expected entry once the cooldown elapsed")
+	}
+}
+
+func TestAISignalStrategy_ResetClearsTrailingHigh(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	s.Init(ctx, nil)
+
+	position := &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 50000.0, Side: entity.SideBuy}
+	s.OnPositionUpdate(ctx, position)
+	s.OnTick(ctx, &service.MarketState{
+		Ticker:   &entity.Ticker{Symbol: "BTC", LastPrice: 50500.0, Timestamp: time.Now()},
+		Position: position,
+	})
+
+	// A mid-trade update keeps the trailing high
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC", Size: 0.02, EntryPrice: 50200.0, Side: entity.SideBuy})
+	if high := s.GetState()["highest_price"]; high != 50500.0 {
+		t.Fatalf("Expected trailing high 50500 kept across position updates, got %v", high)
+	}
+
+	if err := s.Reset(ctx); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	state := s.GetState()
+	if state["highest_price"] != 0.0 || state["entry_price"] != 0.0 {
+		t.Fatalf("Expected trade state cleared after Reset, got high %v entry %v", state["highest_price"], state["entry_price"])
+	}
+
+	// The next trade starts its trailing high at its own entry
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 48000.0, Side: entity.SideBuy})
+	if high := s.GetState()["highest_price"]; high != 48000.0 {
+		t.Errorf("Expected trailing high seeded at the new entry 48000, got %v", high)
+	}
+}
+
+func TestAISignalStrategy_MaxHoldTime(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	if err := s.Init(ctx, map[string]interface{}{"max_hold_time": "4h"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.SetClock(clock)
+
+	position := &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 50000.0, Side: entity.SideBuy}
+	s.OnPositionUpdate(ctx, position)
+	state := &service.MarketState{
+		Ticker:   &entity.Ticker{Symbol: "BTC", LastPrice: 50000.0, Timestamp: clock.Now()},
+		Position: position,
+	}
+
+	clock.Advance(3 * time.Hour)
+	if signals, _ := s.OnTick(ctx, state); len(signals) != 0 {
+		t.Fatalf("Expected no exit before max_hold_time, got %+v", signals[0])
+	}
+
+	clock.Advance(time.Hour)
+	signals, err := s.OnTick(ctx, state)
+	if err != nil {
+		t.Fatalf("OnTick failed: %v", err)
+	}
+	if len(signals) != 1 {
+		t.Fatalf("Expected a timeout exit at a neutral price, got %d signals", len(signals))
+	}
+	sig := signals[0]
+	if sig.Side != entity.SideSell || sig.Quantity != 0.01 || !sig.ReduceOnly {
+		t.Errorf("Expected a reduce-only sell of 0.01, got %+v", sig)
+	}
+	if !strings.Contains(sig.Reason, "Timeout") {
+		t.Errorf("Expected a Timeout reason, got %q", sig.Reason)
+	}
+}
+
+func TestAISignalStrategy_InvalidMaxHoldTime(t *testing.T) {
+	for _, v := range []string{"soon", "-1m"} {
+		if err := NewAISignalStrategy().Init(context.Background(), map[string]interface{}{"max_hold_time": v}); err == nil {
+			t.Errorf("Expected error for max_hold_time %q", v)
+		}
+	}
+}
+
+func TestAISignalStrategy_SignalReversalThreshold(t *testing.T) {
+	position := &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 50000.0, Side: entity.SideBuy}
+	tick := func(s *AISignalStrategy, signal *entity.MarketSignal) []*service.Signal {
+		t.Helper()
+		signals, err := s.OnTick(context.Background(), &service.MarketState{
+			Ticker:       &entity.Ticker{Symbol: "BTC", LastPrice: 50000.0, Timestamp: time.Now()},
+			Position:     position,
+			MarketSignal: signal,
+		})
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+		return signals
+	}
+	bearish := func(strength, confidence float64) *entity.MarketSignal {
+		return &entity.MarketSignal{Symbol: "BTC", Bias: entity.SignalBiasBearish, Strength: strength, Confidence: confidence}
+	}
+
+	tests := []struct {
+		name   string
+		signal *entity.MarketSignal
+		exit   bool
+	}{
+		{"above threshold", bearish(0.75, 0.6), true},
+		{"at threshold", bearish(0.7, 0.6), false},
+		{"below threshold", bearish(0.6, 0.6), false},
+		{"below min confidence", bearish(0.9, 0.4), false},
+		{"same direction", &entity.MarketSignal{Symbol: "BTC", Bias: entity.SignalBiasBullish, Strength: 0.9, Confidence: 0.9}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAISignalStrategy()
+			if err := s.Init(context.Background(), map[string]interface{}{
+				"reversal_strength_threshold": 0.7,
+				"reversal_min_confidence":     0.5,
+			}); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+			s.OnPositionUpdate(context.Background(), position)
+
+			signals := tick(s, tt.signal)
+			if got := len(signals) == 1 && strings.Contains(signals[0].Reason, "Signal Reversal"); got != tt.exit {
+				t.Errorf("Expected reversal exit %v, got %+v", tt.exit, signals)
+			}
+		})
+	}
+
+	// A stale reversal from an earlier tick is replaced by the current signal
+	s := NewAISignalStrategy()
+	s.Init(context.Background(), nil)
+	s.OnPositionUpdate(context.Background(), position)
+	s.lastSignal = bearish(0.9, 0.9)
+	if signals := tick(s, &entity.MarketSignal{Symbol: "BTC", Bias: entity.SignalBiasNeutral}); len(signals) != 0 {
+		t.Errorf("Expected no exit on a stale reversal, got %+v", signals)
+	}
+}
+
+func TestAISignalStrategy_InvalidReversalConfig(t *testing.T) {
+	for _, key := range []string{"reversal_strength_threshold", "reversal_min_confidence"} {
+		if err := NewAISignalStrategy().Init(context.Background(), map[string]interface{}{key: 1.5}); err == nil {
+			t.Errorf("Expected error for %s out of range", key)
+		}
+	}
+}
+
+func TestAISignalStrategy_CooldownDoesNotBlockExits(t *testing.T) {
+	s := NewAISignalStrategy()
+	ctx := context.Background()
+	s.Init(ctx, nil)
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	s.SetClock(clock)
+
+	// Close a long at a loss to start the cooldown
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 50000.0, Side: entity.SideBuy})
+	s.OnOrderUpdate(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Price: 49000.0, Quantity: 0.01, Status: entity.OrderStatusFilled})
+	s.Reset(ctx)
+
+	// A position opened during the cooldown still takes its profit
+	position := &entity.Position{Symbol: "BTC", Size: 0.01, EntryPrice: 48000.0, Side: entity.SideBuy}
+	s.OnPositionUpdate(ctx, position)
+	clock.Advance(5 * time.Minute)
+	if !s.inCooldown() {
+		t.Fatal("Expected the strategy to be in cooldown")
+	}
+
+	signals, err := s.OnTick(ctx, &service.MarketState{
+		Ticker:   &entity.Ticker{Symbol: "BTC", LastPrice: 49200.0, Timestamp: clock.Now()}, // +2.5%
+		Position: position,
+	})
+	if err != nil {
+		t.Fatalf("OnTick failed: %v", err)
+	}
+	if len(signals) != 1 || signals[0].Side != entity.SideSell || !strings.Contains(signals[0].Reason, "Take Profit") {
+		t.Fatalf("Expected a take-profit exit during the cooldown, got %+v", signals)
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/report"
+)
+
+// ErrNoSignal is returned by a Backend when no market signal is available
+var ErrNoSignal = errors.New("no market signal available")
+
+// Backend exposes bot state and controls to the API
+type Backend interface {
+	// Running reports whether the bot is running
+	Running() bool
+
+	// Position returns the current position (nil if flat)
+	Position() *entity.Position
+
+	// RiskStatus returns the risk checker status
+	RiskStatus() map[string]interface{}
+
+	// StrategyState returns the strategy state (nil if not supported)
+	StrategyState() map[string]interface{}
+
+	// MarketSignal returns the latest aggregated market signal for a symbol
+	MarketSignal(ctx context.Context, symbol string) (*entity.MarketSignal, error)
+
+	// Report returns the trade journal report
+	Report(ctx context.Context) (*report.Report, error)
+
+	// Halt stops trading
+	Halt(reason string)
+
+	// Resume resumes trading
+	Resume()
+
+	// FlattenAll cancels all orders and market-closes all positions
+	FlattenAll(ctx context.Context) error
+
+	// Health reports the liveness of the market data feed
+	Health() Health
+}
+
+// Health represents GET /healthz response body
+type Health struct {
+	Healthy   bool      `json:"healthy"`
+	Connected bool      `json:"connected"`           // WebSocket connected
+	LastTick  time.Time `json:"last_tick,omitempty"` // Zero until the first tick
+	Reason    string    `json:"reason,omitempty"`    // Why the feed is unhealthy
+}
+
+// StatusResponse represents GET /status response body
+type StatusResponse struct {
+	Running  bool                   `json:"running"`
+	Position *entity.Position       `json:"position"`
+	Risk     map[string]interface{} `json:"risk"`
+	Strategy map[string]interface{} `json:"strategy,omitempty"`
+}
+
+// haltRequest represents POST /halt request body
+type haltRequest struct {
+	Reason string `json:"reason"`
+}
+
+// errorResponse represents an error response body
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves the bot status and control API
+type Server struct {
+	backend Backend
+	token   string
+	log     *logger.Logger
+	srv     *http.Server
+}
+
+// NewServer creates a new API server; requests must carry the bearer token
+func NewServer(backend Backend, port int, token string, log *logger.Logger) *Server {
+	if log == nil {
+		log = logger.Default()
+	}
+
+	s := &Server{
+		backend: backend,
+		token:   token,
+		log:     log,
+	}
+	s.srv = &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Handler returns the HTTP handler. GET /healthz is open for liveness
+// probes; every other endpoint requires the bearer token.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", s.handleStatus)
+	mux.HandleFunc("GET /signal/{symbol}", s.handleSignal)
+	mux.HandleFunc("GET /report", s.handleReport)
+	mux.HandleFunc("POST /halt", s.handleHalt)
+	mux.HandleFunc("POST /resume", s.handleResume)
+	mux.HandleFunc("POST /flatten", s.handleFlatten)
+
+	root := http.NewServeMux()
+	root.HandleFunc("GET /healthz", s.handleHealth)
+	root.Handle("/", s.authenticate(mux))
+	return root
+}
+
+// Start starts serving in the background
+func (s *Server) Start() {
+	go func() {
+		s.log.Info("API server listening on %s", s.srv.Addr)
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.log.Error("API server error: %v", err)
+		}
+	}()
+}
+
+// Shutdown gracefully stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+// authenticate rejects requests without a valid bearer token
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleStatus handles GET /status
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, StatusResponse{
+		Running:  s.backend.Running(),
+		Position: s.backend.Position(),
+		Risk:     s.backend.RiskStatus(),
+		Strategy: s.backend.StrategyState(),
+	})
+}
+
+// handleHealth handles GET /healthz: 200 while the feed is live, 503 otherwise
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := s.backend.Health()
+	status := http.StatusOK
+	if !health.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, health)
+}
+
+// handleSignal handles GET /signal/{symbol}
+func (s *Server) handleSignal(w http.ResponseWriter, r *http.Request) {
+	symbol := entity.Symbol(r.PathValue("symbol")).Normalize().String()
+
+	signal, err := s.backend.MarketSignal(r.Context(), symbol)
+	switch {
+	case errors.Is(err, ErrNoSignal):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, signal)
+}
+
+// handleReport handles GET /report (?format=csv for CSV output)
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	rep, err := s.backend.Report(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		if err := rep.WriteCSV(w); err != nil {
+			s.log.Error("Failed to write report: %v", err)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rep)
+}
+
+// handleHalt handles POST /halt
+func (s *Server) handleHalt(w http.ResponseWriter, r *http.Request) {
+	req := haltRequest{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = "halted via API"
+	}
+
+	s.log.Warn("Trading halted via API: %s", req.Reason)
+	s.backend.Halt(req.Reason)
+	writeJSON(w, http.StatusOK, s.backend.RiskStatus())
+}
+
+// handleResume handles POST /resume
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	s.log.Info("Trading resumed via API")
+	s.backend.Resume()
+	writeJSON(w, http.StatusOK, s.backend.RiskStatus())
+}
+
+// handleFlatten handles POST /flatten
+func (s *Server) handleFlatten(w http.ResponseWriter, r *http.Request) {
+	s.log.Warn("Flatten all requested via API")
+	if err := s.backend.FlattenAll(r.Context()); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.handleStatus(w, r)
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
@@ -0,0 +1,315 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/report"
+)
+
+const testToken = "secret"
+
+// fakeBackend is a Backend with fixed state for handler tests
+type fakeBackend struct {
+	running    bool
+	position   *entity.Position
+	halted     bool
+	haltReason string
+	signals    map[string]*entity.MarketSignal
+	flattenErr error
+	health     Health
+}
+
+func (f *fakeBackend) Running() bool              { return f.running }
+func (f *fakeBackend) Position() *entity.Position { return f.position }
+
+func (f *fakeBackend) RiskStatus() map[string]interface{} {
+	return map[string]interface{}{
+		"halted":      f.halted,
+		"halt_reason": f.haltReason,
+	}
+}
+
+func (f *fakeBackend) StrategyState() map[string]interface{} {
+	return map[string]interface{}{"running": f.running}
+}
+
+func (f *fakeBackend) MarketSignal(ctx context.Context, symbol string) (*entity.MarketSignal, error) {
+	signal, ok := f.signals[symbol]
+	if !ok {
+		return nil, ErrNoSignal
+	}
+	return signal, nil
+}
+
+func (f *fakeBackend) Report(ctx context.Context) (*report.Report, error) {
+	return report.Build([]report.Fill{
+		{Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1},
+		{Symbol: "BTC", Side: entity.SideSell, Price: 110, Quantity: 1},
+	}, report.Config{}), nil
+}
+
+func (f *fakeBackend) Halt(reason string) {
+	f.halted = true
+	f.haltReason = reason
+}
+
+func (f *fakeBackend) Resume() {
+	f.halted = false
+	f.haltReason = ""
+}
+
+func (f *fakeBackend) FlattenAll(ctx context.Context) error {
+	if f.flattenErr != nil {
+		return f.flattenErr
+	}
+	f.position = nil
+	f.halted = true
+	f.haltReason = "flatten all"
+	return nil
+}
+
+func (f *fakeBackend) Health() Health { return f.health }
+
+func newTestServer(backend Backend) *Server {
+	return NewServer(backend, 0, testToken, logger.New(logger.LevelError, io.Discard))
+}
+
+func doRequest(t *testing.T, s *Server, method, path, token string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, body)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	s := newTestServer(&fakeBackend{})
+
+	for _, token := range []string{"", "wrong"} {
+		rec := doRequest(t, s, "GET", "/status", token, nil)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: expected 401, got %d", token, rec.Code)
+		}
+	}
+}
+
+func TestServer_EmptyTokenRejectsAll(t *testing.T) {
+	s := NewServer(&fakeBackend{}, 0, "", logger.New(logger.LevelError, io.Discard))
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without configured token, got %d", rec.Code)
+	}
+}
+
+func TestServer_Status(t *testing.T) {
+	backend := &fakeBackend{
+		running:  true,
+		position: &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.5, EntryPrice: 50000},
+	}
+	s := newTestServer(backend)
+
+	rec := doRequest(t, s, "GET", "/status", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %s", ct)
+	}
+
+	var got StatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !got.Running {
+		t.Error("Expected running true")
+	}
+	if got.Position == nil || got.Position.Size != 0.5 {
+		t.Errorf("Unexpected position: %+v", got.Position)
+	}
+	if got.Risk["halted"] != false {
+		t.Errorf("Expected halted false, got %v", got.Risk["halted"])
+	}
+	if got.Strategy["running"] != true {
+		t.Errorf("Expected strategy state, got %v", got.Strategy)
+	}
+}
+
+func TestServer_Signal(t *testing.T) {
+	backend := &fakeBackend{
+		signals: map[string]*entity.MarketSignal{
+			"BTC":   {Symbol: "BTC", Strength: 0.4},
+			"kPEPE": {Symbol: "kPEPE"},
+		},
+	}
+	s := newTestServer(backend)
+
+	rec := doRequest(t, s, "GET", "/signal/btc", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	var got entity.MarketSignal
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got.Symbol != "BTC" || got.Strength != 0.4 {
+		t.Errorf("Unexpected signal: %+v", got)
+	}
+
+	// Mixed-case coin names keep their case
+	if rec := doRequest(t, s, "GET", "/signal/kPEPE", testToken, nil); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 for kPEPE, got %d", rec.Code)
+	}
+
+	rec = doRequest(t, s, "GET", "/signal/ETH", testToken, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown symbol, got %d", rec.Code)
+	}
+}
+
+func TestServer_Report(t *testing.T) {
+	s := newTestServer(&fakeBackend{})
+
+	rec := doRequest(t, s, "GET", "/report", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	var got report.Report
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got.Total.RealizedPnL != 10 {
+		t.Errorf("Expected realized PnL 10, got %.2f", got.Total.RealizedPnL)
+	}
+
+	rec = doRequest(t, s, "GET", "/report?format=csv", testToken, nil)
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("Expected CSV content type, got %s", ct)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "symbol,realized_pnl") {
+		t.Errorf("Unexpected CSV body: %s", rec.Body.String())
+	}
+}
+
+func TestServer_HaltResume(t *testing.T) {
+	backend := &fakeBackend{}
+	s := newTestServer(backend)
+
+	rec := doRequest(t, s, "POST", "/halt", testToken, bytes.NewBufferString(`{"reason":"maintenance"}`))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if !backend.halted || backend.haltReason != "maintenance" {
+		t.Errorf("Expected halt with reason, got halted=%v reason=%q", backend.halted, backend.haltReason)
+	}
+
+	rec = doRequest(t, s, "POST", "/resume", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if backend.halted {
+		t.Error("Expected trading resumed")
+	}
+
+	rec = doRequest(t, s, "POST", "/halt", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 without body, got %d", rec.Code)
+	}
+	if backend.haltReason != "halted via API" {
+		t.Errorf("Expected default reason, got %q", backend.haltReason)
+	}
+}
+
+func TestServer_Flatten(t *testing.T) {
+	backend := &fakeBackend{running: true, position: &entity.Position{Symbol: "BTC", Size: 0.5}}
+	s := newTestServer(backend)
+
+	rec := doRequest(t, s, "POST", "/flatten", testToken, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	var resp StatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if resp.Position != nil || resp.Risk["halted"] != true {
+		t.Errorf("Expected flat and halted status, got %+v", resp)
+	}
+
+	backend.flattenErr = errors.New("exchange down")
+	if rec := doRequest(t, s, "POST", "/flatten", testToken, nil); rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 on flatten error, got %d", rec.Code)
+	}
+}
+
+func TestServer_HaltInvalidBody(t *testing.T) {
+	backend := &fakeBackend{}
+	s := newTestServer(backend)
+
+	rec := doRequest(t, s, "POST", "/halt", testToken, strings.NewReader("not json"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", rec.Code)
+	}
+	if backend.halted {
+		t.Error("Expected no halt on invalid body")
+	}
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	s := newTestServer(&fakeBackend{})
+
+	rec := doRequest(t, s, "GET", "/halt", testToken, nil)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
+
+func TestServer_Health(t *testing.T) {
+	lastTick := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	backend := &fakeBackend{health: Health{Healthy: true, Connected: true, LastTick: lastTick}}
+	s := newTestServer(backend)
+
+	// Liveness probes carry no token
+	rec := doRequest(t, s, "GET", "/healthz", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for a live feed, got %d", rec.Code)
+	}
+	var got Health
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !got.Healthy || !got.LastTick.Equal(lastTick) {
+		t.Errorf("Unexpected health: %+v", got)
+	}
+
+	backend.health = Health{Connected: true, LastTick: lastTick, Reason: "no tick for 2m0s"}
+	rec = doRequest(t, s, "GET", "/healthz", "", nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 for a stale feed, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "no tick for 2m0s") {
+		t.Errorf("Expected the reason in the body, got %s", rec.Body.String())
+	}
+
+	if rec := doRequest(t, s, "GET", "/status", "", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected other endpoints to still require the token, got %d", rec.Code)
+	}
+}
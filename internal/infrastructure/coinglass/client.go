@@ -4,30 +4,64 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 const (
-	baseURL = "https://open-api.coinglass.com/public/v2"
+	defaultBaseURL = "https://open-api.coinglass.com/public/v2"
 )
 
+// Ensure Client implements DataSourceGateway
+var _ gateway.DataSourceGateway = (*Client)(nil)
+
 // Client is a CoinGlass API client
 type Client struct {
 	apiKey     string
+	baseURL    string
 	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
+	cache      *httputil.Cache
+	cacheTTL   CacheConfig
+	aggregate  bool
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
+}
+
+// CacheConfig holds response cache TTLs per endpoint (0 = no caching)
+type CacheConfig struct {
+	FundingRate    time.Duration
+	OpenInterest   time.Duration
+	LongShortRatio time.Duration
+}
+
+// DefaultCacheConfig returns default cache TTLs
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		FundingRate:    30 * time.Second,
+		OpenInterest:   30 * time.Second,
+		LongShortRatio: 60 * time.Second,
+	}
 }
 
 // NewClient creates a new CoinGlass client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		apiKey:  apiKey,
+		baseURL: defaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		retry:    httputil.DefaultRetryConfig(),
+		breaker:  httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		cache:    httputil.NewCache(),
+		cacheTTL: DefaultCacheConfig(),
 	}
 }
 
@@ -38,39 +72,65 @@ func (c *Client) Connect(ctx context.Context) error {
 	return err
 }
 
-// Disconnect closes connection
+// Disconnect stops subscriptions and waits for their goroutines to exit
 func (c *Client) Disconnect(ctx context.Context) error {
-	return nil
+	return c.pollers.Stop(ctx)
 }
 
-// doRequest performs HTTP request with authentication
-func (c *Client) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
-	url := baseURL + endpoint
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *Client) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *Client) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
 
-	req.Header.Set("accept", "application/json")
-	req.Header.Set("CG-API-KEY", c.apiKey)
+// BreakerState returns the circuit breaker state
+func (c *Client) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+// SetCacheConfig sets response cache TTLs
+func (c *Client) SetCacheConfig(cfg CacheConfig) {
+	c.cacheTTL = cfg
+}
+
+// CacheHits returns the number of requests served from cache
+func (c *Client) CacheHits() int64 {
+	return c.cache.Hits()
+}
+
+// doCachedRequest performs HTTP request, reusing a cached response within ttl
+func (c *Client) doCachedRequest(ctx context.Context, endpoint string, ttl time.Duration) ([]byte, error) {
+	if body, ok := c.cache.Get(endpoint); ok {
+		return body, nil
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.doRequest(ctx, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
+	c.cache.Set(endpoint, body, ttl)
+	return body, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
-	}
+// doRequest performs HTTP request with authentication
+func (c *Client) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	header := http.Header{}
+	header.Set("accept", "application/json")
+	header.Set("CG-API-KEY", c.apiKey)
 
-	return body, nil
+	return c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, c.baseURL+endpoint, header, c.retry)
+	})
+}
+
+// SetAggregate makes GetFundingRate and GetLongShortRatio return the
+// OI-weighted aggregate across exchanges instead of a single venue
+func (c *Client) SetAggregate(enabled bool) {
+	c.aggregate = enabled
 }
 
 // FundingRateResponse represents CoinGlass funding rate API response
@@ -92,9 +152,30 @@ type ExchangeRate struct {
 	NextFundingTime int64 `json:"nextFundingTime"`
 }
 
-// GetFundingRate retrieves funding rate for a symbol
+// GetFundingRate retrieves funding rate for a symbol (Binance or first
+// available, or the aggregate when SetAggregate is enabled)
 func (c *Client) GetFundingRate(ctx context.Context, symbol string) (*entity.FundingRate, error) {
-	body, err := c.doRequest(ctx, "/funding?symbol="+symbol)
+	if c.aggregate {
+		return c.GetAggregateFundingRate(ctx, symbol)
+	}
+
+	rates, err := c.GetFundingRates(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find Binance or first available
+	for _, rate := range rates {
+		if rate.Exchange == "Binance" {
+			return rate, nil
+		}
+	}
+	return rates[0], nil
+}
+
+// GetFundingRates retrieves funding rates for a symbol on every exchange
+func (c *Client) GetFundingRates(ctx context.Context, symbol string) ([]*entity.FundingRate, error) {
+	body, err := c.doCachedRequest(ctx, "/funding?symbol="+symbol, c.cacheTTL.FundingRate)
 	if err != nil {
 		return nil, err
 	}
@@ -108,35 +189,85 @@ func (c *Client) GetFundingRate(ctx context.Context, symbol string) (*entity.Fun
 		return nil, fmt.Errorf("no data available for %s", symbol)
 	}
 
-	// Find Binance or first available
-	var rate *ExchangeRate
+	var rates []*entity.FundingRate
+	now := time.Now()
 	for _, data := range resp.Data {
-		if data.Symbol == symbol {
-			for i := range data.UMarginList {
-				if data.UMarginList[i].ExchangeName == "Binance" {
-					rate = &data.UMarginList[i]
-					break
-				}
-			}
-			if rate == nil && len(data.UMarginList) > 0 {
-				rate = &data.UMarginList[0]
-			}
-			break
+		if data.Symbol != symbol {
+			continue
 		}
+		for _, rate := range data.UMarginList {
+			rates = append(rates, &entity.FundingRate{
+				Symbol:          symbol,
+				Rate:            rate.Rate,
+				PredictedRate:   rate.PredictedRate,
+				NextFundingTime: time.Unix(rate.NextFundingTime/1000, 0),
+				Exchange:        rate.ExchangeName,
+				Timestamp:       now,
+			})
+		}
+		break
 	}
 
-	if rate == nil {
+	if len(rates) == 0 {
 		return nil, fmt.Errorf("no funding rate data for %s", symbol)
 	}
+	return rates, nil
+}
 
-	return &entity.FundingRate{
-		Symbol:          symbol,
-		Rate:            rate.Rate,
-		PredictedRate:   rate.PredictedRate,
-		NextFundingTime: time.Unix(rate.NextFundingTime/1000, 0),
-		Exchange:        rate.ExchangeName,
-		Timestamp:       time.Now(),
-	}, nil
+// GetAggregateFundingRate retrieves the OI-weighted funding rate across
+// exchanges, with the per-exchange rates in Exchanges
+func (c *Client) GetAggregateFundingRate(ctx context.Context, symbol string) (*entity.FundingRate, error) {
+	rates, err := c.GetFundingRates(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges := make([]string, len(rates))
+	for i, rate := range rates {
+		exchanges[i] = rate.Exchange
+	}
+	weights := c.exchangeWeights(ctx, symbol, exchanges)
+
+	agg := &entity.FundingRate{
+		Symbol:    symbol,
+		Exchange:  "aggregated",
+		Timestamp: rates[0].Timestamp,
+		Exchanges: make([]entity.FundingRate, len(rates)),
+	}
+	for i, rate := range rates {
+		agg.Rate += rate.Rate * weights[i]
+		agg.PredictedRate += rate.PredictedRate * weights[i]
+		// Next funding is the soonest across venues
+		if agg.NextFundingTime.IsZero() || rate.NextFundingTime.Before(agg.NextFundingTime) {
+			agg.NextFundingTime = rate.NextFundingTime
+		}
+		agg.Exchanges[i] = *rate
+	}
+	return agg, nil
+}
+
+// exchangeWeights returns normalized weights for exchanges by their share of
+// open interest, falling back to equal weights when OI is unavailable
+func (c *Client) exchangeWeights(ctx context.Context, symbol string, exchanges []string) []float64 {
+	weights := make([]float64, len(exchanges))
+	var total float64
+	if oi, err := c.openInterestByExchange(ctx, symbol); err == nil {
+		for i, exchange := range exchanges {
+			weights[i] = oi[exchange]
+			total += weights[i]
+		}
+	}
+
+	if total == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
 }
 
 // OpenInterestResponse represents CoinGlass OI API response
@@ -154,20 +285,11 @@ type OpenInterestResponse struct {
 
 // GetOpenInterest retrieves open interest for a symbol
 func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (*entity.OpenInterest, error) {
-	body, err := c.doRequest(ctx, "/open_interest?symbol="+symbol)
+	resp, err := c.fetchOpenInterest(ctx, symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp OpenInterestResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if !resp.Success || len(resp.Data) == 0 {
-		return nil, fmt.Errorf("no data available for %s", symbol)
-	}
-
 	// Aggregate all exchanges
 	var totalOI float64
 	var avgChange float64
@@ -186,6 +308,37 @@ func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (*entity.Op
 	}, nil
 }
 
+// openInterestByExchange returns open interest keyed by exchange name
+func (c *Client) openInterestByExchange(ctx context.Context, symbol string) (map[string]float64, error) {
+	resp, err := c.fetchOpenInterest(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	oi := make(map[string]float64, len(resp.Data))
+	for _, data := range resp.Data {
+		oi[data.ExchangeName] += data.OpenInterest
+	}
+	return oi, nil
+}
+
+func (c *Client) fetchOpenInterest(ctx context.Context, symbol string) (*OpenInterestResponse, error) {
+	body, err := c.doCachedRequest(ctx, "/open_interest?symbol="+symbol, c.cacheTTL.OpenInterest)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp OpenInterestResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !resp.Success || len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no data available for %s", symbol)
+	}
+	return &resp, nil
+}
+
 // LongShortRatioResponse represents CoinGlass L/S ratio API response
 type LongShortRatioResponse struct {
 	Code    string `json:"code"`
@@ -200,9 +353,30 @@ type LongShortRatioResponse struct {
 	} `json:"data"`
 }
 
-// GetLongShortRatio retrieves long/short ratio for a symbol
+// GetLongShortRatio retrieves long/short ratio for a symbol (Binance or
+// first available, or the aggregate when SetAggregate is enabled)
 func (c *Client) GetLongShortRatio(ctx context.Context, symbol string) (*entity.LongShortRatio, error) {
-	body, err := c.doRequest(ctx, "/long_short?symbol="+symbol)
+	if c.aggregate {
+		return c.GetAggregateLongShortRatio(ctx, symbol)
+	}
+
+	ratios, err := c.GetLongShortRatios(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find Binance or first available
+	for _, ratio := range ratios {
+		if ratio.Exchange == "Binance" {
+			return ratio, nil
+		}
+	}
+	return ratios[0], nil
+}
+
+// GetLongShortRatios retrieves long/short ratios for a symbol on every exchange
+func (c *Client) GetLongShortRatios(ctx context.Context, symbol string) ([]*entity.LongShortRatio, error) {
+	body, err := c.doCachedRequest(ctx, "/long_short?symbol="+symbol, c.cacheTTL.LongShortRatio)
 	if err != nil {
 		return nil, err
 	}
@@ -216,32 +390,48 @@ func (c *Client) GetLongShortRatio(ctx context.Context, symbol string) (*entity.
 		return nil, fmt.Errorf("no data available for %s", symbol)
 	}
 
-	// Find Binance or first available
-	var data *struct {
-		Symbol     string  `json:"symbol"`
-		LongRate   float64 `json:"longRate"`
-		ShortRate  float64 `json:"shortRate"`
-		LongShortRatio float64 `json:"longShortRatio"`
-		ExchangeName string `json:"exchangeName"`
-	}
-	for i := range resp.Data {
-		if resp.Data[i].ExchangeName == "Binance" {
-			data = &resp.Data[i]
-			break
+	ratios := make([]*entity.LongShortRatio, len(resp.Data))
+	now := time.Now()
+	for i, data := range resp.Data {
+		ratios[i] = &entity.LongShortRatio{
+			Symbol:         symbol,
+			LongRatio:      data.LongRate,
+			ShortRatio:     data.ShortRate,
+			LongShortRatio: data.LongShortRatio,
+			Exchange:       data.ExchangeName,
+			Timestamp:      now,
 		}
 	}
-	if data == nil {
-		data = &resp.Data[0]
+	return ratios, nil
+}
+
+// GetAggregateLongShortRatio retrieves the OI-weighted long/short ratio across
+// exchanges, with the per-exchange ratios in Exchanges
+func (c *Client) GetAggregateLongShortRatio(ctx context.Context, symbol string) (*entity.LongShortRatio, error) {
+	ratios, err := c.GetLongShortRatios(ctx, symbol)
+	if err != nil {
+		return nil, err
 	}
 
-	return &entity.LongShortRatio{
-		Symbol:         symbol,
-		LongRatio:      data.LongRate,
-		ShortRatio:     data.ShortRate,
-		LongShortRatio: data.LongShortRatio,
-		Exchange:       data.ExchangeName,
-		Timestamp:      time.Now(),
-	}, nil
+	exchanges := make([]string, len(ratios))
+	for i, ratio := range ratios {
+		exchanges[i] = ratio.Exchange
+	}
+	weights := c.exchangeWeights(ctx, symbol, exchanges)
+
+	agg := &entity.LongShortRatio{
+		Symbol:    symbol,
+		Exchange:  "aggregated",
+		Timestamp: ratios[0].Timestamp,
+		Exchanges: make([]entity.LongShortRatio, len(ratios)),
+	}
+	for i, ratio := range ratios {
+		agg.LongRatio += ratio.LongRatio * weights[i]
+		agg.ShortRatio += ratio.ShortRatio * weights[i]
+		agg.LongShortRatio += ratio.LongShortRatio * weights[i]
+		agg.Exchanges[i] = *ratio
+	}
+	return agg, nil
 }
 
 // LiquidationResponse represents CoinGlass liquidation API response
@@ -299,32 +489,17 @@ func (c *Client) GetLiquidations(ctx context.Context, symbol string) ([]*entity.
 // SubscribeLiquidations subscribes to liquidation events (polling implementation)
 func (c *Client) SubscribeLiquidations(ctx context.Context, symbol string, handler func(*entity.Liquidation)) error {
 	// CoinGlass doesn't have WebSocket, use polling
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		var lastSeen time.Time
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				liqs, err := c.GetLiquidations(ctx, symbol)
-				if err != nil {
-					continue
-				}
-				for _, liq := range liqs {
-					if liq.Timestamp.After(lastSeen) {
-						handler(liq)
-						if liq.Timestamp.After(lastSeen) {
-							lastSeen = liq.Timestamp
-						}
-					}
-				}
+	var lastSeen time.Time
+	pollutil.Start(ctx, &c.pollers, 30*time.Second, func(ctx context.Context) ([]*entity.Liquidation, error) {
+		return c.GetLiquidations(ctx, symbol)
+	}, func(liqs []*entity.Liquidation) {
+		for _, liq := range liqs {
+			if liq.Timestamp.After(lastSeen) {
+				handler(liq)
+				lastSeen = liq.Timestamp
 			}
 		}
-	}()
+	})
 
 	return nil
 }
@@ -333,3 +508,10 @@ func (c *Client) SubscribeLiquidations(ctx context.Context, symbol string, handl
 func (c *Client) SubscribeWhaleAlerts(ctx context.Context, handler func(*entity.WhaleAlert)) error {
 	return fmt.Errorf("whale alerts not supported by CoinGlass, use Whale Alert API")
 }
+
+// FundingBias analyzes a funding rate and returns the contrarian trading bias.
+// Very positive funding is bearish (fade longs, collect funding as a short),
+// very negative funding is bullish; strength scales with the rate.
+func FundingBias(fr *entity.FundingRate) (entity.SignalBias, float64) {
+	return fr.Bias()
+}
@@ -0,0 +1,208 @@
+package coinglass
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const fundingResponse = `{"code":"0","msg":"success","success":true,"data":[{"symbol":"BTC","uMarginList":[{"exchangeName":"Binance","rate":0.0001}]}]}`
+
+func newTestClient(t *testing.T, calls *atomic.Int32) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(fundingResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient("test-key")
+	c.baseURL = server.URL
+	return c
+}
+
+func TestClient_GetFundingRate_Cached(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, &calls)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		fr, err := c.GetFundingRate(ctx, "BTC")
+		if err != nil {
+			t.Fatalf("GetFundingRate failed: %v", err)
+		}
+		if fr.Rate != 0.0001 {
+			t.Errorf("Expected rate 0.0001, got %f", fr.Rate)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 HTTP request within TTL, got %d", calls.Load())
+	}
+	if c.CacheHits() != 1 {
+		t.Errorf("Expected 1 cache hit, got %d", c.CacheHits())
+	}
+}
+
+func TestClient_GetFundingRate_CacheDisabled(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, &calls)
+	c.SetCacheConfig(CacheConfig{})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetFundingRate(ctx, "BTC"); err != nil {
+			t.Fatalf("GetFundingRate failed: %v", err)
+		}
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 HTTP requests with caching disabled, got %d", calls.Load())
+	}
+}
+
+func TestClient_GetFundingRate_CacheExpired(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, &calls)
+	c.SetCacheConfig(CacheConfig{FundingRate: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	if _, err := c.GetFundingRate(ctx, "BTC"); err != nil {
+		t.Fatalf("GetFundingRate failed: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.GetFundingRate(ctx, "BTC"); err != nil {
+		t.Fatalf("GetFundingRate failed: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 HTTP requests after TTL expiry, got %d", calls.Load())
+	}
+}
+
+const multiExchangeFunding = `{"code":"0","msg":"success","success":true,"data":[{"symbol":"BTC","uMarginList":[` +
+	`{"exchangeName":"OKX","rate":0.0004,"predictedRate":0.0003,"nextFundingTime":1700003600000},` +
+	`{"exchangeName":"Binance","rate":0.0001,"predictedRate":0.0002,"nextFundingTime":1700000000000},` +
+	`{"exchangeName":"Bybit","rate":-0.0002,"predictedRate":0,"nextFundingTime":1700007200000}]}]}`
+
+const multiExchangeLongShort = `{"code":"0","msg":"success","success":true,"data":[` +
+	`{"symbol":"BTC","longRate":60,"shortRate":40,"longShortRatio":1.5,"exchangeName":"OKX"},` +
+	`{"symbol":"BTC","longRate":50,"shortRate":50,"longShortRatio":1.0,"exchangeName":"Binance"},` +
+	`{"symbol":"BTC","longRate":40,"shortRate":60,"longShortRatio":0.67,"exchangeName":"Bybit"}]}`
+
+// Binance holds half the OI, OKX and Bybit a quarter each
+const multiExchangeOI = `{"code":"0","msg":"success","success":true,"data":[` +
+	`{"symbol":"BTC","openInterest":5000,"h24Change":1,"exchangeName":"Binance"},` +
+	`{"symbol":"BTC","openInterest":2500,"h24Change":2,"exchangeName":"OKX"},` +
+	`{"symbol":"BTC","openInterest":2500,"h24Change":3,"exchangeName":"Bybit"}]}`
+
+func newMultiExchangeClient(t *testing.T, oi string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/funding":
+			w.Write([]byte(multiExchangeFunding))
+		case "/long_short":
+			w.Write([]byte(multiExchangeLongShort))
+		case "/open_interest":
+			w.Write([]byte(oi))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient("test-key")
+	c.baseURL = server.URL
+	c.SetMaxAttempts(1)
+	return c
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestClient_GetFundingRates_AllExchanges(t *testing.T) {
+	c := newMultiExchangeClient(t, multiExchangeOI)
+
+	rates, err := c.GetFundingRates(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetFundingRates failed: %v", err)
+	}
+	if len(rates) != 3 || rates[0].Exchange != "OKX" || rates[2].Exchange != "Bybit" {
+		t.Fatalf("Expected rates for all 3 exchanges, got %+v", rates)
+	}
+
+	// Single-venue mode still prefers Binance
+	fr, err := c.GetFundingRate(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetFundingRate failed: %v", err)
+	}
+	if fr.Exchange != "Binance" || fr.Rate != 0.0001 || len(fr.Exchanges) != 0 {
+		t.Errorf("Expected Binance rate without breakdown, got %+v", fr)
+	}
+}
+
+func TestClient_GetAggregateFundingRate_OIWeighted(t *testing.T) {
+	c := newMultiExchangeClient(t, multiExchangeOI)
+	c.SetAggregate(true)
+
+	fr, err := c.GetFundingRate(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetFundingRate failed: %v", err)
+	}
+	// 0.25*0.0004 + 0.5*0.0001 + 0.25*-0.0002
+	if !approxEqual(fr.Rate, 0.0001) {
+		t.Errorf("Expected OI-weighted rate 0.0001, got %f", fr.Rate)
+	}
+	if !approxEqual(fr.PredictedRate, 0.000175) {
+		t.Errorf("Expected OI-weighted predicted rate 0.000175, got %f", fr.PredictedRate)
+	}
+	if fr.Exchange != "aggregated" || len(fr.Exchanges) != 3 {
+		t.Errorf("Expected aggregate with 3 exchange breakdowns, got %s (%d)", fr.Exchange, len(fr.Exchanges))
+	}
+	if !fr.NextFundingTime.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected soonest next funding time, got %v", fr.NextFundingTime)
+	}
+}
+
+func TestClient_GetAggregateFundingRate_EqualWeightsWithoutOI(t *testing.T) {
+	c := newMultiExchangeClient(t, `{"code":"0","msg":"success","success":true,"data":[]}`)
+
+	fr, err := c.GetAggregateFundingRate(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetAggregateFundingRate failed: %v", err)
+	}
+	if !approxEqual(fr.Rate, 0.0001) {
+		t.Errorf("Expected equal-weighted rate 0.0001, got %f", fr.Rate)
+	}
+}
+
+func TestClient_GetAggregateLongShortRatio_OIWeighted(t *testing.T) {
+	c := newMultiExchangeClient(t, multiExchangeOI)
+
+	single, err := c.GetLongShortRatio(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetLongShortRatio failed: %v", err)
+	}
+	if single.Exchange != "Binance" || single.LongShortRatio != 1.0 {
+		t.Errorf("Expected Binance ratio, got %+v", single)
+	}
+
+	c.SetAggregate(true)
+	lsr, err := c.GetLongShortRatio(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetLongShortRatio failed: %v", err)
+	}
+	// 0.25*1.5 + 0.5*1.0 + 0.25*0.67
+	if !approxEqual(lsr.LongShortRatio, 1.0425) {
+		t.Errorf("Expected OI-weighted ratio 1.0425, got %f", lsr.LongShortRatio)
+	}
+	if !approxEqual(lsr.LongRatio, 50) || len(lsr.Exchanges) != 3 {
+		t.Errorf("Expected long ratio 50 with 3 breakdowns, got %f (%d)", lsr.LongRatio, len(lsr.Exchanges))
+	}
+}
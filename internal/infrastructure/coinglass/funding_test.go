@@ -0,0 +1,60 @@
+package coinglass
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestFundingBias(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		bias     entity.SignalBias
+		strength float64
+	}{
+		{"below neutral threshold", 0.00005, entity.SignalBiasNeutral, 0},
+		{"at neutral threshold", -entity.NeutralFundingRate, entity.SignalBiasNeutral, 0},
+		{"positive funding fades longs", 0.0005, entity.SignalBiasBearish, 0.5},
+		{"negative funding fades shorts", -0.0005, entity.SignalBiasBullish, 0.5},
+		{"extreme positive saturates", 0.003, entity.SignalBiasBearish, 1},
+		{"extreme negative saturates", -entity.ExtremeFundingRate, entity.SignalBiasBullish, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bias, strength := FundingBias(&entity.FundingRate{Symbol: "BTC", Rate: tt.rate})
+			if bias != tt.bias {
+				t.Errorf("Expected %s bias, got %s", tt.bias, bias)
+			}
+			if math.Abs(strength-tt.strength) > 1e-9 {
+				t.Errorf("Expected strength %.2f, got %.2f", tt.strength, strength)
+			}
+		})
+	}
+}
+
+func TestFundingBias_Nil(t *testing.T) {
+	if bias, strength := FundingBias(nil); bias != entity.SignalBiasNeutral || strength != 0 {
+		t.Errorf("Expected neutral for nil funding rate, got %s (%.2f)", bias, strength)
+	}
+}
+
+func TestFundingBias_ProportionalWeightInSignal(t *testing.T) {
+	weights := entity.DefaultSignalWeights()
+	mild := &entity.MarketSignal{FundingRate: &entity.FundingRate{Rate: 0.0003}}
+	extreme := &entity.MarketSignal{FundingRate: &entity.FundingRate{Rate: 0.0009}}
+	// Opposing sentiment so strength reflects the funding contribution
+	for _, s := range []*entity.MarketSignal{mild, extreme} {
+		s.SocialSentiment = &entity.SocialSentiment{SentimentScore: 0.5}
+		s.AnalyzeSignalWithWeights(weights)
+	}
+
+	if mild.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected mild funding to be outweighed by sentiment, got %s", mild.Bias)
+	}
+	if extreme.Bias != entity.SignalBiasBearish {
+		t.Errorf("Expected extreme funding to dominate, got %s", extreme.Bias)
+	}
+}
@@ -2,11 +2,13 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
 )
 
 // Config represents application configuration
@@ -14,9 +16,103 @@ type Config struct {
 	App         AppConfig         `yaml:"app"`
 	Exchange    ExchangeConfig    `yaml:"exchange"`
 	DataSources DataSourcesConfig `yaml:"data_sources"`
+	Signals     SignalsConfig     `yaml:"signals"`
+	Macro       MacroConfig       `yaml:"macro"`
 	Strategy    StrategyConfig    `yaml:"strategy"`
 	Risk        RiskConfig        `yaml:"risk"`
 	Log         LogConfig         `yaml:"log"`
+	Notify      NotifyConfig      `yaml:"notify"`
+	Alerts      AlertsConfig      `yaml:"alerts"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	API         APIConfig         `yaml:"api"`
+	Health      HealthConfig      `yaml:"health"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Paper       PaperConfig       `yaml:"paper"`
+	Fees        entity.FeeModel   `yaml:"fees"`
+	Orders      OrdersConfig      `yaml:"orders"`
+}
+
+// OrdersConfig represents resting order management settings
+type OrdersConfig struct {
+	Timeout time.Duration `yaml:"timeout"` // Cancel limit orders left open longer than this (0 = never)
+	Reprice bool          `yaml:"reprice"` // Re-place the unfilled remainder of a timed-out order at the current price
+
+	ReconcileInterval time.Duration `yaml:"reconcile_interval"` // How often the fill-tracked position is checked against the exchange
+
+	TWAPThreshold float64       `yaml:"twap_threshold"` // Slice orders larger than this over time (0 = never)
+	TWAPSlices    int           `yaml:"twap_slices"`    // Child orders per TWAP order
+	TWAPDuration  time.Duration `yaml:"twap_duration"`  // Time the child orders are spread over
+
+	MaxSlippageBps float64 `yaml:"max_slippage_bps"` // Skip market/IOC orders when the book is this far from the signal price (0 = no check)
+
+	BelowMinimum string `yaml:"below_minimum"` // Entries below the exchange minimum size or notional: BelowMinimumSkip or BelowMinimumRoundUp
+}
+
+// orders.below_minimum policies
+const (
+	BelowMinimumSkip    = "skip"     // Skip the order
+	BelowMinimumRoundUp = "round_up" // Raise the quantity to the minimum
+)
+
+// PaperConfig represents dry-run paper trading settings
+type PaperConfig struct {
+	InitialBalance float64 `yaml:"initial_balance"` // Starting balance in USD
+	SlippageBps    float64 `yaml:"slippage_bps"`    // Slippage on taker fills, in basis points
+
+	Latency           time.Duration `yaml:"latency"`             // Delay before crossing orders fill (0 = instant)
+	SlippageJitterBps float64       `yaml:"slippage_jitter_bps"` // Random extra taker slippage, up to this many bps
+	Seed              uint64        `yaml:"seed"`                // Seed for the slippage jitter; 0 = random
+}
+
+// StorageConfig represents order history storage settings
+type StorageConfig struct {
+	SQLitePath string `yaml:"sqlite_path"` // Empty keeps orders in memory only
+}
+
+// APIConfig represents status/control API server settings
+type APIConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Port      int    `yaml:"port"`
+	Token     string `yaml:"token"`
+	TokenFile string `yaml:"token_file"` // Read the token from this file instead
+}
+
+// HealthConfig represents market data liveness settings for /healthz and the watchdog
+type HealthConfig struct {
+	StaleAfter time.Duration `yaml:"stale_after"` // The feed is stale when no tick arrives for this long
+	Reconnect  bool          `yaml:"reconnect"`   // Reconnect the WebSocket when the feed goes stale
+}
+
+// MetricsConfig represents Prometheus metrics server settings
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"`
+}
+
+// NotifyConfig represents notification settings
+type NotifyConfig struct {
+	Telegram TelegramConfig `yaml:"telegram"`
+	Discord  DiscordConfig  `yaml:"discord"`
+}
+
+// TelegramConfig represents Telegram bot settings
+type TelegramConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	BotToken     string `yaml:"bot_token"`
+	BotTokenFile string `yaml:"bot_token_file"` // Read the bot token from this file instead
+	ChatID       string `yaml:"chat_id"`
+}
+
+// DiscordConfig represents Discord webhook settings
+type DiscordConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+// AlertsConfig represents market signal alert rules, sent through the notifiers
+type AlertsConfig struct {
+	Debounce time.Duration      `yaml:"debounce"` // Minimum time between alerts of a rule per symbol (0 = default)
+	Rules    []entity.AlertRule `yaml:"rules"`
 }
 
 // DataSourcesConfig represents external data sources settings
@@ -24,40 +120,112 @@ type DataSourcesConfig struct {
 	CoinGlass        CoinGlassConfig        `yaml:"coinglass"`
 	WhaleAlert       WhaleAlertConfig       `yaml:"whale_alert"`
 	LunarCrush       LunarCrushConfig       `yaml:"lunarcrush"`
+	SentimentAPI     SentimentAPIConfig     `yaml:"sentiment_api"`
+	FearGreed        FearGreedConfig        `yaml:"fear_greed"`
 	FedWatch         FedWatchConfig         `yaml:"fedwatch"`
 	TradingEconomics TradingEconomicsConfig `yaml:"trading_economics"`
 	Symbols          []string               `yaml:"symbols"`
+	CircuitBreaker   CircuitBreakerConfig   `yaml:"circuit_breaker"`
+
+	Assets          []entity.Asset      `yaml:"assets"`           // Added to or replacing the built-in asset mappings
+	ExchangeAliases map[string][]string `yaml:"exchange_aliases"` // Canonical exchange name -> Whale Alert owner names
+}
+
+// CircuitBreakerConfig represents data source circuit breaker settings
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"` // Consecutive failures that open the circuit
+	Window           time.Duration `yaml:"window"`            // Failures must occur within this window
+	Cooldown         time.Duration `yaml:"cooldown"`          // Time before a half-open probe
+}
+
+// SignalsConfig represents market signal aggregation settings
+type SignalsConfig struct {
+	Weights      *entity.SignalWeights `yaml:"weights"`       // nil = default weights
+	FetchTimeout time.Duration         `yaml:"fetch_timeout"` // Per-call timeout for API fetches
+	MaxAttempts  int                   `yaml:"max_attempts"`  // Attempts per API request
+	MaxAge       SignalMaxAgeConfig    `yaml:"max_age"`
+
+	LiquidationCascade LiquidationCascadeConfig `yaml:"liquidation_cascade"`
+	MaxCachedEvents    int                      `yaml:"max_cached_events"` // Liquidations and whale alerts kept per symbol
+
+	SentimentFloor entity.SentimentVolumeFloor `yaml:"sentiment_floor"` // Social volume below which sentiment is ignored (0 = no floor)
+}
+
+// LiquidationCascadeConfig represents liquidation cascade detection settings (0 = default)
+type LiquidationCascadeConfig struct {
+	Window   time.Duration `yaml:"window"`    // Lookback over which liquidations are summed
+	MinValue float64       `yaml:"min_value"` // USD liquidated on one side within the window
+}
+
+// SignalMaxAgeConfig represents how old data from each source may be before it is ignored
+type SignalMaxAgeConfig struct {
+	CoinGlass  time.Duration `yaml:"coinglass"`
+	WhaleAlert time.Duration `yaml:"whale_alert"`
+	LunarCrush time.Duration `yaml:"lunarcrush"`
+	Macro      time.Duration `yaml:"macro"`
+
+	SentimentAPI time.Duration `yaml:"sentiment_api"`
+	FearGreed    time.Duration `yaml:"fear_greed"`
+}
+
+// MacroConfig represents macro (FedWatch/Trading Economics) provider settings
+type MacroConfig struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	MaxAttempts     int           `yaml:"max_attempts"`  // Attempts per API request (0 = signals.max_attempts)
+	DXYIndicator    string        `yaml:"dxy_indicator"` // Trading Economics indicator name for the US Dollar Index
 }
 
 // CoinGlassConfig represents CoinGlass API settings
 type CoinGlassConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIKey  string `yaml:"api_key"`
+	Enabled    bool   `yaml:"enabled"`
+	APIKey     string `yaml:"api_key"`
+	APIKeyFile string `yaml:"api_key_file"`
+	Aggregate  bool   `yaml:"aggregate"` // OI-weighted funding and L/S across exchanges
 }
 
 // WhaleAlertConfig represents Whale Alert API settings
 type WhaleAlertConfig struct {
-	Enabled  bool    `yaml:"enabled"`
-	APIKey   string  `yaml:"api_key"`
-	MinValue float64 `yaml:"min_value"`
+	Enabled    bool    `yaml:"enabled"`
+	APIKey     string  `yaml:"api_key"`
+	APIKeyFile string  `yaml:"api_key_file"`
+	MinValue   float64 `yaml:"min_value"`
+
+	Blockchains     []string           `yaml:"blockchains"`        // Polled blockchains (empty = those of data_sources.symbols)
+	MinValueByChain map[string]float64 `yaml:"min_value_by_chain"` // Per-blockchain min_value override
 }
 
 // LunarCrushConfig represents LunarCrush API settings
 type LunarCrushConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIKey  string `yaml:"api_key"`
+	Enabled    bool   `yaml:"enabled"`
+	APIKey     string `yaml:"api_key"`
+	APIKeyFile string `yaml:"api_key_file"`
+}
+
+// SentimentAPIConfig represents a generic REST sentiment endpoint, averaged with LunarCrush
+type SentimentAPIConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	URL        string `yaml:"url"` // {symbol} is replaced by the trading symbol
+	APIKey     string `yaml:"api_key"`
+	APIKeyFile string `yaml:"api_key_file"`
+}
+
+// FearGreedConfig represents alternative.me fear & greed index settings (no API key)
+type FearGreedConfig struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 // FedWatchConfig represents CME FedWatch API settings
 type FedWatchConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIKey  string `yaml:"api_key"`
+	Enabled    bool   `yaml:"enabled"`
+	APIKey     string `yaml:"api_key"`
+	APIKeyFile string `yaml:"api_key_file"`
 }
 
 // TradingEconomicsConfig represents Trading Economics API settings
 type TradingEconomicsConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIKey  string `yaml:"api_key"`
+	Enabled    bool   `yaml:"enabled"`
+	APIKey     string `yaml:"api_key"`
+	APIKeyFile string `yaml:"api_key_file"`
 }
 
 // AppConfig represents application settings
@@ -66,17 +234,24 @@ type AppConfig struct {
 	Environment string        `yaml:"environment"`
 	Debug       bool          `yaml:"debug"`
 	GracePeriod time.Duration `yaml:"grace_period"`
+	HotReload   bool          `yaml:"hot_reload"` // Reload strategy params, risk limits and log level on file change
 }
 
 // ExchangeConfig represents exchange connection settings
 type ExchangeConfig struct {
-	Name       string `yaml:"name"`
-	BaseURL    string `yaml:"base_url"`
-	WSURL      string `yaml:"ws_url"`
-	APIKey     string `yaml:"api_key"`
-	APISecret  string `yaml:"api_secret"`
-	Testnet    bool   `yaml:"testnet"`
-	RateLimit  int    `yaml:"rate_limit"`
+	Name          string `yaml:"name"`
+	BaseURL       string `yaml:"base_url"`
+	WSURL         string `yaml:"ws_url"`
+	APIKey        string `yaml:"api_key"`
+	APISecret     string `yaml:"api_secret"`
+	APIKeyFile    string `yaml:"api_key_file"`    // Read the API key from this file (e.g. a Docker/K8s secret)
+	APISecretFile string `yaml:"api_secret_file"` // Read the API secret from this file
+	Testnet       bool   `yaml:"testnet"`
+	RateLimit     int    `yaml:"rate_limit"`
+	VaultAddress  string `yaml:"vault_address"` // Trade for this vault or subaccount (empty = main account)
+	RecordWS      string `yaml:"record_ws"`     // Append raw WebSocket messages to this JSONL file (empty = off)
+
+	BalanceTTL time.Duration `yaml:"balance_ttl"` // Reuse the account balance this long; the bot refreshes equity at this interval
 }
 
 // StrategyConfig represents strategy settings
@@ -84,21 +259,38 @@ type StrategyConfig struct {
 	Name   string                 `yaml:"name"`
 	Symbol string                 `yaml:"symbol"`
 	Params map[string]interface{} `yaml:"params"`
+
+	CandleInterval time.Duration `yaml:"candle_interval"` // Build candles of this interval from ticks for candle strategies (0 = off)
+
+	TickInterval   time.Duration `yaml:"tick_interval"`     // Run the strategy at most this often per symbol (0 = every tick)
+	TickMinMoveBps float64       `yaml:"tick_min_move_bps"` // Run sooner when the price moved this far since the last run (0 = off)
 }
 
 // RiskConfig represents risk management settings
 type RiskConfig struct {
-	MaxPositionSize float64 `yaml:"max_position_size"`
-	MaxLeverage     float64 `yaml:"max_leverage"`
-	MaxDrawdown     float64 `yaml:"max_drawdown"`
-	DailyLossLimit  float64 `yaml:"daily_loss_limit"`
+	MaxPositionSize float64            `yaml:"max_position_size"`
+	MaxLeverage     float64            `yaml:"max_leverage"`
+	MaxDrawdown     float64            `yaml:"max_drawdown"`
+	DailyLossLimit  float64            `yaml:"daily_loss_limit"`
+	SymbolLimits    map[string]float64 `yaml:"symbol_limits"`     // Max open size per symbol
+	DailyResetHour  int                `yaml:"daily_reset_hour"`  // UTC hour at which the daily loss limit resets
+	ResetStatsDaily bool               `yaml:"reset_stats_daily"` // Reset win/loss stats daily instead of keeping them cumulative
+	MaxSpreadBps    float64            `yaml:"max_spread_bps"`    // Reject trades when the spread is wider (0 = disabled)
+	MinTopSize      float64            `yaml:"min_top_size"`      // Reject trades when top-of-book size is smaller (0 = disabled)
+	MaxPositions    int                `yaml:"max_positions"`     // Max symbols in a position at once (0 = unlimited)
+
+	EventBlackoutBefore time.Duration `yaml:"event_blackout_before"` // Block entries this long before high-impact events (needs trading_economics)
+	EventBlackoutAfter  time.Duration `yaml:"event_blackout_after"`  // and this long after them
+	FlattenBeforeEvents bool          `yaml:"flatten_before_events"` // Close the position when a blackout begins
 }
 
 // LogConfig represents logging settings
 type LogConfig struct {
-	Level  string `yaml:"level"`
-	Format string `yaml:"format"`
-	Output string `yaml:"output"`
+	Level      string `yaml:"level"`
+	Format     string `yaml:"format"`      // json or text
+	Output     string `yaml:"output"`      // stdout, stderr or a file path
+	MaxSizeMB  int    `yaml:"max_size_mb"` // Rotate the log file at this size
+	MaxBackups int    `yaml:"max_backups"` // Rotated files to keep
 }
 
 // Load loads configuration from YAML file with env overrides
@@ -111,11 +303,16 @@ func Load(path string) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
-		if err := yaml.Unmarshal(data, cfg); err != nil {
+		if err := decodeConfig(data, cfg); err != nil {
 			return nil, fmt.Errorf("failed to parse config file: %w", err)
 		}
 	}
 
+	// Load secrets from *_file settings
+	if err := cfg.loadSecretFiles(); err != nil {
+		return nil, err
+	}
+
 	// Override with environment variables
 	cfg.loadEnvOverrides()
 
@@ -136,6 +333,9 @@ func (c *Config) loadEnvOverrides() {
 	if v := os.Getenv("EXCHANGE_API_SECRET"); v != "" {
 		c.Exchange.APISecret = v
 	}
+	if v := os.Getenv("EXCHANGE_VAULT_ADDRESS"); v != "" {
+		c.Exchange.VaultAddress = v
+	}
 	if v := os.Getenv("EXCHANGE_BASE_URL"); v != "" {
 		c.Exchange.BaseURL = v
 	}
@@ -158,6 +358,40 @@ func (c *Config) loadEnvOverrides() {
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		c.Log.Level = v
 	}
+	if v := os.Getenv("LOG_FORMAT"); v != "" {
+		c.Log.Format = v
+	}
+	if v := os.Getenv("LOG_OUTPUT"); v != "" {
+		c.Log.Output = v
+	}
+
+	// Metrics settings
+	if v := os.Getenv("METRICS_ENABLED"); v != "" {
+		c.Metrics.Enabled = v == "true" || v == "1"
+	}
+	if v := os.Getenv("METRICS_PORT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.Metrics.Port = n
+		}
+	}
+
+	// API settings
+	if v := os.Getenv("API_ENABLED"); v != "" {
+		c.API.Enabled = v == "true" || v == "1"
+	}
+	if v := os.Getenv("API_PORT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.API.Port = n
+		}
+	}
+	if v := os.Getenv("API_TOKEN"); v != "" {
+		c.API.Token = v
+	}
+
+	// Storage settings
+	if v := os.Getenv("STORAGE_SQLITE_PATH"); v != "" {
+		c.Storage.SQLitePath = v
+	}
 
 	// Risk settings
 	if v := os.Getenv("RISK_MAX_POSITION_SIZE"); v != "" {
@@ -170,13 +404,18 @@ func (c *Config) loadEnvOverrides() {
 			c.Risk.MaxLeverage = f
 		}
 	}
+	if v := os.Getenv("RISK_DAILY_RESET_HOUR"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.Risk.DailyResetHour = n
+		}
+	}
 
 	// Data sources settings
 	if v := os.Getenv("COINGLASS_API_KEY"); v != "" {
 		c.DataSources.CoinGlass.APIKey = v
 		c.DataSources.CoinGlass.Enabled = true
 	}
-	if v := os.Getenv("WHALE_ALERT_API_KEY"); v != "" {
+	if v := firstEnv("WHALE_ALERT_API_KEY", "WHALEALERT_API_KEY"); v != "" {
 		c.DataSources.WhaleAlert.APIKey = v
 		c.DataSources.WhaleAlert.Enabled = true
 	}
@@ -189,6 +428,13 @@ func (c *Config) loadEnvOverrides() {
 		c.DataSources.LunarCrush.APIKey = v
 		c.DataSources.LunarCrush.Enabled = true
 	}
+	if v := os.Getenv("SENTIMENT_API_URL"); v != "" {
+		c.DataSources.SentimentAPI.URL = v
+		c.DataSources.SentimentAPI.Enabled = true
+	}
+	if v := os.Getenv("SENTIMENT_API_KEY"); v != "" {
+		c.DataSources.SentimentAPI.APIKey = v
+	}
 	if v := os.Getenv("FEDWATCH_API_KEY"); v != "" {
 		c.DataSources.FedWatch.APIKey = v
 		c.DataSources.FedWatch.Enabled = true
@@ -197,6 +443,19 @@ func (c *Config) loadEnvOverrides() {
 		c.DataSources.TradingEconomics.APIKey = v
 		c.DataSources.TradingEconomics.Enabled = true
 	}
+
+	// Notification settings
+	if v := os.Getenv("TELEGRAM_BOT_TOKEN"); v != "" {
+		c.Notify.Telegram.BotToken = v
+		c.Notify.Telegram.Enabled = true
+	}
+	if v := os.Getenv("TELEGRAM_CHAT_ID"); v != "" {
+		c.Notify.Telegram.ChatID = v
+	}
+	if v := os.Getenv("DISCORD_WEBHOOK_URL"); v != "" {
+		c.Notify.Discord.WebhookURL = v
+		c.Notify.Discord.Enabled = true
+	}
 }
 
 // validate validates configuration
@@ -207,11 +466,183 @@ func (c *Config) validate() error {
 	if c.Exchange.APISecret == "" {
 		return fmt.Errorf("exchange.api_secret is required")
 	}
+	if v := c.Exchange.VaultAddress; v != "" && (len(v) != 42 || !strings.HasPrefix(v, "0x")) {
+		return fmt.Errorf("exchange.vault_address must be a 0x-prefixed 20-byte address")
+	}
 	if c.Strategy.Symbol == "" {
 		return fmt.Errorf("strategy.symbol is required")
 	}
+	// Symbols are keyed by their canonical form everywhere ("BTC-PERP" -> "BTC")
+	c.Strategy.Symbol = entity.Symbol(c.Strategy.Symbol).Normalize().String()
+	for i, symbol := range c.DataSources.Symbols {
+		c.DataSources.Symbols[i] = entity.Symbol(symbol).Normalize().String()
+	}
 	if c.Risk.MaxLeverage <= 0 {
 		c.Risk.MaxLeverage = 1.0 // default
 	}
+	if c.Strategy.TickInterval < 0 || c.Strategy.TickMinMoveBps < 0 {
+		return fmt.Errorf("strategy.tick_interval and tick_min_move_bps must be non-negative")
+	}
+	if c.Strategy.CandleInterval < 0 {
+		return fmt.Errorf("strategy.candle_interval must be non-negative")
+	}
+	if c.Risk.DailyResetHour < 0 || c.Risk.DailyResetHour > 23 {
+		return fmt.Errorf("risk.daily_reset_hour must be between 0 and 23")
+	}
+	if c.Exchange.BalanceTTL < 0 {
+		return fmt.Errorf("exchange.balance_ttl must be non-negative")
+	}
+	if c.Exchange.BalanceTTL == 0 {
+		c.Exchange.BalanceTTL = 5 * time.Second // default
+	}
+	if c.Orders.Timeout < 0 {
+		return fmt.Errorf("orders.timeout must be non-negative")
+	}
+	if c.Orders.ReconcileInterval < 0 {
+		return fmt.Errorf("orders.reconcile_interval must be non-negative")
+	}
+	if c.Orders.ReconcileInterval == 0 {
+		c.Orders.ReconcileInterval = time.Minute // default
+	}
+	if c.Orders.TWAPThreshold < 0 || c.Orders.TWAPSlices < 0 || c.Orders.TWAPDuration < 0 {
+		return fmt.Errorf("orders.twap_threshold, orders.twap_slices and orders.twap_duration must be non-negative")
+	}
+	if c.Orders.TWAPSlices == 0 {
+		c.Orders.TWAPSlices = 5 // default
+	}
+	if c.Orders.TWAPDuration == 0 {
+		c.Orders.TWAPDuration = time.Minute // default
+	}
+	if c.Orders.MaxSlippageBps < 0 {
+		return fmt.Errorf("orders.max_slippage_bps must be non-negative")
+	}
+	switch c.Orders.BelowMinimum {
+	case "":
+		c.Orders.BelowMinimum = BelowMinimumSkip // default
+	case BelowMinimumSkip, BelowMinimumRoundUp:
+	default:
+		return fmt.Errorf("orders.below_minimum must be %q or %q", BelowMinimumSkip, BelowMinimumRoundUp)
+	}
+	if c.Risk.MaxSpreadBps < 0 || c.Risk.MinTopSize < 0 {
+		return fmt.Errorf("risk.max_spread_bps and risk.min_top_size must be non-negative")
+	}
+	if c.Risk.MaxDrawdown < 0 || c.Risk.MaxDrawdown >= 1 {
+		return fmt.Errorf("risk.max_drawdown must be between 0 and 1")
+	}
+	if c.Risk.MaxPositions < 0 {
+		return fmt.Errorf("risk.max_positions must be non-negative")
+	}
+	if c.Risk.EventBlackoutBefore < 0 || c.Risk.EventBlackoutAfter < 0 {
+		return fmt.Errorf("risk.event_blackout_before and risk.event_blackout_after must be non-negative")
+	}
+	for _, a := range c.DataSources.Assets {
+		if a.Symbol == "" {
+			return fmt.Errorf("data_sources.assets entries must have a symbol")
+		}
+	}
+	if c.DataSources.CircuitBreaker.FailureThreshold == 0 {
+		c.DataSources.CircuitBreaker.FailureThreshold = 5 // default
+	}
+	if c.DataSources.CircuitBreaker.Window == 0 {
+		c.DataSources.CircuitBreaker.Window = time.Minute // default
+	}
+	if c.DataSources.CircuitBreaker.Cooldown == 0 {
+		c.DataSources.CircuitBreaker.Cooldown = 30 * time.Second // default
+	}
+	if c.Alerts.Debounce < 0 {
+		return fmt.Errorf("alerts.debounce must be non-negative")
+	}
+	for i, rule := range c.Alerts.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("alerts.rules[%d]: %w", i, err)
+		}
+	}
+	if err := c.Signals.validate(); err != nil {
+		return err
+	}
+	if c.Macro.MaxAttempts == 0 {
+		c.Macro.MaxAttempts = c.Signals.MaxAttempts // default
+	}
+	if c.Log.MaxSizeMB == 0 {
+		c.Log.MaxSizeMB = 100 // default
+	}
+	if c.Log.MaxBackups == 0 {
+		c.Log.MaxBackups = 5 // default
+	}
+	if c.Paper.InitialBalance == 0 {
+		c.Paper.InitialBalance = 10000 // default
+	}
+	if c.Paper.SlippageBps == 0 {
+		c.Paper.SlippageBps = 1 // default
+	}
+	if c.Paper.InitialBalance < 0 || c.Paper.SlippageBps < 0 || c.Paper.Latency < 0 || c.Paper.SlippageJitterBps < 0 {
+		return fmt.Errorf("paper settings must not be negative")
+	}
+	if c.Fees == (entity.FeeModel{}) {
+		c.Fees = entity.DefaultFeeModel() // default
+	}
+	if c.Fees.MakerBps < 0 || c.Fees.TakerBps < 0 {
+		return fmt.Errorf("fees must not be negative")
+	}
+	if c.Metrics.Port == 0 {
+		c.Metrics.Port = 9090 // default
+	}
+	if c.API.Port == 0 {
+		c.API.Port = 8080 // default
+	}
+	if c.API.Enabled && c.API.Token == "" {
+		return fmt.Errorf("api.token is required when api is enabled")
+	}
+	if c.Health.StaleAfter < 0 {
+		return fmt.Errorf("health.stale_after must be non-negative")
+	}
+	if c.Health.StaleAfter == 0 {
+		c.Health.StaleAfter = time.Minute // default
+	}
+	return c.validateSecrets()
+}
+
+// weightSumTolerance is how far signal weights may sum from 1.0
+const weightSumTolerance = 0.05
+
+// validate validates signal settings
+func (s *SignalsConfig) validate() error {
+	if s.FetchTimeout < 0 {
+		return fmt.Errorf("signals.fetch_timeout must not be negative")
+	}
+	if s.MaxCachedEvents < 0 {
+		return fmt.Errorf("signals.max_cached_events must be non-negative")
+	}
+	if s.LiquidationCascade.Window < 0 || s.LiquidationCascade.MinValue < 0 {
+		return fmt.Errorf("signals.liquidation_cascade window and min_value must be non-negative")
+	}
+	if s.SentimentFloor.MinInteractions < 0 || s.SentimentFloor.MinPosts < 0 {
+		return fmt.Errorf("signals.sentiment_floor min_interactions and min_posts must be non-negative")
+	}
+	if s.Weights == nil {
+		return nil
+	}
+	w := s.Weights
+	weights := []float64{w.FundingRate, w.LongShortRatio, w.WhaleFlow, w.Liquidation, w.Sentiment, w.FedPolicy, w.FearGreed, w.OpenInterest, w.SentimentMomentum, w.SentimentDivergence}
+	sum := 0.0
+	for _, v := range weights {
+		if v < 0 {
+			return fmt.Errorf("signals.weights must not be negative")
+		}
+		sum += v
+	}
+	if math.Abs(sum-1.0) > weightSumTolerance {
+		return fmt.Errorf("signals.weights must sum to 1.0 (got %.2f): scale funding_rate, long_short_ratio, whale_flow, liquidation, sentiment, fed_policy, fear_greed, open_interest, sentiment_momentum and sentiment_divergence so they add up to 1.0", sum)
+	}
 	return nil
 }
+
+// firstEnv returns the value of the first set environment variable in names
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadYAML(t *testing.T, data string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return Load(path)
+}
+
+const baseTestConfig = `
+exchange:
+  api_key: key
+  api_secret: secret
+strategy:
+  symbol: BTC
+`
+
+func TestLoad_SignalsAndMacro(t *testing.T) {
+	cfg, err := loadYAML(t, baseTestConfig+`
+data_sources:
+  whale_alert:
+    enabled: true
+    api_key: wa-key
+    min_value: 1000000
+  symbols: [BTC, ETH]
+signals:
+  fetch_timeout: 5s
+  max_attempts: 2
+  weights:
+    funding_rate: 0.2
+    long_short_ratio: 0.1
+    whale_flow: 0.3
+    liquidation: 0.1
+    sentiment: 0.2
+    fed_policy: 0.1
+  max_age:
+    whale_alert: 10m
+macro:
+  refresh_interval: 15m
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DataSources.WhaleAlert.MinValue != 1000000 {
+		t.Errorf("Expected whale min value 1000000, got %f", cfg.DataSources.WhaleAlert.MinValue)
+	}
+	if len(cfg.DataSources.Symbols) != 2 {
+		t.Errorf("Expected 2 symbols, got %v", cfg.DataSources.Symbols)
+	}
+	if cfg.Signals.Weights == nil || cfg.Signals.Weights.WhaleFlow != 0.3 {
+		t.Errorf("Expected whale_flow weight 0.3, got %+v", cfg.Signals.Weights)
+	}
+	if cfg.Signals.FetchTimeout != 5*time.Second {
+		t.Errorf("Expected fetch timeout 5s, got %v", cfg.Signals.FetchTimeout)
+	}
+	if cfg.Signals.MaxAge.WhaleAlert != 10*time.Minute {
+		t.Errorf("Expected whale alert max age 10m, got %v", cfg.Signals.MaxAge.WhaleAlert)
+	}
+	if cfg.Macro.RefreshInterval != 15*time.Minute {
+		t.Errorf("Expected macro refresh 15m, got %v", cfg.Macro.RefreshInterval)
+	}
+	if cfg.Macro.MaxAttempts != 2 {
+		t.Errorf("Expected macro max attempts to default to signals.max_attempts, got %d", cfg.Macro.MaxAttempts)
+	}
+}
+
+func TestLoad_SignalsDefaultWeights(t *testing.T) {
+	cfg, err := loadYAML(t, baseTestConfig)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Signals.Weights != nil {
+		t.Errorf("Expected nil weights when not configured, got %+v", cfg.Signals.Weights)
+	}
+}
+
+func TestLoad_SignalWeightsMustSumToOne(t *testing.T) {
+	_, err := loadYAML(t, baseTestConfig+`
+signals:
+  weights:
+    funding_rate: 0.5
+    whale_flow: 0.9
+`)
+	if err == nil {
+		t.Fatal("Expected weights summing to 1.4 to be rejected")
+	}
+	if !strings.Contains(err.Error(), "got 1.40") {
+		t.Errorf("Expected error to report the sum, got %v", err)
+	}
+}
+
+func TestLoad_SignalWeightsNegative(t *testing.T) {
+	_, err := loadYAML(t, baseTestConfig+`
+signals:
+  weights:
+    funding_rate: 1.2
+    whale_flow: -0.2
+`)
+	if err == nil || !strings.Contains(err.Error(), "negative") {
+		t.Errorf("Expected negative weight error, got %v", err)
+	}
+}
+
+func TestLoad_SentimentFloorNegative(t *testing.T) {
+	_, err := loadYAML(t, baseTestConfig+`
+signals:
+  sentiment_floor:
+    min_posts: -1
+`)
+	if err == nil || !strings.Contains(err.Error(), "sentiment_floor") {
+		t.Errorf("Expected sentiment_floor error, got %v", err)
+	}
+}
+
+func TestLoad_BelowMinimum(t *testing.T) {
+	cfg, err := loadYAML(t, baseTestConfig)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Orders.BelowMinimum != BelowMinimumSkip {
+		t.Errorf("Expected default below_minimum %q, got %q", BelowMinimumSkip, cfg.Orders.BelowMinimum)
+	}
+
+	_, err = loadYAML(t, baseTestConfig+`
+orders:
+  below_minimum: bump
+`)
+	if err == nil || !strings.Contains(err.Error(), "below_minimum") {
+		t.Errorf("Expected below_minimum error, got %v", err)
+	}
+}
+
+func TestLoad_AlertRules(t *testing.T) {
+	cfg, err := loadYAML(t, baseTestConfig+`
+alerts:
+  debounce: 30m
+  rules:
+    - name: hot funding
+      symbol: BTC
+      field: funding_rate
+      op: ">"
+      threshold: 0.0005
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.Alerts.Rules) != 1 || cfg.Alerts.Rules[0].Threshold != 0.0005 || cfg.Alerts.Debounce != 30*time.Minute {
+		t.Errorf("Expected the alert rule to load, got %+v", cfg.Alerts)
+	}
+
+	_, err = loadYAML(t, baseTestConfig+`
+alerts:
+  rules:
+    - field: funding_rate
+      op: "~"
+`)
+	if err == nil || !strings.Contains(err.Error(), "alerts.rules[0]") {
+		t.Errorf("Expected alerts.rules[0] error, got %v", err)
+	}
+}
+
+func TestLoad_NormalizesSymbols(t *testing.T) {
+	cfg, err := loadYAML(t, `
+exchange:
+  api_key: key
+  api_secret: secret
+strategy:
+  symbol: BTC-PERP
+data_sources:
+  symbols: [eth/usdc, SOLUSDC]
+`)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Strategy.Symbol != "BTC" {
+		t.Errorf("Expected strategy symbol BTC, got %s", cfg.Strategy.Symbol)
+	}
+	if got := strings.Join(cfg.DataSources.Symbols, ","); got != "ETH,SOL" {
+		t.Errorf("Expected data source symbols ETH,SOL, got %s", got)
+	}
+}
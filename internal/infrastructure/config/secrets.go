@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envRefPattern matches ${ENV_VAR} references in config values
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolateEnv replaces ${ENV_VAR} references in scalar values with their
+// environment values. Values are substituted after parsing, so quotes, colons
+// or comment markers in a secret never change the document structure.
+// Unset variables expand to an empty string so required-field validation catches them.
+func interpolateEnv(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		value := envRefPattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			return os.Getenv(envRefPattern.FindStringSubmatch(ref)[1])
+		})
+		if value != node.Value {
+			node.Value = value
+			// Plain scalars are re-resolved so ${PORT} still decodes into an int
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+		return
+	}
+	for _, child := range node.Content {
+		interpolateEnv(child)
+	}
+}
+
+// decodeConfig parses YAML into cfg, interpolating environment references
+func decodeConfig(data []byte, cfg *Config) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		return nil
+	}
+	interpolateEnv(&doc)
+	return doc.Decode(cfg)
+}
+
+// readSecretFile returns the trimmed contents of a secret file such as a Docker/K8s secret
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// loadSecretFiles fills secrets from their *_file settings
+func (c *Config) loadSecretFiles() error {
+	secrets := []struct {
+		name  string
+		file  string
+		value *string
+	}{
+		{"exchange.api_key_file", c.Exchange.APIKeyFile, &c.Exchange.APIKey},
+		{"exchange.api_secret_file", c.Exchange.APISecretFile, &c.Exchange.APISecret},
+		{"data_sources.coinglass.api_key_file", c.DataSources.CoinGlass.APIKeyFile, &c.DataSources.CoinGlass.APIKey},
+		{"data_sources.whale_alert.api_key_file", c.DataSources.WhaleAlert.APIKeyFile, &c.DataSources.WhaleAlert.APIKey},
+		{"data_sources.lunarcrush.api_key_file", c.DataSources.LunarCrush.APIKeyFile, &c.DataSources.LunarCrush.APIKey},
+		{"data_sources.sentiment_api.api_key_file", c.DataSources.SentimentAPI.APIKeyFile, &c.DataSources.SentimentAPI.APIKey},
+		{"data_sources.fedwatch.api_key_file", c.DataSources.FedWatch.APIKeyFile, &c.DataSources.FedWatch.APIKey},
+		{"data_sources.trading_economics.api_key_file", c.DataSources.TradingEconomics.APIKeyFile, &c.DataSources.TradingEconomics.APIKey},
+		{"notify.telegram.bot_token_file", c.Notify.Telegram.BotTokenFile, &c.Notify.Telegram.BotToken},
+		{"api.token_file", c.API.TokenFile, &c.API.Token},
+	}
+	for _, s := range secrets {
+		if s.file == "" {
+			continue
+		}
+		v, err := readSecretFile(s.file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+		*s.value = v
+	}
+	return nil
+}
+
+// validateSecrets checks that secrets required by enabled features resolved to non-empty values
+func (c *Config) validateSecrets() error {
+	required := []struct {
+		name    string
+		enabled bool
+		value   string
+	}{
+		{"data_sources.coinglass.api_key", c.DataSources.CoinGlass.Enabled, c.DataSources.CoinGlass.APIKey},
+		{"data_sources.whale_alert.api_key", c.DataSources.WhaleAlert.Enabled, c.DataSources.WhaleAlert.APIKey},
+		{"data_sources.lunarcrush.api_key", c.DataSources.LunarCrush.Enabled, c.DataSources.LunarCrush.APIKey},
+		{"data_sources.sentiment_api.url", c.DataSources.SentimentAPI.Enabled, c.DataSources.SentimentAPI.URL},
+		{"data_sources.fedwatch.api_key", c.DataSources.FedWatch.Enabled, c.DataSources.FedWatch.APIKey},
+		{"data_sources.trading_economics.api_key", c.DataSources.TradingEconomics.Enabled, c.DataSources.TradingEconomics.APIKey},
+		{"notify.telegram.bot_token", c.Notify.Telegram.Enabled, c.Notify.Telegram.BotToken},
+		{"notify.discord.webhook_url", c.Notify.Discord.Enabled, c.Notify.Discord.WebhookURL},
+	}
+	for _, r := range required {
+		if r.enabled && r.value == "" {
+			return fmt.Errorf("%s is required when enabled", r.name)
+		}
+	}
+	return nil
+}
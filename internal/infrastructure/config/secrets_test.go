@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_InterpolatesEnv(t *testing.T) {
+	t.Setenv("TEST_HL_KEY", "key-from-env")
+	t.Setenv("TEST_HL_SECRET", "secret-from-env")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+exchange:
+  api_key: ${TEST_HL_KEY}
+  api_secret: "${TEST_HL_SECRET}"
+strategy:
+  symbol: BTC
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Exchange.APIKey != "key-from-env" {
+		t.Errorf("Expected interpolated api_key, got %q", cfg.Exchange.APIKey)
+	}
+	if cfg.Exchange.APISecret != "secret-from-env" {
+		t.Errorf("Expected interpolated api_secret, got %q", cfg.Exchange.APISecret)
+	}
+}
+
+func TestLoad_InterpolatedSecretKeepsYAMLSyntax(t *testing.T) {
+	secret := `p@ss": #word`
+	t.Setenv("TEST_HL_KEY", secret)
+	t.Setenv("TEST_HL_SECRET", secret)
+	t.Setenv("TEST_HL_PORT", "9191")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+exchange:
+  api_key: ${TEST_HL_KEY}
+  api_secret: "${TEST_HL_SECRET}"
+strategy:
+  symbol: BTC
+metrics:
+  port: ${TEST_HL_PORT}
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Exchange.APIKey != secret {
+		t.Errorf("Expected plain api_key %q, got %q", secret, cfg.Exchange.APIKey)
+	}
+	if cfg.Exchange.APISecret != secret {
+		t.Errorf("Expected quoted api_secret %q, got %q", secret, cfg.Exchange.APISecret)
+	}
+	if cfg.Metrics.Port != 9191 {
+		t.Errorf("Expected interpolated int port, got %d", cfg.Metrics.Port)
+	}
+}
+
+func TestLoad_UnsetEnvFailsValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+exchange:
+  api_key: key
+  api_secret: ${TEST_HL_UNSET_SECRET}
+strategy:
+  symbol: BTC
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "exchange.api_secret") {
+		t.Errorf("Expected missing secret error, got %v", err)
+	}
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "hl_secret")
+	if err := os.WriteFile(secretPath, []byte("secret-from-file\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+	cgPath := filepath.Join(dir, "coinglass")
+	if err := os.WriteFile(cgPath, []byte("cg-key"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	data := `
+exchange:
+  api_key: key
+  api_secret_file: ` + secretPath + `
+strategy:
+  symbol: BTC
+data_sources:
+  coinglass:
+    enabled: true
+    api_key_file: ` + cgPath + `
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Exchange.APISecret != "secret-from-file" {
+		t.Errorf("Expected trimmed secret from file, got %q", cfg.Exchange.APISecret)
+	}
+	if cfg.DataSources.CoinGlass.APIKey != "cg-key" {
+		t.Errorf("Expected coinglass key from file, got %q", cfg.DataSources.CoinGlass.APIKey)
+	}
+}
+
+func TestLoad_MissingSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+exchange:
+  api_key: key
+  api_secret_file: /nonexistent/hl_secret
+strategy:
+  symbol: BTC
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "exchange.api_secret_file") {
+		t.Errorf("Expected secret file error, got %v", err)
+	}
+}
+
+func TestLoad_EnabledSourceRequiresKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+exchange:
+  api_key: key
+  api_secret: secret
+strategy:
+  symbol: BTC
+data_sources:
+  lunarcrush:
+    enabled: true
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "data_sources.lunarcrush.api_key") {
+		t.Errorf("Expected missing data source key error, got %v", err)
+	}
+
+	t.Setenv("LUNARCRUSH_API_KEY", "lc-key")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed with env key: %v", err)
+	}
+	if cfg.DataSources.LunarCrush.APIKey != "lc-key" {
+		t.Errorf("Expected env override, got %q", cfg.DataSources.LunarCrush.APIKey)
+	}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+// Watcher reloads the config file on change and passes valid updates to handlers
+type Watcher struct {
+	path     string
+	log      *logger.Logger
+	debounce time.Duration
+
+	mu       sync.Mutex
+	current  *Config
+	handlers []func(*Config)
+}
+
+// NewWatcher creates a watcher for the config file at path, starting from current
+func NewWatcher(path string, current *Config, log *logger.Logger) *Watcher {
+	if log == nil {
+		log = logger.Default()
+	}
+	return &Watcher{
+		path:     path,
+		log:      log.WithField("component", "config"),
+		debounce: 200 * time.Millisecond,
+		current:  current,
+	}
+}
+
+// OnReload registers a handler called with each successfully reloaded config
+func (w *Watcher) OnReload(handler func(cfg *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Start watches the config file until ctx is cancelled
+func (w *Watcher) Start(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	// Watch the directory so editors that replace the file are handled
+	if err := fw.Add(filepath.Dir(w.path)); err != nil {
+		fw.Close()
+		return fmt.Errorf("failed to watch %s: %w", w.path, err)
+	}
+
+	go w.run(ctx, fw)
+	return nil
+}
+
+// run handles file events, debouncing bursts of writes into a single reload
+func (w *Watcher) run(ctx context.Context, fw *fsnotify.Watcher) {
+	defer fw.Close()
+
+	name := filepath.Clean(w.path)
+	var timer *time.Timer
+	var fire <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case event, ok := <-fw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != name || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(w.debounce)
+			} else {
+				timer.Reset(w.debounce)
+			}
+			fire = timer.C
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return
+			}
+			w.log.Warn("Config watcher error: %v", err)
+		case <-fire:
+			fire = nil
+			if err := w.Reload(); err != nil {
+				w.log.Error("Config reload rejected: %v", err)
+			}
+		}
+	}
+}
+
+// Reload loads the config file, rejects immutable changes and notifies handlers
+func (w *Watcher) Reload() error {
+	next, err := Load(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	current := w.current
+	if err := current.CheckReload(next); err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	if reflect.DeepEqual(reloadable(current), reloadable(next)) {
+		w.mu.Unlock()
+		return nil
+	}
+	changes := Diff(current, next)
+	w.current = next
+	handlers := make([]func(*Config), len(w.handlers))
+	copy(handlers, w.handlers)
+	w.mu.Unlock()
+
+	for _, change := range changes {
+		w.log.Info("Config changed: %s", change)
+	}
+	for _, handler := range handlers {
+		handler(next)
+	}
+	return nil
+}
+
+// reloadableConfig is the part of the config applied without a restart
+type reloadableConfig struct {
+	Params   map[string]interface{}
+	Risk     RiskConfig
+	LogLevel string
+}
+
+// reloadable returns the settings of c that a reload can change
+func reloadable(c *Config) reloadableConfig {
+	return reloadableConfig{Params: c.Strategy.Params, Risk: c.Risk, LogLevel: c.Log.Level}
+}
+
+// CheckReload returns an error if next changes fields that require a restart
+func (c *Config) CheckReload(next *Config) error {
+	immutable := []struct {
+		name      string
+		old, next interface{}
+	}{
+		{"exchange.base_url", c.Exchange.BaseURL, next.Exchange.BaseURL},
+		{"exchange.ws_url", c.Exchange.WSURL, next.Exchange.WSURL},
+		{"exchange.testnet", c.Exchange.Testnet, next.Exchange.Testnet},
+		{"exchange.api_key", c.Exchange.APIKey, next.Exchange.APIKey},
+		{"strategy.name", c.Strategy.Name, next.Strategy.Name},
+		{"strategy.symbol", c.Strategy.Symbol, next.Strategy.Symbol},
+		// The kill-switch is only built at startup; its threshold reloads
+		{"risk.max_drawdown enabled", c.Risk.MaxDrawdown > 0, next.Risk.MaxDrawdown > 0},
+	}
+	for _, f := range immutable {
+		if f.old != f.next {
+			return fmt.Errorf("%s cannot be changed without a restart", f.name)
+		}
+	}
+	return nil
+}
+
+// Diff describes the reloadable fields that differ between old and next, for
+// logging; whether a reload applies is decided on the whole reloadable config
+func Diff(old, next *Config) []string {
+	var changes []string
+	add := func(name string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, a, b))
+		}
+	}
+
+	keys := make(map[string]bool)
+	for k := range old.Strategy.Params {
+		keys[k] = true
+	}
+	for k := range next.Strategy.Params {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		add("strategy.params."+k, old.Strategy.Params[k], next.Strategy.Params[k])
+	}
+
+	add("risk.max_position_size", old.Risk.MaxPositionSize, next.Risk.MaxPositionSize)
+	add("risk.max_leverage", old.Risk.MaxLeverage, next.Risk.MaxLeverage)
+	add("risk.max_drawdown", old.Risk.MaxDrawdown, next.Risk.MaxDrawdown)
+	add("risk.daily_loss_limit", old.Risk.DailyLossLimit, next.Risk.DailyLossLimit)
+	add("risk.symbol_limits", old.Risk.SymbolLimits, next.Risk.SymbolLimits)
+	add("risk.daily_reset_hour", old.Risk.DailyResetHour, next.Risk.DailyResetHour)
+	add("risk.reset_stats_daily", old.Risk.ResetStatsDaily, next.Risk.ResetStatsDaily)
+	add("risk.max_spread_bps", old.Risk.MaxSpreadBps, next.Risk.MaxSpreadBps)
+	add("risk.min_top_size", old.Risk.MinTopSize, next.Risk.MinTopSize)
+	add("risk.max_positions", old.Risk.MaxPositions, next.Risk.MaxPositions)
+	add("risk.event_blackout_before", old.Risk.EventBlackoutBefore, next.Risk.EventBlackoutBefore)
+	add("risk.event_blackout_after", old.Risk.EventBlackoutAfter, next.Risk.EventBlackoutAfter)
+	add("risk.flatten_before_events", old.Risk.FlattenBeforeEvents, next.Risk.FlattenBeforeEvents)
+	add("log.level", old.Log.Level, next.Log.Level)
+	return changes
+}
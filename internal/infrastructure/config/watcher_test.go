@@ -0,0 +1,156 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/risk"
+)
+
+const watcherTestConfig = `
+exchange:
+  base_url: https://api.hyperliquid.xyz
+  api_key: key
+  api_secret: secret
+strategy:
+  name: mean_reversion
+  symbol: %SYMBOL%
+  params:
+    window_size: 20
+risk:
+  max_position_size: %SIZE%
+log:
+  level: info
+`
+
+func writeConfig(t *testing.T, path, symbol, size string) {
+	t.Helper()
+	data := strings.NewReplacer("%SYMBOL%", symbol, "%SIZE%", size).Replace(watcherTestConfig)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestWatcher_ReloadsRiskLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "BTC", "1.0")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	checker := risk.NewChecker(&risk.Config{MaxPositionSize: cfg.Risk.MaxPositionSize})
+	if r := checker.CheckPositionSize(1.5); r.Allowed {
+		t.Fatal("Expected 1.5 to exceed the initial limit")
+	}
+
+	reloaded := make(chan struct{}, 1)
+	w := NewWatcher(path, cfg, logger.New(logger.LevelError, nil))
+	w.debounce = 10 * time.Millisecond
+	w.OnReload(func(next *Config) {
+		checker.Reconfigure(&risk.Config{MaxPositionSize: next.Risk.MaxPositionSize})
+		reloaded <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	writeConfig(t, path, "BTC", "2.0")
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for config reload")
+	}
+	if r := checker.CheckPositionSize(1.5); !r.Allowed {
+		t.Errorf("Expected reloaded limit to allow 1.5: %s", r.Reason)
+	}
+}
+
+func TestWatcher_RejectsImmutableChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "BTC", "1.0")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	w := NewWatcher(path, cfg, logger.New(logger.LevelError, nil))
+	called := false
+	w.OnReload(func(*Config) { called = true })
+
+	writeConfig(t, path, "ETH", "2.0")
+	err = w.Reload()
+	if err == nil || !strings.Contains(err.Error(), "strategy.symbol") {
+		t.Errorf("Expected symbol change to be rejected, got %v", err)
+	}
+	if called {
+		t.Error("Expected handlers not to be called for a rejected reload")
+	}
+}
+
+func TestConfig_CheckReloadDrawdown(t *testing.T) {
+	current := &Config{Risk: RiskConfig{MaxDrawdown: 0.1}}
+
+	if err := current.CheckReload(&Config{Risk: RiskConfig{MaxDrawdown: 0.2}}); err != nil {
+		t.Errorf("Expected threshold change to reload, got %v", err)
+	}
+	err := current.CheckReload(&Config{})
+	if err == nil || !strings.Contains(err.Error(), "risk.max_drawdown") {
+		t.Errorf("Expected disabling the kill-switch to need a restart, got %v", err)
+	}
+}
+
+func TestWatcher_ReloadsAnyRiskField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "BTC", "1.0")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	w := NewWatcher(path, cfg, logger.New(logger.LevelError, nil))
+	var got *Config
+	w.OnReload(func(next *Config) { got = next })
+
+	// Reload an unchanged file: nothing to apply
+	if err := w.Reload(); err != nil || got != nil {
+		t.Fatalf("Expected an unchanged reload to be skipped, got %v, %v", got, err)
+	}
+
+	// Liquidity and position-count limits reload like the size limits
+	writeConfig(t, path, "BTC", "1.0\n  max_spread_bps: 5\n  max_positions: 2")
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got == nil || got.Risk.MaxSpreadBps != 5 || got.Risk.MaxPositions != 2 {
+		t.Fatalf("Expected handlers to get the new spread and position limits, got %+v", got)
+	}
+	if changes := Diff(cfg, got); len(changes) != 2 {
+		t.Errorf("Expected both changes described, got %v", changes)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &Config{Strategy: StrategyConfig{Params: map[string]interface{}{"window_size": 20}}}
+	next := &Config{Strategy: StrategyConfig{Params: map[string]interface{}{"window_size": 30}}}
+	next.Risk.MaxPositionSize = 2
+
+	changes := Diff(old, next)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v", changes)
+	}
+	if changes[0] != "strategy.params.window_size: 20 -> 30" {
+		t.Errorf("Unexpected change description: %s", changes[0])
+	}
+}
@@ -0,0 +1,116 @@
+package feargreed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+)
+
+const (
+	defaultBaseURL = "https://api.alternative.me"
+)
+
+// Client is an alternative.me crypto fear & greed index client (no API key required)
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
+	cache      *httputil.Cache
+	cacheTTL   time.Duration
+}
+
+// NewClient creates a new fear & greed index client
+func NewClient() *Client {
+	return &Client{
+		baseURL: defaultBaseURL,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		retry:    httputil.DefaultRetryConfig(),
+		breaker:  httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		cache:    httputil.NewCache(),
+		cacheTTL: 10 * time.Minute, // The index is published daily
+	}
+}
+
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *Client) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
+
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *Client) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
+
+// BreakerState returns the circuit breaker state
+func (c *Client) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
+}
+
+// IndexResponse represents the fear & greed API response
+type IndexResponse struct {
+	Data []IndexData `json:"data"`
+}
+
+// IndexData is a single index value; numbers are encoded as strings
+type IndexData struct {
+	Value               string `json:"value"`
+	ValueClassification string `json:"value_classification"`
+	Timestamp           string `json:"timestamp"` // Unix seconds
+}
+
+// GetIndex retrieves the latest fear & greed index
+func (c *Client) GetIndex(ctx context.Context) (*entity.FearGreedIndex, error) {
+	const endpoint = "/fng/?limit=1"
+	body, ok := c.cache.Get(endpoint)
+	if !ok {
+		header := http.Header{}
+		header.Set("Accept", "application/json")
+		var err error
+		body, err = c.breaker.Do(func() ([]byte, error) {
+			return httputil.Get(ctx, c.httpClient, c.baseURL+endpoint, header, c.retry)
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.cache.Set(endpoint, body, c.cacheTTL)
+	}
+
+	var resp IndexResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no fear & greed data")
+	}
+
+	data := resp.Data[0]
+	value, err := strconv.Atoi(data.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index value %q: %w", data.Value, err)
+	}
+	timestamp := time.Now()
+	if ts, err := strconv.ParseInt(data.Timestamp, 10, 64); err == nil {
+		timestamp = time.Unix(ts, 0)
+	}
+
+	return &entity.FearGreedIndex{
+		Value:          value,
+		Classification: data.ValueClassification,
+		Timestamp:      timestamp,
+	}, nil
+}
+
+// GetSentimentBias returns the contrarian trading bias of the index:
+// extreme fear is bullish, extreme greed is bearish
+func GetSentimentBias(index *entity.FearGreedIndex) (entity.SignalBias, float64) {
+	return index.Bias()
+}
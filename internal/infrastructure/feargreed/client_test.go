@@ -0,0 +1,60 @@
+package feargreed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func newTestClient(t *testing.T, body string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fng/" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient()
+	c.baseURL = server.URL
+	return c
+}
+
+func TestClient_GetIndex_ContrarianBias(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		bias     entity.SignalBias
+		strength float64
+	}{
+		{"extreme fear", `{"data":[{"value":"10","value_classification":"Extreme Fear","timestamp":"1700000000"}]}`, entity.SignalBiasBullish, 0.8},
+		{"extreme greed", `{"data":[{"value":"90","value_classification":"Extreme Greed","timestamp":"1700000000"}]}`, entity.SignalBiasBearish, 0.8},
+		{"neutral", `{"data":[{"value":"55","value_classification":"Greed","timestamp":"1700000000"}]}`, entity.SignalBiasNeutral, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := newTestClient(t, tt.body).GetIndex(context.Background())
+			if err != nil {
+				t.Fatalf("GetIndex failed: %v", err)
+			}
+			if index.Timestamp.Unix() != 1700000000 || index.Classification == "" {
+				t.Errorf("Unexpected index: %+v", index)
+			}
+			bias, strength := GetSentimentBias(index)
+			if bias != tt.bias || strength != tt.strength {
+				t.Errorf("Expected %s (%.2f), got %s (%.2f)", tt.bias, tt.strength, bias, strength)
+			}
+		})
+	}
+}
+
+func TestClient_GetIndex_Empty(t *testing.T) {
+	if _, err := newTestClient(t, `{"data":[]}`).GetIndex(context.Background()); err == nil {
+		t.Error("Expected error for an empty response")
+	}
+}
@@ -0,0 +1,154 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a call is short-circuited by an open breaker
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerState represents the state of a circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// BreakerConfig holds circuit breaker thresholds
+type BreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit (0 = disabled)
+	Window           time.Duration // Failures must occur within this window (0 = no window)
+	Cooldown         time.Duration // Time the circuit stays open before a half-open probe
+}
+
+// DefaultBreakerConfig returns default circuit breaker configuration
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		Window:           time.Minute,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// Breaker short-circuits calls after repeated failures
+type Breaker struct {
+	config BreakerConfig
+	now    func() time.Time
+
+	mu           sync.Mutex
+	state        BreakerState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool // A half-open probe is in flight
+}
+
+// NewBreaker creates a new circuit breaker in the closed state
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	return &Breaker{
+		config: cfg,
+		now:    time.Now,
+		state:  BreakerClosed,
+	}
+}
+
+// Do calls fn unless the circuit is open, recording the outcome
+func (b *Breaker) Do(fn func() ([]byte, error)) ([]byte, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+	body, err := fn()
+	b.record(err)
+	return body, err
+}
+
+// State returns the current breaker state
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && !b.now().Before(b.openedAt.Add(b.config.Cooldown)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a call may proceed
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openedAt.Add(b.config.Cooldown)) {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the result of a call
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Caller cancellation and requests the API rejected as invalid say
+	// nothing about the remote API's health
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrInvalidRequest) {
+		if b.state == BreakerHalfOpen {
+			b.probing = false
+		}
+		return
+	}
+
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	if b.state == BreakerHalfOpen {
+		b.trip()
+		return
+	}
+	// Rejected credentials fail every call until they change; stop at once
+	if errors.Is(err, ErrAuth) && b.config.FailureThreshold > 0 {
+		b.trip()
+		return
+	}
+
+	now := b.now()
+	if b.failures == 0 || (b.config.Window > 0 && now.Sub(b.firstFailure) > b.config.Window) {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+
+	if b.config.FailureThreshold > 0 && b.failures >= b.config.FailureThreshold {
+		b.trip()
+	}
+}
+
+// trip opens the circuit; caller must hold the lock
+func (b *Breaker) trip() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+	b.failures = 0
+	b.probing = false
+}
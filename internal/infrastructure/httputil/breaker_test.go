@@ -0,0 +1,154 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	b := NewBreaker(BreakerConfig{FailureThreshold: 3, Window: time.Minute, Cooldown: 30 * time.Second})
+	b.now = func() time.Time { return now }
+
+	get := func() error {
+		_, err := b.Do(func() ([]byte, error) {
+			return Get(context.Background(), server.Client(), server.URL, nil, RetryConfig{MaxAttempts: 1})
+		})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := get(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Call %d: expected upstream error, got %v", i, err)
+		}
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("Expected open after 3 failures, got %s", b.State())
+	}
+
+	// Open circuit short-circuits without hitting the server
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected no request while open, got %d calls", calls.Load())
+	}
+
+	// After cooldown a failing probe re-opens the circuit
+	now = now.Add(31 * time.Second)
+	if b.State() != BreakerHalfOpen {
+		t.Errorf("Expected half-open after cooldown, got %s", b.State())
+	}
+	if err := get(); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected probe to reach the server, got %v", err)
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("Expected open after failed probe, got %s", b.State())
+	}
+
+	// A successful probe closes the circuit
+	now = now.Add(31 * time.Second)
+	healthy.Store(true)
+	if err := get(); err != nil {
+		t.Fatalf("Expected probe to succeed, got %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("Expected closed after successful probe, got %s", b.State())
+	}
+}
+
+func TestBreaker_Window(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(BreakerConfig{FailureThreshold: 2, Window: time.Minute, Cooldown: time.Minute})
+	b.now = func() time.Time { return now }
+	fail := func() ([]byte, error) { return nil, errors.New("boom") }
+
+	b.Do(fail)
+	now = now.Add(2 * time.Minute)
+	b.Do(fail)
+
+	// Failures spread beyond the window do not trip the breaker
+	if b.State() != BreakerClosed {
+		t.Errorf("Expected closed when failures are outside the window, got %s", b.State())
+	}
+
+	b.Do(fail)
+	if b.State() != BreakerOpen {
+		t.Errorf("Expected open after 2 failures within the window, got %s", b.State())
+	}
+}
+
+func TestBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(BreakerConfig{FailureThreshold: 1, Cooldown: time.Second})
+	b.now = func() time.Time { return now }
+
+	b.Do(func() ([]byte, error) { return nil, errors.New("boom") })
+	now = now.Add(2 * time.Second)
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		b.Do(func() ([]byte, error) {
+			<-release
+			return nil, nil
+		})
+		close(done)
+	}()
+
+	// Wait for the probe to be admitted
+	for b.State() != BreakerHalfOpen || !b.isProbing() {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := b.Do(func() ([]byte, error) { return nil, nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected concurrent call to be rejected during probe, got %v", err)
+	}
+
+	close(release)
+	<-done
+	if b.State() != BreakerClosed {
+		t.Errorf("Expected closed after probe succeeded, got %s", b.State())
+	}
+}
+
+// isProbing reports whether a half-open probe is in flight
+func (b *Breaker) isProbing() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.probing
+}
+
+func TestBreaker_ClassifiedErrors(t *testing.T) {
+	b := NewBreaker(BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+
+	// Invalid requests are the caller's fault and never trip the breaker
+	for i := 0; i < 3; i++ {
+		b.Do(func() ([]byte, error) { return nil, &StatusError{StatusCode: http.StatusBadRequest} })
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("Expected closed after invalid requests, got %s", b.State())
+	}
+
+	// Rejected credentials open it on the first failure
+	b.Do(func() ([]byte, error) { return nil, &StatusError{StatusCode: http.StatusUnauthorized} })
+	if b.State() != BreakerOpen {
+		t.Errorf("Expected open after an auth failure, got %s", b.State())
+	}
+}
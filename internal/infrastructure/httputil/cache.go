@@ -0,0 +1,65 @@
+package httputil
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is an in-memory TTL cache for response bodies keyed by endpoint
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	hits    atomic.Int64
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewCache creates a new response cache
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached body for key if it has not expired
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	return entry.body, true
+}
+
+// Set stores body under key for the given TTL (ttl <= 0 disables caching)
+func (c *Cache) Set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{
+		body:      body,
+		expiresAt: c.now().Add(ttl),
+	}
+}
+
+// Hits returns the number of cache hits
+func (c *Cache) Hits() int64 {
+	return c.hits.Load()
+}
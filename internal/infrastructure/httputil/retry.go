@@ -0,0 +1,184 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/metrics"
+)
+
+// RetryConfig holds retry configuration for idempotent requests
+type RetryConfig struct {
+	MaxAttempts int           // Total attempts including the first (1 = no retry)
+	BaseDelay   time.Duration // Delay before the first retry
+	MaxDelay    time.Duration // Upper bound for a single backoff delay
+}
+
+// DefaultRetryConfig returns default retry configuration
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// API failure classes, matched with errors.Is on a StatusError
+var (
+	ErrRateLimited    = errors.New("rate limited")          // 429; retryable after a backoff
+	ErrAuth           = errors.New("authentication failed") // 401 or 403; fatal until the credentials change
+	ErrInvalidRequest = errors.New("invalid request")       // Other 4xx; the request itself is at fault and retrying will not help
+)
+
+// StatusError is returned when the server responds with a non-200 status
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API error: status=%d, body=%s", e.StatusCode, e.Body)
+}
+
+// Is classifies the status as ErrRateLimited, ErrAuth or ErrInvalidRequest
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrInvalidRequest:
+		return e.StatusCode >= 400 && e.StatusCode < 500 &&
+			e.StatusCode != http.StatusTooManyRequests && e.StatusCode != http.StatusUnauthorized && e.StatusCode != http.StatusForbidden
+	}
+	return false
+}
+
+// retryable reports whether the status is worth retrying
+func (e *StatusError) retryable() bool {
+	return errors.Is(e, ErrRateLimited) || e.StatusCode >= 500
+}
+
+// Get performs a GET request, retrying on network errors, 5xx and 429 responses
+func Get(ctx context.Context, client *http.Client, url string, header http.Header, cfg RetryConfig) ([]byte, error) {
+	attempts := cfg.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	host := "unknown"
+	if u, err := neturl.Parse(url); err == nil {
+		host = u.Host
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := backoff(cfg, attempt)
+			var statusErr *StatusError
+			if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+				delay = statusErr.RetryAfter
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		body, err := get(ctx, client, url, header)
+		metrics.ObserveAPIRequest(host, err)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		// Do not retry if the caller gave up or the server rejected the request
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !statusErr.retryable() {
+			return nil, err
+		}
+	}
+
+	return nil, lastErr
+}
+
+// get performs a single GET request
+func get(ctx context.Context, client *http.Client, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	return body, nil
+}
+
+// backoff returns the exponential delay with jitter before the given retry
+func backoff(cfg RetryConfig, attempt int) time.Duration {
+	if cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := cfg.BaseDelay << (attempt - 1)
+	if cfg.MaxDelay > 0 && (delay > cfg.MaxDelay || delay <= 0) {
+		delay = cfg.MaxDelay
+	}
+
+	// Jitter in [delay/2, delay) to avoid synchronized retries
+	half := delay / 2
+	return half + rand.N(delay-half)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}
+}
+
+func TestGet_RetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			t.Errorf("Expected Accept header to be forwarded, got %q", r.Header.Get("Accept"))
+		}
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	body, err := Get(context.Background(), server.Client(), server.URL, header, testRetryConfig())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("Unexpected body: %s", body)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestGet_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := Get(context.Background(), server.Client(), server.URL, nil, testRetryConfig())
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected StatusError with 500, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestGet_NoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	if _, err := Get(context.Background(), server.Client(), server.URL, nil, testRetryConfig()); err == nil {
+		t.Fatal("Expected error for 401 response")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 attempt for non-retryable status, got %d", calls.Load())
+	}
+}
+
+func TestGet_RespectsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	if _, err := Get(context.Background(), server.Client(), server.URL, nil, testRetryConfig()); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected retry to wait for Retry-After, took %v", elapsed)
+	}
+}
+
+func TestGet_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := testRetryConfig()
+	cfg.BaseDelay = time.Second
+	cfg.MaxDelay = time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Get(ctx, server.Client(), server.URL, nil, cfg)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected cancellation to interrupt backoff, took %v", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("5"); d != 5*time.Second {
+		t.Errorf("Expected 5s, got %v", d)
+	}
+	if d := parseRetryAfter(""); d != 0 {
+		t.Errorf("Expected 0 for empty header, got %v", d)
+	}
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(future); d <= 0 || d > time.Minute {
+		t.Errorf("Expected positive delay up to 1m for HTTP date, got %v", d)
+	}
+}
+
+func TestStatusError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusUnauthorized, ErrAuth},
+		{http.StatusForbidden, ErrAuth},
+		{http.StatusBadRequest, ErrInvalidRequest},
+		{http.StatusNotFound, ErrInvalidRequest},
+		{http.StatusInternalServerError, nil},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &StatusError{StatusCode: tt.status})
+		for _, class := range []error{ErrRateLimited, ErrAuth, ErrInvalidRequest} {
+			if got := errors.Is(err, class); got != (class == tt.want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, class, got)
+			}
+		}
+	}
+}
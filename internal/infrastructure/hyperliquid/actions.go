@@ -0,0 +1,52 @@
+package hyperliquid
+
+// Exchange actions in Hyperliquid's wire format. Field order matters: actions
+// are msgpack-encoded in declaration order before hashing.
+
+// OrderAction places one or more orders
+type OrderAction struct {
+	Type     string      `json:"type" msgpack:"type"` // "order"
+	Orders   []OrderWire `json:"orders" msgpack:"orders"`
+	Grouping string      `json:"grouping" msgpack:"grouping"` // "na" for independent orders
+}
+
+// OrderWire is a single order in an OrderAction
+type OrderWire struct {
+	Asset      int           `json:"a" msgpack:"a"`
+	IsBuy      bool          `json:"b" msgpack:"b"`
+	LimitPx    string        `json:"p" msgpack:"p"`
+	Size       string        `json:"s" msgpack:"s"`
+	ReduceOnly bool          `json:"r" msgpack:"r"`
+	OrderType  OrderTypeWire `json:"t" msgpack:"t"`
+	Cloid      string        `json:"c,omitempty" msgpack:"c,omitempty"`
+}
+
+// OrderTypeWire selects a limit or trigger order
+type OrderTypeWire struct {
+	Limit *LimitOrderType `json:"limit,omitempty" msgpack:"limit,omitempty"`
+}
+
+// LimitOrderType sets a limit order's time in force: "Gtc", "Ioc" or "Alo" (post-only)
+type LimitOrderType struct {
+	Tif string `json:"tif" msgpack:"tif"`
+}
+
+// CancelAction cancels orders by ID
+type CancelAction struct {
+	Type    string       `json:"type" msgpack:"type"` // "cancel"
+	Cancels []CancelWire `json:"cancels" msgpack:"cancels"`
+}
+
+// CancelWire identifies an order to cancel
+type CancelWire struct {
+	Asset   int   `json:"a" msgpack:"a"`
+	OrderID int64 `json:"o" msgpack:"o"`
+}
+
+// UpdateLeverageAction sets the leverage of an asset
+type UpdateLeverageAction struct {
+	Type     string `json:"type" msgpack:"type"` // "updateLeverage"
+	Asset    int    `json:"asset" msgpack:"asset"`
+	IsCross  bool   `json:"isCross" msgpack:"isCross"`
+	Leverage int    `json:"leverage" msgpack:"leverage"`
+}
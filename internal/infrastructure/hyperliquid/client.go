@@ -8,14 +8,17 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/metrics"
 )
 
 // ClientConfig holds configuration for the Hyperliquid API client
 type ClientConfig struct {
-	BaseURL   string
-	APIKey    string
-	APISecret string
-	Testnet   bool
+	BaseURL      string
+	APIKey       string
+	APISecret    string
+	Testnet      bool
+	VaultAddress string // Trade for this vault or subaccount instead of the main account
 }
 
 // Client is a Hyperliquid API client
@@ -49,8 +52,101 @@ type InfoRequest struct {
 	Coin string      `json:"coin,omitempty"`
 }
 
+// OrderStatusRequest represents an order status request
+type OrderStatusRequest struct {
+	Type string      `json:"type"`
+	User string      `json:"user"`
+	Oid  interface{} `json:"oid"` // Order ID (number) or client order ID (hex string)
+}
+
+// CandleSnapshotRequest represents a candle history request
+type CandleSnapshotRequest struct {
+	Type string            `json:"type"`
+	Req  CandleSnapshotReq `json:"req"`
+}
+
+// CandleSnapshotReq holds candle history query parameters
+type CandleSnapshotReq struct {
+	Coin      string `json:"coin"`
+	Interval  string `json:"interval"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+}
+
+// CandleSnapshot represents a single candle returned by the info API
+type CandleSnapshot struct {
+	OpenTime  int64  `json:"t"`
+	CloseTime int64  `json:"T"`
+	Coin      string `json:"s"`
+	Interval  string `json:"i"`
+	Open      string `json:"o"`
+	High      string `json:"h"`
+	Low       string `json:"l"`
+	Close     string `json:"c"`
+	Volume    string `json:"v"`
+	Trades    int    `json:"n"`
+}
+
+// ExchangeRequest is a signed action sent to the exchange endpoint
+type ExchangeRequest struct {
+	Action       interface{} `json:"action"`
+	Nonce        uint64      `json:"nonce"`
+	Signature    *Signature  `json:"signature"`
+	VaultAddress string      `json:"vaultAddress,omitempty"`
+}
+
+// ExchangeResponse is the exchange endpoint response envelope
+type ExchangeResponse struct {
+	Status   string          `json:"status"` // "ok" or "err"
+	Response json.RawMessage `json:"response"`
+}
+
+// User returns the address whose state is traded and queried:
+// the vault or subaccount when set, otherwise the main account
+func (c *Client) User() string {
+	if c.config.VaultAddress != "" {
+		return c.config.VaultAddress
+	}
+	return c.config.APIKey
+}
+
+// PostAction signs an action for the vault (or main account) and sends it,
+// returning the response payload
+func (c *Client) PostAction(ctx context.Context, signer *Signer, action interface{}) (json.RawMessage, error) {
+	nonce := signer.NextNonce()
+	sig, err := signer.Sign(action, nonce, c.config.VaultAddress)
+	if err != nil {
+		return nil, fmt.Errorf("sign action: %w", err)
+	}
+
+	respBody, err := c.doRequest(ctx, "/exchange", ExchangeRequest{
+		Action:       action,
+		Nonce:        nonce,
+		Signature:    sig,
+		VaultAddress: c.config.VaultAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ExchangeResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+	if resp.Status != "ok" {
+		// The response is usually the error message as a JSON string
+		message := string(resp.Response)
+		var text string
+		if json.Unmarshal(resp.Response, &text) == nil {
+			message = text
+		}
+		return nil, fmt.Errorf("exchange error: %w", newAPIError(0, message))
+	}
+	return resp.Response, nil
+}
+
 // doRequest performs an HTTP request
-func (c *Client) doRequest(ctx context.Context, endpoint string, body interface{}) ([]byte, error) {
+func (c *Client) doRequest(ctx context.Context, endpoint string, body interface{}) (respBody []byte, err error) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -66,6 +162,7 @@ func (c *Client) doRequest(ctx context.Context, endpoint string, body interface{
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	defer func() { metrics.ObserveAPIRequest(req.URL.Host, err) }()
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -73,13 +170,13 @@ func (c *Client) doRequest(ctx context.Context, endpoint string, body interface{
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp.StatusCode, string(respBody))
 	}
 
 	return respBody, nil
@@ -148,3 +245,37 @@ func (c *Client) GetOpenOrders(ctx context.Context, user string) ([]map[string]i
 
 	return result, nil
 }
+
+// GetOrderStatus retrieves the status of a user's order by order ID or client order ID
+func (c *Client) GetOrderStatus(ctx context.Context, user string, oid interface{}) (json.RawMessage, error) {
+	req := OrderStatusRequest{Type: "orderStatus", User: user, Oid: oid}
+	respBody, err := c.doRequest(ctx, "/info", req)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(respBody), nil
+}
+
+// GetCandles retrieves candle history for a coin between start and end
+func (c *Client) GetCandles(ctx context.Context, coin, interval string, start, end time.Time) ([]CandleSnapshot, error) {
+	req := CandleSnapshotRequest{
+		Type: "candleSnapshot",
+		Req: CandleSnapshotReq{
+			Coin:      coin,
+			Interval:  interval,
+			StartTime: start.UnixMilli(),
+			EndTime:   end.UnixMilli(),
+		},
+	}
+	respBody, err := c.doRequest(ctx, "/info", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []CandleSnapshot
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return result, nil
+}
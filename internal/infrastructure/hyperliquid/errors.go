@@ -0,0 +1,92 @@
+package hyperliquid
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+)
+
+// Hyperliquid failure classes, matched with errors.Is. ErrRateLimited and
+// ErrAuth are the data source classes, so callers can check either package.
+var (
+	ErrRateLimited         = httputil.ErrRateLimited
+	ErrAuth                = httputil.ErrAuth
+	ErrInvalidOrder        = errors.New("invalid order")        // Rejected as malformed or against exchange rules; retrying will not help
+	ErrInsufficientBalance = errors.New("insufficient balance") // Not enough margin or balance for the order
+	ErrNotConnected        = errors.New("websocket not connected")
+)
+
+// APIError is an error response from the Hyperliquid API. It matches the
+// failure class it was recognized as, if any.
+type APIError struct {
+	StatusCode int    // HTTP status; 0 for errors reported in a successful response
+	Message    string // Response body or error message
+	Kind       error  // One of the Err classes, or nil when unrecognized
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("API error: status=%d, body=%s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the failure class
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// errorPatterns map lowercase substrings of Hyperliquid error messages to
+// failure classes, checked in order
+var errorPatterns = []struct {
+	substr string
+	kind   error
+}{
+	{"too many", ErrRateLimited},
+	{"rate limit", ErrRateLimited},
+	{"api wallet", ErrAuth}, // "User or API Wallet 0x... does not exist."
+	{"signature", ErrAuth},
+	{"insufficient", ErrInsufficientBalance}, // "Insufficient margin to place order."
+	{"minimum value", ErrInvalidOrder},       // "Order must have minimum value of $10."
+	{"invalid", ErrInvalidOrder},             // "Order has invalid price.", "Invalid TP/SL price."
+	{"tick size", ErrInvalidOrder},
+	{"post only", ErrInvalidOrder},
+	{"reduce only", ErrInvalidOrder},
+	{"could not immediately match", ErrInvalidOrder}, // IOC with nothing to take
+}
+
+// classifyError returns the failure class of an API error by message, then
+// by HTTP status; nil when unrecognized
+func classifyError(status int, message string) error {
+	lower := strings.ToLower(message)
+	for _, p := range errorPatterns {
+		if strings.Contains(lower, p.substr) {
+			return p.kind
+		}
+	}
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	}
+	return nil
+}
+
+// newAPIError builds a classified APIError
+func newAPIError(status int, message string) *APIError {
+	return &APIError{StatusCode: status, Message: message, Kind: classifyError(status, message)}
+}
+
+// orderError builds the error for an order the exchange rejected; rejections
+// that are not recognized otherwise are ErrInvalidOrder
+func orderError(message string) *APIError {
+	err := newAPIError(0, message)
+	if err.Kind == nil {
+		err.Kind = ErrInvalidOrder
+	}
+	return err
+}
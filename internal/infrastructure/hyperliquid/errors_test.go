@@ -0,0 +1,95 @@
+package hyperliquid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		want    error
+	}{
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusOK, "Too many cumulative requests sent (1201 > 1200) for cumulative volume traded.", ErrRateLimited},
+		{http.StatusForbidden, "", ErrAuth},
+		{0, "User or API Wallet 0x0000000000000000000000000000000000000abc does not exist.", ErrAuth},
+		{0, "Insufficient margin to place order. asset=0", ErrInsufficientBalance},
+		{0, "Order must have minimum value of $10.", ErrInvalidOrder},
+		{0, "Order has invalid price.", ErrInvalidOrder},
+		{0, "Post only order would have immediately matched, bbo was 3000@3001.", ErrInvalidOrder},
+		{http.StatusInternalServerError, "upstream timeout", nil},
+	}
+	for _, tt := range tests {
+		if got := classifyError(tt.status, tt.message); got != tt.want {
+			t.Errorf("classifyError(%d, %q) = %v, want %v", tt.status, tt.message, got, tt.want)
+		}
+	}
+}
+
+// errorAPI serves meta and answers every exchange request with a fixed response
+type errorAPI struct {
+	status int
+	body   string
+}
+
+func (f *errorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/info" {
+		w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5}]}`))
+		return
+	}
+	w.WriteHeader(f.status)
+	w.Write([]byte(f.body))
+}
+
+func TestExchange_PlaceOrderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"rate limited", http.StatusTooManyRequests, `null`, ErrRateLimited},
+		{"unknown signer", http.StatusOK, `{"status":"err","response":"User or API Wallet 0xabc does not exist."}`, ErrAuth},
+		{"insufficient margin", http.StatusOK,
+			`{"status":"ok","response":{"type":"order","data":{"statuses":[{"error":"Insufficient margin to place order. asset=0"}]}}}`,
+			ErrInsufficientBalance},
+		{"below minimum", http.StatusOK,
+			`{"status":"ok","response":{"type":"order","data":{"statuses":[{"error":"Order must have minimum value of $10."}]}}}`,
+			ErrInvalidOrder},
+		{"unrecognized rejection", http.StatusOK,
+			`{"status":"ok","response":{"type":"order","data":{"statuses":[{"error":"Something new."}]}}}`,
+			ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&errorAPI{status: tt.status, body: tt.body})
+			defer server.Close()
+			e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APISecret: testPrivateKey, Testnet: true}, nil)
+
+			_, err := e.PlaceOrder(context.Background(), &entity.Order{
+				Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 50000, Quantity: 0.001,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Errorf("Expected an APIError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestExchange_WSSendNotConnected(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{}, nil)
+	if err := e.wsSend(map[string]string{"method": "ping"}); !errors.Is(err, ErrNotConnected) {
+		t.Errorf("Expected ErrNotConnected, got %v", err)
+	}
+}
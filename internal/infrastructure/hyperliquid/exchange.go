@@ -2,8 +2,13 @@ package hyperliquid
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,18 +16,33 @@ import (
 	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/metrics"
 )
 
 // Ensure HyperliquidExchange implements ExchangeGateway
 var _ gateway.ExchangeGateway = (*HyperliquidExchange)(nil)
 
+// Ensure HyperliquidExchange reports account equity
+var _ gateway.AccountGateway = (*HyperliquidExchange)(nil)
+
+// Ensure HyperliquidExchange can restore its WebSocket stream
+var _ gateway.StreamGateway = (*HyperliquidExchange)(nil)
+
+// Ensure HyperliquidExchange publishes its minimum order sizes
+var _ gateway.LimitsGateway = (*HyperliquidExchange)(nil)
+
 // ExchangeConfig contains Hyperliquid exchange configuration
 type ExchangeConfig struct {
-	BaseURL   string
-	WSURL     string
-	APIKey    string
-	APISecret string
-	Testnet   bool
+	BaseURL      string
+	WSURL        string
+	APIKey       string // Account address
+	APISecret    string // Hex private key used to sign actions
+	Testnet      bool
+	VaultAddress string // Trade for this vault or subaccount (empty = main account)
+	RecordPath   string // Append received WebSocket messages to this JSONL file (empty = off)
+	Offline      bool   // Do not dial the WebSocket; market data comes from Replay
+
+	BalanceTTL time.Duration // Reuse the account balance this long before re-reading it (0 = always read)
 }
 
 // HyperliquidExchange implements ExchangeGateway for Hyperliquid
@@ -36,12 +56,34 @@ type HyperliquidExchange struct {
 	wsMu       sync.RWMutex
 	wsConnected bool
 	wsDone     chan struct{}
+	wsDialed   bool // true after the first successful dial
+	recorder   *Recorder // nil unless WebSocket messages are recorded
+
+	// Subscription messages sent, replayed on reconnect
+	subMu         sync.Mutex
+	subscriptions []json.RawMessage
+	subscribed    map[string]bool
 
 	// Handlers
 	tickerHandlers    map[string][]func(*entity.Ticker)
 	orderbookHandlers map[string][]func(*entity.OrderBook)
 	orderHandlers     []func(*entity.Order)
 	handlerMu         sync.RWMutex
+
+	// Signing and order routing
+	signer     *Signer
+	signerErr  error
+	assetMu    sync.Mutex
+	assets     map[string]assetInfo // Perp asset index by coin, loaded from meta
+	orderMu    sync.Mutex
+	orderCoins map[string]string      // Coin of each open order by ID, for cancels
+	orderFills map[string]*orderFills // Fills seen so far by ID of each unfinished order
+	fillWait   time.Duration          // How long a finished order's update waits for its fills
+
+	// Account balance cache
+	balanceMu sync.Mutex
+	balance   *entity.AccountBalance
+	balanceAt time.Time
 }
 
 // NewHyperliquidExchange creates a new Hyperliquid exchange gateway
@@ -51,23 +93,41 @@ func NewHyperliquidExchange(config *ExchangeConfig, log *logger.Logger) *Hyperli
 	}
 
 	client := NewClient(ClientConfig{
-		BaseURL:   config.BaseURL,
-		APIKey:    config.APIKey,
-		APISecret: config.APISecret,
-		Testnet:   config.Testnet,
+		BaseURL:      config.BaseURL,
+		APIKey:       config.APIKey,
+		APISecret:    config.APISecret,
+		Testnet:      config.Testnet,
+		VaultAddress: config.VaultAddress,
 	})
 
-	return &HyperliquidExchange{
+	e := &HyperliquidExchange{
 		config:            config,
 		client:            client,
 		log:               log.WithField("component", "hyperliquid"),
 		tickerHandlers:    make(map[string][]func(*entity.Ticker)),
 		orderbookHandlers: make(map[string][]func(*entity.OrderBook)),
+		orderCoins:        make(map[string]string),
+		orderFills:        make(map[string]*orderFills),
+		fillWait:          fillWait,
+		subscribed:        make(map[string]bool),
+	}
+	if config.APISecret != "" {
+		e.signer, e.signerErr = NewSigner(config.APISecret, !config.Testnet)
 	}
+	return e
 }
 
 // Connect establishes connection to Hyperliquid
 func (e *HyperliquidExchange) Connect(ctx context.Context) error {
+	if e.config.Offline {
+		// Handlers are fed by Replay; subscriptions are only registered
+		e.wsMu.Lock()
+		e.wsConnected = true
+		e.wsMu.Unlock()
+		e.log.Info("Hyperliquid market data is offline, waiting for a replay")
+		return nil
+	}
+
 	e.log.Info("Connecting to Hyperliquid (testnet: %v)", e.config.Testnet)
 
 	// Connect WebSocket
@@ -85,14 +145,34 @@ func (e *HyperliquidExchange) Connect(ctx context.Context) error {
 		return fmt.Errorf("websocket dial failed: %w", err)
 	}
 
+	var recorder *Recorder
+	if e.config.RecordPath != "" {
+		if recorder, err = OpenRecorder(e.config.RecordPath); err != nil {
+			conn.Close()
+			return err
+		}
+		e.log.Info("Recording WebSocket messages to %s", e.config.RecordPath)
+	}
+
 	e.wsMu.Lock()
+	if e.wsDialed {
+		metrics.WSReconnects.Inc()
+	}
+	e.wsDialed = true
 	e.wsConn = conn
 	e.wsConnected = true
-	e.wsDone = make(chan struct{})
+	done := make(chan struct{})
+	e.wsDone = done
+	if recorder != nil {
+		if e.recorder != nil {
+			e.recorder.Close()
+		}
+		e.recorder = recorder
+	}
 	e.wsMu.Unlock()
 
 	// Start read loop
-	go e.wsReadLoop()
+	go e.wsReadLoop(conn, done)
 
 	e.log.Info("Connected to Hyperliquid")
 	return nil
@@ -105,52 +185,612 @@ func (e *HyperliquidExchange) Disconnect(ctx context.Context) error {
 	e.wsMu.Lock()
 	defer e.wsMu.Unlock()
 
+	e.wsConnected = false
 	if e.wsConn != nil {
-		e.wsConnected = false
 		close(e.wsDone)
 		e.wsConn.Close()
 		e.wsConn = nil
 	}
+	if e.recorder != nil {
+		if err := e.recorder.Close(); err != nil {
+			e.log.Warn("Failed to close WebSocket recording: %v", err)
+		}
+		e.recorder = nil
+	}
+
+	return nil
+}
+
+// Connected reports whether the WebSocket is connected; it turns false when
+// the read loop ends on an error
+func (e *HyperliquidExchange) Connected() bool {
+	e.wsMu.RLock()
+	defer e.wsMu.RUnlock()
+	return e.wsConnected
+}
+
+// Reconnect closes the WebSocket, dials it again and re-sends every
+// subscription. Handlers stay registered, so they are not duplicated.
+func (e *HyperliquidExchange) Reconnect(ctx context.Context) error {
+	if err := e.Disconnect(ctx); err != nil {
+		return err
+	}
+	if err := e.Connect(ctx); err != nil {
+		return err
+	}
+
+	if e.config.Offline {
+		return nil
+	}
 
+	e.subMu.Lock()
+	subscriptions := append([]json.RawMessage(nil), e.subscriptions...)
+	e.subMu.Unlock()
+	for _, msg := range subscriptions {
+		if err := e.wsSend(msg); err != nil {
+			return fmt.Errorf("resubscribe: %w", err)
+		}
+	}
 	return nil
 }
 
-// PlaceOrder places a new order
+// subscribe remembers a subscription message for reconnects and sends it
+func (e *HyperliquidExchange) subscribe(msg interface{}) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+	e.subMu.Lock()
+	if !e.subscribed[string(data)] {
+		e.subscribed[string(data)] = true
+		e.subscriptions = append(e.subscriptions, data)
+	}
+	e.subMu.Unlock()
+	if e.config.Offline {
+		return nil
+	}
+	return e.wsSend(json.RawMessage(data))
+}
+
+// marketSlippage is how far through the mid a market order's IOC limit is set
+const marketSlippage = 0.05
+
+// minOrderNotional is the smallest order value Hyperliquid accepts, in USD
+const minOrderNotional = 10.0
+
+// userFills and orderUpdates are separate channels. A finished order's update
+// waits up to fillWait for fills it does not have yet, and fills of orders not
+// known to be placed are kept for unclaimedFillTTL in case the placement
+// response is still in flight.
+const (
+	fillWait         = 5 * time.Second
+	unclaimedFillTTL = time.Minute
+)
+
+// assetInfo is a perp asset's index and size precision from meta
+type assetInfo struct {
+	Index      int
+	SzDecimals int
+}
+
+// orderSigner returns the signer used for write actions
+func (e *HyperliquidExchange) orderSigner() (*Signer, error) {
+	if e.signerErr != nil {
+		return nil, fmt.Errorf("invalid api secret: %w", e.signerErr)
+	}
+	if e.signer == nil {
+		return nil, fmt.Errorf("api secret not configured")
+	}
+	return e.signer, nil
+}
+
+// asset returns the perp asset info for a coin, loading meta on first use
+func (e *HyperliquidExchange) asset(ctx context.Context, coin string) (assetInfo, error) {
+	e.assetMu.Lock()
+	defer e.assetMu.Unlock()
+
+	if e.assets == nil {
+		meta, err := e.client.GetMeta(ctx)
+		if err != nil {
+			return assetInfo{}, fmt.Errorf("get meta: %w", err)
+		}
+		raw, err := json.Marshal(meta["universe"])
+		if err != nil {
+			return assetInfo{}, fmt.Errorf("marshal universe: %w", err)
+		}
+		var universe []struct {
+			Name       string `json:"name"`
+			SzDecimals int    `json:"szDecimals"`
+		}
+		if err := json.Unmarshal(raw, &universe); err != nil {
+			return assetInfo{}, fmt.Errorf("unmarshal universe: %w", err)
+		}
+		e.assets = make(map[string]assetInfo, len(universe))
+		for i, a := range universe {
+			e.assets[a.Name] = assetInfo{Index: i, SzDecimals: a.SzDecimals}
+		}
+	}
+
+	info, ok := e.assets[coin]
+	if !ok {
+		return assetInfo{}, fmt.Errorf("unknown asset: %s", coin)
+	}
+	return info, nil
+}
+
+// OrderLimits returns the minimum notional and the size increment of a
+// symbol's perp asset
+func (e *HyperliquidExchange) OrderLimits(ctx context.Context, symbol string) (entity.OrderLimits, error) {
+	asset, err := e.asset(ctx, entity.Symbol(symbol).Hyperliquid())
+	if err != nil {
+		return entity.OrderLimits{}, err
+	}
+	return entity.OrderLimits{
+		MinNotional: minOrderNotional,
+		MinSize:     math.Pow(10, -float64(asset.SzDecimals)),
+	}, nil
+}
+
+// roundTo rounds x to the given number of decimals
+func roundTo(x float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(x*scale) / scale
+}
+
+// formatSize rounds a size to the asset's size decimals
+func formatSize(size float64, szDecimals int) string {
+	return strconv.FormatFloat(roundTo(size, szDecimals), 'f', -1, 64)
+}
+
+// formatPrice rounds a perp price to 5 significant figures and at most
+// 6 - szDecimals decimals, as Hyperliquid requires
+func formatPrice(price float64, szDecimals int) string {
+	sig, _ := strconv.ParseFloat(strconv.FormatFloat(price, 'g', 5, 64), 64)
+	return strconv.FormatFloat(roundTo(sig, 6-szDecimals), 'f', -1, 64)
+}
+
+// cloid returns the client order ID if it is a valid Hyperliquid cloid
+// (0x followed by 16 hex-encoded bytes), otherwise empty
+func cloid(clientOrderID string) string {
+	raw, err := hex.DecodeString(strings.TrimPrefix(clientOrderID, "0x"))
+	if err != nil || len(raw) != 16 || !strings.HasPrefix(clientOrderID, "0x") {
+		return ""
+	}
+	return clientOrderID
+}
+
+// timeInForce maps an order to Hyperliquid's limit time in force
+func timeInForce(order *entity.Order) string {
+	if order.Type == entity.OrderTypeMarket {
+		return "Ioc"
+	}
+	switch order.TimeInForce {
+	case entity.TimeInForceIOC:
+		return "Ioc"
+	case entity.TimeInForcePostOnly:
+		return "Alo"
+	default:
+		return "Gtc"
+	}
+}
+
+// orderResponse is the response payload of an order action
+type orderResponse struct {
+	Data struct {
+		Statuses []struct {
+			Resting *struct {
+				Oid int64 `json:"oid"`
+			} `json:"resting"`
+			Filled *struct {
+				TotalSz string `json:"totalSz"`
+				AvgPx   string `json:"avgPx"`
+				Oid     int64  `json:"oid"`
+			} `json:"filled"`
+			Error string `json:"error"`
+		} `json:"statuses"`
+	} `json:"data"`
+}
+
+// PlaceOrder places a new order. Market orders are sent as IOC limits
+// marketSlippage through the order price, or the mid when it has none.
 func (e *HyperliquidExchange) PlaceOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
 	e.log.Info("Placing order: %s %s %s @ %f x %f",
 		order.Symbol, order.Side, order.Type, order.Price, order.Quantity)
 
-	// TODO: Implement order placement via REST API
-	return nil, fmt.Errorf("order placement not implemented")
+	signer, err := e.orderSigner()
+	if err != nil {
+		return nil, err
+	}
+	coin := entity.Symbol(order.Symbol).Hyperliquid()
+	asset, err := e.asset(ctx, coin)
+	if err != nil {
+		return nil, err
+	}
+
+	price := order.Price
+	if order.Type == entity.OrderTypeMarket {
+		if price <= 0 {
+			mids, err := e.client.GetAllMids(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("get mids: %w", err)
+			}
+			price, _ = strconv.ParseFloat(mids[coin], 64)
+			if price <= 0 {
+				return nil, fmt.Errorf("no mid price for %s", order.Symbol)
+			}
+		}
+		if order.Side == entity.SideBuy {
+			price *= 1 + marketSlippage
+		} else {
+			price *= 1 - marketSlippage
+		}
+	}
+
+	action := OrderAction{
+		Type: "order",
+		Orders: []OrderWire{{
+			Asset:      asset.Index,
+			IsBuy:      order.Side == entity.SideBuy,
+			LimitPx:    formatPrice(price, asset.SzDecimals),
+			Size:       formatSize(order.Quantity, asset.SzDecimals),
+			ReduceOnly: order.ReduceOnly,
+			OrderType:  OrderTypeWire{Limit: &LimitOrderType{Tif: timeInForce(order)}},
+			Cloid:      cloid(order.ClientOrderID),
+		}},
+		Grouping: "na",
+	}
+
+	raw, err := e.client.PostAction(ctx, signer, action)
+	if err != nil {
+		return nil, fmt.Errorf("place order: %w", err)
+	}
+	e.invalidateBalance()
+	var resp orderResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal order response: %w", err)
+	}
+	if len(resp.Data.Statuses) == 0 {
+		return nil, fmt.Errorf("empty order response")
+	}
+
+	placed := *order
+	placed.CreatedAt = time.Now()
+	placed.UpdatedAt = placed.CreatedAt
+	switch status := resp.Data.Statuses[0]; {
+	case status.Error != "":
+		return nil, fmt.Errorf("order rejected: %w", orderError(status.Error))
+	case status.Filled != nil:
+		placed.ID = strconv.FormatInt(status.Filled.Oid, 10)
+		placed.Status = entity.OrderStatusFilled
+		placed.FilledQty, _ = strconv.ParseFloat(status.Filled.TotalSz, 64)
+		placed.Price, _ = strconv.ParseFloat(status.Filled.AvgPx, 64)
+	case status.Resting != nil:
+		placed.ID = strconv.FormatInt(status.Resting.Oid, 10)
+		placed.Status = entity.OrderStatusOpen
+	default:
+		return nil, fmt.Errorf("unexpected order status: %s", string(raw))
+	}
+
+	// Filled orders still get an order update, priced from their fills,
+	// which may have arrived before this response
+	e.orderMu.Lock()
+	if placed.Status == entity.OrderStatusOpen {
+		e.orderCoins[placed.ID] = coin
+	}
+	if e.orderFills[placed.ID] == nil {
+		e.orderFills[placed.ID] = &orderFills{}
+	}
+	e.orderFills[placed.ID].placed = true
+	e.orderMu.Unlock()
+	return &placed, nil
 }
 
 // CancelOrder cancels an order
 func (e *HyperliquidExchange) CancelOrder(ctx context.Context, orderID string) error {
 	e.log.Info("Canceling order: %s", orderID)
-	// TODO: Implement
-	return nil
+
+	e.orderMu.Lock()
+	coin, ok := e.orderCoins[orderID]
+	e.orderMu.Unlock()
+	if !ok {
+		// Placed before a restart or by another client
+		open, err := e.GetOpenOrders(ctx, "")
+		if err != nil {
+			return err
+		}
+		for _, o := range open {
+			if o.ID == orderID {
+				coin, ok = o.Symbol, true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("order not found: %s", orderID)
+		}
+	}
+
+	return e.cancel(ctx, coin, []string{orderID})
 }
 
 // CancelAllOrders cancels all orders for a symbol
 func (e *HyperliquidExchange) CancelAllOrders(ctx context.Context, symbol string) error {
 	e.log.Info("Canceling all orders for: %s", symbol)
-	// TODO: Implement
+
+	open, err := e.GetOpenOrders(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	if len(open) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(open))
+	for _, o := range open {
+		ids = append(ids, o.ID)
+	}
+	return e.cancel(ctx, entity.Symbol(symbol).Hyperliquid(), ids)
+}
+
+// cancel sends one cancel action for orders of a coin
+func (e *HyperliquidExchange) cancel(ctx context.Context, coin string, orderIDs []string) error {
+	signer, err := e.orderSigner()
+	if err != nil {
+		return err
+	}
+	asset, err := e.asset(ctx, coin)
+	if err != nil {
+		return err
+	}
+
+	action := CancelAction{Type: "cancel"}
+	for _, id := range orderIDs {
+		oid, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid order id %q: %w", id, err)
+		}
+		action.Cancels = append(action.Cancels, CancelWire{Asset: asset.Index, OrderID: oid})
+	}
+
+	raw, err := e.client.PostAction(ctx, signer, action)
+	if err != nil {
+		return fmt.Errorf("cancel orders: %w", err)
+	}
+	var resp struct {
+		Data struct {
+			Statuses []json.RawMessage `json:"statuses"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return fmt.Errorf("unmarshal cancel response: %w", err)
+	}
+	// Each status is "success" or {"error": "..."}
+	for i, status := range resp.Data.Statuses {
+		var failed struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(status, &failed) == nil && failed.Error != "" {
+			return fmt.Errorf("cancel %s: %s", orderIDs[i], failed.Error)
+		}
+	}
 	return nil
 }
 
-// GetOrder retrieves order by ID
+// GetOrder retrieves an order by its order ID, or by client order ID when
+// given one (0x followed by 16 hex-encoded bytes)
 func (e *HyperliquidExchange) GetOrder(ctx context.Context, orderID string) (*entity.Order, error) {
-	return nil, fmt.Errorf("not implemented")
+	user := e.client.User()
+	if user == "" {
+		return nil, fmt.Errorf("account address not configured")
+	}
+
+	var oid interface{} = orderID
+	if cloid(orderID) == "" {
+		n, err := strconv.ParseInt(orderID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID %q", orderID)
+		}
+		oid = n
+	}
+
+	raw, err := e.client.GetOrderStatus(ctx, user, oid)
+	if err != nil {
+		return nil, fmt.Errorf("get order status: %w", err)
+	}
+	var resp struct {
+		Status string         `json:"status"` // "order", or "unknownOid"
+		Order  *wsOrderUpdate `json:"order"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal order status: %w", err)
+	}
+	if resp.Status != "order" || resp.Order == nil {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+
+	order := resp.Order.Order.toEntity(orderStatus(resp.Order.Status))
+	order.UpdatedAt = time.UnixMilli(resp.Order.StatusTimestamp)
+	return order, nil
 }
 
-// GetOpenOrders retrieves all open orders
+// GetOpenOrders retrieves open orders for a symbol, or for all symbols when empty
 func (e *HyperliquidExchange) GetOpenOrders(ctx context.Context, symbol string) ([]*entity.Order, error) {
-	return nil, fmt.Errorf("not implemented")
+	user := e.client.User()
+	if user == "" {
+		return nil, fmt.Errorf("account address not configured")
+	}
+
+	result, err := e.client.GetOpenOrders(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("get open orders: %w", err)
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal open orders: %w", err)
+	}
+	var wire []wireOrder
+	if err := json.Unmarshal(raw, &wire); err != nil {
+		return nil, fmt.Errorf("unmarshal open orders: %w", err)
+	}
+
+	coin := entity.Symbol(symbol).Hyperliquid()
+	orders := make([]*entity.Order, 0, len(wire))
+	for _, w := range wire {
+		if symbol != "" && w.Coin != coin {
+			continue
+		}
+		order := w.toEntity(entity.OrderStatusOpen)
+		order.UpdatedAt = order.CreatedAt
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// assetPosition is an entry of clearinghouseState.assetPositions
+type assetPosition struct {
+	Position struct {
+		Coin          string `json:"coin"`
+		Szi           string `json:"szi"` // Signed size, negative when short
+		EntryPx       string `json:"entryPx"`
+		UnrealizedPnl string `json:"unrealizedPnl"`
+		Leverage      struct {
+			Value float64 `json:"value"`
+		} `json:"leverage"`
+	} `json:"position"`
 }
 
-// GetPosition retrieves current position
+// GetPosition retrieves the current position from the user state, or nil when flat.
+// Size is signed: positive for longs, negative for shorts.
 func (e *HyperliquidExchange) GetPosition(ctx context.Context, symbol string) (*entity.Position, error) {
-	return nil, fmt.Errorf("not implemented")
+	user := e.client.User()
+	if user == "" {
+		return nil, fmt.Errorf("account address not configured")
+	}
+
+	state, err := e.client.GetUserState(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("get user state: %w", err)
+	}
+
+	raw, err := json.Marshal(state["assetPositions"])
+	if err != nil {
+		return nil, fmt.Errorf("marshal assetPositions: %w", err)
+	}
+	var positions []assetPosition
+	if err := json.Unmarshal(raw, &positions); err != nil {
+		return nil, fmt.Errorf("unmarshal assetPositions: %w", err)
+	}
+
+	coin := entity.Symbol(symbol).Hyperliquid()
+	for _, ap := range positions {
+		p := ap.Position
+		if p.Coin != coin {
+			continue
+		}
+		size, err := strconv.ParseFloat(p.Szi, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse szi: %w", err)
+		}
+		if size == 0 {
+			return nil, nil
+		}
+		entry, _ := strconv.ParseFloat(p.EntryPx, 64)
+		upnl, _ := strconv.ParseFloat(p.UnrealizedPnl, 64)
+
+		side := entity.SideBuy
+		if size < 0 {
+			side = entity.SideSell
+		}
+		return &entity.Position{
+			Symbol:        symbol,
+			Side:          side,
+			Size:          size,
+			EntryPrice:    entry,
+			Leverage:      p.Leverage.Value,
+			UnrealizedPnL: upnl,
+			UpdatedAt:     time.Now(),
+		}, nil
+	}
+	return nil, nil
+}
+
+// GetAccountValue retrieves the account equity (marginSummary.accountValue)
+func (e *HyperliquidExchange) GetAccountValue(ctx context.Context) (float64, error) {
+	balance, err := e.GetBalance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return balance.Equity, nil
+}
+
+// GetBalance retrieves the account equity and margin usage from the
+// clearinghouse state, reusing the last one for BalanceTTL. The API key is the
+// account address on Hyperliquid; a vault address takes its place when set.
+func (e *HyperliquidExchange) GetBalance(ctx context.Context) (*entity.AccountBalance, error) {
+	e.balanceMu.Lock()
+	defer e.balanceMu.Unlock()
+	if e.balance != nil && time.Since(e.balanceAt) < e.config.BalanceTTL {
+		balance := *e.balance
+		return &balance, nil
+	}
+
+	user := e.client.User()
+	if user == "" {
+		return nil, fmt.Errorf("account address not configured")
+	}
+	state, err := e.client.GetUserState(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("get user state: %w", err)
+	}
+	balance, err := parseBalance(state)
+	if err != nil {
+		return nil, err
+	}
+
+	e.balance, e.balanceAt = balance, time.Now()
+	copied := *balance
+	return &copied, nil
+}
+
+// invalidateBalance makes the next GetBalance re-read the account, after
+// orders change the margin in use
+func (e *HyperliquidExchange) invalidateBalance() {
+	e.balanceMu.Lock()
+	e.balance = nil
+	e.balanceMu.Unlock()
+}
+
+// parseBalance reads the margin summary and withdrawable amount of a
+// clearinghouse state
+func parseBalance(state map[string]interface{}) (*entity.AccountBalance, error) {
+	summary, ok := state["marginSummary"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing marginSummary in user state")
+	}
+	if _, ok := summary["accountValue"].(string); !ok {
+		return nil, fmt.Errorf("missing accountValue in user state")
+	}
+
+	var balance entity.AccountBalance
+	for _, field := range []struct {
+		value interface{}
+		name  string
+		dst   *float64
+	}{
+		{summary["accountValue"], "accountValue", &balance.Equity},
+		{summary["totalMarginUsed"], "totalMarginUsed", &balance.MarginUsed},
+		{summary["totalNtlPos"], "totalNtlPos", &balance.Notional},
+		{state["withdrawable"], "withdrawable", &balance.Withdrawable},
+	} {
+		value, ok := field.value.(string)
+		if !ok {
+			continue // Only accountValue is required
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", field.name, err)
+		}
+		*field.dst = parsed
+	}
+	return &balance, nil
 }
 
 // GetTicker retrieves current ticker
@@ -163,10 +803,11 @@ func (e *HyperliquidExchange) GetOrderBook(ctx context.Context, symbol string, d
 	return nil, fmt.Errorf("not implemented")
 }
 
-// SubscribeTicker subscribes to ticker updates
+// SubscribeTicker subscribes to ticker updates; tickers carry the coin name as their symbol
 func (e *HyperliquidExchange) SubscribeTicker(ctx context.Context, symbol string, handler func(*entity.Ticker)) error {
+	coin := entity.Symbol(symbol).Hyperliquid()
 	e.handlerMu.Lock()
-	e.tickerHandlers[symbol] = append(e.tickerHandlers[symbol], handler)
+	e.tickerHandlers[coin] = append(e.tickerHandlers[coin], handler)
 	e.handlerMu.Unlock()
 
 	// Send subscription message
@@ -177,34 +818,63 @@ func (e *HyperliquidExchange) SubscribeTicker(ctx context.Context, symbol string
 		},
 	}
 
-	return e.wsSend(msg)
+	return e.subscribe(msg)
 }
 
 // SubscribeOrderBook subscribes to order book updates
 func (e *HyperliquidExchange) SubscribeOrderBook(ctx context.Context, symbol string, handler func(*entity.OrderBook)) error {
+	coin := entity.Symbol(symbol).Hyperliquid()
 	e.handlerMu.Lock()
-	e.orderbookHandlers[symbol] = append(e.orderbookHandlers[symbol], handler)
+	e.orderbookHandlers[coin] = append(e.orderbookHandlers[coin], handler)
 	e.handlerMu.Unlock()
 
 	msg := map[string]interface{}{
 		"method": "subscribe",
 		"subscription": map[string]interface{}{
 			"type": "l2Book",
-			"coin": symbol,
+			"coin": coin,
 		},
 	}
 
-	return e.wsSend(msg)
+	return e.subscribe(msg)
 }
 
-// SubscribeOrders subscribes to order updates
+// SubscribeOrders subscribes to order updates, and to the user's fills for
+// the fees they report. Without an account address there are no updates to
+// follow, so it warns and subscribes to nothing.
 func (e *HyperliquidExchange) SubscribeOrders(ctx context.Context, handler func(*entity.Order)) error {
+	user := e.client.User()
+	if user == "" {
+		e.log.Warn("Account address not configured, skipping order update subscription")
+		return nil
+	}
+
 	e.handlerMu.Lock()
 	e.orderHandlers = append(e.orderHandlers, handler)
 	e.handlerMu.Unlock()
 
-	// TODO: Implement user order subscription
-	return nil
+	// The API key is the account address on Hyperliquid
+	msg := map[string]interface{}{
+		"method": "subscribe",
+		"subscription": map[string]interface{}{
+			"type": "orderUpdates",
+			"user": user,
+		},
+	}
+	fills := map[string]interface{}{
+		"method": "subscribe",
+		"subscription": map[string]interface{}{
+			"type": "userFills",
+			"user": user,
+		},
+	}
+
+	// Both are remembered for reconnects even if sending one fails
+	err := e.subscribe(msg)
+	if fillsErr := e.subscribe(fills); err == nil {
+		err = fillsErr
+	}
+	return err
 }
 
 // wsSend sends a message via WebSocket
@@ -215,7 +885,7 @@ func (e *HyperliquidExchange) wsSend(msg interface{}) error {
 	e.wsMu.RUnlock()
 
 	if !connected || conn == nil {
-		return fmt.Errorf("websocket not connected")
+		return ErrNotConnected
 	}
 
 	data, err := json.Marshal(msg)
@@ -228,18 +898,15 @@ func (e *HyperliquidExchange) wsSend(msg interface{}) error {
 	return e.wsConn.WriteMessage(websocket.TextMessage, data)
 }
 
-// wsReadLoop reads messages from WebSocket
-func (e *HyperliquidExchange) wsReadLoop() {
+// wsReadLoop reads messages from one WebSocket connection until it fails or
+// done is closed. Each connection has its own loop, so a loop still in a
+// handler when the exchange reconnects never reads the new connection.
+func (e *HyperliquidExchange) wsReadLoop(conn *websocket.Conn, done chan struct{}) {
 	for {
 		e.wsMu.RLock()
-		conn := e.wsConn
-		done := e.wsDone
+		recorder := e.recorder
 		e.wsMu.RUnlock()
 
-		if conn == nil {
-			return
-		}
-
 		select {
 		case <-done:
 			return
@@ -251,15 +918,31 @@ func (e *HyperliquidExchange) wsReadLoop() {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				e.log.Error("WebSocket read error: %v", err)
 			}
+			e.wsMu.Lock()
+			if e.wsConn == conn {
+				e.wsConnected = false
+			}
+			e.wsMu.Unlock()
 			return
 		}
 
-		e.handleWSMessage(message)
+		received := time.Now()
+		if recorder != nil {
+			if err := recorder.Record(message, received); err != nil {
+				e.log.Warn("Failed to record WebSocket message: %v", err)
+			}
+		}
+		e.handleWSMessageAt(message, received)
 	}
 }
 
 // handleWSMessage processes incoming WebSocket messages
 func (e *HyperliquidExchange) handleWSMessage(data []byte) {
+	e.handleWSMessageAt(data, time.Now())
+}
+
+// handleWSMessageAt processes a WebSocket message received at the given time
+func (e *HyperliquidExchange) handleWSMessageAt(data []byte, received time.Time) {
 	var msg struct {
 		Channel string          `json:"channel"`
 		Data    json.RawMessage `json:"data"`
@@ -271,14 +954,18 @@ func (e *HyperliquidExchange) handleWSMessage(data []byte) {
 
 	switch msg.Channel {
 	case "allMids":
-		e.handleAllMids(msg.Data)
+		e.handleAllMids(msg.Data, received)
 	case "l2Book":
 		e.handleL2Book(msg.Data)
+	case "orderUpdates":
+		e.handleOrderUpdates(msg.Data)
+	case "userFills":
+		e.handleUserFills(msg.Data)
 	}
 }
 
-// handleAllMids processes ticker data
-func (e *HyperliquidExchange) handleAllMids(data json.RawMessage) {
+// handleAllMids processes ticker data; tickers are stamped with the receive time
+func (e *HyperliquidExchange) handleAllMids(data json.RawMessage, received time.Time) {
 	var midsData struct {
 		Mids map[string]string `json:"mids"`
 	}
@@ -303,7 +990,7 @@ func (e *HyperliquidExchange) handleAllMids(data json.RawMessage) {
 			LastPrice: mid,
 			BidPrice:  mid,
 			AskPrice:  mid,
-			Timestamp: time.Now(),
+			Timestamp: received,
 		}
 
 		for _, h := range handlers {
@@ -312,6 +999,231 @@ func (e *HyperliquidExchange) handleAllMids(data json.RawMessage) {
 	}
 }
 
+// wireOrder is an order as reported by order updates and openOrders
+type wireOrder struct {
+	Coin      string `json:"coin"`
+	Side      string `json:"side"` // "B" = bid (buy), "A" = ask (sell)
+	LimitPx   string `json:"limitPx"`
+	Sz        string `json:"sz"`     // Remaining size
+	OrigSz    string `json:"origSz"` // Original size
+	Oid       int64  `json:"oid"`
+	Timestamp int64  `json:"timestamp"`
+	Cloid     string `json:"cloid"`
+}
+
+// toEntity converts the wire order to a domain order with the given status
+func (w *wireOrder) toEntity(status entity.OrderStatus) *entity.Order {
+	price, _ := strconv.ParseFloat(w.LimitPx, 64)
+	remaining, _ := strconv.ParseFloat(w.Sz, 64)
+	quantity, _ := strconv.ParseFloat(w.OrigSz, 64)
+
+	side := entity.SideBuy
+	if w.Side == "A" {
+		side = entity.SideSell
+	}
+
+	return &entity.Order{
+		ID:            strconv.FormatInt(w.Oid, 10),
+		Symbol:        w.Coin,
+		Side:          side,
+		Type:          entity.OrderTypeLimit,
+		Price:         price,
+		Quantity:      quantity,
+		FilledQty:     quantity - remaining,
+		Status:        status,
+		ClientOrderID: w.Cloid,
+		CreatedAt:     time.UnixMilli(w.Timestamp),
+	}
+}
+
+// wsOrderUpdate is an entry of the orderUpdates channel
+type wsOrderUpdate struct {
+	Order           wireOrder `json:"order"`
+	Status          string    `json:"status"`
+	StatusTimestamp int64     `json:"statusTimestamp"`
+}
+
+// orderStatus maps a Hyperliquid order status to the domain status
+func orderStatus(status string) entity.OrderStatus {
+	switch status {
+	case "open", "triggered":
+		return entity.OrderStatusOpen
+	case "filled":
+		return entity.OrderStatusFilled
+	case "rejected":
+		return entity.OrderStatusRejected
+	default:
+		// canceled, marginCanceled, reduceOnlyCanceled, ...
+		return entity.OrderStatusCanceled
+	}
+}
+
+// handleOrderUpdates processes user order updates
+func (e *HyperliquidExchange) handleOrderUpdates(data json.RawMessage) {
+	var updates []wsOrderUpdate
+	if err := json.Unmarshal(data, &updates); err != nil {
+		e.log.Warn("Failed to parse order updates: %v", err)
+		return
+	}
+
+	for _, u := range updates {
+		order := u.Order.toEntity(orderStatus(u.Status))
+		order.UpdatedAt = time.UnixMilli(u.StatusTimestamp)
+
+		// Finished orders no longer need their coin for cancels or fill
+		// tracking, once their fills are in
+		e.orderMu.Lock()
+		fills := e.orderFills[order.ID]
+		if order.Status != entity.OrderStatusOpen {
+			delete(e.orderCoins, order.ID)
+			if fills != nil && !fills.covers(order) {
+				fills.held = order
+				e.orderMu.Unlock()
+				time.AfterFunc(e.fillWait, func() { e.releaseOrder(order.ID) })
+				continue
+			}
+			delete(e.orderFills, order.ID)
+		}
+		if fills != nil {
+			fills.apply(order)
+		}
+		e.orderMu.Unlock()
+
+		e.emitOrder(order)
+	}
+}
+
+// releaseOrder emits the held update of a finished order whose fills did not
+// all arrive within fillWait; the quantity they miss keeps the limit price
+func (e *HyperliquidExchange) releaseOrder(id string) {
+	e.orderMu.Lock()
+	fills := e.orderFills[id]
+	if fills == nil || fills.held == nil {
+		e.orderMu.Unlock()
+		return
+	}
+	delete(e.orderFills, id)
+	order := fills.held
+	fills.apply(order)
+	e.orderMu.Unlock()
+
+	e.log.Warn("Fills of order %s did not arrive within %s; pricing the rest at the limit", id, e.fillWait)
+	e.emitOrder(order)
+}
+
+// emitOrder passes an order update to the order handlers
+func (e *HyperliquidExchange) emitOrder(order *entity.Order) {
+	// Fills change the margin in use
+	if order.Status != entity.OrderStatusOpen {
+		e.invalidateBalance()
+	}
+
+	e.handlerMu.RLock()
+	handlers := e.orderHandlers
+	e.handlerMu.RUnlock()
+	for _, h := range handlers {
+		h(order)
+	}
+}
+
+// wsUserFills is a message of the userFills channel
+type wsUserFills struct {
+	IsSnapshot bool     `json:"isSnapshot"` // The recent fills sent on subscribing
+	Fills      []wsFill `json:"fills"`
+}
+
+// wsFill is a fill of the userFills channel
+type wsFill struct {
+	Coin string `json:"coin"`
+	Px   string `json:"px"`
+	Sz   string `json:"sz"`
+	Oid  int64  `json:"oid"`
+	Fee  string `json:"fee"` // In USDC; negative for maker rebates
+}
+
+// orderFills sums the fills of an order, which order updates do not carry:
+// they only report the limit price, which for market orders is marketSlippage
+// away from where they execute
+type orderFills struct {
+	size     float64
+	notional float64 // Sum of price times size
+	fee      float64
+
+	placed bool          // Placed by this process; other entries are pruned after unclaimedFillTTL
+	seen   time.Time     // Last fill
+	held   *entity.Order // Finished order update waiting for the rest of its fills
+}
+
+// covers reports whether the fills account for all of an order's filled quantity
+func (f *orderFills) covers(order *entity.Order) bool {
+	return f.size >= order.FilledQty*(1-1e-9)
+}
+
+// apply prices the filled quantity of an order update at its fills, and sets
+// the fee they paid. Quantity the fills do not cover yet keeps the limit price.
+func (f *orderFills) apply(order *entity.Order) {
+	order.Fee = f.fee
+	if f.size <= 0 || order.FilledQty <= 0 {
+		return
+	}
+	size := math.Min(f.size, order.FilledQty)
+	notional := f.notional * size / f.size
+	order.Price = (notional + order.Price*(order.FilledQty-size)) / order.FilledQty
+}
+
+// handleUserFills adds new fills to their unfinished orders, for the order
+// updates that follow, and emits held updates the fills complete. Fills of
+// orders that already finished are dropped; the snapshot is skipped.
+func (e *HyperliquidExchange) handleUserFills(data json.RawMessage) {
+	var msg wsUserFills
+	if err := json.Unmarshal(data, &msg); err != nil {
+		e.log.Warn("Failed to parse user fills: %v", err)
+		return
+	}
+	if msg.IsSnapshot {
+		return
+	}
+
+	now := time.Now()
+	var completed []*entity.Order
+	e.orderMu.Lock()
+	for id, fills := range e.orderFills {
+		if !fills.placed && now.Sub(fills.seen) > unclaimedFillTTL {
+			delete(e.orderFills, id)
+		}
+	}
+	for _, f := range msg.Fills {
+		id := strconv.FormatInt(f.Oid, 10)
+		fills := e.orderFills[id]
+		if fills == nil {
+			fills = &orderFills{}
+			e.orderFills[id] = fills
+		}
+		px, pxErr := strconv.ParseFloat(f.Px, 64)
+		sz, szErr := strconv.ParseFloat(f.Sz, 64)
+		fee, feeErr := strconv.ParseFloat(f.Fee, 64)
+		if err := errors.Join(pxErr, szErr, feeErr); err != nil {
+			e.log.Warn("Invalid fill of order %s: %v", id, err)
+			continue
+		}
+		fills.size += sz
+		fills.notional += px * sz
+		fills.fee += fee
+		fills.seen = now
+
+		if order := fills.held; order != nil && fills.covers(order) {
+			delete(e.orderFills, id)
+			fills.apply(order)
+			completed = append(completed, order)
+		}
+	}
+	e.orderMu.Unlock()
+
+	for _, order := range completed {
+		e.emitOrder(order)
+	}
+}
+
 // handleL2Book processes order book data
 func (e *HyperliquidExchange) handleL2Book(data json.RawMessage) {
 	var bookData struct {
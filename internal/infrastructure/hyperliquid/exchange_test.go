@@ -0,0 +1,647 @@
+package hyperliquid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestExchange_HandleOrderUpdates(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{APIKey: "0xabc"}, nil)
+
+	var got []*entity.Order
+	// Not connected, so the subscribe message fails but the handler is registered
+	e.SubscribeOrders(context.Background(), func(o *entity.Order) { got = append(got, o) })
+
+	e.handleWSMessage([]byte(`{"channel":"orderUpdates","data":[
+		{"order":{"coin":"BTC","side":"B","limitPx":"50000.5","sz":"0.04","origSz":"0.1","oid":123,"timestamp":1700000000000},"status":"open","statusTimestamp":1700000001000},
+		{"order":{"coin":"ETH","side":"A","limitPx":"3000","sz":"0","origSz":"2","oid":124,"timestamp":1700000000000,"cloid":"0x01"},"status":"filled","statusTimestamp":1700000002000},
+		{"order":{"coin":"BTC","side":"B","limitPx":"49000","sz":"0.1","origSz":"0.1","oid":125,"timestamp":1700000000000},"status":"marginCanceled","statusTimestamp":1700000003000}
+	]}`))
+
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 order updates, got %d", len(got))
+	}
+
+	partial := got[0]
+	if partial.ID != "123" || partial.Symbol != "BTC" || partial.Side != entity.SideBuy || partial.Status != entity.OrderStatusOpen {
+		t.Errorf("Unexpected open order: %+v", partial)
+	}
+	if partial.Price != 50000.5 || partial.Quantity != 0.1 || partial.FilledQty < 0.0599 || partial.FilledQty > 0.0601 {
+		t.Errorf("Expected 0.06 of 0.1 filled at 50000.5, got %f of %f at %f", partial.FilledQty, partial.Quantity, partial.Price)
+	}
+
+	filled := got[1]
+	if filled.Side != entity.SideSell || filled.Status != entity.OrderStatusFilled || filled.FilledQty != 2 || filled.ClientOrderID != "0x01" {
+		t.Errorf("Unexpected filled order: %+v", filled)
+	}
+	if got[2].Status != entity.OrderStatusCanceled {
+		t.Errorf("Expected margin cancel to map to canceled, got %s", got[2].Status)
+	}
+}
+
+func TestExchange_UserFillsSetOrderFees(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{APIKey: "0xabc"}, nil)
+	e.orderFills["123"] = &orderFills{} // Orders placed by this process
+
+	var got []*entity.Order
+	e.SubscribeOrders(context.Background(), func(o *entity.Order) { got = append(got, o) })
+
+	e.handleWSMessage([]byte(`{"channel":"userFills","data":{"isSnapshot":true,"user":"0xabc","fills":[
+		{"coin":"BTC","px":"50000","sz":"0.05","oid":123,"fee":"9.99","tid":1}
+	]}}`))
+	e.handleWSMessage([]byte(`{"channel":"userFills","data":{"user":"0xabc","fills":[
+		{"coin":"BTC","px":"50000","sz":"0.04","oid":123,"fee":"0.3","tid":2},
+		{"coin":"BTC","px":"50000","sz":"0.06","oid":123,"fee":"0.45","tid":3},
+		{"coin":"ETH","px":"3000","sz":"1","oid":999,"fee":"1.35","tid":4}
+	]}}`))
+	e.handleWSMessage([]byte(`{"channel":"orderUpdates","data":[
+		{"order":{"coin":"BTC","side":"B","limitPx":"50000","sz":"0","origSz":"0.1","oid":123,"timestamp":1700000000000},"status":"filled","statusTimestamp":1700000001000}
+	]}`))
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 order update, got %d", len(got))
+	}
+	if math.Abs(got[0].Fee-0.75) > 1e-9 {
+		t.Errorf("Expected the fees of both new fills (0.75), got %f", got[0].Fee)
+	}
+	if e.orderFills["123"] != nil {
+		t.Error("Expected fill tracking dropped once the order finished")
+	}
+}
+
+// marketAPI fills every order immediately at the given average prices, in turn
+type marketAPI struct {
+	avgPx []string
+	sent  []string // Limit prices of the orders placed
+}
+
+func (f *marketAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/info" {
+		w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5}]}`))
+		return
+	}
+	var req struct {
+		Action OrderAction `json:"action"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	f.sent = append(f.sent, req.Action.Orders[0].LimitPx)
+	n := len(f.sent)
+	fmt.Fprintf(w, `{"status":"ok","response":{"type":"order","data":{"statuses":[{"filled":{"oid":%d,"totalSz":"0.1","avgPx":%q}}]}}}`, n, f.avgPx[n-1])
+}
+
+func TestExchange_MarketFillsPricedAtExecution(t *testing.T) {
+	api := &marketAPI{avgPx: []string{"50000", "51000"}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APIKey: "0xabc", APISecret: testPrivateKey, Testnet: true}, nil)
+	ctx := context.Background()
+
+	var got []*entity.Order
+	e.SubscribeOrders(ctx, func(o *entity.Order) { got = append(got, o) })
+
+	// Buy and sell at marketSlippage through mids of 50000 and 51000; both
+	// execute at the mid, but order updates only carry the limit price
+	for _, side := range []entity.Side{entity.SideBuy, entity.SideSell} {
+		price := 50000.0
+		if side == entity.SideSell {
+			price = 51000
+		}
+		if _, err := e.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: side, Type: entity.OrderTypeMarket, Price: price, Quantity: 0.1}); err != nil {
+			t.Fatalf("PlaceOrder failed: %v", err)
+		}
+	}
+	if len(api.sent) != 2 || api.sent[0] != "52500" || api.sent[1] != "48450" {
+		t.Fatalf("Expected limits 5%% through the mids, got %v", api.sent)
+	}
+	e.handleWSMessage([]byte(`{"channel":"userFills","data":{"user":"0xabc","fills":[
+		{"coin":"BTC","px":"50000","sz":"0.1","oid":1,"fee":"2.25","tid":1},
+		{"coin":"BTC","px":"51000","sz":"0.1","oid":2,"fee":"2.3","tid":2}
+	]}}`))
+	e.handleWSMessage([]byte(`{"channel":"orderUpdates","data":[
+		{"order":{"coin":"BTC","side":"B","limitPx":"52500","sz":"0","origSz":"0.1","oid":1,"timestamp":1700000000000},"status":"filled","statusTimestamp":1700000001000},
+		{"order":{"coin":"BTC","side":"A","limitPx":"48450","sz":"0","origSz":"0.1","oid":2,"timestamp":1700000000000},"status":"filled","statusTimestamp":1700000002000}
+	]}`))
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 order updates, got %d", len(got))
+	}
+	if got[0].Price != 50000 || got[1].Price != 51000 {
+		t.Errorf("Expected fills at the mids 50000 and 51000, got %f and %f", got[0].Price, got[1].Price)
+	}
+	if got[0].Fee != 2.25 || got[1].Fee != 2.3 {
+		t.Errorf("Expected the reported fees of immediately filled orders, got %f and %f", got[0].Fee, got[1].Fee)
+	}
+	var pos entity.Position
+	for _, o := range got {
+		pos.ApplyFill(o.Side, o.FilledQty, o.Price)
+	}
+	if math.Abs(pos.RealizedPnL-100) > 1e-9 {
+		t.Errorf("Expected 100 realized from the mids, got %f", pos.RealizedPnL)
+	}
+}
+
+func TestExchange_FillsAndOrderUpdatesInAnyOrder(t *testing.T) {
+	const (
+		fill   = `{"channel":"userFills","data":{"user":"0xabc","fills":[{"coin":"BTC","px":"50000","sz":"0.1","oid":1,"fee":"2.25","tid":1}]}}`
+		update = `{"channel":"orderUpdates","data":[{"order":{"coin":"BTC","side":"B","limitPx":"52500","sz":"0","origSz":"0.1","oid":1,"timestamp":1700000000000},"status":"filled","statusTimestamp":1700000001000}]}`
+	)
+	newExchange := func(t *testing.T) (*HyperliquidExchange, chan *entity.Order) {
+		server := httptest.NewServer(&marketAPI{avgPx: []string{"50000"}})
+		t.Cleanup(server.Close)
+		e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APIKey: "0xabc", APISecret: testPrivateKey, Testnet: true}, nil)
+		got := make(chan *entity.Order, 1)
+		e.SubscribeOrders(context.Background(), func(o *entity.Order) { got <- o })
+		return e, got
+	}
+	place := func(t *testing.T, e *HyperliquidExchange) {
+		if _, err := e.PlaceOrder(context.Background(), &entity.Order{
+			Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Price: 50000, Quantity: 0.1,
+		}); err != nil {
+			t.Fatalf("PlaceOrder failed: %v", err)
+		}
+	}
+	expect := func(t *testing.T, got chan *entity.Order, price, fee float64) {
+		t.Helper()
+		select {
+		case o := <-got:
+			if o.Price != price || o.Fee != fee {
+				t.Errorf("Expected fill at %f with fee %f, got %f with fee %f", price, fee, o.Price, o.Fee)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the filled order update")
+		}
+	}
+
+	t.Run("fill before placement response", func(t *testing.T) {
+		e, got := newExchange(t)
+		e.handleWSMessage([]byte(fill))
+		place(t, e)
+		e.handleWSMessage([]byte(update))
+		expect(t, got, 50000, 2.25)
+	})
+
+	t.Run("fill after order update", func(t *testing.T) {
+		e, got := newExchange(t)
+		place(t, e)
+		e.handleWSMessage([]byte(update))
+		select {
+		case o := <-got:
+			t.Fatalf("Expected the update held for its fills, got %+v", o)
+		default:
+		}
+		e.handleWSMessage([]byte(fill))
+		expect(t, got, 50000, 2.25)
+		if len(e.orderFills) != 0 {
+			t.Errorf("Expected fill tracking dropped once emitted, got %v", e.orderFills)
+		}
+	})
+
+	t.Run("fill never arrives", func(t *testing.T) {
+		e, got := newExchange(t)
+		e.fillWait = 10 * time.Millisecond
+		place(t, e)
+		e.handleWSMessage([]byte(update))
+		expect(t, got, 52500, 0) // Released at the limit price
+	})
+}
+
+func TestExchange_SubscribeOrdersSkipsWithoutAddress(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{}, nil)
+	if err := e.SubscribeOrders(context.Background(), func(*entity.Order) {}); err != nil {
+		t.Errorf("Expected order subscription without an account address to be skipped, got %v", err)
+	}
+	if len(e.subscriptions) != 0 || len(e.orderHandlers) != 0 {
+		t.Errorf("Expected nothing subscribed, got %d subscriptions and %d handlers", len(e.subscriptions), len(e.orderHandlers))
+	}
+}
+
+func TestExchange_GetOrder(t *testing.T) {
+	var requests []OrderStatusRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OrderStatusRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		if req.Oid == float64(404) {
+			w.Write([]byte(`{"status":"unknownOid"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"order","order":{"order":{"coin":"BTC","side":"A","limitPx":"50000","sz":"0.4","origSz":"1","oid":77,"timestamp":1700000000000,"cloid":"0x0123456789abcdef0123456789abcdef"},"status":"open","statusTimestamp":1700000001000}}`))
+	}))
+	defer server.Close()
+	e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APIKey: "0xabc"}, nil)
+	ctx := context.Background()
+
+	order, err := e.GetOrder(ctx, "77")
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	if order.ID != "77" || order.Side != entity.SideSell || order.Status != entity.OrderStatusOpen || math.Abs(order.FilledQty-0.6) > 1e-9 {
+		t.Errorf("Expected open sell 77 with 0.6 filled, got %+v", order)
+	}
+	if !order.UpdatedAt.Equal(time.UnixMilli(1700000001000)) {
+		t.Errorf("Expected the status time as UpdatedAt, got %v", order.UpdatedAt)
+	}
+	if req := requests[0]; req.Type != "orderStatus" || req.User != "0xabc" || req.Oid != float64(77) {
+		t.Errorf("Expected an orderStatus request by oid, got %+v", req)
+	}
+
+	if _, err := e.GetOrder(ctx, "0x0123456789abcdef0123456789abcdef"); err != nil {
+		t.Fatalf("GetOrder by cloid failed: %v", err)
+	}
+	if req := requests[1]; req.Oid != "0x0123456789abcdef0123456789abcdef" {
+		t.Errorf("Expected an orderStatus request by cloid, got %+v", req)
+	}
+
+	if _, err := e.GetOrder(ctx, "404"); err == nil {
+		t.Error("Expected an unknown order to fail")
+	}
+	if _, err := e.GetOrder(ctx, "not-an-id"); err == nil {
+		t.Error("Expected an invalid order ID to fail")
+	}
+}
+
+func TestExchange_GetPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"assetPositions":[
+			{"type":"oneWay","position":{"coin":"ETH","szi":"2.5","entryPx":"3000","unrealizedPnl":"10","leverage":{"type":"cross","value":5}}},
+			{"type":"oneWay","position":{"coin":"BTC","szi":"-0.3","entryPx":"50000.5","unrealizedPnl":"-12.5","leverage":{"type":"cross","value":3}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APIKey: "0xabc"}, nil)
+	ctx := context.Background()
+
+	pos, err := e.GetPosition(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("GetPosition failed: %v", err)
+	}
+	if pos == nil || pos.Side != entity.SideSell || pos.Size != -0.3 || pos.EntryPrice != 50000.5 {
+		t.Fatalf("Expected 0.3 short at 50000.5, got %+v", pos)
+	}
+	if pos.Leverage != 3 || pos.UnrealizedPnL != -12.5 {
+		t.Errorf("Unexpected leverage or PnL: %+v", pos)
+	}
+
+	if pos, err := e.GetPosition(ctx, "SOL"); err != nil || pos != nil {
+		t.Errorf("Expected no position for SOL, got %+v, %v", pos, err)
+	}
+}
+
+// fakeAPI serves the info and exchange endpoints and records what it receives
+type fakeAPI struct {
+	infoUsers map[string]string // User of each info request by type
+	exchange  []json.RawMessage // Bodies posted to /exchange
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	json.NewDecoder(r.Body).Decode(&body)
+
+	if r.URL.Path == "/exchange" {
+		f.exchange = append(f.exchange, body)
+		w.Write([]byte(`{"status":"ok","response":{"type":"order","data":{"statuses":[{"resting":{"oid":77}}]}}}`))
+		return
+	}
+
+	var req InfoRequest
+	json.Unmarshal(body, &req)
+	f.infoUsers[req.Type] = req.User
+	switch req.Type {
+	case "meta":
+		w.Write([]byte(`{"universe":[{"name":"BTC","szDecimals":5},{"name":"ETH","szDecimals":4}]}`))
+	case "openOrders":
+		w.Write([]byte(`[{"coin":"ETH","side":"B","limitPx":"3000","sz":"1","origSz":"1","oid":77,"timestamp":1700000000000}]`))
+	default:
+		w.Write([]byte(`{"assetPositions":[]}`))
+	}
+}
+
+func TestExchange_VaultAddress(t *testing.T) {
+	const (
+		account = "0x0000000000000000000000000000000000000abc"
+		vault   = "0x1719884eb866cb12b2287399b15f7db5e7d775ea"
+	)
+
+	for _, tt := range []struct {
+		name      string
+		vault     string
+		wantUser  string
+		wantVault string
+	}{
+		{"main account", "", account, ""},
+		{"vault", vault, vault, vault},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{infoUsers: make(map[string]string)}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			e := NewHyperliquidExchange(&ExchangeConfig{
+				BaseURL:      server.URL,
+				APIKey:       account,
+				APISecret:    testPrivateKey,
+				Testnet:      true,
+				VaultAddress: tt.vault,
+			}, nil)
+			ctx := context.Background()
+
+			placed, err := e.PlaceOrder(ctx, &entity.Order{
+				Symbol: "ETH", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 3000, Quantity: 1,
+			})
+			if err != nil {
+				t.Fatalf("PlaceOrder failed: %v", err)
+			}
+			if placed.ID != "77" || placed.Status != entity.OrderStatusOpen {
+				t.Errorf("Expected resting order 77, got %+v", placed)
+			}
+
+			if len(api.exchange) != 1 {
+				t.Fatalf("Expected one exchange request, got %d", len(api.exchange))
+			}
+			var sent struct {
+				Action       json.RawMessage `json:"action"`
+				Nonce        uint64          `json:"nonce"`
+				Signature    Signature       `json:"signature"`
+				VaultAddress *string         `json:"vaultAddress"`
+			}
+			if err := json.Unmarshal(api.exchange[0], &sent); err != nil {
+				t.Fatalf("Invalid exchange request: %v", err)
+			}
+			if tt.wantVault == "" && sent.VaultAddress != nil {
+				t.Errorf("Expected no vaultAddress for the main account, got %q", *sent.VaultAddress)
+			}
+			if tt.wantVault != "" && (sent.VaultAddress == nil || *sent.VaultAddress != tt.wantVault) {
+				t.Errorf("Expected vaultAddress %s, got %v", tt.wantVault, sent.VaultAddress)
+			}
+
+			// The signature must commit to the vault address
+			action := OrderAction{
+				Type: "order",
+				Orders: []OrderWire{{
+					Asset: 1, IsBuy: true, LimitPx: "3000", Size: "1",
+					OrderType: OrderTypeWire{Limit: &LimitOrderType{Tif: "Gtc"}},
+				}},
+				Grouping: "na",
+			}
+			if want, _ := json.Marshal(action); string(want) != string(sent.Action) {
+				t.Errorf("Expected action %s, got %s", want, sent.Action)
+			}
+			signer, _ := NewSigner(testPrivateKey, false)
+			want, err := signer.Sign(action, sent.Nonce, tt.wantVault)
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			if sent.Signature != *want {
+				t.Errorf("Expected signature over vault %q, got %+v", tt.wantVault, sent.Signature)
+			}
+
+			if _, err := e.GetPosition(ctx, "ETH"); err != nil {
+				t.Fatalf("GetPosition failed: %v", err)
+			}
+			if _, err := e.GetOpenOrders(ctx, "ETH"); err != nil {
+				t.Fatalf("GetOpenOrders failed: %v", err)
+			}
+			for _, typ := range []string{"clearinghouseState", "openOrders"} {
+				if got := api.infoUsers[typ]; got != tt.wantUser {
+					t.Errorf("Expected %s for user %s, got %s", typ, tt.wantUser, got)
+				}
+			}
+		})
+	}
+}
+
+func TestExchange_PlaceOrderRequiresSecret(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{APIKey: "0xabc"}, nil)
+	if _, err := e.PlaceOrder(context.Background(), &entity.Order{Symbol: "BTC", Quantity: 1}); err == nil {
+		t.Error("Expected order placement without an API secret to fail")
+	}
+}
+
+func TestExchange_GetBalance(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{
+			"marginSummary":{"accountValue":"10250.75","totalNtlPos":"30000.0","totalRawUsd":"-19749.25","totalMarginUsed":"3000.0"},
+			"crossMarginSummary":{"accountValue":"10250.75","totalNtlPos":"30000.0","totalRawUsd":"-19749.25","totalMarginUsed":"3000.0"},
+			"withdrawable":"7250.75",
+			"assetPositions":[]
+		}`))
+	}))
+	defer server.Close()
+	e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, APIKey: "0xabc", BalanceTTL: time.Minute}, nil)
+	ctx := context.Background()
+
+	balance, err := e.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	want := entity.AccountBalance{Equity: 10250.75, MarginUsed: 3000, Notional: 30000, Withdrawable: 7250.75}
+	if *balance != want {
+		t.Errorf("Expected %+v, got %+v", want, *balance)
+	}
+	if equity, err := e.GetAccountValue(ctx); err != nil || equity != 10250.75 {
+		t.Errorf("Expected equity 10250.75, got %v (%v)", equity, err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the balance to be cached within the TTL, got %d requests", requests)
+	}
+
+	// Fills change the margin in use, so the next read goes to the API
+	e.handleOrderUpdates([]byte(`[{"order":{"coin":"BTC","side":"B","limitPx":"50000","sz":"0","oid":1,"origSz":"0.1"},"status":"filled","statusTimestamp":1700000000000}]`))
+	if _, err := e.GetBalance(ctx); err != nil || requests != 2 {
+		t.Errorf("Expected a fill to invalidate the cache, got %d requests (%v)", requests, err)
+	}
+}
+
+func TestParseBalance_MissingAccountValue(t *testing.T) {
+	if _, err := parseBalance(map[string]interface{}{"marginSummary": map[string]interface{}{}}); err == nil {
+		t.Error("Expected an error without accountValue")
+	}
+	if _, err := parseBalance(map[string]interface{}{"marginSummary": map[string]interface{}{"accountValue": "abc"}}); err == nil {
+		t.Error("Expected an error for an unparsable accountValue")
+	}
+}
+
+func TestExchange_OrderLimits(t *testing.T) {
+	server := httptest.NewServer(&fakeAPI{infoUsers: make(map[string]string)})
+	defer server.Close()
+	e := NewHyperliquidExchange(&ExchangeConfig{BaseURL: server.URL, Testnet: true}, nil)
+
+	limits, err := e.OrderLimits(context.Background(), "ETH")
+	if err != nil {
+		t.Fatalf("OrderLimits failed: %v", err)
+	}
+	if limits.MinNotional != 10 || math.Abs(limits.MinSize-0.0001) > 1e-12 {
+		t.Errorf("Expected $10 minimum and 0.0001 size increment, got %+v", limits)
+	}
+	if _, err := e.OrderLimits(context.Background(), "DOGE"); err == nil {
+		t.Error("Expected an error for an unknown asset")
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		price      float64
+		szDecimals int
+		want       string
+	}{
+		{50123.456, 5, "50123"},
+		{3000.123, 4, "3000.1"},
+		{1.234567, 0, "1.2346"},
+		{0.00012345678, 0, "0.000123"},
+	}
+	for _, tt := range tests {
+		if got := formatPrice(tt.price, tt.szDecimals); got != tt.want {
+			t.Errorf("formatPrice(%v, %d) = %s, want %s", tt.price, tt.szDecimals, got, tt.want)
+		}
+	}
+}
+
+func TestExchange_ReconnectResubscribes(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	received := make(chan string, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}))
+	defer server.Close()
+
+	e := NewHyperliquidExchange(&ExchangeConfig{WSURL: "ws" + strings.TrimPrefix(server.URL, "http")}, nil)
+	ctx := context.Background()
+	if err := e.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer e.Disconnect(ctx)
+
+	var ticks int
+	// Two symbols share the allMids subscription
+	e.SubscribeTicker(ctx, "BTC", func(*entity.Ticker) { ticks++ })
+	e.SubscribeTicker(ctx, "ETH", func(*entity.Ticker) {})
+	e.SubscribeOrderBook(ctx, "BTC", func(*entity.OrderBook) {})
+	for i := 0; i < 3; i++ {
+		<-received
+	}
+
+	if err := e.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	if !e.Connected() {
+		t.Error("Expected the exchange to be connected after reconnecting")
+	}
+	var resent []string
+	for len(resent) < 2 {
+		select {
+		case msg := <-received:
+			resent = append(resent, msg)
+		case <-time.After(time.Second):
+			t.Fatalf("Expected allMids and l2Book to be re-sent, got %v", resent)
+		}
+	}
+	if !strings.Contains(resent[0], "allMids") || !strings.Contains(resent[1], "l2Book") {
+		t.Errorf("Expected the subscriptions re-sent in order, got %v", resent)
+	}
+	select {
+	case msg := <-received:
+		t.Errorf("Expected each subscription re-sent once, also got %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Handlers are not duplicated by the reconnect
+	e.handleWSMessage([]byte(`{"channel":"allMids","data":{"mids":{"BTC":"50000"}}}`))
+	if ticks != 1 {
+		t.Errorf("Expected one ticker callback, got %d", ticks)
+	}
+}
+
+func TestExchange_ReconnectWhileHandlerBlocked(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	conns := make(chan *websocket.Conn, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- conn
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	e := NewHyperliquidExchange(&ExchangeConfig{WSURL: "ws" + strings.TrimPrefix(server.URL, "http")}, nil)
+	ctx := context.Background()
+	if err := e.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer e.Disconnect(ctx)
+
+	blocked, release := make(chan struct{}), make(chan struct{})
+	ticks := make(chan float64, 4)
+	first := true
+	e.SubscribeTicker(ctx, "BTC", func(ticker *entity.Ticker) {
+		if first {
+			first = false
+			close(blocked)
+			<-release
+		}
+		ticks <- ticker.LastPrice
+	})
+	send := func(conn *websocket.Conn, mid string) {
+		msg := `{"channel":"allMids","data":{"mids":{"BTC":"` + mid + `"}}}`
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+
+	// The first connection's loop is stuck in the handler across the reconnect
+	send(<-conns, "1")
+	<-blocked
+	if err := e.Reconnect(ctx); err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	close(release)
+	if got := <-ticks; got != 1 {
+		t.Fatalf("Expected the blocked tick, got %f", got)
+	}
+
+	// Only the new connection's loop reads it; under -race a second reader
+	// on the connection is reported
+	conn := <-conns
+	for _, mid := range []string{"2", "3"} {
+		send(conn, mid)
+	}
+	for _, want := range []float64{2, 3} {
+		select {
+		case got := <-ticks:
+			if got != want {
+				t.Errorf("Expected tick %f, got %f", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected tick %f from the new connection", want)
+		}
+	}
+}
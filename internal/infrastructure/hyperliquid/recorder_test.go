@@ -0,0 +1,176 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// recordedSession is a live session's messages, including the non-JSON greeting
+var recordedSession = []string{
+	`Websocket connection established.`,
+	`{"channel":"subscriptionResponse","data":{"method":"subscribe"}}`,
+	`{"channel":"allMids","data":{"mids":{"BTC":"50000.5","ETH":"3000"}}}`,
+	`{"channel":"allMids","data":{"mids":{"BTC":"50010","ETH":"3001.25"}}}`,
+	`{"channel":"allMids","data":{"mids":{"BTC":"49990"}}}`,
+}
+
+// tickerExchange returns an exchange recording the BTC and ETH tickers it handles
+func tickerExchange(t *testing.T) (*HyperliquidExchange, *[]entity.Ticker) {
+	t.Helper()
+	e := NewHyperliquidExchange(&ExchangeConfig{}, nil)
+	var got []entity.Ticker
+	for _, symbol := range []string{"BTC", "ETH"} {
+		// Not connected, so the subscribe message fails but the handler is registered
+		e.SubscribeTicker(context.Background(), symbol, func(tk *entity.Ticker) { got = append(got, *tk) })
+	}
+	return e, &got
+}
+
+// sortTickers orders tickers by time then symbol, as allMids handlers run in map order
+func sortTickers(tickers []entity.Ticker) []entity.Ticker {
+	sorted := append([]entity.Ticker(nil), tickers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].Timestamp.Equal(sorted[j].Timestamp) {
+			return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+		}
+		return sorted[i].Symbol < sorted[j].Symbol
+	})
+	return sorted
+}
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	live, liveTickers := tickerExchange(t)
+	var recording bytes.Buffer
+	recorder := NewRecorder(&recording)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, msg := range recordedSession {
+		// As the read loop does: record, then handle
+		received := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		if err := recorder.Record([]byte(msg), received); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+		live.handleWSMessageAt([]byte(msg), received)
+	}
+	if len(*liveTickers) != 5 {
+		t.Fatalf("Expected 5 live tickers, got %d", len(*liveTickers))
+	}
+
+	replayed, replayTickers := tickerExchange(t)
+	n, err := replayed.Replay(context.Background(), &recording, 0)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if n != len(recordedSession) {
+		t.Errorf("Expected %d messages replayed, got %d", len(recordedSession), n)
+	}
+	if want, got := sortTickers(*liveTickers), sortTickers(*replayTickers); !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected replay to reproduce the live tickers\nlive:   %+v\nreplay: %+v", want, got)
+	}
+}
+
+func TestReplay_Speed(t *testing.T) {
+	var recording bytes.Buffer
+	recorder := NewRecorder(&recording)
+	start := time.Now()
+	for i, msg := range recordedSession[2:] {
+		recorder.Record([]byte(msg), start.Add(time.Duration(i)*200*time.Millisecond))
+	}
+	data := recording.String()
+
+	e, _ := tickerExchange(t)
+	began := time.Now()
+	if _, err := e.Replay(context.Background(), strings.NewReader(data), 10); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	// 400ms of recorded gaps at 10x
+	if elapsed := time.Since(began); elapsed < 40*time.Millisecond || elapsed > 300*time.Millisecond {
+		t.Errorf("Expected a 10x replay to take about 40ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if n, err := e.Replay(ctx, strings.NewReader(data), 1); err == nil || n != 0 {
+		t.Errorf("Expected a canceled replay to stop, got %d, %v", n, err)
+	}
+}
+
+func TestReplay_Offline(t *testing.T) {
+	e := NewHyperliquidExchange(&ExchangeConfig{Offline: true}, nil)
+	ctx := context.Background()
+	if err := e.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	var got []entity.Ticker
+	if err := e.SubscribeTicker(ctx, "BTC", func(tk *entity.Ticker) { got = append(got, *tk) }); err != nil {
+		t.Fatalf("Expected an offline subscribe to succeed, got %v", err)
+	}
+	if !e.Connected() {
+		t.Error("Expected an offline exchange to report connected")
+	}
+	if err := e.Reconnect(ctx); err != nil || !e.Connected() {
+		t.Errorf("Expected an offline reconnect to be a no-op, got %v", err)
+	}
+
+	if _, err := e.Replay(ctx, strings.NewReader(recordTickers(t)), 0); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected the 3 recorded BTC tickers, got %d", len(got))
+	}
+
+	e.Disconnect(ctx)
+	if e.Connected() {
+		t.Error("Expected an offline exchange to report disconnected after Disconnect")
+	}
+}
+
+// recordTickers returns a recording of the session's allMids messages
+func recordTickers(t *testing.T) string {
+	t.Helper()
+	var recording bytes.Buffer
+	recorder := NewRecorder(&recording)
+	start := time.Now()
+	for i, msg := range recordedSession[2:] {
+		if err := recorder.Record([]byte(msg), start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+	return recording.String()
+}
+
+func TestReplay_InvalidLine(t *testing.T) {
+	e, _ := tickerExchange(t)
+	if _, err := e.Replay(context.Background(), strings.NewReader("\nnot json\n"), 0); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected a parse error naming line 2, got %v", err)
+	}
+}
+
+func TestOpenRecorder_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ws.jsonl")
+	for i := 0; i < 2; i++ {
+		r, err := OpenRecorder(path)
+		if err != nil {
+			t.Fatalf("OpenRecorder failed: %v", err)
+		}
+		r.Record([]byte(recordedSession[2]), time.Now())
+		if err := r.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		if err := r.Record([]byte(recordedSession[2]), time.Now()); err != nil {
+			t.Errorf("Expected Record after Close to be a no-op, got %v", err)
+		}
+	}
+
+	e, tickers := tickerExchange(t)
+	if n, err := e.ReplayFile(context.Background(), path, 0); err != nil || n != 2 || len(*tickers) != 4 {
+		t.Errorf("Expected both sessions appended (2 messages, 4 tickers), got %d, %d, %v", n, len(*tickers), err)
+	}
+}
@@ -0,0 +1,158 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/vmihailenco/msgpack/v5"
+	"golang.org/x/crypto/sha3"
+)
+
+// EIP-712 domain Hyperliquid uses for L1 actions on both mainnet and testnet;
+// the network is selected by the phantom agent source instead
+const (
+	domainName    = "Exchange"
+	domainVersion = "1"
+	domainChainID = 1337
+)
+
+// Phantom agent sources
+const (
+	sourceMainnet = "a"
+	sourceTestnet = "b"
+)
+
+var (
+	domainTypeHash = keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	agentTypeHash  = keccak256([]byte("Agent(string source,bytes32 connectionId)"))
+)
+
+// Signature is the {r,s,v} signature Hyperliquid expects with an action
+type Signature struct {
+	R string `json:"r"`
+	S string `json:"s"`
+	V byte   `json:"v"`
+}
+
+// Signer signs Hyperliquid L1 actions (orders, cancels, leverage updates)
+// with the account's private key
+type Signer struct {
+	key     *secp256k1.PrivateKey
+	mainnet bool
+
+	mu        sync.Mutex
+	lastNonce uint64
+	now       func() time.Time
+}
+
+// NewSigner creates a signer from a hex private key (with or without 0x)
+func NewSigner(privateKeyHex string, mainnet bool) (*Signer, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode private key: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("private key must be 32 bytes, got %d", len(raw))
+	}
+	return &Signer{
+		key:     secp256k1.PrivKeyFromBytes(raw),
+		mainnet: mainnet,
+		now:     time.Now,
+	}, nil
+}
+
+// Address returns the account address derived from the private key
+func (s *Signer) Address() string {
+	pub := s.key.PubKey().SerializeUncompressed()
+	return "0x" + hex.EncodeToString(keccak256(pub[1:])[12:])
+}
+
+// NextNonce returns the current time in milliseconds, bumped when needed so
+// nonces are strictly increasing as Hyperliquid requires
+func (s *Signer) NextNonce() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nonce := uint64(s.now().UnixMilli())
+	if nonce <= s.lastNonce {
+		nonce = s.lastNonce + 1
+	}
+	s.lastNonce = nonce
+	return nonce
+}
+
+// Sign signs an action for the given nonce. vaultAddress is empty when trading
+// for the main account.
+func (s *Signer) Sign(action interface{}, nonce uint64, vaultAddress string) (*Signature, error) {
+	hash, err := ActionHash(action, nonce, vaultAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	source := sourceTestnet
+	if s.mainnet {
+		source = sourceMainnet
+	}
+	digest := agentDigest(source, hash)
+
+	// Compact format: <27 + recovery id><32-byte R><32-byte S>
+	sig := ecdsa.SignCompact(s.key, digest, false)
+	return &Signature{
+		R: "0x" + hex.EncodeToString(sig[1:33]),
+		S: "0x" + hex.EncodeToString(sig[33:65]),
+		V: sig[0],
+	}, nil
+}
+
+// ActionHash returns the connection ID signed for an action:
+// keccak256(msgpack(action) || nonce || vault flag [|| vault address])
+func ActionHash(action interface{}, nonce uint64, vaultAddress string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(action); err != nil {
+		return nil, fmt.Errorf("msgpack action: %w", err)
+	}
+
+	binary.Write(&buf, binary.BigEndian, nonce)
+	if vaultAddress == "" {
+		buf.WriteByte(0)
+	} else {
+		vault, err := hex.DecodeString(strings.TrimPrefix(vaultAddress, "0x"))
+		if err != nil || len(vault) != 20 {
+			return nil, fmt.Errorf("invalid vault address: %s", vaultAddress)
+		}
+		buf.WriteByte(1)
+		buf.Write(vault)
+	}
+
+	return keccak256(buf.Bytes()), nil
+}
+
+// agentDigest returns the EIP-712 digest of the phantom agent {source, connectionId}
+func agentDigest(source string, connectionID []byte) []byte {
+	chainID := make([]byte, 32)
+	binary.BigEndian.PutUint64(chainID[24:], domainChainID)
+	verifyingContract := make([]byte, 32) // Zero address
+
+	domainSeparator := keccak256(domainTypeHash, keccak256([]byte(domainName)),
+		keccak256([]byte(domainVersion)), chainID, verifyingContract)
+	structHash := keccak256(agentTypeHash, keccak256([]byte(source)), connectionID)
+
+	return keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+// keccak256 hashes the concatenation of data with Ethereum's Keccak-256
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
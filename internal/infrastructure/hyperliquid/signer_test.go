@@ -0,0 +1,111 @@
+package hyperliquid
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+// Vectors from the Hyperliquid Python SDK signing tests
+const testPrivateKey = "0x0123456789012345678901234567890123456789012345678901234567890123"
+
+func TestActionHash_Order(t *testing.T) {
+	action := OrderAction{
+		Type: "order",
+		Orders: []OrderWire{{
+			Asset:     4,
+			IsBuy:     true,
+			LimitPx:   "1670.1",
+			Size:      "0.0147",
+			OrderType: OrderTypeWire{Limit: &LimitOrderType{Tif: "Ioc"}},
+		}},
+		Grouping: "na",
+	}
+
+	hash, err := ActionHash(action, 1677777606040, "")
+	if err != nil {
+		t.Fatalf("ActionHash failed: %v", err)
+	}
+	if got, want := hex.EncodeToString(hash), "0fcbeda5ae3c4950a548021552a4fea2226858c4453571bf3f24ba017eac2908"; got != want {
+		t.Errorf("Expected action hash %s, got %s", want, got)
+	}
+}
+
+// dummyAction is the minimal action used by the SDK signing tests
+type dummyAction struct {
+	Type string `msgpack:"type"`
+	Num  int64  `msgpack:"num"`
+}
+
+func TestSigner_Sign(t *testing.T) {
+	action := dummyAction{Type: "dummy", Num: 100000000000}
+
+	tests := []struct {
+		name    string
+		mainnet bool
+		want    Signature
+	}{
+		{"mainnet", true, Signature{
+			R: "0x053749d5b30552aeb2fca34b530185976545bb22d0b3ce6f62e31be961a59298",
+			S: "0x755c40ba9bf05223521753995abb2f73ab3229be8ec921f350cb447e384d8ed8",
+			V: 27,
+		}},
+		{"testnet", false, Signature{
+			R: "0x542af61ef1f429707e3c76c5293c80d01f74ef853e34b76efffcb57e574f9510",
+			S: "0x17b8b32f086e8cdede991f1e2c529f5dd5297cbe8128500e00cbaf766204a613",
+			V: 28,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewSigner(testPrivateKey, tt.mainnet)
+			if err != nil {
+				t.Fatalf("NewSigner failed: %v", err)
+			}
+			sig, err := signer.Sign(action, 0, "")
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+			if *sig != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, *sig)
+			}
+		})
+	}
+}
+
+func TestActionHash_Vault(t *testing.T) {
+	action := dummyAction{Type: "dummy", Num: 1}
+
+	main, _ := ActionHash(action, 1, "")
+	vault, err := ActionHash(action, 1, "0x1719884eb866cb12b2287399b15f7db5e7d775ea")
+	if err != nil {
+		t.Fatalf("ActionHash with vault failed: %v", err)
+	}
+	if hex.EncodeToString(main) == hex.EncodeToString(vault) {
+		t.Error("Expected vault address to change the action hash")
+	}
+	if _, err := ActionHash(action, 1, "0x1234"); err == nil {
+		t.Error("Expected invalid vault address to be rejected")
+	}
+}
+
+func TestSigner_NextNonceIncreases(t *testing.T) {
+	signer, _ := NewSigner(testPrivateKey, false)
+	now := time.UnixMilli(1700000000000)
+	signer.now = func() time.Time { return now }
+
+	first := signer.NextNonce()
+	second := signer.NextNonce()
+	if first != 1700000000000 || second != first+1 {
+		t.Errorf("Expected nonces 1700000000000 then +1, got %d, %d", first, second)
+	}
+}
+
+func TestNewSigner_InvalidKey(t *testing.T) {
+	for _, key := range []string{"", "0xzz", "0x0123"} {
+		if _, err := NewSigner(key, true); err == nil {
+			t.Errorf("Expected key %q to be rejected", key)
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -50,6 +53,34 @@ func ParseLevel(s string) Level {
 	}
 }
 
+// Format represents log output format
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatText
+)
+
+// ParseFormat parses log format from string (defaults to JSON)
+func ParseFormat(s string) Format {
+	switch s {
+	case "text", "TEXT", "console":
+		return FormatText
+	default:
+		return FormatJSON
+	}
+}
+
+// ANSI colors per level for text output
+var levelColors = map[Level]string{
+	LevelDebug: "\033[90m",
+	LevelInfo:  "\033[36m",
+	LevelWarn:  "\033[33m",
+	LevelError: "\033[31m",
+}
+
+const colorReset = "\033[0m"
+
 // Entry represents a log entry
 type Entry struct {
 	Time    time.Time              `json:"time"`
@@ -61,56 +92,83 @@ type Entry struct {
 // Logger provides structured logging
 type Logger struct {
 	mu     sync.Mutex
-	level  Level
+	level  *atomic.Int32 // Shared with derived loggers so SetLevel applies to all
+	format Format
+	color  bool // Colorize text output (enabled for terminals)
 	output io.Writer
 	fields map[string]interface{}
 }
 
-// New creates a new logger
+// New creates a new JSON logger
 func New(level Level, output io.Writer) *Logger {
+	return NewWithFormat(level, FormatJSON, output)
+}
+
+// NewWithFormat creates a new logger with the given output format
+func NewWithFormat(level Level, format Format, output io.Writer) *Logger {
 	if output == nil {
 		output = os.Stdout
 	}
-	return &Logger{
-		level:  level,
+	l := &Logger{
+		level:  new(atomic.Int32),
+		format: format,
+		color:  format == FormatText && isTerminal(output),
 		output: output,
 		fields: make(map[string]interface{}),
 	}
+	l.level.Store(int32(level))
+	return l
+}
+
+// SetLevel changes the minimum level of this logger and all loggers derived from it
+func (l *Logger) SetLevel(level Level) {
+	l.level.Store(int32(level))
+}
+
+// isTerminal reports whether w is a character device such as a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
 
 // WithField returns a new logger with the field added
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	newLogger := &Logger{
-		level:  l.level,
-		output: l.output,
-		fields: make(map[string]interface{}),
-	}
-	for k, v := range l.fields {
-		newLogger.fields[k] = v
-	}
+	newLogger := l.clone()
 	newLogger.fields[key] = value
 	return newLogger
 }
 
 // WithFields returns a new logger with the fields added
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	newLogger := l.clone()
+	for k, v := range fields {
+		newLogger.fields[k] = v
+	}
+	return newLogger
+}
+
+// clone returns a copy of the logger with its own fields map
+func (l *Logger) clone() *Logger {
 	newLogger := &Logger{
 		level:  l.level,
+		format: l.format,
+		color:  l.color,
 		output: l.output,
-		fields: make(map[string]interface{}),
+		fields: make(map[string]interface{}, len(l.fields)+1),
 	}
 	for k, v := range l.fields {
 		newLogger.fields[k] = v
 	}
-	for k, v := range fields {
-		newLogger.fields[k] = v
-	}
 	return newLogger
 }
 
 // log writes a log entry
 func (l *Logger) log(level Level, msg string, args ...interface{}) {
-	if level < l.level {
+	if level < Level(l.level.Load()) {
 		return
 	}
 
@@ -127,6 +185,11 @@ func (l *Logger) log(level Level, msg string, args ...interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	if l.format == FormatText {
+		fmt.Fprintln(l.output, l.formatText(level, entry))
+		return
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return
@@ -135,6 +198,38 @@ func (l *Logger) log(level Level, msg string, args ...interface{}) {
 	fmt.Fprintln(l.output, string(data))
 }
 
+// formatText renders an entry as "time LEVEL message key=value ..."
+func (l *Logger) formatText(level Level, entry Entry) string {
+	var b strings.Builder
+	b.WriteString(entry.Time.Format("2006-01-02T15:04:05.000Z07:00"))
+	b.WriteByte(' ')
+
+	levelStr := fmt.Sprintf("%-5s", entry.Level)
+	if l.color {
+		levelStr = levelColors[level] + levelStr + colorReset
+	}
+	b.WriteString(levelStr)
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := fmt.Sprint(entry.Fields[k])
+		if strings.ContainsAny(value, " \t\n\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		b.WriteByte(' ')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(msg string, args ...interface{}) {
 	l.log(LevelDebug, msg, args...)
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(LevelInfo, &buf).WithField("component", "test")
+
+	log.Debug("hidden")
+	log.Info("hello %s", "world")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line (debug filtered), got %d: %q", len(lines), buf.String())
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if entry.Level != "INFO" || entry.Message != "hello world" || entry.Fields["component"] != "test" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+}
+
+func TestLogger_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithFormat(LevelDebug, FormatText, &buf).
+		WithFields(map[string]interface{}{"symbol": "BTC", "reason": "two words"})
+
+	log.Warn("order %d rejected", 7)
+
+	line := strings.TrimSpace(buf.String())
+	pattern := `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z WARN  order 7 rejected reason="two words" symbol=BTC$`
+	if !regexp.MustCompile(pattern).MatchString(line) {
+		t.Errorf("Unexpected text line: %q", line)
+	}
+	if strings.Contains(line, "\033[") {
+		t.Error("Expected no color codes when not writing to a terminal")
+	}
+}
+
+func TestLogger_TextFormat_Color(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithFormat(LevelInfo, FormatText, &buf)
+	log.color = true
+
+	log.Error("boom")
+
+	if !strings.Contains(buf.String(), levelColors[LevelError]+"ERROR"+colorReset) {
+		t.Errorf("Expected colorized level, got %q", buf.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if ParseFormat("text") != FormatText {
+		t.Error("Expected text format")
+	}
+	if ParseFormat("json") != FormatJSON || ParseFormat("") != FormatJSON {
+		t.Error("Expected JSON format by default")
+	}
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(LevelInfo, &buf)
+	child := root.WithField("component", "test")
+
+	child.Debug("hidden")
+	root.SetLevel(LevelDebug)
+	child.Debug("visible")
+
+	if out := buf.String(); strings.Contains(out, "hidden") || !strings.Contains(out, "visible") {
+		t.Errorf("Expected SetLevel to apply to derived loggers, got %q", out)
+	}
+}
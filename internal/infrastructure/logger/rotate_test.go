@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "bot.log")
+	w, err := NewRotatingFile(path, 100, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer w.Close()
+
+	line := strings.Repeat("x", 39) + "\n" // 40 bytes
+	for i := 0; i < 7; i++ {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	// 7 lines at 2 per file: bot.log.2, bot.log.1 full, bot.log has 1 line, oldest dropped
+	for _, tc := range []struct {
+		path string
+		size int64
+	}{
+		{path, 40},
+		{path + ".1", 80},
+		{path + ".2", 80},
+	} {
+		info, err := os.Stat(tc.path)
+		if err != nil {
+			t.Fatalf("Expected %s to exist: %v", tc.path, err)
+		}
+		if info.Size() != tc.size {
+			t.Errorf("Expected %s to be %d bytes, got %d", tc.path, tc.size, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected backups beyond maxBackups to be removed")
+	}
+}
+
+func TestRotatingFile_AppendsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 90)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewRotatingFile(path, 100, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	w.Write([]byte(strings.Repeat("y", 20)))
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// The existing size counts toward the limit
+	if data, _ := os.ReadFile(path + ".1"); len(data) != 90 {
+		t.Errorf("Expected existing content rotated to backup, got %d bytes", len(data))
+	}
+	if _, err := w.Write([]byte("z")); err == nil {
+		t.Error("Expected write after Close to fail")
+	}
+}
+
+func TestOpenOutput(t *testing.T) {
+	for _, output := range []string{"", "stdout", "stderr"} {
+		w, err := OpenOutput(output, 0, 0)
+		if err != nil {
+			t.Fatalf("OpenOutput(%q) failed: %v", output, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Errorf("Expected closing %q to be a no-op, got %v", output, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "bot.log")
+	w, err := OpenOutput(path, 1024, 1)
+	if err != nil {
+		t.Fatalf("OpenOutput(file) failed: %v", err)
+	}
+	New(LevelInfo, w).Info("to file")
+	w.Close()
+
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "to file") {
+		t.Errorf("Expected log line in file, got %q", data)
+	}
+}
@@ -4,31 +4,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 const (
-	baseURL = "https://lunarcrush.com/api4"
+	defaultBaseURL = "https://lunarcrush.com/api4"
 )
 
+// Ensure Client implements SentimentGateway
+var _ gateway.SentimentGateway = (*Client)(nil)
+
 // Client is a LunarCrush API v4 client
 type Client struct {
 	apiKey     string
+	baseURL    string
 	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
+	cache      *httputil.Cache
+	cacheTTL   CacheConfig
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
+}
+
+// CacheConfig holds response cache TTLs per endpoint (0 = no caching)
+type CacheConfig struct {
+	Sentiment time.Duration
+	Trending  time.Duration
+}
+
+// DefaultCacheConfig returns default cache TTLs
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		Sentiment: 60 * time.Second,
+		Trending:  5 * time.Minute,
+	}
 }
 
 // NewClient creates a new LunarCrush client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		apiKey:  apiKey,
+		baseURL: defaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		retry:    httputil.DefaultRetryConfig(),
+		breaker:  httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		cache:    httputil.NewCache(),
+		cacheTTL: DefaultCacheConfig(),
 	}
 }
 
@@ -38,39 +68,59 @@ func (c *Client) Connect(ctx context.Context) error {
 	return err
 }
 
-// Disconnect closes connection
+// Disconnect stops subscriptions and waits for their goroutines to exit
 func (c *Client) Disconnect(ctx context.Context) error {
-	return nil
+	return c.pollers.Stop(ctx)
 }
 
-// doRequest performs HTTP request with authentication
-func (c *Client) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
-	url := baseURL + endpoint
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *Client) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *Client) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Accept", "application/json")
+// BreakerState returns the circuit breaker state
+func (c *Client) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+// SetCacheConfig sets response cache TTLs
+func (c *Client) SetCacheConfig(cfg CacheConfig) {
+	c.cacheTTL = cfg
+}
+
+// CacheHits returns the number of requests served from cache
+func (c *Client) CacheHits() int64 {
+	return c.cache.Hits()
+}
+
+// doCachedRequest performs HTTP request, reusing a cached response within ttl
+func (c *Client) doCachedRequest(ctx context.Context, endpoint string, ttl time.Duration) ([]byte, error) {
+	if body, ok := c.cache.Get(endpoint); ok {
+		return body, nil
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.doRequest(ctx, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
+	c.cache.Set(endpoint, body, ttl)
+	return body, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
-	}
+// doRequest performs HTTP request with authentication
+func (c *Client) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.apiKey)
+	header.Set("Accept", "application/json")
 
-	return body, nil
+	return c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, c.baseURL+endpoint, header, c.retry)
+	})
 }
 
 // TopicResponse represents LunarCrush topic API response
@@ -115,7 +165,7 @@ type PlatformSentiment struct {
 // GetSentiment retrieves sentiment data for a crypto topic
 func (c *Client) GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error) {
 	topic := symbolToTopic(symbol)
-	body, err := c.doRequest(ctx, "/public/topic/"+topic+"/v1")
+	body, err := c.doCachedRequest(ctx, "/public/topic/"+topic+"/v1", c.cacheTTL.Sentiment)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +211,7 @@ func (c *Client) GetSentiment(ctx context.Context, symbol string) (*entity.Socia
 		Contributors:     int64(data.NumContributors),
 		GalaxyScore:      data.GalaxyScore,
 		AltRank:          data.AltRank,
+		PriceChange24h:   data.PriceChange24h,
 		PlatformBreakdown: map[string]entity.PlatformMetrics{
 			"twitter": {
 				Positive: data.TypesSentimentDetail.Twitter.Positive,
@@ -229,6 +280,7 @@ func (c *Client) GetSentimentHistory(ctx context.Context, symbol string, interva
 			SocialVolume:   int64(point.NumPosts),
 			Interactions:   point.Interactions,
 			Contributors:   int64(point.NumContributors),
+			Price:          point.Price,
 			Timestamp:      time.Unix(point.Time, 0),
 		})
 	}
@@ -254,7 +306,7 @@ type TrendingTopic struct {
 func (c *Client) GetTrendingTopics(ctx context.Context, limit int) ([]*entity.TrendingTopic, error) {
 	endpoint := fmt.Sprintf("/public/topics/list/v1?limit=%d", limit)
 
-	body, err := c.doRequest(ctx, endpoint)
+	body, err := c.doCachedRequest(ctx, endpoint, c.cacheTTL.Trending)
 	if err != nil {
 		return nil, err
 	}
@@ -281,51 +333,23 @@ func (c *Client) GetTrendingTopics(ctx context.Context, limit int) ([]*entity.Tr
 
 // SubscribeSentiment subscribes to sentiment updates (polling)
 func (c *Client) SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error {
-	go func() {
-		ticker := time.NewTicker(60 * time.Second) // LunarCrush rate limits
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				sentiment, err := c.GetSentiment(ctx, symbol)
-				if err != nil {
-					continue
-				}
-				handler(sentiment)
-			}
-		}
-	}()
+	// LunarCrush rate limits
+	pollutil.Start(ctx, &c.pollers, 60*time.Second, func(ctx context.Context) (*entity.SocialSentiment, error) {
+		return c.GetSentiment(ctx, symbol)
+	}, handler)
 
 	return nil
 }
 
-// symbolToTopic converts trading symbol to LunarCrush topic
+// symbolToTopic converts trading symbol to LunarCrush topic via the asset registry
 func symbolToTopic(symbol string) string {
-	topicMap := map[string]string{
-		"BTC":  "bitcoin",
-		"ETH":  "ethereum",
-		"SOL":  "solana",
-		"XRP":  "xrp",
-		"DOGE": "dogecoin",
-		"ADA":  "cardano",
-		"AVAX": "avalanche",
-		"DOT":  "polkadot",
-		"LINK": "chainlink",
-		"MATIC": "polygon",
-	}
-
-	if topic, ok := topicMap[strings.ToUpper(symbol)]; ok {
-		return topic
-	}
-	return strings.ToLower(symbol)
+	return entity.Assets().Topic(symbol)
 }
 
-// GetSentimentBias analyzes sentiment and returns trading bias
-func GetSentimentBias(sentiment *entity.SocialSentiment) (entity.SignalBias, float64) {
-	if sentiment == nil {
+// GetSentimentBias analyzes sentiment and returns trading bias; sentiment
+// below the volume floor is neutral
+func GetSentimentBias(sentiment *entity.SocialSentiment, floor entity.SentimentVolumeFloor) (entity.SignalBias, float64) {
+	if !floor.Meets(sentiment) {
 		return entity.SignalBiasNeutral, 0
 	}
 
@@ -0,0 +1,55 @@
+package lunarcrush
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestGetSentimentBias_VolumeFloor(t *testing.T) {
+	floor := entity.SentimentVolumeFloor{MinInteractions: 10000, MinPosts: 50}
+
+	tiny := &entity.SocialSentiment{SentimentScore: 0.8, SocialVolume: 4, Interactions: 120}
+	if bias, strength := GetSentimentBias(tiny, floor); bias != entity.SignalBiasNeutral || strength != 0 {
+		t.Errorf("Expected tiny volume to be neutral, got %s (%.2f)", bias, strength)
+	}
+
+	busy := &entity.SocialSentiment{SentimentScore: 0.8, SocialVolume: 5000, Interactions: 200000}
+	if bias, strength := GetSentimentBias(busy, floor); bias != entity.SignalBiasBullish || strength <= 0.8 {
+		t.Errorf("Expected high volume to give a bullish bias, got %s (%.2f)", bias, strength)
+	}
+}
+
+func TestClient_GetSentiment_Cached(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"data":{"sentiment":75,"num_posts":100}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-key")
+	c.baseURL = server.URL
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		sentiment, err := c.GetSentiment(ctx, "BTC")
+		if err != nil {
+			t.Fatalf("GetSentiment failed: %v", err)
+		}
+		if sentiment.SentimentScore != 0.5 {
+			t.Errorf("Expected sentiment score 0.5, got %f", sentiment.SentimentScore)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 HTTP request within TTL, got %d", calls.Load())
+	}
+	if c.CacheHits() != 1 {
+		t.Errorf("Expected 1 cache hit, got %d", c.CacheHits())
+	}
+}
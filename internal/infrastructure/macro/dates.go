@@ -0,0 +1,29 @@
+package macro
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the date formats seen in FedWatch and Trading Economics
+// responses, tried in order. Fractional seconds are accepted by any layout
+// ending in seconds.
+var dateLayouts = []string{
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+}
+
+// parseDate parses an API date in any of dateLayouts; dates without a zone are UTC
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"sort"
 	"time"
 
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 const (
@@ -20,6 +22,11 @@ const (
 type FedWatchClient struct {
 	apiKey     string
 	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
+	log        *logger.Logger
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
 }
 
 // NewFedWatchClient creates a new FedWatch client
@@ -29,6 +36,9 @@ func NewFedWatchClient(apiKey string) *FedWatchClient {
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		retry:   httputil.DefaultRetryConfig(),
+		breaker: httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		log:     logger.Default().WithField("component", "fedwatch"),
 	}
 }
 
@@ -38,39 +48,35 @@ func (c *FedWatchClient) Connect(ctx context.Context) error {
 	return err
 }
 
-// Disconnect closes connection
+// Disconnect stops subscriptions and waits for their goroutines to exit
 func (c *FedWatchClient) Disconnect(ctx context.Context) error {
-	return nil
+	return c.pollers.Stop(ctx)
 }
 
-// doRequest performs authenticated HTTP request
-func (c *FedWatchClient) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
-	url := fedWatchBaseURL + endpoint
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Accept", "application/json")
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *FedWatchClient) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *FedWatchClient) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+// BreakerState returns the circuit breaker state
+func (c *FedWatchClient) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
-	}
+// doRequest performs authenticated HTTP request
+func (c *FedWatchClient) doRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.apiKey)
+	header.Set("Accept", "application/json")
 
-	return body, nil
+	return c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, fedWatchBaseURL+endpoint, header, c.retry)
+	})
 }
 
 // ForecastResponse represents CME FedWatch API response
@@ -107,15 +113,22 @@ func (c *FedWatchClient) GetFedWatchData(ctx context.Context) (*entity.FedWatchD
 		return nil, fmt.Errorf("no forecast data available")
 	}
 
+	return c.fedWatchData(resp.Forecasts, time.Now()), nil
+}
+
+// fedWatchData builds FedWatch data from forecasts, picking the first meeting
+// after now as the next one. Forecasts with an unparseable date are skipped.
+func (c *FedWatchClient) fedWatchData(forecasts []Forecast, now time.Time) *entity.FedWatchData {
 	data := &entity.FedWatchData{
-		CurrentRate:      resp.Forecasts[0].CurrentRate,
-		UpcomingMeetings: make([]*entity.FOMCMeeting, 0, len(resp.Forecasts)),
-		Timestamp:        time.Now(),
+		CurrentRate:      forecasts[0].CurrentRate,
+		UpcomingMeetings: make([]*entity.FOMCMeeting, 0, len(forecasts)),
+		Timestamp:        now,
 	}
 
-	for _, forecast := range resp.Forecasts {
+	for _, forecast := range forecasts {
 		meeting, err := parseForecast(forecast)
 		if err != nil {
+			c.log.Warn("Skipping FedWatch forecast: %v", err)
 			continue
 		}
 		data.UpcomingMeetings = append(data.UpcomingMeetings, meeting)
@@ -127,7 +140,6 @@ func (c *FedWatchClient) GetFedWatchData(ctx context.Context) (*entity.FedWatchD
 	})
 
 	// Find next meeting (first meeting after now)
-	now := time.Now()
 	for _, meeting := range data.UpcomingMeetings {
 		if meeting.MeetingDate.After(now) {
 			data.NextMeeting = meeting
@@ -135,14 +147,14 @@ func (c *FedWatchClient) GetFedWatchData(ctx context.Context) (*entity.FedWatchD
 		}
 	}
 
-	return data, nil
+	return data
 }
 
 // parseForecast converts API forecast to entity
 func parseForecast(f Forecast) (*entity.FOMCMeeting, error) {
-	meetingDate, err := time.Parse("2006-01-02", f.MeetingDate)
+	meetingDate, err := parseDate(f.MeetingDate)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid meeting date: %w", err)
 	}
 
 	meeting := &entity.FOMCMeeting{
@@ -196,24 +208,8 @@ func (c *FedWatchClient) GetNextMeetingProbabilities(ctx context.Context) (*enti
 
 // SubscribeFedWatch subscribes to FedWatch updates (polling)
 func (c *FedWatchClient) SubscribeFedWatch(ctx context.Context, handler func(*entity.FedWatchData)) error {
-	go func() {
-		// FedWatch updates every 60 seconds for real-time, EOD at 01:45 UTC
-		ticker := time.NewTicker(5 * time.Minute)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				data, err := c.GetFedWatchData(ctx)
-				if err != nil {
-					continue
-				}
-				handler(data)
-			}
-		}
-	}()
+	// FedWatch updates every 60 seconds for real-time, EOD at 01:45 UTC
+	pollutil.Start(ctx, &c.pollers, 5*time.Minute, c.GetFedWatchData, handler)
 
 	return nil
 }
@@ -0,0 +1,42 @@
+package macro
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+func TestFedWatchData_MixedAndInvalidDates(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewFedWatchClient("key")
+	c.log = logger.New(logger.LevelInfo, &buf)
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	forecasts := []Forecast{
+		{MeetingDate: "2024-01-31", CurrentRate: 0.0525},
+		{MeetingDate: "not a date", CurrentRate: 0.0525},
+		{MeetingDate: "2024-05-01T18:00:00Z", CurrentRate: 0.0525},
+		{MeetingDate: "", CurrentRate: 0.0525},
+		{MeetingDate: "2024-03-20T18:00:00", CurrentRate: 0.0525},
+	}
+
+	data := c.fedWatchData(forecasts, now)
+	if len(data.UpcomingMeetings) != 3 {
+		t.Fatalf("Expected invalid dates skipped leaving 3 meetings, got %d", len(data.UpcomingMeetings))
+	}
+	for _, m := range data.UpcomingMeetings {
+		if m.MeetingDate.IsZero() {
+			t.Error("Expected no zero-dated meetings")
+		}
+	}
+	want := time.Date(2024, 3, 20, 18, 0, 0, 0, time.UTC)
+	if data.NextMeeting == nil || !data.NextMeeting.MeetingDate.Equal(want) {
+		t.Errorf("Expected next meeting %s, got %+v", want, data.NextMeeting)
+	}
+	if n := strings.Count(buf.String(), "Skipping FedWatch forecast"); n != 2 {
+		t.Errorf("Expected a warning per invalid date, got %d:\n%s", n, buf.String())
+	}
+}
@@ -2,11 +2,14 @@ package macro
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 // Provider aggregates macro data sources
@@ -14,9 +17,11 @@ type Provider struct {
 	fedWatch         *FedWatchClient
 	tradingEconomics *TradingEconomicsClient
 
-	mu             sync.RWMutex
-	running        bool
-	signalHandlers []func(*entity.MacroSignal)
+	mu              sync.RWMutex
+	running         bool
+	refreshInterval time.Duration
+	signalHandlers  []func(*entity.MacroSignal)
+	pollers         pollutil.Group // Background collection, drained by Stop
 
 	// Cached data
 	cachedFedWatch *entity.FedWatchData
@@ -27,6 +32,10 @@ type Provider struct {
 type Config struct {
 	FedWatchAPIKey         string
 	TradingEconomicsAPIKey string
+	MaxAttempts            int                     // Attempts per API request (0 = client default)
+	Breaker                *httputil.BreakerConfig // nil = default circuit breaker
+	RefreshInterval        time.Duration           // Interval between data refreshes (0 = 10 minutes)
+	DXYIndicator           string                  // Trading Economics indicator for the dollar index (empty = DefaultDXYIndicator)
 }
 
 // NewProvider creates a new macro provider
@@ -36,18 +45,58 @@ func NewProvider(cfg Config) *Provider {
 
 	if cfg.FedWatchAPIKey != "" {
 		fw = NewFedWatchClient(cfg.FedWatchAPIKey)
+		if cfg.MaxAttempts > 0 {
+			fw.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			fw.SetBreakerConfig(*cfg.Breaker)
+		}
 	}
 	if cfg.TradingEconomicsAPIKey != "" {
 		te = NewTradingEconomicsClient(cfg.TradingEconomicsAPIKey)
+		if cfg.MaxAttempts > 0 {
+			te.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			te.SetBreakerConfig(*cfg.Breaker)
+		}
+		if cfg.DXYIndicator != "" {
+			te.SetDXYIndicator(cfg.DXYIndicator)
+		}
+	}
+
+	refreshInterval := cfg.RefreshInterval
+	if refreshInterval == 0 {
+		refreshInterval = 10 * time.Minute
 	}
 
 	return &Provider{
 		fedWatch:         fw,
 		tradingEconomics: te,
+		refreshInterval:  refreshInterval,
 		signalHandlers:   make([]func(*entity.MacroSignal), 0),
 	}
 }
 
+// BreakerState returns the most degraded circuit breaker state of the macro clients
+func (p *Provider) BreakerState() httputil.BreakerState {
+	states := make([]httputil.BreakerState, 0, 2)
+	if p.fedWatch != nil {
+		states = append(states, p.fedWatch.BreakerState())
+	}
+	if p.tradingEconomics != nil {
+		states = append(states, p.tradingEconomics.BreakerState())
+	}
+
+	worst := httputil.BreakerClosed
+	for _, s := range states {
+		if s == httputil.BreakerOpen || (s == httputil.BreakerHalfOpen && worst == httputil.BreakerClosed) {
+			worst = s
+		}
+	}
+	return worst
+}
+
 // Start starts macro data collection
 func (p *Provider) Start(ctx context.Context) error {
 	p.mu.Lock()
@@ -73,7 +122,7 @@ func (p *Provider) Start(ctx context.Context) error {
 	}
 
 	// Start background data collection
-	go p.collectData(ctx)
+	p.pollers.Go(ctx, p.collectData)
 
 	// Subscribe to FedWatch updates
 	if p.fedWatch != nil {
@@ -102,7 +151,7 @@ func (p *Provider) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop stops macro data collection
+// Stop stops macro data collection and waits for its goroutines to exit
 func (p *Provider) Stop(ctx context.Context) error {
 	p.mu.Lock()
 	if !p.running {
@@ -112,14 +161,16 @@ func (p *Provider) Stop(ctx context.Context) error {
 	p.running = false
 	p.mu.Unlock()
 
+	var errs []error
 	if p.fedWatch != nil {
-		p.fedWatch.Disconnect(ctx)
+		errs = append(errs, p.fedWatch.Disconnect(ctx))
 	}
 	if p.tradingEconomics != nil {
-		p.tradingEconomics.Disconnect(ctx)
+		errs = append(errs, p.tradingEconomics.Disconnect(ctx))
 	}
+	errs = append(errs, p.pollers.Stop(ctx))
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // collectData periodically collects macro data
@@ -128,7 +179,7 @@ func (p *Provider) collectData(ctx context.Context) {
 	p.refreshData(ctx)
 
 	// Periodic refresh
-	ticker := time.NewTicker(10 * time.Minute)
+	ticker := time.NewTicker(p.refreshInterval)
 	defer ticker.Stop()
 
 	for {
@@ -179,6 +230,9 @@ func (p *Provider) refreshData(ctx context.Context) {
 		if pce, err := p.tradingEconomics.GetUSPCE(ctx); err == nil {
 			signal.PCE = pce
 		}
+		if dxy, err := p.tradingEconomics.GetDXY(ctx); err == nil {
+			signal.DXY = dxy
+		}
 		if events, err := p.tradingEconomics.GetHighImpactEvents(ctx, 7); err == nil {
 			signal.UpcomingEvents = events
 		}
@@ -224,6 +278,15 @@ func (p *Provider) GetMacroSignal(ctx context.Context) (*entity.MacroSignal, err
 		if unemp, err := p.tradingEconomics.GetUSUnemployment(ctx); err == nil {
 			signal.Unemployment = unemp
 		}
+		if pce, err := p.tradingEconomics.GetUSPCE(ctx); err == nil {
+			signal.PCE = pce
+		}
+		if dxy, err := p.tradingEconomics.GetDXY(ctx); err == nil {
+			signal.DXY = dxy
+		}
+		if events, err := p.tradingEconomics.GetHighImpactEvents(ctx, 7); err == nil {
+			signal.UpcomingEvents = events
+		}
 	}
 
 	signal.AnalyzeMacroSignal()
@@ -235,6 +298,16 @@ func (p *Provider) GetMacroSignal(ctx context.Context) (*entity.MacroSignal, err
 	return signal, nil
 }
 
+// UpcomingEvents returns the high-impact events of the latest macro signal
+func (p *Provider) UpcomingEvents() []*entity.EconomicEvent {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.cachedMacro == nil {
+		return nil
+	}
+	return p.cachedMacro.UpcomingEvents
+}
+
 // GetFedWatchData returns the current FedWatch data
 func (p *Provider) GetFedWatchData(ctx context.Context) (*entity.FedWatchData, error) {
 	if p.fedWatch == nil {
@@ -287,10 +360,22 @@ func GetMacroSummary(signal *entity.MacroSignal) string {
 		summary += "  CPI: " + formatFloat(signal.CPI.Value) + "% (prev: " + formatFloat(signal.CPI.Previous) + "%)\n"
 	}
 
+	if signal.PCE != nil {
+		summary += "  Core PCE: " + formatFloat(signal.PCE.Value) + "% (prev: " + formatFloat(signal.PCE.Previous) + "%)\n"
+	}
+
 	if signal.Unemployment != nil {
 		summary += "  Unemployment: " + formatFloat(signal.Unemployment.Value) + "%\n"
 	}
 
+	if signal.DXY != nil {
+		summary += "  DXY: " + formatFloat(signal.DXY.Value)
+		if change, ok := signal.DXYChange(); ok {
+			summary += fmt.Sprintf(" (%+.1f%%)", change*100)
+		}
+		summary += "\n"
+	}
+
 	if len(signal.UpcomingEvents) > 0 {
 		summary += "  Upcoming: " + signal.UpcomingEvents[0].Event + " (" + signal.UpcomingEvents[0].Date.Format("Jan 2") + ")"
 	}
@@ -0,0 +1,22 @@
+package macro
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestGetMacroSummary_ShowsPCE(t *testing.T) {
+	signal := &entity.MacroSignal{
+		Timestamp: time.Now(),
+		PCE:       &entity.EconomicIndicator{Value: 2.4, Previous: 2.8},
+	}
+	signal.AnalyzeMacroSignal()
+
+	summary := GetMacroSummary(signal)
+	if !strings.Contains(summary, "Core PCE: 2.4% (prev: 2.8%)") {
+		t.Errorf("Expected PCE in summary, got:\n%s", summary)
+	}
+}
@@ -4,22 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 const (
 	tradingEconomicsBaseURL = "https://api.tradingeconomics.com"
+
+	// DefaultDXYIndicator is the Trading Economics indicator tracking the US Dollar Index
+	DefaultDXYIndicator = "currency"
 )
 
 // TradingEconomicsClient is a Trading Economics API client
 type TradingEconomicsClient struct {
 	apiKey     string
 	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
+	log        *logger.Logger
+
+	dxyIndicator string
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
 }
 
 // NewTradingEconomicsClient creates a new Trading Economics client
@@ -29,18 +41,44 @@ func NewTradingEconomicsClient(apiKey string) *TradingEconomicsClient {
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
+		retry:   httputil.DefaultRetryConfig(),
+		breaker: httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		log:     logger.Default().WithField("component", "tradingeconomics"),
+
+		dxyIndicator: DefaultDXYIndicator,
 	}
 }
 
+// SetDXYIndicator sets the indicator name GetDXY fetches, since Trading
+// Economics names vary by plan and market
+func (c *TradingEconomicsClient) SetDXYIndicator(name string) {
+	c.dxyIndicator = name
+}
+
 // Connect validates API connection
 func (c *TradingEconomicsClient) Connect(ctx context.Context) error {
 	_, err := c.GetIndicator(ctx, "united states", "inflation rate")
 	return err
 }
 
-// Disconnect closes connection
+// Disconnect stops subscriptions and waits for their goroutines to exit
 func (c *TradingEconomicsClient) Disconnect(ctx context.Context) error {
-	return nil
+	return c.pollers.Stop(ctx)
+}
+
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *TradingEconomicsClient) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
+
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *TradingEconomicsClient) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
+
+// BreakerState returns the circuit breaker state
+func (c *TradingEconomicsClient) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
 }
 
 // doRequest performs authenticated HTTP request
@@ -54,29 +92,12 @@ func (c *TradingEconomicsClient) doRequest(ctx context.Context, endpoint string)
 	}
 	fullURL := tradingEconomicsBaseURL + endpoint + separator + "c=" + c.apiKey
 
-	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	header := http.Header{}
+	header.Set("Accept", "application/json")
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+	return c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, fullURL, header, c.retry)
+	})
 }
 
 func containsQuery(s string) bool {
@@ -121,20 +142,32 @@ func (c *TradingEconomicsClient) GetIndicator(ctx context.Context, country, indi
 		return nil, fmt.Errorf("no data found for %s %s", country, indicator)
 	}
 
-	data := resp[0]
-	lastUpdate, _ := time.Parse("2006-01-02T15:04:05", data.LatestValueDate)
-
-	return &entity.EconomicIndicator{
-		Country:    data.Country,
-		Category:   data.Category,
-		Name:       data.Title,
-		Value:      data.LatestValue,
-		Previous:   data.PreviousValue,
-		Unit:       data.Unit,
-		Frequency:  data.Frequency,
-		LastUpdate: lastUpdate,
-		Timestamp:  time.Now(),
-	}, nil
+	return c.parseIndicator(resp, country, indicator)
+}
+
+// parseIndicator converts the first row with a parseable date, so a bad date
+// skips the row instead of reporting a zero LastUpdate
+func (c *TradingEconomicsClient) parseIndicator(resp IndicatorResponse, country, indicator string) (*entity.EconomicIndicator, error) {
+	for _, data := range resp {
+		lastUpdate, err := parseDate(data.LatestValueDate)
+		if err != nil {
+			c.log.Warn("Skipping %s %s row: invalid latest value date: %v", country, indicator, err)
+			continue
+		}
+
+		return &entity.EconomicIndicator{
+			Country:    data.Country,
+			Category:   data.Category,
+			Name:       data.Title,
+			Value:      data.LatestValue,
+			Previous:   data.PreviousValue,
+			Unit:       data.Unit,
+			Frequency:  data.Frequency,
+			LastUpdate: lastUpdate,
+			Timestamp:  time.Now(),
+		}, nil
+	}
+	return nil, fmt.Errorf("no dated data found for %s %s", country, indicator)
 }
 
 // GetUSInflation retrieves US CPI/Inflation data
@@ -177,6 +210,17 @@ func (c *TradingEconomicsClient) GetUSPCE(ctx context.Context) (*entity.Economic
 	return indicator, nil
 }
 
+// GetDXY retrieves the US Dollar Index
+func (c *TradingEconomicsClient) GetDXY(ctx context.Context) (*entity.EconomicIndicator, error) {
+	indicator, err := c.GetIndicator(ctx, "united states", c.dxyIndicator)
+	if err != nil {
+		return nil, err
+	}
+	indicator.Category = "DXY"
+	indicator.Importance = "medium"
+	return indicator, nil
+}
+
 // CalendarResponse represents economic calendar response
 type CalendarResponse []struct {
 	ID          string  `json:"CalendarId"`
@@ -208,9 +252,19 @@ func (c *TradingEconomicsClient) GetEconomicCalendar(ctx context.Context, countr
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	return c.parseCalendar(resp), nil
+}
+
+// parseCalendar converts calendar items to events, skipping items with an
+// unparseable date so they never look like events at the zero time
+func (c *TradingEconomicsClient) parseCalendar(resp CalendarResponse) []*entity.EconomicEvent {
 	events := make([]*entity.EconomicEvent, 0, len(resp))
 	for _, item := range resp {
-		eventDate, _ := time.Parse("2006-01-02T15:04:05", item.Date)
+		eventDate, err := parseDate(item.Date)
+		if err != nil {
+			c.log.Warn("Skipping calendar event %s (%s): %v", item.ID, item.Event, err)
+			continue
+		}
 
 		importance := "low"
 		if item.Importance == 2 {
@@ -243,7 +297,7 @@ func (c *TradingEconomicsClient) GetEconomicCalendar(ctx context.Context, countr
 		})
 	}
 
-	return events, nil
+	return events
 }
 
 // GetHighImpactEvents returns only high-importance events
@@ -265,23 +319,14 @@ func (c *TradingEconomicsClient) GetHighImpactEvents(ctx context.Context, days i
 
 // SubscribeIndicators subscribes to indicator updates (polling)
 func (c *TradingEconomicsClient) SubscribeIndicators(ctx context.Context, handler func(*entity.MacroSignal)) error {
-	go func() {
-		// Economic data updates infrequently, check every 15 minutes
-		ticker := time.NewTicker(15 * time.Minute)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				signal := c.buildMacroSignal(ctx)
-				if signal != nil {
-					handler(signal)
-				}
-			}
+	// Economic data updates infrequently, check every 15 minutes
+	pollutil.Start(ctx, &c.pollers, 15*time.Minute, func(ctx context.Context) (*entity.MacroSignal, error) {
+		return c.buildMacroSignal(ctx), nil
+	}, func(signal *entity.MacroSignal) {
+		if signal != nil {
+			handler(signal)
 		}
-	}()
+	})
 
 	return nil
 }
@@ -305,6 +350,9 @@ func (c *TradingEconomicsClient) buildMacroSignal(ctx context.Context) *entity.M
 	if pce, err := c.GetUSPCE(ctx); err == nil {
 		signal.PCE = pce
 	}
+	if dxy, err := c.GetDXY(ctx); err == nil {
+		signal.DXY = dxy
+	}
 
 	// Get upcoming events
 	if events, err := c.GetHighImpactEvents(ctx, 7); err == nil {
@@ -0,0 +1,63 @@
+package macro
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+func TestParseCalendar_MixedAndInvalidDates(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewTradingEconomicsClient("key")
+	c.log = logger.New(logger.LevelInfo, &buf)
+
+	resp := CalendarResponse{
+		{ID: "1", Date: "2024-03-12T12:30:00", Event: "CPI", Importance: 3},
+		{ID: "2", Date: "2024-03-13 08:30:00", Event: "PPI", Importance: 2},
+		{ID: "3", Date: "tentative", Event: "Speech", Importance: 1},
+		{ID: "4", Date: "2024-03-20T18:00:00.000", Event: "Fed Decision", Importance: 3},
+		{ID: "5", Date: "", Event: "Unknown", Importance: 3},
+	}
+
+	events := c.parseCalendar(resp)
+	if len(events) != 3 {
+		t.Fatalf("Expected invalid dates skipped leaving 3 events, got %d", len(events))
+	}
+	want := []time.Time{
+		time.Date(2024, 3, 12, 12, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 13, 8, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 20, 18, 0, 0, 0, time.UTC),
+	}
+	for i, e := range events {
+		if !e.Date.Equal(want[i]) {
+			t.Errorf("Event %s: expected date %s, got %s", e.ID, want[i], e.Date)
+		}
+	}
+	if n := strings.Count(buf.String(), "Skipping calendar event"); n != 2 {
+		t.Errorf("Expected a warning per invalid date, got %d:\n%s", n, buf.String())
+	}
+}
+
+func TestParseIndicator_SkipsUndatedRows(t *testing.T) {
+	c := NewTradingEconomicsClient("key")
+	c.log = logger.New(logger.LevelError, nil)
+
+	resp := IndicatorResponse{
+		{Title: "stale", LatestValue: 1, LatestValueDate: "n/a"},
+		{Title: "Inflation Rate", LatestValue: 3.1, LatestValueDate: "2024-02-13"},
+	}
+	ind, err := c.parseIndicator(resp, "united states", "inflation rate")
+	if err != nil {
+		t.Fatalf("parseIndicator failed: %v", err)
+	}
+	if ind.Value != 3.1 || !ind.LastUpdate.Equal(time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the dated row, got %+v", ind)
+	}
+
+	if _, err := c.parseIndicator(resp[:1], "united states", "inflation rate"); err == nil {
+		t.Error("Expected an error when no row has a valid date")
+	}
+}
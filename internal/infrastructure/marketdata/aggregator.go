@@ -0,0 +1,115 @@
+package marketdata
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// Aggregator builds OHLCV candles of a fixed interval from ticker updates.
+// Candles are timestamped with their open time and emitted once a tick from a
+// later interval arrives; intervals without ticks produce flat candles at the
+// previous close.
+type Aggregator struct {
+	interval time.Duration
+
+	mu         sync.Mutex
+	bars       map[string]*entity.Candle // Open bar by symbol
+	lastVolume map[string]float64        // Latest 24h volume by symbol
+}
+
+// NewAggregator creates an aggregator emitting candles of the given interval
+func NewAggregator(interval time.Duration) *Aggregator {
+	return &Aggregator{
+		interval:   interval,
+		bars:       make(map[string]*entity.Candle),
+		lastVolume: make(map[string]float64),
+	}
+}
+
+// Interval returns the candle interval
+func (a *Aggregator) Interval() time.Duration {
+	return a.interval
+}
+
+// Add folds a ticker into the open bar of its symbol and returns the candles
+// closed by it, oldest first. Ticks older than the open bar are ignored.
+// Volume is the increase in the ticker's 24h volume, since ticks carry no trade size.
+func (a *Aggregator) Add(ticker *entity.Ticker) []entity.Candle {
+	price := ticker.LastPrice
+	if price <= 0 || ticker.Timestamp.IsZero() {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	volume := 0.0
+	if last, ok := a.lastVolume[ticker.Symbol]; ok {
+		volume = math.Max(ticker.Volume24h-last, 0)
+	}
+	a.lastVolume[ticker.Symbol] = ticker.Volume24h
+
+	start := ticker.Timestamp.Truncate(a.interval)
+	bar := a.bars[ticker.Symbol]
+	if bar == nil {
+		a.bars[ticker.Symbol] = newBar(ticker.Symbol, start, price, volume)
+		return nil
+	}
+	if start.Before(bar.Timestamp) {
+		return nil
+	}
+	if start.Equal(bar.Timestamp) {
+		bar.High = math.Max(bar.High, price)
+		bar.Low = math.Min(bar.Low, price)
+		bar.Close = price
+		bar.Volume += volume
+		return nil
+	}
+
+	closed := a.closeUntil(bar, start)
+	a.bars[ticker.Symbol] = newBar(ticker.Symbol, start, price, volume)
+	return closed
+}
+
+// Flush closes bars whose interval ended before now, so candles are emitted
+// even when no new tick arrives. The next bar opens flat at the last close.
+func (a *Aggregator) Flush(now time.Time) []entity.Candle {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	start := now.Truncate(a.interval)
+	var closed []entity.Candle
+	for symbol, bar := range a.bars {
+		if !start.After(bar.Timestamp) {
+			continue
+		}
+		closed = append(closed, a.closeUntil(bar, start)...)
+		a.bars[symbol] = newBar(symbol, start, bar.Close, 0)
+	}
+	return closed
+}
+
+// closeUntil returns bar followed by flat candles for each empty interval before start
+func (a *Aggregator) closeUntil(bar *entity.Candle, start time.Time) []entity.Candle {
+	closed := []entity.Candle{*bar}
+	for t := bar.Timestamp.Add(a.interval); t.Before(start); t = t.Add(a.interval) {
+		closed = append(closed, *newBar(bar.Symbol, t, bar.Close, 0))
+	}
+	return closed
+}
+
+// newBar opens a bar at price
+func newBar(symbol string, start time.Time, price, volume float64) *entity.Candle {
+	return &entity.Candle{
+		Symbol:    symbol,
+		Open:      price,
+		High:      price,
+		Low:       price,
+		Close:     price,
+		Volume:    volume,
+		Timestamp: start,
+	}
+}
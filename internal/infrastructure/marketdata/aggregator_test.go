@@ -0,0 +1,90 @@
+package marketdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+var aggStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func aggTick(offset time.Duration, price, volume24h float64) *entity.Ticker {
+	return &entity.Ticker{Symbol: "BTC", LastPrice: price, Volume24h: volume24h, Timestamp: aggStart.Add(offset)}
+}
+
+func TestAggregator_BuildsOHLCV(t *testing.T) {
+	a := NewAggregator(time.Minute)
+
+	for _, tick := range []*entity.Ticker{
+		aggTick(0, 100, 1000),
+		aggTick(10*time.Second, 103, 1002),
+		aggTick(30*time.Second, 98, 1005),
+		aggTick(59*time.Second, 101, 1006),
+	} {
+		if closed := a.Add(tick); len(closed) != 0 {
+			t.Fatalf("Expected no candle within the interval, got %+v", closed)
+		}
+	}
+
+	closed := a.Add(aggTick(time.Minute+5*time.Second, 102, 1010))
+	if len(closed) != 1 {
+		t.Fatalf("Expected one closed candle, got %d", len(closed))
+	}
+	c := closed[0]
+	if c.Open != 100 || c.High != 103 || c.Low != 98 || c.Close != 101 {
+		t.Errorf("Unexpected OHLC: %+v", c)
+	}
+	if c.Volume != 6 || !c.Timestamp.Equal(aggStart) || c.Symbol != "BTC" {
+		t.Errorf("Expected volume 6 at the interval open, got %+v", c)
+	}
+
+	// Late ticks from a closed interval are ignored
+	if closed := a.Add(aggTick(30*time.Second, 50, 1010)); len(closed) != 0 {
+		t.Errorf("Expected late tick to be ignored, got %+v", closed)
+	}
+	closed = a.Add(aggTick(2*time.Minute, 104, 1011))
+	if len(closed) != 1 || closed[0].Low != 102 || closed[0].Open != 102 || closed[0].Volume != 4 {
+		t.Errorf("Unexpected second candle: %+v", closed)
+	}
+}
+
+func TestAggregator_EmptyIntervalsAreFlat(t *testing.T) {
+	a := NewAggregator(time.Minute)
+	a.Add(aggTick(0, 100, 0))
+	a.Add(aggTick(20*time.Second, 105, 0))
+
+	// Nothing traded during minutes 1 and 2
+	closed := a.Add(aggTick(3*time.Minute+1*time.Second, 110, 0))
+	if len(closed) != 3 {
+		t.Fatalf("Expected the bar plus 2 flat candles, got %d", len(closed))
+	}
+	for i, c := range closed[1:] {
+		if c.Open != 105 || c.High != 105 || c.Low != 105 || c.Close != 105 || c.Volume != 0 {
+			t.Errorf("Expected flat candle at the last close, got %+v", c)
+		}
+		if want := aggStart.Add(time.Duration(i+1) * time.Minute); !c.Timestamp.Equal(want) {
+			t.Errorf("Expected flat candle at %s, got %s", want, c.Timestamp)
+		}
+	}
+}
+
+func TestAggregator_Flush(t *testing.T) {
+	a := NewAggregator(5 * time.Minute)
+	a.Add(aggTick(time.Minute, 100, 0))
+
+	if closed := a.Flush(aggStart.Add(4 * time.Minute)); len(closed) != 0 {
+		t.Errorf("Expected open bar kept before the interval ends, got %+v", closed)
+	}
+	closed := a.Flush(aggStart.Add(10*time.Minute + time.Second))
+	if len(closed) != 2 || closed[0].Close != 100 || closed[1].Volume != 0 {
+		t.Fatalf("Expected the bar plus one flat candle, got %+v", closed)
+	}
+
+	// The next bar opens at the previous close
+	a.Add(aggTick(11*time.Minute, 104, 0))
+	closed = a.Add(aggTick(15*time.Minute, 103, 0))
+	if len(closed) != 1 || closed[0].Open != 100 || closed[0].High != 104 || closed[0].Close != 104 {
+		t.Errorf("Expected bar opened at the carried-forward close, got %+v", closed)
+	}
+}
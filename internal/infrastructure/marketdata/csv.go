@@ -0,0 +1,152 @@
+package marketdata
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// csvColumns is the expected CSV layout
+const csvColumns = 6 // timestamp,open,high,low,close,volume
+
+// CSVIterator streams validated candles from CSV without loading the whole file.
+// Rows are timestamp,open,high,low,close,volume; the timestamp is RFC3339 or
+// Unix milliseconds and an optional header row is skipped.
+type CSVIterator struct {
+	reader *csv.Reader
+	symbol string
+	line   int
+	prev   *entity.Candle
+	cur    entity.Candle
+	err    error
+}
+
+// NewCSVIterator creates an iterator over CSV rows tagged with symbol
+func NewCSVIterator(r io.Reader, symbol string) *CSVIterator {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = csvColumns
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &CSVIterator{
+		reader: reader,
+		symbol: symbol,
+	}
+}
+
+// Next advances to the next candle; it returns false at EOF or on error
+func (it *CSVIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for {
+		record, err := it.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		it.line++
+		if err != nil {
+			it.err = fmt.Errorf("line %d: %w", it.line, err)
+			return false
+		}
+
+		if it.line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "timestamp") {
+			continue
+		}
+
+		candle, err := parseRecord(record, it.symbol)
+		if err == nil {
+			err = validate(&candle, it.prev)
+		}
+		if err != nil {
+			it.err = fmt.Errorf("line %d: %w", it.line, err)
+			return false
+		}
+
+		it.cur = candle
+		it.prev = &it.cur
+		return true
+	}
+}
+
+// Candle returns the current candle
+func (it *CSVIterator) Candle() entity.Candle {
+	return it.cur
+}
+
+// Err returns the first error encountered
+func (it *CSVIterator) Err() error {
+	return it.err
+}
+
+// LoadCSV reads all candles from CSV
+func LoadCSV(r io.Reader, symbol string) ([]entity.Candle, error) {
+	it := NewCSVIterator(r, symbol)
+
+	var candles []entity.Candle
+	for it.Next() {
+		candles = append(candles, it.Candle())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// LoadCSVFile reads all candles from a CSV file
+func LoadCSVFile(path, symbol string) ([]entity.Candle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return LoadCSV(f, symbol)
+}
+
+// parseRecord parses a single CSV record into a candle
+func parseRecord(record []string, symbol string) (entity.Candle, error) {
+	ts, err := parseTimestamp(strings.TrimSpace(record[0]))
+	if err != nil {
+		return entity.Candle{}, err
+	}
+
+	var values [csvColumns - 1]float64
+	for i, field := range record[1:] {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return entity.Candle{}, fmt.Errorf("invalid number %q", field)
+		}
+		values[i] = v
+	}
+
+	return entity.Candle{
+		Symbol:    symbol,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+		Timestamp: ts,
+	}, nil
+}
+
+// parseTimestamp accepts RFC3339 or Unix milliseconds
+func parseTimestamp(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return ts, nil
+}
@@ -0,0 +1,73 @@
+package marketdata
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadCSV(t *testing.T) {
+	data := `timestamp,open,high,low,close,volume
+2024-01-01T00:00:00Z,100,105,99,104,10.5
+1704070800000,104,106,103,105,8
+`
+	candles, err := LoadCSV(strings.NewReader(data), "ETH")
+	if err != nil {
+		t.Fatalf("LoadCSV failed: %v", err)
+	}
+	if len(candles) != 2 {
+		t.Fatalf("Expected 2 candles, got %d", len(candles))
+	}
+	if candles[0].Symbol != "ETH" || candles[0].High != 105 || candles[0].Volume != 10.5 {
+		t.Errorf("Unexpected first candle: %+v", candles[0])
+	}
+	if !candles[1].Timestamp.Equal(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected second timestamp: %v", candles[1].Timestamp)
+	}
+}
+
+func TestLoadCSV_MalformedRow(t *testing.T) {
+	tests := map[string]string{
+		"bad number":     "2024-01-01T00:00:00Z,100,abc,99,104,1\n",
+		"bad timestamp":  "yesterday,100,105,99,104,1\n",
+		"missing column": "2024-01-01T00:00:00Z,100,105,99,104\n",
+		"negative price": "2024-01-01T00:00:00Z,-1,105,99,104,1\n",
+	}
+	for name, data := range tests {
+		if _, err := LoadCSV(strings.NewReader(data), "BTC"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadCSV_OutOfOrder(t *testing.T) {
+	data := `2024-01-01T01:00:00Z,100,105,99,104,1
+2024-01-01T00:00:00Z,104,106,103,105,1
+`
+	_, err := LoadCSV(strings.NewReader(data), "BTC")
+	if err == nil {
+		t.Fatal("Expected error for out-of-order timestamps")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error to reference line 2, got %v", err)
+	}
+}
+
+func TestCSVIterator_StopsAtError(t *testing.T) {
+	data := `2024-01-01T00:00:00Z,100,105,99,104,1
+2024-01-01T01:00:00Z,100,x,99,104,1
+2024-01-01T02:00:00Z,100,105,99,104,1
+`
+	it := NewCSVIterator(strings.NewReader(data), "BTC")
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 candle before the error, got %d", count)
+	}
+	if it.Err() == nil {
+		t.Error("Expected iterator error")
+	}
+}
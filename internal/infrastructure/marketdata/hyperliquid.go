@@ -0,0 +1,91 @@
+package marketdata
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
+)
+
+// HyperliquidLoader fetches candle history from the Hyperliquid info API
+type HyperliquidLoader struct {
+	client *hyperliquid.Client
+	symbol string
+}
+
+// NewHyperliquidLoader creates a loader; candles are tagged with symbol (defaults to the coin)
+func NewHyperliquidLoader(client *hyperliquid.Client, symbol string) *HyperliquidLoader {
+	return &HyperliquidLoader{
+		client: client,
+		symbol: symbol,
+	}
+}
+
+// Load fetches candles for coin in [start, end], paging past the per-request limit
+func (l *HyperliquidLoader) Load(ctx context.Context, coin, interval string, start, end time.Time) ([]entity.Candle, error) {
+	symbol := l.symbol
+	if symbol == "" {
+		symbol = coin
+	}
+
+	var candles []entity.Candle
+	from := start
+	for from.Before(end) {
+		batch, err := l.client.GetCandles(ctx, coin, interval, from, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch candles: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, snap := range batch {
+			candle, err := snapshotToCandle(snap, symbol)
+			if err != nil {
+				return nil, err
+			}
+			// Pages overlap at the boundary candle
+			if n := len(candles); n > 0 && !candle.Timestamp.After(candles[n-1].Timestamp) {
+				continue
+			}
+			candles = append(candles, candle)
+		}
+
+		next := time.UnixMilli(batch[len(batch)-1].OpenTime + 1)
+		if !next.After(from) {
+			break
+		}
+		from = next
+	}
+
+	if err := Validate(candles); err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// snapshotToCandle converts an API candle with string-encoded numbers
+func snapshotToCandle(snap hyperliquid.CandleSnapshot, symbol string) (entity.Candle, error) {
+	fields := []string{snap.Open, snap.High, snap.Low, snap.Close, snap.Volume}
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return entity.Candle{}, fmt.Errorf("invalid number %q in candle at %d", field, snap.OpenTime)
+		}
+		values[i] = v
+	}
+
+	return entity.Candle{
+		Symbol:    symbol,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+		Timestamp: time.UnixMilli(snap.OpenTime).UTC(),
+	}, nil
+}
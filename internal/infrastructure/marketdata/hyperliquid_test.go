@@ -0,0 +1,64 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
+)
+
+func TestHyperliquidLoader_Load(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := time.Hour.Milliseconds()
+
+	// Two pages: the second repeats the boundary candle, the third is empty
+	pages := [][]hyperliquid.CandleSnapshot{
+		{
+			{OpenTime: start.UnixMilli(), Coin: "BTC", Open: "100", High: "105", Low: "99", Close: "104", Volume: "10"},
+			{OpenTime: start.UnixMilli() + hour, Coin: "BTC", Open: "104", High: "106", Low: "103", Close: "105", Volume: "8"},
+		},
+		{
+			{OpenTime: start.UnixMilli() + hour, Coin: "BTC", Open: "104", High: "106", Low: "103", Close: "105", Volume: "8"},
+			{OpenTime: start.UnixMilli() + 2*hour, Coin: "BTC", Open: "105", High: "107", Low: "104", Close: "106", Volume: "5"},
+		},
+		{},
+	}
+
+	var requests []hyperliquid.CandleSnapshotRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req hyperliquid.CandleSnapshotRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		page := pages[min(len(requests)-1, len(pages)-1)]
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := hyperliquid.NewClient(hyperliquid.ClientConfig{BaseURL: server.URL})
+	loader := NewHyperliquidLoader(client, "BTC-PERP")
+
+	candles, err := loader.Load(context.Background(), "BTC", "1h", start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(candles) != 3 {
+		t.Fatalf("Expected 3 candles, got %d", len(candles))
+	}
+	if candles[0].Symbol != "BTC-PERP" || candles[2].Close != 106 {
+		t.Errorf("Unexpected candles: %+v", candles)
+	}
+	if requests[0].Type != "candleSnapshot" || requests[0].Req.Coin != "BTC" || requests[0].Req.Interval != "1h" {
+		t.Errorf("Unexpected request: %+v", requests[0])
+	}
+	if requests[1].Req.StartTime != start.UnixMilli()+hour+1 {
+		t.Errorf("Expected second page to start after last candle, got %d", requests[1].Req.StartTime)
+	}
+}
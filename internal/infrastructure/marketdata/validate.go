@@ -0,0 +1,38 @@
+package marketdata
+
+import (
+	"fmt"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// Validate checks that candles have sane prices and strictly increasing timestamps
+func Validate(candles []entity.Candle) error {
+	for i := range candles {
+		var prev *entity.Candle
+		if i > 0 {
+			prev = &candles[i-1]
+		}
+		if err := validate(&candles[i], prev); err != nil {
+			return fmt.Errorf("candle %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a single candle against the previous one
+func validate(c, prev *entity.Candle) error {
+	if c.Open < 0 || c.High < 0 || c.Low < 0 || c.Close < 0 {
+		return fmt.Errorf("negative price at %s", c.Timestamp)
+	}
+	if c.Volume < 0 {
+		return fmt.Errorf("negative volume at %s", c.Timestamp)
+	}
+	if c.High < c.Low {
+		return fmt.Errorf("high %.8g below low %.8g at %s", c.High, c.Low, c.Timestamp)
+	}
+	if prev != nil && !c.Timestamp.After(prev.Timestamp) {
+		return fmt.Errorf("timestamp %s not after previous %s", c.Timestamp, prev.Timestamp)
+	}
+	return nil
+}
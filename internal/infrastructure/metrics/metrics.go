@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "hyperliquid_bot"
+
+// Order rejection reasons
+const (
+	RejectRisk      = "risk"
+	RejectSize      = "position_size"
+	RejectExchange  = "exchange"
+	RejectLeverage  = "leverage"
+	RejectEquity    = "equity_unavailable"
+	RejectLiquidity = "liquidity"
+	RejectPositions = "max_positions"
+	RejectSlippage  = "slippage"
+	RejectBlackout  = "event_blackout"
+	RejectMinimum   = "below_minimum"
+	RejectRateLimit = "rate_limited"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	// SignalsGenerated counts strategy signals by symbol and side
+	SignalsGenerated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signals_generated_total",
+		Help:      "Number of trading signals generated by the strategy.",
+	}, []string{"symbol", "side"})
+
+	// OrdersPlaced counts orders sent to the exchange (or simulated in dry-run)
+	OrdersPlaced = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_placed_total",
+		Help:      "Number of orders placed.",
+	}, []string{"symbol", "side"})
+
+	// OrdersFilled counts filled orders
+	OrdersFilled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_filled_total",
+		Help:      "Number of orders filled.",
+	}, []string{"symbol", "side"})
+
+	// OrdersRejected counts orders rejected by risk checks or the exchange
+	OrdersRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_rejected_total",
+		Help:      "Number of orders rejected before or during placement.",
+	}, []string{"symbol", "reason"})
+
+	// PositionSize tracks the current signed position size per symbol
+	PositionSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "position_size",
+		Help:      "Current signed position size.",
+	}, []string{"symbol"})
+
+	// APIRequests counts external HTTP requests per data source
+	APIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Number of external API requests.",
+	}, []string{"source"})
+
+	// APIErrors counts failed external HTTP requests per data source
+	APIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_errors_total",
+		Help:      "Number of failed external API requests.",
+	}, []string{"source"})
+
+	// WSReconnects counts WebSocket reconnections
+	WSReconnects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ws_reconnects_total",
+		Help:      "Number of WebSocket reconnections.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		SignalsGenerated,
+		OrdersPlaced,
+		OrdersFilled,
+		OrdersRejected,
+		PositionSize,
+		APIRequests,
+		APIErrors,
+		WSReconnects,
+	)
+}
+
+// ObserveAPIRequest records the outcome of an external API request
+func ObserveAPIRequest(source string, err error) {
+	APIRequests.WithLabelValues(source).Inc()
+	if err != nil {
+		APIErrors.WithLabelValues(source).Inc()
+	}
+}
+
+// RegisterRiskStatus exposes daily PnL and consecutive losses read from a
+// risk status snapshot (e.g. risk.Checker.Status) at scrape time
+func RegisterRiskStatus(status func() map[string]interface{}) error {
+	read := func(key string) func() float64 {
+		return func() float64 {
+			switch v := status()[key].(type) {
+			case float64:
+				return v
+			case int:
+				return float64(v)
+			case bool:
+				if v {
+					return 1
+				}
+			}
+			return 0
+		}
+	}
+
+	gauges := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "daily_pnl",
+			Help:      "Realized PnL since the last daily reset.",
+		}, read("daily_pnl")),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "consecutive_losses",
+			Help:      "Number of consecutive losing trades.",
+		}, read("consecutive_loss")),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "trading_halted",
+			Help:      "1 if trading is halted.",
+		}, read("halted")),
+	}
+	for _, g := range gauges {
+		if err := registry.Register(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler returns the HTTP handler serving /metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestHandler_ExposesCounters(t *testing.T) {
+	SignalsGenerated.WithLabelValues("BTC", "buy").Inc()
+	ObserveAPIRequest("open-api.coinglass.com", nil)
+	ObserveAPIRequest("open-api.coinglass.com", errors.New("timeout"))
+
+	output := scrape(t)
+
+	for _, want := range []string{
+		`hyperliquid_bot_signals_generated_total{side="buy",symbol="BTC"} 1`,
+		`hyperliquid_bot_api_requests_total{source="open-api.coinglass.com"} 2`,
+		`hyperliquid_bot_api_errors_total{source="open-api.coinglass.com"} 1`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected metrics output to contain %q", want)
+		}
+	}
+}
+
+func TestRegisterRiskStatus(t *testing.T) {
+	status := func() map[string]interface{} {
+		return map[string]interface{}{
+			"daily_pnl":        -12.5,
+			"consecutive_loss": 2,
+			"halted":           true,
+		}
+	}
+	if err := RegisterRiskStatus(status); err != nil {
+		t.Fatalf("RegisterRiskStatus failed: %v", err)
+	}
+
+	output := scrape(t)
+
+	for _, want := range []string{
+		"hyperliquid_bot_daily_pnl -12.5",
+		"hyperliquid_bot_consecutive_losses 2",
+		"hyperliquid_bot_trading_halted 1",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected metrics output to contain %q", want)
+		}
+	}
+
+	if err := RegisterRiskStatus(status); err == nil {
+		t.Error("Expected duplicate registration to fail")
+	}
+}
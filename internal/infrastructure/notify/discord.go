@@ -0,0 +1,192 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+const (
+	discordQueueSize = 32
+	// Discord allows roughly 5 webhook requests per 2 seconds
+	discordMinInterval = 400 * time.Millisecond
+)
+
+// Embed colors by level
+var discordColors = map[Level]int{
+	LevelInfo:  0x3498db, // blue
+	LevelWarn:  0xf1c40f, // yellow
+	LevelError: 0xe74c3c, // red
+}
+
+// Ensure Discord implements Notifier
+var _ Notifier = (*Discord)(nil)
+
+// Discord sends notifications to a Discord webhook through a rate-limited queue
+type Discord struct {
+	webhookURL  string
+	httpClient  *http.Client
+	minInterval time.Duration
+
+	mu        sync.RWMutex
+	closed    bool
+	queue     chan discordWebhook
+	startOnce sync.Once
+	done      chan struct{}
+}
+
+// discordWebhook represents Discord webhook request body
+type discordWebhook struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+// discordEmbed represents a Discord message embed
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// NewDiscord creates a new Discord webhook notifier (no-op if webhook URL is empty)
+func NewDiscord(webhookURL string) *Discord {
+	return &Discord{
+		webhookURL:  webhookURL,
+		minInterval: discordMinInterval,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		queue: make(chan discordWebhook, discordQueueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// Enabled returns true if the notifier is configured
+func (d *Discord) Enabled() bool {
+	return d != nil && d.webhookURL != ""
+}
+
+// Notify queues an embed for delivery; it fails only if the queue is full
+func (d *Discord) Notify(ctx context.Context, level Level, title, body string) error {
+	if !d.Enabled() {
+		return nil
+	}
+
+	d.startOnce.Do(func() { go d.run() })
+
+	msg := discordWebhook{
+		Embeds: []discordEmbed{{
+			Title:       title,
+			Description: body,
+			Color:       discordColors[level],
+			Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		}},
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return nil
+	}
+
+	select {
+	case d.queue <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return fmt.Errorf("discord queue full, dropping notification: %s", title)
+	}
+}
+
+// Close stops the delivery worker after queued messages are sent
+func (d *Discord) Close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+		d.startOnce.Do(func() { close(d.done) })
+	}
+	d.mu.Unlock()
+
+	<-d.done
+}
+
+// run delivers queued messages sequentially, spacing requests to respect rate limits
+func (d *Discord) run() {
+	defer close(d.done)
+
+	for msg := range d.queue {
+		if err := d.send(msg); err != nil {
+			logger.Default().Warn("Failed to send Discord notification: %v", err)
+		}
+		time.Sleep(d.minInterval)
+	}
+}
+
+// send posts a message, retrying once if Discord asks us to back off
+func (d *Discord) send(msg discordWebhook) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook: %w", err)
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		retryAfter, err := d.post(payload)
+		if err != nil || retryAfter == 0 {
+			return err
+		}
+		time.Sleep(retryAfter)
+	}
+	return fmt.Errorf("discord rate limit exceeded")
+}
+
+// post performs a single webhook request and returns the requested backoff on 429
+func (d *Discord) post(payload []byte) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", d.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return parseDiscordRetryAfter(resp.Header.Get("Retry-After"), respBody), nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return 0, fmt.Errorf("discord error: status=%d, body=%s", resp.StatusCode, string(respBody))
+	}
+	return 0, nil
+}
+
+// parseDiscordRetryAfter reads the backoff from the header or the JSON retry_after field (seconds)
+func parseDiscordRetryAfter(header string, body []byte) time.Duration {
+	var rl struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.Unmarshal(body, &rl); err == nil && rl.RetryAfter > 0 {
+		return time.Duration(rl.RetryAfter * float64(time.Second))
+	}
+	if secs, err := strconv.ParseFloat(header, 64); err == nil && secs > 0 {
+		return time.Duration(secs * float64(time.Second))
+	}
+	return time.Second
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiscord_Notify(t *testing.T) {
+	received := make(chan discordWebhook, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		var msg discordWebhook
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		received <- msg
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDiscord(server.URL)
+	defer d.Close()
+
+	if err := d.Notify(context.Background(), LevelWarn, "Risk check failed", "daily loss limit exceeded"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		if len(msg.Embeds) != 1 {
+			t.Fatalf("Expected 1 embed, got %d", len(msg.Embeds))
+		}
+		embed := msg.Embeds[0]
+		if embed.Title != "Risk check failed" || embed.Description != "daily loss limit exceeded" {
+			t.Errorf("Unexpected embed content: %+v", embed)
+		}
+		if embed.Color != discordColors[LevelWarn] {
+			t.Errorf("Expected warn color %#x, got %#x", discordColors[LevelWarn], embed.Color)
+		}
+		if _, err := time.Parse(time.RFC3339, embed.Timestamp); err != nil {
+			t.Errorf("Expected RFC3339 timestamp, got %q", embed.Timestamp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Webhook was not called")
+	}
+}
+
+func TestDiscord_RetriesOnRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"retry_after":0.01}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDiscord(server.URL)
+	d.minInterval = 0
+
+	if err := d.Notify(context.Background(), LevelError, "Trading halted", "manual"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	d.Close()
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected retry after 429, got %d calls", calls.Load())
+	}
+}
+
+func TestDiscord_Unconfigured(t *testing.T) {
+	d := NewDiscord("")
+	if d.Enabled() {
+		t.Error("Expected unconfigured notifier to be disabled")
+	}
+	if err := d.Notify(context.Background(), LevelInfo, "hello", ""); err != nil {
+		t.Errorf("Expected no-op without error, got %v", err)
+	}
+	d.Close()
+}
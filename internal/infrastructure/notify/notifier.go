@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// Level represents notification severity
+type Level string
+
+const (
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Notifier delivers notifications to an external channel
+type Notifier interface {
+	// Notify sends a notification with a title and optional body
+	Notify(ctx context.Context, level Level, title, body string) error
+}
+
+// NotifyOrder sends an order placement notification
+func NotifyOrder(ctx context.Context, n Notifier, order *entity.Order) error {
+	return n.Notify(ctx, LevelInfo, "Order placed", fmt.Sprintf("%s %s @ %.2f x %.4f (ID=%s, Status=%s)",
+		order.Side, order.Symbol, order.Price, order.Quantity, order.ID, order.Status))
+}
+
+// NotifyFill sends an order fill notification
+func NotifyFill(ctx context.Context, n Notifier, order *entity.Order) error {
+	return n.Notify(ctx, LevelInfo, "Order filled", fmt.Sprintf("%s %s @ %.2f x %.4f (ID=%s)",
+		order.Side, order.Symbol, order.Price, order.FilledQty, order.ID))
+}
+
+// NotifySignal sends a strategy signal notification
+func NotifySignal(ctx context.Context, n Notifier, sig *service.Signal) error {
+	return n.Notify(ctx, LevelInfo, "Signal", fmt.Sprintf("%s %s @ %.2f x %.4f - %s",
+		sig.Side, sig.Symbol, sig.Price, sig.Quantity, sig.Reason))
+}
+
+// NotifyHalt sends a trading halt notification
+func NotifyHalt(ctx context.Context, n Notifier, reason string) error {
+	return n.Notify(ctx, LevelError, "Trading halted", reason)
+}
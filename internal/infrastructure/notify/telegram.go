@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	telegramBaseURL = "https://api.telegram.org"
+)
+
+// Ensure Telegram implements Notifier
+var _ Notifier = (*Telegram)(nil)
+
+// Telegram sends notifications through the Telegram Bot API
+type Telegram struct {
+	botToken   string
+	chatID     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewTelegram creates a new Telegram notifier (no-op if token or chat ID is empty)
+func NewTelegram(botToken, chatID string) *Telegram {
+	return &Telegram{
+		botToken: botToken,
+		chatID:   chatID,
+		baseURL:  telegramBaseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Enabled returns true if the notifier is configured
+func (t *Telegram) Enabled() bool {
+	return t != nil && t.botToken != "" && t.chatID != ""
+}
+
+// sendMessageRequest represents Telegram sendMessage request body
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+// Notify sends a message with the given severity
+func (t *Telegram) Notify(ctx context.Context, level Level, title, body string) error {
+	if !t.Enabled() {
+		return nil
+	}
+
+	text := "[" + strings.ToUpper(string(level)) + "] " + title
+	if body != "" {
+		text += "\n" + body
+	}
+
+	payload, err := json.Marshal(sendMessageRequest{
+		ChatID: t.chatID,
+		Text:   text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	url := t.baseURL + "/bot" + t.botToken + "/sendMessage"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telegram error: status=%d, body=%s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
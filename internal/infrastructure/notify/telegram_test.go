@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestTelegram_Notify(t *testing.T) {
+	var got sendMessageRequest
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	tg := NewTelegram("token123", "42")
+	tg.baseURL = server.URL
+
+	order := &entity.Order{
+		ID:        "abc",
+		Symbol:    "BTC",
+		Side:      entity.SideBuy,
+		Price:     50000,
+		FilledQty: 0.01,
+	}
+	if err := NotifyFill(context.Background(), tg, order); err != nil {
+		t.Fatalf("NotifyFill failed: %v", err)
+	}
+
+	if path != "/bottoken123/sendMessage" {
+		t.Errorf("Unexpected request path: %s", path)
+	}
+	if got.ChatID != "42" {
+		t.Errorf("Expected chat_id 42, got %s", got.ChatID)
+	}
+	want := "[INFO] Order filled\nbuy BTC @ 50000.00 x 0.0100 (ID=abc)"
+	if got.Text != want {
+		t.Errorf("Expected text %q, got %q", want, got.Text)
+	}
+}
+
+func TestTelegram_NotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	tg := NewTelegram("token123", "42")
+	tg.baseURL = server.URL
+
+	if err := NotifyHalt(context.Background(), tg, "manual"); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
+
+func TestTelegram_Unconfigured(t *testing.T) {
+	tg := NewTelegram("", "")
+	if tg.Enabled() {
+		t.Error("Expected unconfigured notifier to be disabled")
+	}
+	if err := tg.Notify(context.Background(), LevelInfo, "hello", ""); err != nil {
+		t.Errorf("Expected no-op without error, got %v", err)
+	}
+}
@@ -0,0 +1,508 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/hyperliquid"
+)
+
+// Ensure Exchange implements ExchangeGateway
+var _ gateway.ExchangeGateway = (*Exchange)(nil)
+
+// Ensure Exchange reports account equity
+var _ gateway.AccountGateway = (*Exchange)(nil)
+
+// Ensure Exchange passes through the market data stream state
+var _ gateway.StreamGateway = (*Exchange)(nil)
+
+// Ensure Exchange passes through the market's minimum order sizes
+var _ gateway.LimitsGateway = (*Exchange)(nil)
+
+// Config contains paper trading settings
+type Config struct {
+	InitialBalance float64         // Starting balance in USD
+	SlippageBps    float64         // Slippage applied to taker fills, in basis points
+	Fees           entity.FeeModel // Maker fees for resting orders, taker fees for crossing orders
+
+	Latency           time.Duration // Delay before a crossing order fills, at the price then (0 = instant)
+	SlippageJitterBps float64       // Random extra taker slippage, uniform in [0, SlippageJitterBps]
+	Seed              uint64        // Seed for the slippage jitter; 0 picks a random seed
+}
+
+// DefaultConfig returns default paper trading settings
+func DefaultConfig() Config {
+	return Config{
+		InitialBalance: 10000,
+		SlippageBps:    1,
+		Fees:           entity.DefaultFeeModel(),
+	}
+}
+
+// Exchange simulates order execution against live market data.
+// Market data calls are delegated to the wrapped exchange; orders, positions
+// and balance are kept in memory and updated as ticker prices cross resting orders.
+type Exchange struct {
+	market gateway.ExchangeGateway
+	config Config
+	now    func() time.Time
+	after  func(time.Duration, func()) // Schedules delayed fills
+
+	mu            sync.Mutex
+	balance       float64
+	nextID        int
+	lastPrice     map[string]float64
+	orders        map[string]*entity.Order
+	inFlight      map[string]bool // Crossing orders waiting out the latency
+	positions     map[string]*entity.Position
+	orderHandlers []func(*entity.Order)
+	rng           *rand.Rand
+}
+
+// NewExchange creates a paper exchange using market for live prices
+func NewExchange(market gateway.ExchangeGateway, config Config) *Exchange {
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Exchange{
+		market:    market,
+		config:    config,
+		now:       time.Now,
+		after:     func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+		rng:       rand.New(rand.NewPCG(seed, seed)),
+		balance:   config.InitialBalance,
+		lastPrice: make(map[string]float64),
+		orders:    make(map[string]*entity.Order),
+		inFlight:  make(map[string]bool),
+		positions: make(map[string]*entity.Position),
+	}
+}
+
+// Connect connects to the market data source
+func (e *Exchange) Connect(ctx context.Context) error {
+	return e.market.Connect(ctx)
+}
+
+// Disconnect disconnects from the market data source
+func (e *Exchange) Disconnect(ctx context.Context) error {
+	return e.market.Disconnect(ctx)
+}
+
+// Connected reports whether the market data stream is connected; sources
+// without a stream are always connected
+func (e *Exchange) Connected() bool {
+	if stream, ok := e.market.(gateway.StreamGateway); ok {
+		return stream.Connected()
+	}
+	return true
+}
+
+// Reconnect restores the market data stream; a no-op for sources without one
+func (e *Exchange) Reconnect(ctx context.Context) error {
+	if stream, ok := e.market.(gateway.StreamGateway); ok {
+		return stream.Reconnect(ctx)
+	}
+	return nil
+}
+
+// OrderLimits returns the market's minimum order requirements, so dry runs
+// skip the orders live trading would have rejected; none for sources without them
+func (e *Exchange) OrderLimits(ctx context.Context, symbol string) (entity.OrderLimits, error) {
+	if limits, ok := e.market.(gateway.LimitsGateway); ok {
+		return limits.OrderLimits(ctx, symbol)
+	}
+	return entity.OrderLimits{}, nil
+}
+
+// PlaceOrder simulates placing an order. Market orders and limit orders that
+// cross the last price fill as taker, after Latency if set; other limit orders
+// rest until the ticker price reaches them. Post-only orders that would cross are rejected.
+// Rejections match the Hyperliquid failure classes.
+func (e *Exchange) PlaceOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	if order.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity %f", hyperliquid.ErrInvalidOrder, order.Quantity)
+	}
+	if order.Type == entity.OrderTypeLimit && order.Price <= 0 {
+		return nil, fmt.Errorf("%w: limit price %f", hyperliquid.ErrInvalidOrder, order.Price)
+	}
+
+	e.mu.Lock()
+	if e.equityLocked() <= 0 && !order.ReduceOnly {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("%w: no paper equity left", hyperliquid.ErrInsufficientBalance)
+	}
+
+	// Reduce-only orders are checked against the position when placed
+	quantity := order.Quantity
+	if order.ReduceOnly {
+		pos, ok := e.positions[order.Symbol]
+		if !ok || pos.Side == order.Side {
+			e.mu.Unlock()
+			return nil, fmt.Errorf("%w: reduce-only order would increase position in %s", hyperliquid.ErrInvalidOrder, order.Symbol)
+		}
+		quantity = math.Min(quantity, pos.Size)
+	}
+
+	last, hasPrice := e.lastPrice[order.Symbol]
+	if order.Type == entity.OrderTypeMarket && !hasPrice {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("no market price for %s", order.Symbol)
+	}
+
+	if order.TimeInForce == entity.TimeInForcePostOnly && hasPrice && crosses(order, last) {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("%w: post-only order would cross at %f", hyperliquid.ErrInvalidOrder, last)
+	}
+
+	now := e.now()
+	e.nextID++
+	placed := *order
+	placed.ID = fmt.Sprintf("paper-%d", e.nextID)
+	placed.Quantity = quantity
+	placed.Status = entity.OrderStatusOpen
+	placed.FilledQty = 0
+	placed.CreatedAt = now
+	placed.UpdatedAt = now
+	e.orders[placed.ID] = &placed
+
+	updates := []entity.Order{placed}
+	delayed := hasPrice && crosses(&placed, last) && e.config.Latency > 0
+	if delayed {
+		e.inFlight[placed.ID] = true
+	} else if hasPrice && crosses(&placed, last) {
+		e.fillLocked(&placed, e.takerPrice(&placed, last), entity.LiquidityTaker)
+		updates = append(updates, placed)
+	}
+	result := placed
+	e.mu.Unlock()
+
+	e.emit(updates)
+	if delayed {
+		e.after(e.config.Latency, func() { e.fillDelayed(placed.ID) })
+	}
+	return &result, nil
+}
+
+// fillDelayed fills an order whose latency has elapsed at the price then,
+// unless it was canceled meanwhile. A limit order the price moved away from
+// keeps resting as a maker order.
+func (e *Exchange) fillDelayed(orderID string) {
+	e.mu.Lock()
+	delete(e.inFlight, orderID)
+	order, ok := e.orders[orderID]
+	if !ok || order.Status != entity.OrderStatusOpen {
+		e.mu.Unlock()
+		return
+	}
+	last := e.lastPrice[order.Symbol]
+	if !crosses(order, last) {
+		e.mu.Unlock()
+		return
+	}
+	e.fillLocked(order, e.takerPrice(order, last), entity.LiquidityTaker)
+	update := *order
+	e.mu.Unlock()
+
+	e.emit([]entity.Order{update})
+}
+
+// CancelOrder cancels an open order
+func (e *Exchange) CancelOrder(ctx context.Context, orderID string) error {
+	e.mu.Lock()
+	order, ok := e.orders[orderID]
+	if !ok {
+		e.mu.Unlock()
+		return fmt.Errorf("order not found: %s", orderID)
+	}
+	if order.Status != entity.OrderStatusOpen {
+		e.mu.Unlock()
+		return fmt.Errorf("order %s is %s", orderID, order.Status)
+	}
+	order.Status = entity.OrderStatusCanceled
+	order.UpdatedAt = e.now()
+	update := *order
+	e.mu.Unlock()
+
+	e.emit([]entity.Order{update})
+	return nil
+}
+
+// CancelAllOrders cancels all open orders for a symbol
+func (e *Exchange) CancelAllOrders(ctx context.Context, symbol string) error {
+	e.mu.Lock()
+	now := e.now()
+	var updates []entity.Order
+	for _, order := range e.orders {
+		if order.Symbol == symbol && order.Status == entity.OrderStatusOpen {
+			order.Status = entity.OrderStatusCanceled
+			order.UpdatedAt = now
+			updates = append(updates, *order)
+		}
+	}
+	e.mu.Unlock()
+
+	e.emit(updates)
+	return nil
+}
+
+// GetOrder retrieves order by ID
+func (e *Exchange) GetOrder(ctx context.Context, orderID string) (*entity.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	order, ok := e.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order not found: %s", orderID)
+	}
+	result := *order
+	return &result, nil
+}
+
+// GetOpenOrders retrieves all open orders for a symbol
+func (e *Exchange) GetOpenOrders(ctx context.Context, symbol string) ([]*entity.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var orders []*entity.Order
+	for _, order := range e.orders {
+		if order.Symbol == symbol && order.Status == entity.OrderStatusOpen {
+			o := *order
+			orders = append(orders, &o)
+		}
+	}
+	return orders, nil
+}
+
+// GetPosition retrieves the simulated position for a symbol (nil if flat)
+func (e *Exchange) GetPosition(ctx context.Context, symbol string) (*entity.Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pos, ok := e.positions[symbol]
+	if !ok {
+		return nil, nil
+	}
+	result := *pos
+	if mark, ok := e.lastPrice[symbol]; ok {
+		result.MarkPrice = mark
+		result.UnrealizedPnL = unrealizedPnL(pos, mark)
+	}
+	return &result, nil
+}
+
+// GetAccountValue returns the simulated equity: balance plus unrealized PnL
+func (e *Exchange) GetAccountValue(ctx context.Context) (float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.equityLocked(), nil
+}
+
+// GetBalance returns the simulated equity and position notional; paper
+// positions hold no margin, so all equity is withdrawable
+func (e *Exchange) GetBalance(ctx context.Context) (*entity.AccountBalance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	equity := e.equityLocked()
+	notional := 0.0
+	for symbol, pos := range e.positions {
+		if mark, ok := e.lastPrice[symbol]; ok {
+			notional += math.Abs(pos.Size * mark)
+		}
+	}
+	return &entity.AccountBalance{Equity: equity, Notional: notional, Withdrawable: equity}, nil
+}
+
+// Balance returns the simulated cash balance (realized PnL net of fees)
+func (e *Exchange) Balance() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.balance
+}
+
+// GetTicker retrieves current ticker from the market data source
+func (e *Exchange) GetTicker(ctx context.Context, symbol string) (*entity.Ticker, error) {
+	return e.market.GetTicker(ctx, symbol)
+}
+
+// GetOrderBook retrieves order book from the market data source
+func (e *Exchange) GetOrderBook(ctx context.Context, symbol string, depth int) (*entity.OrderBook, error) {
+	return e.market.GetOrderBook(ctx, symbol, depth)
+}
+
+// SubscribeTicker subscribes to live ticker updates. Resting orders are
+// matched against each tick before handler is called.
+func (e *Exchange) SubscribeTicker(ctx context.Context, symbol string, handler func(*entity.Ticker)) error {
+	return e.market.SubscribeTicker(ctx, symbol, func(ticker *entity.Ticker) {
+		e.OnTicker(ticker)
+		handler(ticker)
+	})
+}
+
+// SubscribeOrderBook subscribes to live order book updates
+func (e *Exchange) SubscribeOrderBook(ctx context.Context, symbol string, handler func(*entity.OrderBook)) error {
+	return e.market.SubscribeOrderBook(ctx, symbol, handler)
+}
+
+// SubscribeOrders subscribes to simulated order updates
+func (e *Exchange) SubscribeOrders(ctx context.Context, handler func(*entity.Order)) error {
+	e.mu.Lock()
+	e.orderHandlers = append(e.orderHandlers, handler)
+	e.mu.Unlock()
+	return nil
+}
+
+// OnTicker records the latest price and fills resting limit orders it crosses
+func (e *Exchange) OnTicker(ticker *entity.Ticker) {
+	if ticker == nil || ticker.LastPrice <= 0 {
+		return
+	}
+
+	e.mu.Lock()
+	e.lastPrice[ticker.Symbol] = ticker.LastPrice
+	var updates []entity.Order
+	for _, order := range e.orders {
+		if order.Symbol != ticker.Symbol || order.Status != entity.OrderStatusOpen || e.inFlight[order.ID] {
+			continue
+		}
+		if crosses(order, ticker.LastPrice) {
+			// Resting limit orders fill at their own price as maker
+			e.fillLocked(order, order.Price, entity.LiquidityMaker)
+			updates = append(updates, *order)
+		}
+	}
+	e.mu.Unlock()
+
+	e.emit(updates)
+}
+
+// crosses reports whether an order is marketable at price
+func crosses(order *entity.Order, price float64) bool {
+	if order.Type == entity.OrderTypeMarket {
+		return true
+	}
+	if order.Side == entity.SideBuy {
+		return price <= order.Price
+	}
+	return price >= order.Price
+}
+
+// takerPrice returns the fill price of a marketable order after slippage,
+// never worse than a limit order's own price
+func (e *Exchange) takerPrice(order *entity.Order, last float64) float64 {
+	bps := e.config.SlippageBps
+	if e.config.SlippageJitterBps > 0 {
+		bps += e.rng.Float64() * e.config.SlippageJitterBps
+	}
+	slip := last * bps / 10000
+	if order.Side == entity.SideBuy {
+		price := last + slip
+		if order.Type == entity.OrderTypeLimit && price > order.Price {
+			price = order.Price
+		}
+		return price
+	}
+	price := last - slip
+	if order.Type == entity.OrderTypeLimit && price < order.Price {
+		price = order.Price
+	}
+	return price
+}
+
+// fillLocked fills an order completely at price and updates position and balance.
+// Price holds the executed price and Fee the fee model's charge once the order is filled.
+func (e *Exchange) fillLocked(order *entity.Order, price float64, liquidity entity.Liquidity) {
+	order.Price = price
+	order.FilledQty = order.Quantity
+	order.Status = entity.OrderStatusFilled
+	order.UpdatedAt = e.now()
+
+	order.Fee = e.config.Fees.Fee(price, order.Quantity, liquidity)
+	e.balance -= order.Fee
+	e.applyFillLocked(order.Symbol, order.Side, order.Quantity, price)
+	if pos, ok := e.positions[order.Symbol]; ok {
+		pos.Fees += order.Fee
+	}
+}
+
+// applyFillLocked nets a fill into the symbol's position, realizing PnL on the closed part
+func (e *Exchange) applyFillLocked(symbol string, side entity.Side, qty, price float64) {
+	pos, ok := e.positions[symbol]
+	if !ok {
+		e.positions[symbol] = &entity.Position{
+			Symbol:     symbol,
+			Side:       side,
+			Size:       qty,
+			EntryPrice: price,
+			Leverage:   1,
+			UpdatedAt:  e.now(),
+		}
+		return
+	}
+	pos.UpdatedAt = e.now()
+
+	if pos.Side == side {
+		pos.EntryPrice = (pos.EntryPrice*pos.Size + price*qty) / (pos.Size + qty)
+		pos.Size += qty
+		return
+	}
+
+	closing := qty
+	if closing > pos.Size {
+		closing = pos.Size
+	}
+	pnl := entity.GrossPnL(pos.Side, pos.EntryPrice, price, closing)
+	pos.RealizedPnL += pnl
+	e.balance += pnl
+	pos.Size -= closing
+
+	if remaining := qty - closing; remaining > 0 {
+		// Position flips to the other side
+		pos.Side = side
+		pos.Size = remaining
+		pos.EntryPrice = price
+	} else if pos.Size == 0 {
+		delete(e.positions, symbol)
+	}
+}
+
+// equityLocked returns balance plus unrealized PnL at the last prices
+func (e *Exchange) equityLocked() float64 {
+	equity := e.balance
+	for symbol, pos := range e.positions {
+		if mark, ok := e.lastPrice[symbol]; ok {
+			equity += unrealizedPnL(pos, mark)
+		}
+	}
+	return equity
+}
+
+// unrealizedPnL returns the position's PnL at mark
+func unrealizedPnL(pos *entity.Position, mark float64) float64 {
+	pnl := (mark - pos.EntryPrice) * pos.Size
+	if pos.Side == entity.SideSell {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// emit sends order updates to subscribers
+func (e *Exchange) emit(updates []entity.Order) {
+	if len(updates) == 0 {
+		return
+	}
+	e.mu.Lock()
+	handlers := make([]func(*entity.Order), len(e.orderHandlers))
+	copy(handlers, e.orderHandlers)
+	e.mu.Unlock()
+
+	for i := range updates {
+		for _, handler := range handlers {
+			order := updates[i]
+			handler(&order)
+		}
+	}
+}
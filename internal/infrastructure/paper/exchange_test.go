@@ -0,0 +1,346 @@
+package paper
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// mockMarket is a market data source that lets tests push ticks
+type mockMarket struct {
+	gateway.ExchangeGateway
+	tickerHandlers []func(*entity.Ticker)
+}
+
+func (m *mockMarket) SubscribeTicker(ctx context.Context, symbol string, handler func(*entity.Ticker)) error {
+	m.tickerHandlers = append(m.tickerHandlers, handler)
+	return nil
+}
+
+func (m *mockMarket) tick(symbol string, price float64) {
+	for _, h := range m.tickerHandlers {
+		h(&entity.Ticker{Symbol: symbol, LastPrice: price})
+	}
+}
+
+func newTestExchange(t *testing.T, cfg Config) (*Exchange, *mockMarket, *[]entity.Order) {
+	t.Helper()
+	market := &mockMarket{}
+	ex := NewExchange(market, cfg)
+
+	var updates []entity.Order
+	ex.SubscribeOrders(context.Background(), func(o *entity.Order) {
+		updates = append(updates, *o)
+	})
+	ex.SubscribeTicker(context.Background(), "BTC", func(*entity.Ticker) {})
+	return ex, market, &updates
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestExchange_LimitFillsOnlyAfterPriceCrosses(t *testing.T) {
+	ex, market, updates := newTestExchange(t, Config{InitialBalance: 10000, Fees: entity.FeeModel{MakerBps: 1}})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	order, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 49000, Quantity: 0.1,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder failed: %v", err)
+	}
+	if order.Status != entity.OrderStatusOpen {
+		t.Fatalf("Expected open order, got %s", order.Status)
+	}
+
+	// Price moves down but not through the limit
+	market.tick("BTC", 49500)
+	if got, _ := ex.GetOrder(ctx, order.ID); got.Status != entity.OrderStatusOpen {
+		t.Fatalf("Expected order to stay open above the limit, got %s", got.Status)
+	}
+	if pos, _ := ex.GetPosition(ctx, "BTC"); pos != nil {
+		t.Fatalf("Expected no position before fill, got %+v", pos)
+	}
+
+	// Price crosses the limit
+	market.tick("BTC", 48900)
+	got, _ := ex.GetOrder(ctx, order.ID)
+	if got.Status != entity.OrderStatusFilled || got.FilledQty != 0.1 {
+		t.Fatalf("Expected order filled after crossing, got %s (%f)", got.Status, got.FilledQty)
+	}
+	if got.Price != 49000 {
+		t.Errorf("Expected resting limit to fill at its price, got %f", got.Price)
+	}
+
+	pos, _ := ex.GetPosition(ctx, "BTC")
+	if pos == nil || pos.Side != entity.SideBuy || pos.Size != 0.1 || pos.EntryPrice != 49000 {
+		t.Fatalf("Unexpected position: %+v", pos)
+	}
+	if !approxEqual(ex.Balance(), 10000-49000*0.1*0.0001) {
+		t.Errorf("Expected maker fee deducted, got balance %f", ex.Balance())
+	}
+
+	if len(*updates) != 2 || (*updates)[0].Status != entity.OrderStatusOpen || (*updates)[1].Status != entity.OrderStatusFilled {
+		t.Errorf("Expected open then filled updates, got %+v", *updates)
+	}
+}
+
+func TestExchange_SellLimitFillsAboveLimit(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	order, _ := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeLimit, Price: 51000, Quantity: 0.1,
+	})
+
+	market.tick("BTC", 50900)
+	if got, _ := ex.GetOrder(ctx, order.ID); got.Status != entity.OrderStatusOpen {
+		t.Fatalf("Expected sell limit to stay open below its price, got %s", got.Status)
+	}
+	market.tick("BTC", 51000)
+	if got, _ := ex.GetOrder(ctx, order.ID); got.Status != entity.OrderStatusFilled {
+		t.Fatalf("Expected sell limit filled at its price, got %s", got.Status)
+	}
+}
+
+func TestExchange_MarketOrderSlippageAndFee(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000, SlippageBps: 10, Fees: entity.FeeModel{TakerBps: 5}})
+	ctx := context.Background()
+
+	if _, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1,
+	}); err == nil {
+		t.Fatal("Expected market order without a price to be rejected")
+	}
+
+	market.tick("BTC", 50000)
+	order, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder failed: %v", err)
+	}
+	if order.Status != entity.OrderStatusFilled {
+		t.Fatalf("Expected market order filled, got %s", order.Status)
+	}
+	if !approxEqual(order.Price, 50050) {
+		t.Errorf("Expected 10bps slippage on buy, got %f", order.Price)
+	}
+	if !approxEqual(ex.Balance(), 10000-50050*0.1*0.0005) {
+		t.Errorf("Expected taker fee deducted, got balance %f", ex.Balance())
+	}
+	if !approxEqual(order.Fee, 50050*0.1*0.0005) {
+		t.Errorf("Expected taker fee recorded on the order, got %f", order.Fee)
+	}
+	if pos, _ := ex.GetPosition(ctx, "BTC"); pos == nil || !approxEqual(pos.Fees, order.Fee) {
+		t.Errorf("Expected the fee accumulated on the position, got %+v", pos)
+	}
+}
+
+func TestExchange_CrossingLimitCappedAtLimitPrice(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000, SlippageBps: 100})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	order, _ := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 50100, Quantity: 0.1,
+	})
+	if order.Status != entity.OrderStatusFilled {
+		t.Fatalf("Expected crossing limit to fill immediately, got %s", order.Status)
+	}
+	if order.Price != 50100 {
+		t.Errorf("Expected slippage capped at the limit price, got %f", order.Price)
+	}
+}
+
+func TestExchange_SlippageJitterIsSeeded(t *testing.T) {
+	cfg := Config{InitialBalance: 10000, SlippageBps: 2, SlippageJitterBps: 20, Seed: 42}
+	fill := func() float64 {
+		ex, market, _ := newTestExchange(t, cfg)
+		market.tick("BTC", 50000)
+		order, err := ex.PlaceOrder(context.Background(), &entity.Order{
+			Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1,
+		})
+		if err != nil {
+			t.Fatalf("PlaceOrder failed: %v", err)
+		}
+		return order.Price
+	}
+
+	price := fill()
+	if price <= 50000*(1+0.0002) || price > 50000*(1+0.0022) {
+		t.Errorf("Expected buy filled worse than 50000 within 2-22bps, got %f", price)
+	}
+	if again := fill(); again != price {
+		t.Errorf("Expected the same seed to give the same fill, got %f and %f", price, again)
+	}
+}
+
+func TestExchange_LatencyDelaysFill(t *testing.T) {
+	ex, market, updates := newTestExchange(t, Config{InitialBalance: 10000, SlippageBps: 10, Latency: 200 * time.Millisecond})
+	var delay time.Duration
+	var pending func()
+	ex.after = func(d time.Duration, f func()) { delay, pending = d, f }
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	order, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder failed: %v", err)
+	}
+	if order.Status != entity.OrderStatusOpen || pending == nil || delay != 200*time.Millisecond {
+		t.Fatalf("Expected fill scheduled after the latency, got %s (delay %s)", order.Status, delay)
+	}
+	if len(*updates) != 1 {
+		t.Fatalf("Expected only the open update before the latency elapses, got %+v", *updates)
+	}
+
+	// The price moves against the order while it is in flight
+	market.tick("BTC", 50200)
+	pending()
+	got, _ := ex.GetOrder(ctx, order.ID)
+	if got.Status != entity.OrderStatusFilled || !approxEqual(got.Price, 50200*1.001) {
+		t.Errorf("Expected fill at the later price plus slippage, got %s @ %f", got.Status, got.Price)
+	}
+	if len(*updates) != 2 || (*updates)[1].Status != entity.OrderStatusFilled {
+		t.Errorf("Expected the fill notified after the latency, got %+v", *updates)
+	}
+
+	// A crossing limit order the price leaves while in flight keeps resting
+	limit, _ := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 50300, Quantity: 0.1,
+	})
+	market.tick("BTC", 50400)
+	pending()
+	if got, _ := ex.GetOrder(ctx, limit.ID); got.Status != entity.OrderStatusOpen {
+		t.Errorf("Expected limit left resting after the price moved away, got %s", got.Status)
+	}
+}
+
+func TestExchange_RoundTripRealizesPnL(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	ex.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.2})
+
+	market.tick("BTC", 51000)
+	equity, _ := ex.GetAccountValue(ctx)
+	if !approxEqual(equity, 10200) {
+		t.Errorf("Expected equity to include unrealized PnL, got %f", equity)
+	}
+
+	ex.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.2})
+	if pos, _ := ex.GetPosition(ctx, "BTC"); pos != nil {
+		t.Errorf("Expected flat position after round trip, got %+v", pos)
+	}
+	if !approxEqual(ex.Balance(), 10200) {
+		t.Errorf("Expected realized PnL of 200, got balance %f", ex.Balance())
+	}
+}
+
+func TestExchange_PositionFlip(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	ex.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1})
+	market.tick("BTC", 49000)
+	ex.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.3})
+
+	pos, _ := ex.GetPosition(ctx, "BTC")
+	if pos == nil || pos.Side != entity.SideSell || !approxEqual(pos.Size, 0.2) || pos.EntryPrice != 49000 {
+		t.Fatalf("Expected 0.2 short at 49000 after flip, got %+v", pos)
+	}
+	if !approxEqual(ex.Balance(), 9900) {
+		t.Errorf("Expected realized loss of 100, got balance %f", ex.Balance())
+	}
+}
+
+func TestExchange_CancelAllOrders(t *testing.T) {
+	ex, market, updates := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	order, _ := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 45000, Quantity: 0.1,
+	})
+	if err := ex.CancelAllOrders(ctx, "BTC"); err != nil {
+		t.Fatalf("CancelAllOrders failed: %v", err)
+	}
+
+	open, _ := ex.GetOpenOrders(ctx, "BTC")
+	if len(open) != 0 {
+		t.Errorf("Expected no open orders, got %d", len(open))
+	}
+	last := (*updates)[len(*updates)-1]
+	if last.ID != order.ID || last.Status != entity.OrderStatusCanceled {
+		t.Errorf("Expected canceled update, got %+v", last)
+	}
+
+	// Canceled orders never fill
+	market.tick("BTC", 44000)
+	if pos, _ := ex.GetPosition(ctx, "BTC"); pos != nil {
+		t.Errorf("Expected no fill for canceled order, got %+v", pos)
+	}
+}
+
+func TestExchange_PostOnlyRejectedWhenCrossing(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	if _, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 50100, Quantity: 0.1,
+		TimeInForce: entity.TimeInForcePostOnly,
+	}); err == nil {
+		t.Fatal("Expected crossing post-only order to be rejected")
+	}
+
+	order, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeLimit, Price: 49900, Quantity: 0.1,
+		TimeInForce: entity.TimeInForcePostOnly,
+	})
+	if err != nil || order.Status != entity.OrderStatusOpen {
+		t.Fatalf("Expected resting post-only order, got %v, %v", order, err)
+	}
+}
+
+func TestExchange_ReduceOnly(t *testing.T) {
+	ex, market, _ := newTestExchange(t, Config{InitialBalance: 10000})
+	ctx := context.Background()
+
+	market.tick("BTC", 50000)
+	if _, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.1, ReduceOnly: true,
+	}); err == nil {
+		t.Fatal("Expected reduce-only order without a position to be rejected")
+	}
+
+	ex.PlaceOrder(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1})
+	if _, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 0.1, ReduceOnly: true,
+	}); err == nil {
+		t.Fatal("Expected reduce-only order increasing the position to be rejected")
+	}
+
+	// Oversized reduce-only orders close the position without flipping it
+	order, err := ex.PlaceOrder(ctx, &entity.Order{
+		Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 0.5, ReduceOnly: true,
+	})
+	if err != nil || order.FilledQty != 0.1 {
+		t.Fatalf("Expected reduce-only sell capped at 0.1, got %+v, %v", order, err)
+	}
+	if pos, _ := ex.GetPosition(ctx, "BTC"); pos != nil {
+		t.Errorf("Expected flat position, got %+v", pos)
+	}
+}
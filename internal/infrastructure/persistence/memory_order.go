@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+// Ensure MemoryOrderRepository implements OrderRepository
+var _ repository.OrderRepository = (*MemoryOrderRepository)(nil)
+
+// MemoryOrderRepository stores orders in memory
+type MemoryOrderRepository struct {
+	mu       sync.RWMutex
+	orders   map[string]*entity.Order
+	byClient map[string]string // client order ID -> order ID
+	seq      map[string]int    // insertion order for stable sorting
+	next     int
+}
+
+// NewMemoryOrderRepository creates a new in-memory order repository
+func NewMemoryOrderRepository() *MemoryOrderRepository {
+	return &MemoryOrderRepository{
+		orders:   make(map[string]*entity.Order),
+		byClient: make(map[string]string),
+		seq:      make(map[string]int),
+	}
+}
+
+// Create creates a new order
+func (r *MemoryOrderRepository) Create(ctx context.Context, order *entity.Order) error {
+	if order.ID == "" {
+		return fmt.Errorf("order ID is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[order.ID]; ok {
+		return fmt.Errorf("order %s: %w", order.ID, repository.ErrAlreadyExists)
+	}
+	if order.ClientOrderID != "" {
+		if _, ok := r.byClient[order.ClientOrderID]; ok {
+			return fmt.Errorf("client order %s: %w", order.ClientOrderID, repository.ErrAlreadyExists)
+		}
+		r.byClient[order.ClientOrderID] = order.ID
+	}
+
+	stored := *order
+	r.orders[order.ID] = &stored
+	r.seq[order.ID] = r.next
+	r.next++
+	return nil
+}
+
+// GetByID retrieves order by ID
+func (r *MemoryOrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("order %s: %w", id, repository.ErrNotFound)
+	}
+	result := *order
+	return &result, nil
+}
+
+// GetByClientOrderID retrieves order by client order ID
+func (r *MemoryOrderRepository) GetByClientOrderID(ctx context.Context, clientOrderID string) (*entity.Order, error) {
+	r.mu.RLock()
+	id, ok := r.byClient[clientOrderID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("client order %s: %w", clientOrderID, repository.ErrNotFound)
+	}
+	return r.GetByID(ctx, id)
+}
+
+// List retrieves orders matching the filter, most recent first
+func (r *MemoryOrderRepository) List(ctx context.Context, filter repository.OrderFilter) ([]*entity.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*entity.Order, 0)
+	for _, order := range r.orders {
+		if filter.Symbol != "" && order.Symbol != filter.Symbol {
+			continue
+		}
+		if filter.Status != "" && order.Status != filter.Status {
+			continue
+		}
+		if filter.Side != "" && order.Side != filter.Side {
+			continue
+		}
+		o := *order
+		result = append(result, &o)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return r.seq[result[i].ID] > r.seq[result[j].ID]
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+// Update updates order
+func (r *MemoryOrderRepository) Update(ctx context.Context, order *entity.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.orders[order.ID]
+	if !ok {
+		return fmt.Errorf("order %s: %w", order.ID, repository.ErrNotFound)
+	}
+
+	if order.ClientOrderID != existing.ClientOrderID {
+		if order.ClientOrderID != "" {
+			if id, ok := r.byClient[order.ClientOrderID]; ok && id != order.ID {
+				return fmt.Errorf("client order %s: %w", order.ClientOrderID, repository.ErrAlreadyExists)
+			}
+			r.byClient[order.ClientOrderID] = order.ID
+		}
+		delete(r.byClient, existing.ClientOrderID)
+	}
+
+	stored := *order
+	r.orders[order.ID] = &stored
+	return nil
+}
+
+// Delete deletes order
+func (r *MemoryOrderRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return fmt.Errorf("order %s: %w", id, repository.ErrNotFound)
+	}
+	if order.ClientOrderID != "" {
+		delete(r.byClient, order.ClientOrderID)
+	}
+	delete(r.orders, id)
+	delete(r.seq, id)
+	return nil
+}
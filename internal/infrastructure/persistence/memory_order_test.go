@@ -0,0 +1,206 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+func testOrder(id, clientID, symbol string, side entity.Side, status entity.OrderStatus, created time.Time) *entity.Order {
+	return &entity.Order{
+		ID:            id,
+		ClientOrderID: clientID,
+		Symbol:        symbol,
+		Side:          side,
+		Type:          entity.OrderTypeLimit,
+		Price:         100,
+		Quantity:      1,
+		Status:        status,
+		CreatedAt:     created,
+		UpdatedAt:     created,
+	}
+}
+
+func TestMemoryOrderRepository_CreateAndGet(t *testing.T) {
+	repo := NewMemoryOrderRepository()
+	ctx := context.Background()
+
+	order := testOrder("1", "c1", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	if err := repo.Create(ctx, order); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Symbol != "BTC" || got.ClientOrderID != "c1" {
+		t.Errorf("Unexpected order: %+v", got)
+	}
+
+	// Stored copies are isolated from caller mutation
+	order.Price = 999
+	got.Price = 555
+	if again, _ := repo.GetByID(ctx, "1"); again.Price != 100 {
+		t.Errorf("Expected stored price 100, got %.2f", again.Price)
+	}
+
+	byClient, err := repo.GetByClientOrderID(ctx, "c1")
+	if err != nil {
+		t.Fatalf("GetByClientOrderID failed: %v", err)
+	}
+	if byClient.ID != "1" {
+		t.Errorf("Expected order 1, got %s", byClient.ID)
+	}
+
+	if err := repo.Create(ctx, order); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for duplicate ID, got %v", err)
+	}
+	dup := testOrder("2", "c1", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	if err := repo.Create(ctx, dup); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for duplicate client ID, got %v", err)
+	}
+	if err := repo.Create(ctx, &entity.Order{}); err == nil {
+		t.Error("Expected error for empty ID")
+	}
+
+	if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := repo.GetByClientOrderID(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryOrderRepository_List(t *testing.T) {
+	repo := NewMemoryOrderRepository()
+	ctx := context.Background()
+	base := time.Now()
+
+	orders := []*entity.Order{
+		testOrder("1", "", "BTC", entity.SideBuy, entity.OrderStatusFilled, base),
+		testOrder("2", "", "BTC", entity.SideSell, entity.OrderStatusOpen, base.Add(time.Second)),
+		testOrder("3", "", "ETH", entity.SideBuy, entity.OrderStatusOpen, base.Add(2*time.Second)),
+		testOrder("4", "", "BTC", entity.SideBuy, entity.OrderStatusOpen, base.Add(3*time.Second)),
+	}
+	for _, o := range orders {
+		if err := repo.Create(ctx, o); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter repository.OrderFilter
+		want   []string
+	}{
+		{"all", repository.OrderFilter{}, []string{"4", "3", "2", "1"}},
+		{"symbol", repository.OrderFilter{Symbol: "BTC"}, []string{"4", "2", "1"}},
+		{"status", repository.OrderFilter{Status: entity.OrderStatusOpen}, []string{"4", "3", "2"}},
+		{"side", repository.OrderFilter{Side: entity.SideBuy}, []string{"4", "3", "1"}},
+		{"combined", repository.OrderFilter{Symbol: "BTC", Side: entity.SideBuy, Status: entity.OrderStatusOpen}, []string{"4"}},
+		{"limit", repository.OrderFilter{Symbol: "BTC", Limit: 2}, []string{"4", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d orders, got %d", len(tt.want), len(got))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("Position %d: expected %s, got %s", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryOrderRepository_Update(t *testing.T) {
+	repo := NewMemoryOrderRepository()
+	ctx := context.Background()
+
+	order := testOrder("1", "c1", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	repo.Create(ctx, order)
+
+	updated := *order
+	updated.Status = entity.OrderStatusFilled
+	updated.FilledQty = 1
+	updated.ClientOrderID = "c2"
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, _ := repo.GetByID(ctx, "1")
+	if got.Status != entity.OrderStatusFilled || got.FilledQty != 1 {
+		t.Errorf("Expected filled order, got %+v", got)
+	}
+	if _, err := repo.GetByClientOrderID(ctx, "c1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Error("Expected old client ID to be unindexed")
+	}
+	if _, err := repo.GetByClientOrderID(ctx, "c2"); err != nil {
+		t.Errorf("Expected new client ID to be indexed: %v", err)
+	}
+
+	missing := testOrder("x", "", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	if err := repo.Update(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryOrderRepository_Delete(t *testing.T) {
+	repo := NewMemoryOrderRepository()
+	ctx := context.Background()
+
+	repo.Create(ctx, testOrder("1", "c1", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now()))
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Error("Expected order to be deleted")
+	}
+	if _, err := repo.GetByClientOrderID(ctx, "c1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Error("Expected client ID index to be removed")
+	}
+	if err := repo.Delete(ctx, "1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestMemoryOrderRepository_Concurrent(t *testing.T) {
+	repo := NewMemoryOrderRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("%d", i)
+			order := testOrder(id, "c"+id, "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+			if err := repo.Create(ctx, order); err != nil {
+				t.Errorf("Create failed: %v", err)
+			}
+			order.Status = entity.OrderStatusFilled
+			repo.Update(ctx, order)
+			repo.List(ctx, repository.OrderFilter{Symbol: "BTC"})
+		}(i)
+	}
+	wg.Wait()
+
+	got, _ := repo.List(ctx, repository.OrderFilter{Status: entity.OrderStatusFilled})
+	if len(got) != 50 {
+		t.Errorf("Expected 50 filled orders, got %d", len(got))
+	}
+}
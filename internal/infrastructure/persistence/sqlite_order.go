@@ -0,0 +1,284 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+// Ensure SQLiteOrderRepository implements OrderRepository
+var _ repository.OrderRepository = (*SQLiteOrderRepository)(nil)
+
+// migrations are applied in order; the index of the last applied one is kept in PRAGMA user_version
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS orders (
+		id              TEXT PRIMARY KEY,
+		client_order_id TEXT NOT NULL DEFAULT '',
+		symbol          TEXT NOT NULL,
+		side            TEXT NOT NULL,
+		type            TEXT NOT NULL,
+		price           REAL NOT NULL,
+		quantity        REAL NOT NULL,
+		filled_qty      REAL NOT NULL,
+		status          TEXT NOT NULL,
+		created_at      INTEGER NOT NULL,
+		updated_at      INTEGER NOT NULL
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_client_order_id ON orders(client_order_id) WHERE client_order_id != '';
+	CREATE INDEX IF NOT EXISTS idx_orders_symbol_status ON orders(symbol, status);
+	CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at);`,
+	`ALTER TABLE orders ADD COLUMN time_in_force TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE orders ADD COLUMN fee REAL NOT NULL DEFAULT 0;`,
+}
+
+const orderColumns = "id, client_order_id, symbol, side, type, price, quantity, filled_qty, status, created_at, updated_at, time_in_force, fee"
+
+// SQLiteOrderRepository stores orders in a SQLite database
+type SQLiteOrderRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOrderRepository opens (or creates) the database at path and applies migrations
+func NewSQLiteOrderRepository(path string) (*SQLiteOrderRepository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer; serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000; PRAGMA journal_mode = WAL;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure database: %w", err)
+	}
+
+	r := &SQLiteOrderRepository{db: db}
+	if err := r.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Close closes the database
+func (r *SQLiteOrderRepository) Close() error {
+	return r.db.Close()
+}
+
+// migrate applies pending schema migrations
+func (r *SQLiteOrderRepository) migrate(ctx context.Context) error {
+	var version int
+	if err := r.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %d: %w", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Create creates a new order
+func (r *SQLiteOrderRepository) Create(ctx context.Context, order *entity.Order) error {
+	if order.ID == "" {
+		return fmt.Errorf("order ID is required")
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO orders ("+orderColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		order.ID, order.ClientOrderID, order.Symbol, string(order.Side), string(order.Type),
+		order.Price, order.Quantity, order.FilledQty, string(order.Status),
+		toUnixNano(order.CreatedAt), toUnixNano(order.UpdatedAt), string(order.TimeInForce), order.Fee,
+	)
+	if isConstraintError(err) {
+		return fmt.Errorf("order %s: %w", order.ID, repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert order: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves order by ID
+func (r *SQLiteOrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = ?", id)
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order %s: %w", id, repository.ErrNotFound)
+	}
+	return order, err
+}
+
+// GetByClientOrderID retrieves order by client order ID
+func (r *SQLiteOrderRepository) GetByClientOrderID(ctx context.Context, clientOrderID string) (*entity.Order, error) {
+	if clientOrderID == "" {
+		return nil, fmt.Errorf("client order %s: %w", clientOrderID, repository.ErrNotFound)
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE client_order_id = ?", clientOrderID)
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("client order %s: %w", clientOrderID, repository.ErrNotFound)
+	}
+	return order, err
+}
+
+// List retrieves orders matching the filter, most recent first
+func (r *SQLiteOrderRepository) List(ctx context.Context, filter repository.OrderFilter) ([]*entity.Order, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Symbol != "" {
+		conditions = append(conditions, "symbol = ?")
+		args = append(args, filter.Symbol)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if filter.Side != "" {
+		conditions = append(conditions, "side = ?")
+		args = append(args, string(filter.Side))
+	}
+
+	query := "SELECT " + orderColumns + " FROM orders"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, rowid DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]*entity.Order, 0)
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read orders: %w", err)
+	}
+	return result, nil
+}
+
+// Update updates order
+func (r *SQLiteOrderRepository) Update(ctx context.Context, order *entity.Order) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE orders SET client_order_id = ?, symbol = ?, side = ?, type = ?, price = ?, quantity = ?,
+			filled_qty = ?, status = ?, created_at = ?, updated_at = ?, time_in_force = ?, fee = ? WHERE id = ?`,
+		order.ClientOrderID, order.Symbol, string(order.Side), string(order.Type),
+		order.Price, order.Quantity, order.FilledQty, string(order.Status),
+		toUnixNano(order.CreatedAt), toUnixNano(order.UpdatedAt), string(order.TimeInForce), order.Fee, order.ID,
+	)
+	if isConstraintError(err) {
+		return fmt.Errorf("client order %s: %w", order.ClientOrderID, repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	return requireAffected(res, order.ID)
+}
+
+// Delete deletes order
+func (r *SQLiteOrderRepository) Delete(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM orders WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete order: %w", err)
+	}
+	return requireAffected(res, id)
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOrder reads an order row selected with orderColumns
+func scanOrder(s scanner) (*entity.Order, error) {
+	var (
+		o                    entity.Order
+		createdAt, updatedAt int64
+	)
+	err := s.Scan(&o.ID, &o.ClientOrderID, &o.Symbol, &o.Side, &o.Type,
+		&o.Price, &o.Quantity, &o.FilledQty, &o.Status, &createdAt, &updatedAt, &o.TimeInForce, &o.Fee)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan order: %w", err)
+	}
+
+	o.CreatedAt = fromUnixNano(createdAt)
+	o.UpdatedAt = fromUnixNano(updatedAt)
+	return &o, nil
+}
+
+// requireAffected returns ErrNotFound if no row was changed
+func requireAffected(res sql.Result, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("order %s: %w", id, repository.ErrNotFound)
+	}
+	return nil
+}
+
+// isConstraintError reports whether err is a primary key or unique violation
+func isConstraintError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || code == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// toUnixNano stores zero times as 0
+func toUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano maps 0 back to the zero time
+func fromUnixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns).UTC()
+}
@@ -0,0 +1,164 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+func newTestSQLite(t *testing.T) (*SQLiteOrderRepository, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "orders.db")
+	repo, err := NewSQLiteOrderRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteOrderRepository failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo, path
+}
+
+func TestSQLiteOrderRepository_RoundTrip(t *testing.T) {
+	repo, path := newTestSQLite(t)
+	ctx := context.Background()
+
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	order := &entity.Order{
+		ID:            "1",
+		ClientOrderID: "c1",
+		Symbol:        "BTC",
+		Side:          entity.SideBuy,
+		Type:          entity.OrderTypeLimit,
+		Price:         50000.5,
+		Quantity:      0.25,
+		FilledQty:     0.1,
+		Status:        entity.OrderStatusOpen,
+		TimeInForce:   entity.TimeInForcePostOnly,
+		CreatedAt:     created,
+		UpdatedAt:     created.Add(time.Second),
+		Fee:           0.75,
+	}
+	if err := repo.Create(ctx, order); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if *got != *order {
+		t.Errorf("Round trip mismatch:\n got %+v\nwant %+v", got, order)
+	}
+
+	byClient, err := repo.GetByClientOrderID(ctx, "c1")
+	if err != nil || byClient.ID != "1" {
+		t.Errorf("GetByClientOrderID failed: %v", err)
+	}
+
+	if err := repo.Create(ctx, order); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists, got %v", err)
+	}
+
+	// Data survives reopening the database
+	repo.Close()
+	reopened, err := NewSQLiteOrderRepository(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+	if _, err := reopened.GetByID(ctx, "1"); err != nil {
+		t.Errorf("Expected order after reopen: %v", err)
+	}
+}
+
+func TestSQLiteOrderRepository_List(t *testing.T) {
+	repo, _ := newTestSQLite(t)
+	ctx := context.Background()
+	base := time.Now()
+
+	orders := []*entity.Order{
+		testOrder("1", "", "BTC", entity.SideBuy, entity.OrderStatusFilled, base),
+		testOrder("2", "", "BTC", entity.SideSell, entity.OrderStatusOpen, base.Add(time.Second)),
+		testOrder("3", "", "ETH", entity.SideBuy, entity.OrderStatusOpen, base.Add(2*time.Second)),
+		testOrder("4", "", "BTC", entity.SideBuy, entity.OrderStatusOpen, base.Add(3*time.Second)),
+	}
+	for _, o := range orders {
+		if err := repo.Create(ctx, o); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter repository.OrderFilter
+		want   []string
+	}{
+		{"all", repository.OrderFilter{}, []string{"4", "3", "2", "1"}},
+		{"symbol", repository.OrderFilter{Symbol: "BTC"}, []string{"4", "2", "1"}},
+		{"status", repository.OrderFilter{Status: entity.OrderStatusOpen}, []string{"4", "3", "2"}},
+		{"side", repository.OrderFilter{Side: entity.SideBuy}, []string{"4", "3", "1"}},
+		{"combined", repository.OrderFilter{Symbol: "BTC", Side: entity.SideBuy, Status: entity.OrderStatusOpen}, []string{"4"}},
+		{"limit", repository.OrderFilter{Symbol: "BTC", Limit: 2}, []string{"4", "2"}},
+		{"injection", repository.OrderFilter{Symbol: "BTC' OR '1'='1"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d orders, got %d", len(tt.want), len(got))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("Position %d: expected %s, got %s", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLiteOrderRepository_UpdateDelete(t *testing.T) {
+	repo, _ := newTestSQLite(t)
+	ctx := context.Background()
+
+	order := testOrder("1", "c1", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	repo.Create(ctx, order)
+	repo.Create(ctx, testOrder("2", "c2", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now()))
+
+	order.Status = entity.OrderStatusFilled
+	order.FilledQty = order.Quantity
+	if err := repo.Update(ctx, order); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ := repo.GetByID(ctx, "1")
+	if got.Status != entity.OrderStatusFilled || got.FilledQty != 1 {
+		t.Errorf("Expected filled order, got %+v", got)
+	}
+
+	order.ClientOrderID = "c2"
+	if err := repo.Update(ctx, order); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for duplicate client ID, got %v", err)
+	}
+
+	missing := testOrder("x", "", "BTC", entity.SideBuy, entity.OrderStatusOpen, time.Now())
+	if err := repo.Update(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on update, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, "1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound on second delete, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package pollutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DrainTimeout bounds how long Stop waits for goroutines when ctx has no earlier deadline
+const DrainTimeout = 5 * time.Second
+
+// Group tracks a client's polling goroutines so it can stop them and wait for
+// them to exit on Disconnect. The zero value is ready to use.
+type Group struct {
+	mu      sync.Mutex
+	nextID  int
+	cancels map[int]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// Go runs fn in a new goroutine with a child of ctx that Stop cancels
+func (g *Group) Go(ctx context.Context, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	g.mu.Lock()
+	if g.cancels == nil {
+		g.cancels = make(map[int]context.CancelFunc)
+	}
+	id := g.nextID
+	g.nextID++
+	g.cancels[id] = cancel
+	g.wg.Add(1)
+	g.mu.Unlock()
+
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			g.mu.Lock()
+			delete(g.cancels, id)
+			g.mu.Unlock()
+			cancel()
+		}()
+		fn(ctx)
+	}()
+}
+
+// Stop cancels the group's goroutines and waits for them to exit, up to
+// DrainTimeout or until ctx is done
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	for _, cancel := range g.cancels {
+		cancel()
+	}
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, DrainTimeout)
+	defer cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for polling goroutines: %w", ctx.Err())
+	}
+}
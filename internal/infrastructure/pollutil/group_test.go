@@ -0,0 +1,58 @@
+package pollutil
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_StopWaitsAndSilencesHandlers(t *testing.T) {
+	jitter = func(time.Duration) time.Duration { return 0 }
+	defer func() { jitter = defaultJitter }()
+
+	var g Group
+	var handled, afterStop atomic.Int32
+	var stopped atomic.Bool
+	inFetch := make(chan struct{}, 1)
+	for i := 0; i < 3; i++ {
+		Start(context.Background(), &g, 5*time.Millisecond, func(ctx context.Context) (int, error) {
+			select {
+			case inFetch <- struct{}{}:
+			default:
+			}
+			// A slow request that only returns once canceled
+			<-ctx.Done()
+			return 1, nil
+		}, func(int) {
+			handled.Add(1)
+			if stopped.Load() {
+				afterStop.Add(1)
+			}
+		})
+	}
+
+	<-inFetch
+	if err := g.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	stopped.Store(true)
+
+	time.Sleep(30 * time.Millisecond)
+	if handled.Load() != 0 || afterStop.Load() != 0 {
+		t.Errorf("Expected no handler calls for canceled fetches or after Stop, got %d", handled.Load())
+	}
+}
+
+func TestGroup_StopTimesOut(t *testing.T) {
+	var g Group
+	release := make(chan struct{})
+	defer close(release)
+	g.Go(context.Background(), func(context.Context) { <-release }) // Ignores cancellation
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.Stop(ctx); err == nil {
+		t.Error("Expected Stop to give up on a goroutine that does not exit")
+	}
+}
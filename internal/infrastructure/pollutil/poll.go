@@ -0,0 +1,59 @@
+// Package pollutil runs the polling loops behind the data source clients'
+// Subscribe methods.
+package pollutil
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// jitter returns the delay before the first fetch; replaced in tests
+var jitter = defaultJitter
+
+// defaultJitter returns a random delay below interval
+func defaultJitter(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	return rand.N(interval)
+}
+
+// Start calls fetch every interval in a new goroutine until ctx is done or
+// group is stopped, and passes each successful result to handler; a failed
+// fetch skips that tick. The first fetch happens after a random delay of up to
+// one interval, so subscriptions started together do not poll their APIs in
+// lockstep. A nil group leaves the goroutine untracked. The returned channel is
+// closed once the goroutine has exited.
+func Start[T any](ctx context.Context, group *Group, interval time.Duration, fetch func(context.Context) (T, error), handler func(T)) <-chan struct{} {
+	done := make(chan struct{})
+	run := func(ctx context.Context) {
+		defer close(done)
+
+		timer := time.NewTimer(jitter(interval))
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			// Check again so a fetch never starts after cancellation
+			if ctx.Err() != nil {
+				return
+			}
+			if v, err := fetch(ctx); err == nil && ctx.Err() == nil {
+				handler(v)
+			}
+			timer.Reset(interval)
+		}
+	}
+
+	if group == nil {
+		go run(ctx)
+	} else {
+		group.Go(ctx, run)
+	}
+	return done
+}
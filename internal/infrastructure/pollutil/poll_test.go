@@ -0,0 +1,71 @@
+package pollutil
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStart_FiresOnScheduleAndStops(t *testing.T) {
+	jitter = func(time.Duration) time.Duration { return 0 }
+	defer func() { jitter = defaultJitter }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var fetches, handled atomic.Int32
+	done := Start(ctx, nil, 20*time.Millisecond, func(context.Context) (int32, error) {
+		n := fetches.Add(1)
+		if n%2 == 0 {
+			return 0, errors.New("failed")
+		}
+		return n, nil
+	}, func(int32) { handled.Add(1) })
+
+	time.Sleep(110 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the poller to stop after cancel")
+	}
+
+	n := fetches.Load()
+	if n < 4 || n > 7 {
+		t.Errorf("Expected about 6 fetches at 20ms over 110ms, got %d", n)
+	}
+	if want := (n + 1) / 2; handled.Load() != want {
+		t.Errorf("Expected failed fetches to be skipped (%d handled), got %d", want, handled.Load())
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if fetches.Load() != n {
+		t.Errorf("Expected no fetches after cancel, got %d more", fetches.Load()-n)
+	}
+}
+
+func TestStart_JitteredStart(t *testing.T) {
+	var delay time.Duration
+	jitter = func(interval time.Duration) time.Duration {
+		delay = interval / 2
+		return delay
+	}
+	defer func() { jitter = defaultJitter }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := make(chan time.Time, 1)
+	start := time.Now()
+	Start(ctx, nil, 100*time.Millisecond, func(context.Context) (struct{}, error) {
+		select {
+		case first <- time.Now():
+		default:
+		}
+		return struct{}{}, nil
+	}, func(struct{}) {})
+
+	at := <-first
+	if elapsed := at.Sub(start); elapsed < delay || elapsed > delay+40*time.Millisecond {
+		t.Errorf("Expected the first fetch after the %s jitter, got %s", delay, elapsed)
+	}
+}
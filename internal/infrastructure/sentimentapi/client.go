@@ -0,0 +1,174 @@
+// Package sentimentapi reads social sentiment from a generic REST endpoint.
+//
+// The endpoint URL may contain a {symbol} placeholder, replaced by the base
+// asset of the trading symbol (e.g. "BTC"), and must respond with a JSON object of the form:
+//
+//	{
+//	  "symbol": "BTC",
+//	  "score": 0.35,         // -1 (bearish) to 1 (bullish), required
+//	  "positive": 0.55,      // Share of positive posts (0-1)
+//	  "negative": 0.20,      // Share of negative posts (0-1)
+//	  "neutral": 0.25,       // Share of neutral posts (0-1)
+//	  "volume": 12000,       // Posts counted
+//	  "interactions": 850000,
+//	  "contributors": 4100,
+//	  "timestamp": 1700000000 // Unix seconds (0 = now)
+//	}
+package sentimentapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
+)
+
+// Source is the SocialSentiment source name of this client
+const Source = "sentiment_api"
+
+// Ensure Client implements SentimentGateway
+var _ gateway.SentimentGateway = (*Client)(nil)
+
+// Client fetches sentiment from a configurable REST endpoint
+type Client struct {
+	url          string
+	apiKey       string
+	httpClient   *http.Client
+	retry        httputil.RetryConfig
+	breaker      *httputil.Breaker
+	pollInterval time.Duration
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
+}
+
+// Response is the JSON shape the endpoint must return
+type Response struct {
+	Symbol       string   `json:"symbol"`
+	Score        *float64 `json:"score"`
+	Positive     float64  `json:"positive"`
+	Negative     float64  `json:"negative"`
+	Neutral      float64  `json:"neutral"`
+	Volume       int64    `json:"volume"`
+	Interactions int64    `json:"interactions"`
+	Contributors int64    `json:"contributors"`
+	Timestamp    int64    `json:"timestamp"`
+}
+
+// NewClient creates a client for the endpoint URL; apiKey is sent as a
+// bearer token when non-empty
+func NewClient(endpointURL, apiKey string) *Client {
+	return &Client{
+		url:    endpointURL,
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+		retry:        httputil.DefaultRetryConfig(),
+		breaker:      httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		pollInterval: 60 * time.Second,
+	}
+}
+
+// Connect validates the endpoint
+func (c *Client) Connect(ctx context.Context) error {
+	_, err := c.GetSentiment(ctx, "BTC")
+	return err
+}
+
+// Disconnect stops subscriptions and waits for their goroutines to exit
+func (c *Client) Disconnect(ctx context.Context) error {
+	return c.pollers.Stop(ctx)
+}
+
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *Client) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
+
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *Client) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
+
+// BreakerState returns the circuit breaker state
+func (c *Client) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
+}
+
+// SetPollInterval sets how often SubscribeSentiment polls the endpoint
+func (c *Client) SetPollInterval(d time.Duration) {
+	c.pollInterval = d
+}
+
+// GetSentiment retrieves current sentiment for a symbol
+func (c *Client) GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error) {
+	endpoint := strings.ReplaceAll(c.url, "{symbol}", url.PathEscape(entity.Symbol(symbol).Base()))
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	body, err := c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, endpoint, header, c.retry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Score == nil {
+		return nil, fmt.Errorf("response has no score")
+	}
+	return resp.toEntity(symbol), nil
+}
+
+// toEntity converts the response to a SocialSentiment, clamping the score to [-1, 1]
+func (r *Response) toEntity(symbol string) *entity.SocialSentiment {
+	score := *r.Score
+	if score > 1 {
+		score = 1
+	} else if score < -1 {
+		score = -1
+	}
+
+	timestamp := time.Now()
+	if r.Timestamp > 0 {
+		timestamp = time.Unix(r.Timestamp, 0)
+	}
+
+	return &entity.SocialSentiment{
+		Symbol:         symbol,
+		Source:         Source,
+		Sentiment:      (score + 1) / 2, // Convert to 0-1 scale
+		SentimentScore: score,
+		PositiveRatio:  r.Positive,
+		NegativeRatio:  r.Negative,
+		NeutralRatio:   r.Neutral,
+		SocialVolume:   r.Volume,
+		Interactions:   r.Interactions,
+		Contributors:   r.Contributors,
+		Timestamp:      timestamp,
+	}
+}
+
+// SubscribeSentiment subscribes to sentiment updates (polling)
+func (c *Client) SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error {
+	pollutil.Start(ctx, &c.pollers, c.pollInterval, func(ctx context.Context) (*entity.SocialSentiment, error) {
+		return c.GetSentiment(ctx, symbol)
+	}, handler)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package sentimentapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestClient_GetSentiment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sentiment/BTC" {
+			t.Errorf("Expected symbol in path, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer key" {
+			t.Errorf("Expected bearer token, got %q", got)
+		}
+		w.Write([]byte(`{"symbol":"BTC","score":0.4,"positive":0.6,"negative":0.2,"neutral":0.2,"volume":1200,"interactions":50000,"timestamp":1700000000}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL+"/sentiment/{symbol}", "key")
+	sentiment, err := c.GetSentiment(context.Background(), "btc")
+	if err != nil {
+		t.Fatalf("GetSentiment failed: %v", err)
+	}
+	if sentiment.SentimentScore != 0.4 || sentiment.Sentiment != 0.7 {
+		t.Errorf("Expected score 0.4 (0.7 on the 0-1 scale), got %+v", sentiment)
+	}
+	if sentiment.Source != Source || sentiment.SocialVolume != 1200 || sentiment.PositiveRatio != 0.6 {
+		t.Errorf("Unexpected sentiment: %+v", sentiment)
+	}
+	if sentiment.Timestamp.Unix() != 1700000000 {
+		t.Errorf("Expected response timestamp, got %s", sentiment.Timestamp)
+	}
+}
+
+func TestClient_GetSentiment_RequiresScore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"symbol":"BTC","volume":10}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "")
+	if _, err := c.GetSentiment(context.Background(), "BTC"); err == nil {
+		t.Error("Expected error for a response without a score")
+	}
+}
+
+func TestClient_DisconnectStopsSubscription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"symbol":"BTC","score":0.1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "")
+	c.SetPollInterval(5 * time.Millisecond)
+	var calls atomic.Int32
+	if err := c.SubscribeSentiment(context.Background(), "BTC", func(*entity.SocialSentiment) { calls.Add(1) }); err != nil {
+		t.Fatalf("SubscribeSentiment failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if calls.Load() == 0 {
+		t.Fatal("Expected the subscription to poll")
+	}
+
+	if err := c.Disconnect(context.Background()); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	n := calls.Load()
+	time.Sleep(30 * time.Millisecond)
+	if got := calls.Load(); got != n {
+		t.Errorf("Expected no callbacks after Disconnect, got %d more", got-n)
+	}
+}
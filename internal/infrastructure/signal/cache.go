@@ -0,0 +1,30 @@
+package signal
+
+import (
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func liquidationTime(l *entity.Liquidation) time.Time { return l.Timestamp }
+func whaleAlertTime(a *entity.WhaleAlert) time.Time   { return a.Timestamp }
+
+// appendRecent appends item to events, in arrival order, and prunes the result
+func appendRecent[T any](events []T, item T, cutoff time.Time, max int, ts func(T) time.Time) []T {
+	return pruneRecent(append(events, item), cutoff, max, ts)
+}
+
+// pruneRecent drops events not after cutoff and then the oldest arrivals beyond
+// max. It returns a new slice, so callers holding the old one are unaffected.
+func pruneRecent[T any](events []T, cutoff time.Time, max int, ts func(T) time.Time) []T {
+	kept := make([]T, 0, len(events))
+	for _, e := range events {
+		if ts(e).After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	if max > 0 && len(kept) > max {
+		kept = append([]T(nil), kept[len(kept)-max:]...)
+	}
+	return kept
+}
@@ -0,0 +1,66 @@
+package signal
+
+import (
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// CascadeConfig controls liquidation cascade detection
+type CascadeConfig struct {
+	Window   time.Duration // Lookback over which liquidations are summed
+	MinValue float64       // USD liquidated on one side within Window to flag a cascade
+}
+
+// DefaultCascadeConfig returns default cascade detection settings
+func DefaultCascadeConfig() CascadeConfig {
+	return CascadeConfig{
+		Window:   5 * time.Minute,
+		MinValue: 10_000_000,
+	}
+}
+
+// enabled reports whether detection is configured
+func (c CascadeConfig) enabled() bool {
+	return c.Window > 0 && c.MinValue > 0
+}
+
+// detectCascade flags a cascade when the side liquidated most within the window
+// exceeds MinValue and is accelerating: more of it was liquidated in the later
+// half of the window than in the earlier half. It returns nil otherwise.
+func detectCascade(liqs []*entity.Liquidation, now time.Time, cfg CascadeConfig) *entity.LiquidationCascade {
+	if !cfg.enabled() {
+		return nil
+	}
+
+	start := now.Add(-cfg.Window)
+	mid := now.Add(-cfg.Window / 2)
+	early, late := make(map[string]float64, 2), make(map[string]float64, 2)
+	for _, l := range liqs {
+		switch {
+		case !l.Timestamp.After(start) || l.Timestamp.After(now):
+			continue
+		case l.Timestamp.After(mid):
+			late[l.Side] += l.Value
+		default:
+			early[l.Side] += l.Value
+		}
+	}
+
+	side, direction := "long", entity.SignalBiasBullish
+	if early["short"]+late["short"] > early["long"]+late["long"] {
+		side, direction = "short", entity.SignalBiasBearish
+	}
+	total := early[side] + late[side]
+	if total < cfg.MinValue || late[side] <= early[side] {
+		return nil
+	}
+
+	return &entity.LiquidationCascade{
+		Side:      side,
+		Direction: direction,
+		ValueUSD:  total,
+		Magnitude: total / cfg.MinValue,
+		Window:    cfg.Window,
+	}
+}
@@ -2,81 +2,296 @@ package signal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/coinglass"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/feargreed"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/lunarcrush"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/macro"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/sentimentapi"
 	"github.com/zono819/hyperliquid-bot/internal/infrastructure/whalealert"
 )
 
+// Data source names used for staleness tracking
+const (
+	SourceCoinGlass  = "coinglass"
+	SourceWhaleAlert = "whalealert"
+	SourceLunarCrush = "lunarcrush"
+	SourceMacro      = "macro"
+
+	SourceSentimentAPI = sentimentapi.Source
+	SourceFearGreed    = "feargreed"
+)
+
+// MaxAgeConfig holds the maximum age of data per source before it is treated as stale
+type MaxAgeConfig struct {
+	CoinGlass  time.Duration
+	WhaleAlert time.Duration
+	LunarCrush time.Duration
+	Macro      time.Duration
+
+	SentimentAPI time.Duration
+	FearGreed    time.Duration
+}
+
+// DefaultMaxAgeConfig returns default max ages
+func DefaultMaxAgeConfig() MaxAgeConfig {
+	return MaxAgeConfig{
+		CoinGlass:  5 * time.Minute,
+		WhaleAlert: 5 * time.Minute,
+		LunarCrush: 5 * time.Minute,
+		Macro:      30 * time.Minute, // Macro data is refreshed every 10 minutes
+
+		SentimentAPI: 5 * time.Minute,
+		FearGreed:    24 * time.Hour, // The index is published daily
+	}
+}
+
+// get returns the max age for a source
+func (c MaxAgeConfig) get(source string) time.Duration {
+	switch source {
+	case SourceCoinGlass:
+		return c.CoinGlass
+	case SourceWhaleAlert:
+		return c.WhaleAlert
+	case SourceLunarCrush:
+		return c.LunarCrush
+	case SourceMacro:
+		return c.Macro
+	case SourceSentimentAPI:
+		return c.SentimentAPI
+	case SourceFearGreed:
+		return c.FearGreed
+	default:
+		return 0
+	}
+}
+
+// Event cache limits
+const (
+	liquidationRetention = 10 * time.Minute
+	whaleAlertRetention  = 30 * time.Minute
+
+	DefaultMaxCachedEvents = 500 // Per symbol and event type
+)
+
+// SourceHealth describes data freshness for a single source
+type SourceHealth struct {
+	LastUpdate time.Time `json:"last_update"`
+	Fresh      bool      `json:"fresh"`
+}
+
+// SentimentSource is a named sentiment provider; the name keys its staleness tracking
+type SentimentSource struct {
+	Name     string
+	Provider gateway.SentimentProvider
+}
+
 // Provider aggregates multiple data sources for market signals
 type Provider struct {
-	coinglass     *coinglass.Client
-	whalealert    *whalealert.Client
-	lunarcrush    *lunarcrush.Client
+	coinglass     gateway.DataSourceGateway
+	whalealert    gateway.DataSourceGateway
+	sentiment     []SentimentSource // Blended into a single SocialSentiment
+	fearGreed     gateway.FearGreedGateway
 	macroProvider *macro.Provider
+	log           *logger.Logger
 
 	mu             sync.RWMutex
 	running        bool
 	symbols        []string
+	weights        entity.SignalWeights
+	sentimentFloor entity.SentimentVolumeFloor
+	fetchTimeout   time.Duration
+	maxAge         MaxAgeConfig
+	cascade        CascadeConfig
+	maxEvents      int
 	signalHandlers []func(*entity.MarketSignal)
+	now            func() time.Time
+	pollers        pollutil.Group // Background collection, drained by Stop
+	macroUpdated   chan struct{}  // Signals collectData to rebroadcast after a macro update
+
+	// Per-source connection state
+	lastUpdate map[string]time.Time
+	connected  map[string]bool
+	lastError  map[string]error
 
 	// Cached data
-	recentWhaleAlerts  map[string][]*entity.WhaleAlert     // symbol -> alerts
-	recentLiquidations map[string][]*entity.Liquidation    // symbol -> liquidations
-	recentSentiment    map[string]*entity.SocialSentiment  // symbol -> sentiment
-	cachedMacro        *entity.MacroSignal                 // macro signal
+	recentWhaleAlerts  map[string][]*entity.WhaleAlert               // symbol -> alerts
+	recentLiquidations map[string][]*entity.Liquidation              // symbol -> liquidations
+	recentSentiment    map[string]map[string]*entity.SocialSentiment // symbol -> source -> sentiment
+	cachedMacro        *entity.MacroSignal                           // macro signal
+	cachedFearGreed    *entity.FearGreedIndex                        // market-wide, not per symbol
+	sentimentTrends    map[string]*entity.SentimentTrend             // symbol -> trend from sentiment history
 }
 
 // Config holds provider configuration
 type Config struct {
 	CoinGlassAPIKey        string
+	CoinGlassAggregate     bool // Aggregate funding and L/S across exchanges
 	WhaleAlertAPIKey       string
 	WhaleMinValue          float64
+	WhaleBlockchains       []string           // Blockchains to poll (empty = derived from Symbols)
+	WhaleChainMinValue     map[string]float64 // Per-blockchain minimum USD value
 	LunarCrushAPIKey       string
+	SentimentAPIURL        string // Generic REST sentiment endpoint (see package sentimentapi)
+	SentimentAPIKey        string
 	FedWatchAPIKey         string
 	TradingEconomicsAPIKey string
 	Symbols                []string
+	Weights                *entity.SignalWeights // nil = default weights
+	FetchTimeout           time.Duration         // Per-call timeout for API fetches (0 = default)
+	MaxAttempts            int                   // Attempts per API request (0 = client default)
+	MaxAge                 *MaxAgeConfig         // nil = default max ages
+	Breaker                *httputil.BreakerConfig // nil = default circuit breaker per client
+	MacroProvider          *macro.Provider         // Overrides the FedWatch/TradingEconomics keys when set
+
+	Sentiment []SentimentSource // Additional sentiment sources, averaged with the configured ones
+	FearGreed bool              // Fetch the alternative.me fear & greed index
+	Cascade   *CascadeConfig    // nil = default liquidation cascade detection
+
+	MaxCachedEvents int // Liquidations and whale alerts kept per symbol (0 = DefaultMaxCachedEvents)
+
+	SentimentFloor entity.SentimentVolumeFloor // Social volume below which sentiment is ignored (0 = no floor)
 }
 
-// NewProvider creates a new signal provider
-func NewProvider(cfg Config) *Provider {
-	var cg *coinglass.Client
-	var wa *whalealert.Client
-	var lc *lunarcrush.Client
-	var mp *macro.Provider
+// NewProvider creates a new signal provider (nil log uses the default logger)
+func NewProvider(cfg Config, log *logger.Logger) *Provider {
+	if log == nil {
+		log = logger.Default()
+	}
+
+	weights := entity.DefaultSignalWeights()
+	if cfg.Weights != nil {
+		weights = *cfg.Weights
+	}
+
+	fetchTimeout := cfg.FetchTimeout
+	if fetchTimeout == 0 {
+		fetchTimeout = 10 * time.Second
+	}
+
+	maxAge := DefaultMaxAgeConfig()
+	if cfg.MaxAge != nil {
+		maxAge = *cfg.MaxAge
+	}
+
+	maxEvents := cfg.MaxCachedEvents
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxCachedEvents
+	}
+
+	cascade := DefaultCascadeConfig()
+	if cfg.Cascade != nil {
+		cascade = *cfg.Cascade
+	}
+
+	symbols := make([]string, len(cfg.Symbols))
+	for i, symbol := range cfg.Symbols {
+		symbols[i] = entity.Symbol(symbol).Normalize().String()
+	}
+
+	p := &Provider{
+		symbols:            symbols,
+		weights:            weights,
+		sentimentFloor:     cfg.SentimentFloor,
+		fetchTimeout:       fetchTimeout,
+		maxAge:             maxAge,
+		cascade:            cascade,
+		maxEvents:          maxEvents,
+		log:                log,
+		signalHandlers:     make([]func(*entity.MarketSignal), 0),
+		now:                time.Now,
+		macroUpdated:       make(chan struct{}, 1),
+		lastUpdate:         make(map[string]time.Time),
+		connected:          make(map[string]bool),
+		lastError:          make(map[string]error),
+		recentWhaleAlerts:  make(map[string][]*entity.WhaleAlert),
+		recentLiquidations: make(map[string][]*entity.Liquidation),
+		recentSentiment:    make(map[string]map[string]*entity.SocialSentiment),
+		sentimentTrends:    make(map[string]*entity.SentimentTrend),
+	}
 
 	if cfg.CoinGlassAPIKey != "" {
-		cg = coinglass.NewClient(cfg.CoinGlassAPIKey)
+		cg := coinglass.NewClient(cfg.CoinGlassAPIKey)
+		cg.SetAggregate(cfg.CoinGlassAggregate)
+		if cfg.MaxAttempts > 0 {
+			cg.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			cg.SetBreakerConfig(*cfg.Breaker)
+		}
+		p.coinglass = cg
 	}
 	if cfg.WhaleAlertAPIKey != "" {
-		wa = whalealert.NewClient(cfg.WhaleAlertAPIKey, cfg.WhaleMinValue)
+		wa := whalealert.NewClient(cfg.WhaleAlertAPIKey, cfg.WhaleMinValue)
+		chains := cfg.WhaleBlockchains
+		if len(chains) == 0 {
+			chains = blockchainsFor(cfg.Symbols)
+		}
+		wa.SetBlockchains(chains...)
+		for chain, usd := range cfg.WhaleChainMinValue {
+			wa.SetMinValue(chain, usd)
+		}
+		if cfg.MaxAttempts > 0 {
+			wa.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			wa.SetBreakerConfig(*cfg.Breaker)
+		}
+		p.whalealert = wa
 	}
 	if cfg.LunarCrushAPIKey != "" {
-		lc = lunarcrush.NewClient(cfg.LunarCrushAPIKey)
+		lc := lunarcrush.NewClient(cfg.LunarCrushAPIKey)
+		if cfg.MaxAttempts > 0 {
+			lc.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			lc.SetBreakerConfig(*cfg.Breaker)
+		}
+		p.sentiment = append(p.sentiment, SentimentSource{Name: SourceLunarCrush, Provider: lc})
+	}
+	if cfg.SentimentAPIURL != "" {
+		sa := sentimentapi.NewClient(cfg.SentimentAPIURL, cfg.SentimentAPIKey)
+		if cfg.MaxAttempts > 0 {
+			sa.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			sa.SetBreakerConfig(*cfg.Breaker)
+		}
+		p.sentiment = append(p.sentiment, SentimentSource{Name: SourceSentimentAPI, Provider: sa})
+	}
+	p.sentiment = append(p.sentiment, cfg.Sentiment...)
+	if cfg.FearGreed {
+		fg := feargreed.NewClient()
+		if cfg.MaxAttempts > 0 {
+			fg.SetMaxAttempts(cfg.MaxAttempts)
+		}
+		if cfg.Breaker != nil {
+			fg.SetBreakerConfig(*cfg.Breaker)
+		}
+		p.fearGreed = fg
 	}
-	if cfg.FedWatchAPIKey != "" || cfg.TradingEconomicsAPIKey != "" {
-		mp = macro.NewProvider(macro.Config{
+	if cfg.MacroProvider != nil {
+		p.macroProvider = cfg.MacroProvider
+	} else if cfg.FedWatchAPIKey != "" || cfg.TradingEconomicsAPIKey != "" {
+		p.macroProvider = macro.NewProvider(macro.Config{
 			FedWatchAPIKey:         cfg.FedWatchAPIKey,
 			TradingEconomicsAPIKey: cfg.TradingEconomicsAPIKey,
+			MaxAttempts:            cfg.MaxAttempts,
+			Breaker:                cfg.Breaker,
 		})
 	}
 
-	return &Provider{
-		coinglass:          cg,
-		whalealert:         wa,
-		lunarcrush:         lc,
-		macroProvider:      mp,
-		symbols:            cfg.Symbols,
-		signalHandlers:     make([]func(*entity.MarketSignal), 0),
-		recentWhaleAlerts:  make(map[string][]*entity.WhaleAlert),
-		recentLiquidations: make(map[string][]*entity.Liquidation),
-		recentSentiment:    make(map[string]*entity.SocialSentiment),
-	}
+	return p
 }
 
 // Start starts all data source connections
@@ -91,27 +306,23 @@ func (p *Provider) Start(ctx context.Context) error {
 
 	// Connect CoinGlass
 	if p.coinglass != nil {
-		if err := p.coinglass.Connect(ctx); err != nil {
-			// Log warning but continue
-		}
+		p.recordConnect(SourceCoinGlass, p.coinglass.Connect(ctx))
 	}
 
 	// Connect Whale Alert
 	if p.whalealert != nil {
-		if err := p.whalealert.Connect(ctx); err != nil {
-			// Log warning but continue
-		}
+		p.recordConnect(SourceWhaleAlert, p.whalealert.Connect(ctx))
 	}
 
-	// Connect LunarCrush
-	if p.lunarcrush != nil {
-		if err := p.lunarcrush.Connect(ctx); err != nil {
-			// Log warning but continue
+	// Connect sentiment sources
+	for _, src := range p.sentiment {
+		if c, ok := src.Provider.(connector); ok {
+			p.recordConnect(src.Name, c.Connect(ctx))
 		}
 	}
 
 	// Start background data collection
-	go p.collectData(ctx)
+	p.pollers.Go(ctx, p.collectData)
 
 	// Subscribe to liquidations for each symbol
 	if p.coinglass != nil {
@@ -129,20 +340,18 @@ func (p *Provider) Start(ctx context.Context) error {
 	}
 
 	// Subscribe to sentiment updates
-	if p.lunarcrush != nil {
+	for _, src := range p.sentiment {
 		for _, symbol := range p.symbols {
-			sym := symbol // Capture for closure
-			p.lunarcrush.SubscribeSentiment(ctx, symbol, func(sentiment *entity.SocialSentiment) {
-				p.onSentimentUpdate(sym, sentiment)
+			name, sym := src.Name, symbol // Capture for closure
+			src.Provider.SubscribeSentiment(ctx, symbol, func(sentiment *entity.SocialSentiment) {
+				p.onSentimentUpdate(name, sym, sentiment)
 			})
 		}
 	}
 
 	// Start macro provider
 	if p.macroProvider != nil {
-		if err := p.macroProvider.Start(ctx); err != nil {
-			// Log warning but continue
-		}
+		p.recordConnect(SourceMacro, p.macroProvider.Start(ctx))
 		// Subscribe to macro signal updates
 		p.macroProvider.SubscribeSignals(ctx, func(signal *entity.MacroSignal) {
 			p.onMacroUpdate(signal)
@@ -152,7 +361,7 @@ func (p *Provider) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop stops all data source connections
+// Stop stops all data source connections and waits for their goroutines to exit
 func (p *Provider) Stop(ctx context.Context) error {
 	p.mu.Lock()
 	if !p.running {
@@ -162,74 +371,171 @@ func (p *Provider) Stop(ctx context.Context) error {
 	p.running = false
 	p.mu.Unlock()
 
+	// Each source waits for its subscription goroutines, so no callbacks follow Stop
+	var errs []error
 	if p.coinglass != nil {
-		p.coinglass.Disconnect(ctx)
+		errs = append(errs, p.coinglass.Disconnect(ctx))
 	}
 	if p.whalealert != nil {
-		p.whalealert.Disconnect(ctx)
+		errs = append(errs, p.whalealert.Disconnect(ctx))
 	}
-	if p.lunarcrush != nil {
-		p.lunarcrush.Disconnect(ctx)
+	for _, src := range p.sentiment {
+		if c, ok := src.Provider.(connector); ok {
+			errs = append(errs, c.Disconnect(ctx))
+		}
 	}
 	if p.macroProvider != nil {
-		p.macroProvider.Stop(ctx)
+		errs = append(errs, p.macroProvider.Stop(ctx))
 	}
+	errs = append(errs, p.pollers.Stop(ctx))
 
-	return nil
+	return errors.Join(errs...)
 }
 
-// onMacroUpdate handles incoming macro signal updates
+// onMacroUpdate caches a macro signal update and has collectData broadcast
+// market signals with it without waiting for the next refresh
 func (p *Provider) onMacroUpdate(signal *entity.MacroSignal) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
 	p.cachedMacro = signal
+	p.lastUpdate[SourceMacro] = p.now()
+	p.mu.Unlock()
+
+	select {
+	case p.macroUpdated <- struct{}{}:
+	default: // A rebroadcast is already pending
+	}
 }
 
 // collectData periodically collects and broadcasts market signals
 func (p *Provider) collectData(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
+	historyTicker := time.NewTicker(sentimentHistoryRefresh)
+	defer historyTicker.Stop()
 
+	p.refreshSentimentTrends(ctx)
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-historyTicker.C:
+			p.refreshSentimentTrends(ctx)
 		case <-ticker.C:
-			p.mu.RLock()
-			running := p.running
-			p.mu.RUnlock()
-
-			if !running {
+			if !p.broadcastAll(ctx) {
 				return
 			}
-
-			for _, symbol := range p.symbols {
-				signal, err := p.GetMarketSignal(ctx, symbol)
-				if err != nil {
-					continue
-				}
-				p.broadcastSignal(signal)
+		case <-p.macroUpdated:
+			if !p.broadcastAll(ctx) {
+				return
 			}
 		}
 	}
 }
 
+// broadcastAll builds and broadcasts the market signal of every symbol. It
+// returns false once the provider is stopped.
+func (p *Provider) broadcastAll(ctx context.Context) bool {
+	p.mu.RLock()
+	running := p.running
+	p.mu.RUnlock()
+
+	if !running {
+		return false
+	}
+
+	p.pruneCaches()
+	for _, symbol := range p.symbols {
+		signal, err := p.GetMarketSignal(ctx, symbol)
+		if err != nil {
+			continue
+		}
+		p.broadcastSignal(signal)
+	}
+	return true
+}
+
+// refreshSentimentTrends pulls sentiment history from the first source that
+// serves it and caches the trend of each symbol. A failed fetch drops the
+// symbol's trend rather than keep a stale one.
+func (p *Provider) refreshSentimentTrends(ctx context.Context) {
+	var historian sentimentHistorian
+	var source string
+	for _, src := range p.sentiment {
+		if h, ok := src.Provider.(sentimentHistorian); ok {
+			historian, source = h, src.Name
+			break
+		}
+	}
+	if historian == nil {
+		return
+	}
+
+	for _, symbol := range p.symbols {
+		fetchCtx, cancel := context.WithTimeout(ctx, p.fetchTimeout)
+		history, err := historian.GetSentimentHistory(fetchCtx, symbol, sentimentHistoryInterval, sentimentHistoryPoints)
+		cancel()
+		var trend *entity.SentimentTrend
+		if err != nil {
+			p.recordError(source, err)
+		} else {
+			sort.SliceStable(history, func(i, j int) bool {
+				return history[i].Timestamp.Before(history[j].Timestamp)
+			})
+			trend = entity.AnalyzeSentimentTrend(history, sentimentHistoryPoints)
+		}
+
+		p.mu.Lock()
+		if trend == nil {
+			delete(p.sentimentTrends, symbol)
+		} else {
+			p.sentimentTrends[symbol] = trend
+		}
+		p.mu.Unlock()
+	}
+}
+
 // onLiquidation handles incoming liquidation events
 func (p *Provider) onLiquidation(symbol string, liq *entity.Liquidation) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Keep only recent liquidations (last 10 minutes)
-	cutoff := time.Now().Add(-10 * time.Minute)
-	current := p.recentLiquidations[symbol]
-	filtered := make([]*entity.Liquidation, 0)
-	for _, l := range current {
-		if l.Timestamp.After(cutoff) {
-			filtered = append(filtered, l)
+	p.recentLiquidations[symbol] = appendRecent(p.recentLiquidations[symbol], liq,
+		p.liquidationCutoff(), p.maxEvents, liquidationTime)
+	p.lastUpdate[SourceCoinGlass] = p.now()
+}
+
+// liquidationCutoff returns the time before which liquidations are dropped: the
+// retention period, or the cascade window if longer
+func (p *Provider) liquidationCutoff() time.Time {
+	retention := liquidationRetention
+	if p.cascade.Window > retention {
+		retention = p.cascade.Window
+	}
+	return p.now().Add(-retention)
+}
+
+// pruneCaches drops expired liquidations and whale alerts of every symbol, so
+// symbols that stop receiving events do not hold on to them
+func (p *Provider) pruneCaches() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	liqCutoff := p.liquidationCutoff()
+	for symbol, liqs := range p.recentLiquidations {
+		if liqs = pruneRecent(liqs, liqCutoff, p.maxEvents, liquidationTime); len(liqs) == 0 {
+			delete(p.recentLiquidations, symbol)
+		} else {
+			p.recentLiquidations[symbol] = liqs
+		}
+	}
+	alertCutoff := p.now().Add(-whaleAlertRetention)
+	for symbol, alerts := range p.recentWhaleAlerts {
+		if alerts = pruneRecent(alerts, alertCutoff, p.maxEvents, whaleAlertTime); len(alerts) == 0 {
+			delete(p.recentWhaleAlerts, symbol)
+		} else {
+			p.recentWhaleAlerts[symbol] = alerts
 		}
 	}
-	filtered = append(filtered, liq)
-	p.recentLiquidations[symbol] = filtered
 }
 
 // onWhaleAlert handles incoming whale alerts
@@ -242,79 +548,224 @@ func (p *Provider) onWhaleAlert(alert *entity.WhaleAlert) {
 		return
 	}
 
-	// Keep only recent alerts (last 30 minutes)
-	cutoff := time.Now().Add(-30 * time.Minute)
-	current := p.recentWhaleAlerts[symbol]
-	filtered := make([]*entity.WhaleAlert, 0)
-	for _, a := range current {
-		if a.Timestamp.After(cutoff) {
-			filtered = append(filtered, a)
-		}
+	p.recentWhaleAlerts[symbol] = appendRecent(p.recentWhaleAlerts[symbol], alert,
+		p.now().Add(-whaleAlertRetention), p.maxEvents, whaleAlertTime)
+	p.lastUpdate[SourceWhaleAlert] = p.now()
+}
+
+// onSentimentUpdate handles incoming sentiment updates from a source
+func (p *Provider) onSentimentUpdate(source, symbol string, sentiment *entity.SocialSentiment) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.recentSentiment[symbol] == nil {
+		p.recentSentiment[symbol] = make(map[string]*entity.SocialSentiment)
 	}
-	filtered = append(filtered, alert)
-	p.recentWhaleAlerts[symbol] = filtered
+	p.recentSentiment[symbol][source] = sentiment
+	p.lastUpdate[source] = p.now()
 }
 
-// onSentimentUpdate handles incoming sentiment updates
-func (p *Provider) onSentimentUpdate(symbol string, sentiment *entity.SocialSentiment) {
+// markUpdated records a successful fetch from a source
+func (p *Provider) markUpdated(source string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.recentSentiment[symbol] = sentiment
+	p.lastUpdate[source] = p.now()
+	p.connected[source] = true
 }
 
-// mapBlockchainToSymbol maps blockchain name to trading symbol
-func mapBlockchainToSymbol(blockchain string) string {
-	switch blockchain {
-	case "bitcoin":
-		return "BTC"
-	case "ethereum":
-		return "ETH"
-	case "tron":
-		return "TRX"
-	case "solana":
-		return "SOL"
-	default:
-		return ""
+// recordError records a failed fetch from a source
+func (p *Provider) recordError(source string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastError[source] = err
+}
+
+// recordConnect records the result of connecting to a source; failures do not stop the provider
+func (p *Provider) recordConnect(source string, err error) {
+	if err != nil {
+		p.log.WithField("source", source).Warn("Failed to connect %s: %v", source, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.connected[source] = err == nil
+	if err != nil {
+		p.lastError[source] = err
+	}
+}
+
+// isFresh reports whether a source has been updated within its max age.
+// Caller must hold p.mu.
+func (p *Provider) isFresh(source string) bool {
+	last, ok := p.lastUpdate[source]
+	if !ok {
+		return false
 	}
+	maxAge := p.maxAge.get(source)
+	return maxAge <= 0 || p.now().Sub(last) <= maxAge
+}
+
+// GetHealth returns per-source last update times and freshness
+func (p *Provider) GetHealth() map[string]SourceHealth {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	sources := p.sourceNames()
+	health := make(map[string]SourceHealth, len(sources))
+	for _, source := range sources {
+		health[source] = SourceHealth{
+			LastUpdate: p.lastUpdate[source],
+			Fresh:      p.isFresh(source),
+		}
+	}
+	return health
+}
+
+// sourceNames returns the built-in sources followed by any other sentiment sources.
+// Caller must hold p.mu.
+func (p *Provider) sourceNames() []string {
+	names := []string{SourceCoinGlass, SourceWhaleAlert, SourceLunarCrush, SourceMacro, SourceFearGreed}
+	for _, src := range p.sentiment {
+		if src.Name != SourceLunarCrush {
+			names = append(names, src.Name)
+		}
+	}
+	return names
+}
+
+// blockchainsFor returns the blockchains tracked for symbols, without duplicates
+func blockchainsFor(symbols []string) []string {
+	var chains []string
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		chain := entity.Assets().Blockchain(symbol)
+		if chain != "" && !seen[chain] {
+			seen[chain] = true
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// mapBlockchainToSymbol maps blockchain name to trading symbol via the asset registry
+func mapBlockchainToSymbol(blockchain string) string {
+	return entity.Assets().SymbolForBlockchain(blockchain)
 }
 
 // GetMarketSignal returns aggregated market signal for a symbol
 func (p *Provider) GetMarketSignal(ctx context.Context, symbol string) (*entity.MarketSignal, error) {
+	symbol = entity.Symbol(symbol).Normalize().String()
 	signal := &entity.MarketSignal{
 		Symbol:    symbol,
-		Timestamp: time.Now(),
+		Timestamp: p.now(),
+	}
+
+	// Fetch independent API sources concurrently; failures are ignored
+	fetchCtx, cancel := context.WithTimeout(ctx, p.fetchTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	fetch := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
 	}
 
 	// Get CoinGlass data
 	if p.coinglass != nil {
-		if oi, err := p.coinglass.GetOpenInterest(ctx, symbol); err == nil {
+		fetch(func() {
+			oi, err := p.coinglass.GetOpenInterest(fetchCtx, symbol)
+			if err != nil {
+				p.recordError(SourceCoinGlass, err)
+				return
+			}
 			signal.OpenInterest = oi
-		}
-		if fr, err := p.coinglass.GetFundingRate(ctx, symbol); err == nil {
+			p.markUpdated(SourceCoinGlass)
+		})
+		fetch(func() {
+			fr, err := p.coinglass.GetFundingRate(fetchCtx, symbol)
+			if err != nil {
+				p.recordError(SourceCoinGlass, err)
+				return
+			}
 			signal.FundingRate = fr
-		}
-		if lsr, err := p.coinglass.GetLongShortRatio(ctx, symbol); err == nil {
+			p.markUpdated(SourceCoinGlass)
+		})
+		fetch(func() {
+			lsr, err := p.coinglass.GetLongShortRatio(fetchCtx, symbol)
+			if err != nil {
+				p.recordError(SourceCoinGlass, err)
+				return
+			}
 			signal.LongShortRatio = lsr
-		}
+			p.markUpdated(SourceCoinGlass)
+		})
 	}
 
-	// Get LunarCrush sentiment data
-	if p.lunarcrush != nil {
-		if sentiment, err := p.lunarcrush.GetSentiment(ctx, symbol); err == nil {
-			signal.SocialSentiment = sentiment
-		}
+	// Get sentiment data from each source
+	sentiments := make([]*entity.SocialSentiment, len(p.sentiment))
+	for i, src := range p.sentiment {
+		i, src := i, src // Capture for closure
+		fetch(func() {
+			sentiment, err := src.Provider.GetSentiment(fetchCtx, symbol)
+			if err != nil {
+				p.recordError(src.Name, err)
+				return
+			}
+			sentiments[i] = sentiment
+			p.markUpdated(src.Name)
+		})
 	}
 
-	// Get cached whale alerts, liquidations, and sentiment
+	// Get the fear & greed index
+	if p.fearGreed != nil {
+		fetch(func() {
+			index, err := p.fearGreed.GetIndex(fetchCtx)
+			if err != nil {
+				p.recordError(SourceFearGreed, err)
+				return
+			}
+			signal.FearGreed = index
+			p.mu.Lock()
+			p.cachedFearGreed = index
+			p.mu.Unlock()
+			p.markUpdated(SourceFearGreed)
+		})
+	}
+
+	// Each fetch writes a distinct field, so waiting is the only synchronization needed
+	wg.Wait()
+
+	// Get cached whale alerts, liquidations, and sentiment, skipping stale sources
 	p.mu.RLock()
-	signal.RecentWhaleAlerts = p.recentWhaleAlerts[symbol]
-	signal.RecentLiquidations = p.recentLiquidations[symbol]
-	// Use cached sentiment if fresh API call failed
-	if signal.SocialSentiment == nil {
-		signal.SocialSentiment = p.recentSentiment[symbol]
+	if p.isFresh(SourceWhaleAlert) {
+		signal.RecentWhaleAlerts = p.recentWhaleAlerts[symbol]
+	}
+	if p.isFresh(SourceCoinGlass) {
+		signal.RecentLiquidations = p.recentLiquidations[symbol]
+		signal.LiquidationCascade = detectCascade(signal.RecentLiquidations, signal.Timestamp, p.cascade)
+	}
+	// Use cached sentiment for sources whose fresh API call failed
+	fetched := make(map[string]bool, len(p.sentiment))
+	for i, src := range p.sentiment {
+		fetched[src.Name] = sentiments[i] != nil
+	}
+	for _, source := range sortedKeys(p.recentSentiment[symbol]) {
+		if !fetched[source] && p.isFresh(source) {
+			sentiments = append(sentiments, p.recentSentiment[symbol][source])
+		}
+	}
+	signal.SocialSentiment = blendSentiment(sentiments)
+	if signal.SocialSentiment != nil {
+		signal.PriceChange24h = signal.SocialSentiment.PriceChange24h
+	}
+	signal.SentimentTrend = p.sentimentTrends[symbol]
+	if signal.FearGreed == nil && p.isFresh(SourceFearGreed) {
+		signal.FearGreed = p.cachedFearGreed
 	}
 	// Add macro data (Fed policy probabilities)
-	if p.cachedMacro != nil {
+	if p.cachedMacro != nil && p.isFresh(SourceMacro) {
 		signal.MacroBias = p.cachedMacro.Bias
 		signal.MacroStrength = p.cachedMacro.Strength
 		signal.MacroConfidence = p.cachedMacro.Confidence
@@ -327,7 +778,7 @@ func (p *Provider) GetMarketSignal(ctx context.Context, symbol string) (*entity.
 	p.mu.RUnlock()
 
 	// Analyze and set bias/strength/confidence
-	signal.AnalyzeSignal()
+	signal.AnalyzeSignalWith(p.weights, p.sentimentFloor)
 
 	return signal, nil
 }
@@ -367,17 +818,25 @@ func GetSignalSummary(signal *entity.MarketSignal) string {
 	if signal.LongShortRatio != nil {
 		summary += "\n  Long/Short Ratio: " + formatFloat(signal.LongShortRatio.LongShortRatio)
 	}
-	if len(signal.RecentWhaleAlerts) > 0 {
-		var inflow, outflow float64
-		for _, a := range signal.RecentWhaleAlerts {
-			switch a.GetAlertType() {
-			case entity.WhaleAlertExchangeInflow:
-				inflow += a.AmountUSD
-			case entity.WhaleAlertExchangeOutflow:
-				outflow += a.AmountUSD
-			}
+	if oi := signal.OpenInterest; oi != nil {
+		summary += fmt.Sprintf("\n  Open Interest: $%s (%+.1f%% 24h)", formatLargeNumber(oi.OpenInterest), oi.Change24h)
+		switch oi.Trend(signal.PriceChange24h) {
+		case entity.OITrendConfirmed:
+			summary += " - rising with price, trend confirmed"
+		case entity.OITrendNewShorts:
+			summary += " - rising as price falls, new shorts"
+		case entity.OITrendUnwinding:
+			summary += " - falling, positions unwinding"
+		case entity.OITrendNoPrice:
+			summary += " - rising, no 24h price change to read it against"
 		}
+	}
+	if len(signal.RecentWhaleAlerts) > 0 {
+		inflow, outflow, _ := entity.NetExchangeFlow(signal.RecentWhaleAlerts)
 		summary += "\n  Whale Inflow: $" + formatLargeNumber(inflow) + ", Outflow: $" + formatLargeNumber(outflow)
+		for _, c := range entity.ClusterWhaleAlerts(signal.RecentWhaleAlerts, entity.DefaultWhaleClusterBucket, entity.DefaultWhaleClusterSize) {
+			summary += fmt.Sprintf("\n  Whale Cluster: %d %s ($%s) at %s", len(c.Alerts), c.Type, formatLargeNumber(c.AmountUSD), c.Start.Format("15:04"))
+		}
 	}
 	if signal.SocialSentiment != nil {
 		s := signal.SocialSentiment
@@ -390,6 +849,14 @@ func GetSignalSummary(signal *entity.MarketSignal) string {
 		summary += "\n  Social Sentiment: " + sentimentStr + " (score: " + formatFloat(s.SentimentScore) + ")"
 		summary += "\n  Social Volume: " + formatLargeNumber(float64(s.SocialVolume)) + " posts, " + formatLargeNumber(float64(s.Interactions)) + " interactions"
 	}
+	if t := signal.SentimentTrend; t != nil {
+		summary += fmt.Sprintf("\n  Sentiment Momentum: %+.2f over %d points", t.Change(), t.Points)
+		if t.Divergence > 0 {
+			summary += " - rising as price falls"
+		} else if t.Divergence < 0 {
+			summary += " - falling as price rises"
+		}
+	}
 
 	return summary
 }
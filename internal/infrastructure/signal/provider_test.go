@@ -2,6 +2,10 @@ package signal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,7 +17,7 @@ func TestNewProvider(t *testing.T) {
 		Symbols: []string{"BTC", "ETH"},
 	}
 
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 
 	if provider == nil {
 		t.Fatal("Expected provider to be created")
@@ -28,7 +32,7 @@ func TestProvider_GetMarketSignal_NoDataSources(t *testing.T) {
 		Symbols: []string{"BTC"},
 	}
 
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 	ctx := context.Background()
 
 	signal, err := provider.GetMarketSignal(ctx, "BTC")
@@ -54,7 +58,7 @@ func TestProvider_onLiquidation(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 
 	liq := &entity.Liquidation{
 		Symbol:    "BTC",
@@ -77,11 +81,40 @@ func TestProvider_onLiquidation(t *testing.T) {
 	}
 }
 
+func TestProvider_EventCachesStayBounded(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider(Config{Symbols: []string{"BTC"}, MaxCachedEvents: 50}, nil)
+	provider.now = func() time.Time { return now }
+
+	for i := 0; i < 1000; i++ {
+		ts := now.Add(-time.Duration(1000-i) * time.Millisecond)
+		provider.onLiquidation("BTC", &entity.Liquidation{Symbol: "BTC", Side: "long", Value: float64(i), Timestamp: ts})
+		provider.onWhaleAlert(&entity.WhaleAlert{ID: fmt.Sprint(i), Blockchain: "bitcoin", Timestamp: ts})
+	}
+
+	liqs := provider.recentLiquidations["BTC"]
+	if len(liqs) != 50 || liqs[49].Value != 999 || liqs[0].Value != 950 {
+		t.Errorf("Expected the 50 most recent liquidations, got %d", len(liqs))
+	}
+	if alerts := provider.recentWhaleAlerts["BTC"]; len(alerts) != 50 || alerts[49].ID != "999" {
+		t.Errorf("Expected the 50 most recent whale alerts, got %d", len(alerts))
+	}
+
+	// A symbol that stops receiving events is emptied once its events expire
+	provider.onLiquidation("ETH", &entity.Liquidation{Symbol: "ETH", Side: "short", Timestamp: now})
+	now = now.Add(time.Hour)
+	provider.pruneCaches()
+	if len(provider.recentLiquidations) != 0 || len(provider.recentWhaleAlerts) != 0 {
+		t.Errorf("Expected expired events to be pruned, got %d liquidation and %d whale alert symbols",
+			len(provider.recentLiquidations), len(provider.recentWhaleAlerts))
+	}
+}
+
 func TestProvider_onWhaleAlert(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 
 	alert := &entity.WhaleAlert{
 		ID:         "test-123",
@@ -105,11 +138,42 @@ func TestProvider_onWhaleAlert(t *testing.T) {
 	}
 }
 
+func TestProvider_onWhaleAlert_ConfiguredAsset(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"AVAX"}}, nil)
+	alert := &entity.WhaleAlert{
+		ID:         "avax-1",
+		Blockchain: "avalanche",
+		Symbol:     "avax",
+		AmountUSD:  8_000_000,
+		FromOwner:  "unknown",
+		ToOwner:    "binance",
+		Timestamp:  time.Now(),
+	}
+
+	// Unmapped chains are dropped
+	provider.onWhaleAlert(alert)
+	if n := len(provider.recentWhaleAlerts["AVAX"]); n != 0 {
+		t.Fatalf("Expected the avalanche alert to be dropped by default, got %d", n)
+	}
+
+	entity.SetAssets(entity.DefaultAssetRegistry().With([]entity.Asset{{Symbol: "AVAX", Blockchain: "avalanche"}}, nil))
+	defer entity.SetAssets(nil)
+
+	provider.onWhaleAlert(alert)
+	signal, err := provider.GetMarketSignal(context.Background(), "AVAX")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if len(signal.RecentWhaleAlerts) != 1 || signal.RecentWhaleAlerts[0].ID != "avax-1" {
+		t.Errorf("Expected the AVAX whale alert to reach the signal, got %+v", signal.RecentWhaleAlerts)
+	}
+}
+
 func TestProvider_onSentimentUpdate(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 
 	sentiment := &entity.SocialSentiment{
 		Symbol:         "BTC",
@@ -119,10 +183,10 @@ func TestProvider_onSentimentUpdate(t *testing.T) {
 		Timestamp:      time.Now(),
 	}
 
-	provider.onSentimentUpdate("BTC", sentiment)
+	provider.onSentimentUpdate(SourceLunarCrush, "BTC", sentiment)
 
 	provider.mu.RLock()
-	cached := provider.recentSentiment["BTC"]
+	cached := provider.recentSentiment["BTC"][SourceLunarCrush]
 	provider.mu.RUnlock()
 
 	if cached == nil {
@@ -137,7 +201,7 @@ func TestProvider_onMacroUpdate(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 
 	macroSignal := &entity.MacroSignal{
 		Timestamp: time.Now(),
@@ -166,11 +230,52 @@ func TestProvider_onMacroUpdate(t *testing.T) {
 	}
 }
 
+func TestProvider_MacroUpdateRebroadcasts(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	ctx := context.Background()
+
+	received := make(chan *entity.MarketSignal, 4)
+	provider.SubscribeSignals(ctx, func(signal *entity.MarketSignal) { received <- signal })
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer provider.Stop(ctx)
+
+	update := func(cut, hike float64) *entity.MarketSignal {
+		t.Helper()
+		provider.onMacroUpdate(&entity.MacroSignal{
+			Timestamp: time.Now(),
+			FedWatch:  &entity.FedWatchData{NextMeeting: &entity.FOMCMeeting{CutProb: cut, HikeProb: hike}},
+			Bias:      entity.SignalBiasBullish,
+		})
+		select {
+		case signal := <-received:
+			return signal
+		case <-time.After(time.Second):
+			t.Fatal("Expected a market signal broadcast after the macro update")
+			return nil
+		}
+	}
+
+	signal := update(0.7, 0.1)
+	if signal.Symbol != "BTC" || signal.FedCutProb != 0.7 || signal.FedHikeProb != 0.1 {
+		t.Errorf("Expected BTC signal with Fed cut 0.7 / hike 0.1, got %+v", signal)
+	}
+	if signal.MacroBias != entity.SignalBiasBullish {
+		t.Errorf("Expected bullish macro bias, got %s", signal.MacroBias)
+	}
+
+	// The next signal carries the new probabilities
+	if signal := update(0.2, 0.4); signal.FedCutProb != 0.2 || signal.FedHikeProb != 0.4 {
+		t.Errorf("Expected Fed cut 0.2 / hike 0.4 after the second update, got %.2f / %.2f", signal.FedCutProb, signal.FedHikeProb)
+	}
+}
+
 func TestProvider_GetMarketSignal_WithCachedData(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 	ctx := context.Background()
 
 	// Add cached data
@@ -178,9 +283,8 @@ func TestProvider_GetMarketSignal_WithCachedData(t *testing.T) {
 	provider.recentWhaleAlerts["BTC"] = []*entity.WhaleAlert{
 		{FromOwner: "binance", ToOwner: "unknown", AmountUSD: 50000000, Timestamp: time.Now()},
 	}
-	provider.recentSentiment["BTC"] = &entity.SocialSentiment{
-		SentimentScore: 0.4,
-		Timestamp:      time.Now(),
+	provider.recentSentiment["BTC"] = map[string]*entity.SocialSentiment{
+		SourceLunarCrush: {SentimentScore: 0.4, Timestamp: time.Now()},
 	}
 	provider.cachedMacro = &entity.MacroSignal{
 		FedWatch: &entity.FedWatchData{
@@ -193,6 +297,9 @@ func TestProvider_GetMarketSignal_WithCachedData(t *testing.T) {
 		Strength:   0.4,
 		Confidence: 0.5,
 	}
+	provider.lastUpdate[SourceWhaleAlert] = time.Now()
+	provider.lastUpdate[SourceLunarCrush] = time.Now()
+	provider.lastUpdate[SourceMacro] = time.Now()
 	provider.mu.Unlock()
 
 	signal, err := provider.GetMarketSignal(ctx, "BTC")
@@ -214,6 +321,433 @@ func TestProvider_GetMarketSignal_WithCachedData(t *testing.T) {
 		signal.Bias, signal.Strength, signal.Confidence, signal.FedCutProb*100)
 }
 
+func TestProvider_GetMarketSignal_LiquidationCascade(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	feed := func(provider *Provider, liqs map[time.Duration]float64) {
+		for ago, value := range liqs {
+			provider.onLiquidation("BTC", &entity.Liquidation{
+				Symbol: "BTC", Side: "long", Value: value, Timestamp: now.Add(-ago),
+			})
+		}
+	}
+
+	// A burst of long liquidations, most of it in the last couple of minutes
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	provider.now = func() time.Time { return now }
+	feed(provider, map[time.Duration]float64{
+		4 * time.Minute: 2_000_000,
+		2 * time.Minute: 4_000_000,
+		time.Minute:     8_000_000,
+	})
+	provider.onLiquidation("BTC", &entity.Liquidation{Symbol: "BTC", Side: "short", Value: 1_000_000, Timestamp: now})
+
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	c := signal.LiquidationCascade
+	if c == nil {
+		t.Fatal("Expected a liquidation cascade to be flagged")
+	}
+	if c.Side != "long" || c.Direction != entity.SignalBiasBullish || c.ValueUSD != 14_000_000 {
+		t.Errorf("Expected a bullish cascade of $14M longs, got %+v", c)
+	}
+	if math.Abs(c.Magnitude-1.4) > 1e-9 {
+		t.Errorf("Expected magnitude 1.4, got %f", c.Magnitude)
+	}
+	if signal.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected long liquidations flushing to be bullish, got %s", signal.Bias)
+	}
+
+	// The same value liquidated mostly early in the window is slowing down
+	provider = NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	provider.now = func() time.Time { return now }
+	feed(provider, map[time.Duration]float64{
+		4 * time.Minute: 8_000_000,
+		time.Minute:     4_000_000,
+	})
+	signal, err = provider.GetMarketSignal(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if signal.LiquidationCascade != nil {
+		t.Errorf("Expected no cascade while liquidations decelerate, got %+v", signal.LiquidationCascade)
+	}
+	if signal.Bias != entity.SignalBiasBearish {
+		t.Errorf("Expected one-sided long liquidations without a cascade to stay bearish, got %s", signal.Bias)
+	}
+}
+
+func TestProvider_GetMarketSignal_CustomWeights(t *testing.T) {
+	weights := entity.DefaultSignalWeights()
+	weights.WhaleFlow = 0
+	weights.Sentiment = 1.0
+
+	provider := NewProvider(Config{
+		Symbols: []string{"BTC"},
+		Weights: &weights,
+	}, nil)
+	ctx := context.Background()
+
+	// Whale inflow is bearish, sentiment is bullish
+	provider.mu.Lock()
+	provider.recentWhaleAlerts["BTC"] = []*entity.WhaleAlert{
+		{FromOwner: "unknown", ToOwner: "binance", AmountUSD: 50000000, Timestamp: time.Now()},
+	}
+	provider.recentSentiment["BTC"] = map[string]*entity.SocialSentiment{
+		SourceLunarCrush: {SentimentScore: 0.4, Timestamp: time.Now()},
+	}
+	provider.lastUpdate[SourceWhaleAlert] = time.Now()
+	provider.lastUpdate[SourceLunarCrush] = time.Now()
+	provider.mu.Unlock()
+
+	signal, err := provider.GetMarketSignal(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+
+	if signal.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected bullish bias with zero whale weight, got %s", signal.Bias)
+	}
+}
+
+func TestProvider_GetMarketSignal_ExcludesStaleData(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	ctx := context.Background()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	provider.now = func() time.Time { return now }
+
+	provider.onSentimentUpdate(SourceLunarCrush, "BTC", &entity.SocialSentiment{SentimentScore: 0.4, Timestamp: start})
+	provider.onMacroUpdate(&entity.MacroSignal{Timestamp: start, Bias: entity.SignalBiasBullish})
+
+	// Within max age both sources are used
+	now = start.Add(4 * time.Minute)
+	signal, err := provider.GetMarketSignal(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if signal.SocialSentiment == nil {
+		t.Error("Expected fresh sentiment to be included")
+	}
+
+	// Sentiment exceeds its 5 minute max age, macro is still within 30 minutes
+	now = start.Add(6 * time.Minute)
+	signal, err = provider.GetMarketSignal(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if signal.SocialSentiment != nil {
+		t.Error("Expected stale sentiment to be excluded")
+	}
+	if signal.MacroBias != entity.SignalBiasBullish {
+		t.Errorf("Expected fresh macro bias to be included, got %s", signal.MacroBias)
+	}
+}
+
+func TestProvider_GetHealth(t *testing.T) {
+	provider := NewProvider(Config{
+		Symbols: []string{"BTC"},
+		MaxAge:  &MaxAgeConfig{LunarCrush: time.Minute, WhaleAlert: time.Minute},
+	}, nil)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	provider.now = func() time.Time { return now }
+
+	provider.onSentimentUpdate(SourceLunarCrush, "BTC", &entity.SocialSentiment{SentimentScore: 0.1})
+	now = start.Add(2 * time.Minute)
+	provider.onWhaleAlert(&entity.WhaleAlert{Blockchain: "bitcoin", Timestamp: now})
+
+	health := provider.GetHealth()
+	if len(health) != 5 {
+		t.Fatalf("Expected 5 sources, got %d", len(health))
+	}
+
+	if h := health[SourceLunarCrush]; !h.LastUpdate.Equal(start) || h.Fresh {
+		t.Errorf("Expected stale LunarCrush updated at %v, got %+v", start, h)
+	}
+	if h := health[SourceWhaleAlert]; !h.LastUpdate.Equal(now) || !h.Fresh {
+		t.Errorf("Expected fresh WhaleAlert updated at %v, got %+v", now, h)
+	}
+	if h := health[SourceCoinGlass]; !h.LastUpdate.IsZero() || h.Fresh {
+		t.Errorf("Expected CoinGlass never updated, got %+v", h)
+	}
+}
+
+// mockDerivatives is a DataSourceGateway that responds after a fixed delay
+type mockDerivatives struct {
+	delay time.Duration
+}
+
+func (m *mockDerivatives) wait(ctx context.Context) error {
+	select {
+	case <-time.After(m.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *mockDerivatives) Connect(ctx context.Context) error    { return nil }
+func (m *mockDerivatives) Disconnect(ctx context.Context) error { return nil }
+
+func (m *mockDerivatives) GetLiquidations(ctx context.Context, symbol string) ([]*entity.Liquidation, error) {
+	return nil, nil
+}
+
+func (m *mockDerivatives) GetOpenInterest(ctx context.Context, symbol string) (*entity.OpenInterest, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &entity.OpenInterest{Symbol: symbol, OpenInterest: 1000000}, nil
+}
+
+func (m *mockDerivatives) GetFundingRate(ctx context.Context, symbol string) (*entity.FundingRate, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &entity.FundingRate{Symbol: symbol, Rate: -0.0003}, nil
+}
+
+func (m *mockDerivatives) GetLongShortRatio(ctx context.Context, symbol string) (*entity.LongShortRatio, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &entity.LongShortRatio{Symbol: symbol, LongShortRatio: 0.8}, nil
+}
+
+func (m *mockDerivatives) SubscribeLiquidations(ctx context.Context, symbol string, handler func(*entity.Liquidation)) error {
+	return nil
+}
+
+func (m *mockDerivatives) SubscribeWhaleAlerts(ctx context.Context, handler func(*entity.WhaleAlert)) error {
+	return nil
+}
+
+// mockSentiment is a SentimentGateway that responds after a fixed delay
+type mockSentiment struct {
+	delay time.Duration
+}
+
+func (m *mockSentiment) Connect(ctx context.Context) error    { return nil }
+func (m *mockSentiment) Disconnect(ctx context.Context) error { return nil }
+
+func (m *mockSentiment) GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error) {
+	select {
+	case <-time.After(m.delay):
+		return &entity.SocialSentiment{Symbol: symbol, SentimentScore: 0.4}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (m *mockSentiment) SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error {
+	return nil
+}
+
+// staticSentiment is a SentimentProvider returning a fixed sentiment
+type staticSentiment struct {
+	sentiment *entity.SocialSentiment
+}
+
+func (m *staticSentiment) GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error) {
+	return m.sentiment, nil
+}
+
+func (m *staticSentiment) SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error {
+	return nil
+}
+
+// historySentiment is a staticSentiment that also serves sentiment history
+type historySentiment struct {
+	staticSentiment
+	history []*entity.SocialSentiment
+	err     error
+}
+
+func (m *historySentiment) GetSentimentHistory(ctx context.Context, symbol string, interval string, limit int) ([]*entity.SocialSentiment, error) {
+	return m.history, m.err
+}
+
+func TestProvider_RefreshSentimentTrends(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &historySentiment{staticSentiment: staticSentiment{&entity.SocialSentiment{Symbol: "BTC", SentimentScore: 0}}}
+	// Served newest first; the provider orders it by time
+	for i := 4; i >= 0; i-- {
+		source.history = append(source.history, &entity.SocialSentiment{
+			SentimentScore: -0.2 + 0.1*float64(i),
+			Timestamp:      base.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	provider := NewProvider(Config{
+		Symbols:   []string{"BTC"},
+		Sentiment: []SentimentSource{{Name: SourceLunarCrush, Provider: source}},
+	}, nil)
+
+	provider.refreshSentimentTrends(context.Background())
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if trend := signal.SentimentTrend; trend == nil || math.Abs(trend.Momentum-0.1) > 1e-9 {
+		t.Fatalf("Expected cached momentum 0.1, got %+v", trend)
+	}
+	if signal.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected rising sentiment to make the signal bullish, got %s", signal.Bias)
+	}
+	if summary := GetSignalSummary(signal); !strings.Contains(summary, "Sentiment Momentum: +0.40 over 5 points") {
+		t.Errorf("Expected momentum in summary, got %q", summary)
+	}
+
+	source.err = errors.New("unavailable")
+	provider.refreshSentimentTrends(context.Background())
+	if signal, _ = provider.GetMarketSignal(context.Background(), "BTC"); signal.SentimentTrend != nil {
+		t.Errorf("Expected a failed refresh to drop the trend, got %+v", signal.SentimentTrend)
+	}
+}
+
+func TestProvider_GetMarketSignal_BlendsSentimentSources(t *testing.T) {
+	provider := NewProvider(Config{
+		Symbols: []string{"BTC"},
+		Sentiment: []SentimentSource{
+			{Name: "first", Provider: &staticSentiment{&entity.SocialSentiment{
+				Symbol: "BTC", Source: "first", SentimentScore: 0.6, PositiveRatio: 0.7,
+				SocialVolume: 100, GalaxyScore: 70,
+				PlatformBreakdown: map[string]entity.PlatformMetrics{"twitter": {Positive: 5}},
+			}}},
+			{Name: "second", Provider: &staticSentiment{&entity.SocialSentiment{
+				Symbol: "BTC", Source: "second", SentimentScore: -0.2, PositiveRatio: 0.3,
+				SocialVolume:      50,
+				PlatformBreakdown: map[string]entity.PlatformMetrics{"twitter": {Positive: 2, Negative: 1}},
+			}}},
+		},
+	}, nil)
+
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	s := signal.SocialSentiment
+	if s == nil {
+		t.Fatal("Expected blended social sentiment")
+	}
+	if math.Abs(s.SentimentScore-0.2) > 1e-9 || math.Abs(s.PositiveRatio-0.5) > 1e-9 {
+		t.Errorf("Expected averaged score 0.2 and positive ratio 0.5, got %+v", s)
+	}
+	if s.SocialVolume != 150 || s.GalaxyScore != 70 || s.Source != "first+second" {
+		t.Errorf("Expected summed volume and first galaxy score, got %+v", s)
+	}
+	if tw := s.PlatformBreakdown["twitter"]; tw.Positive != 7 || tw.Negative != 1 {
+		t.Errorf("Expected summed platform breakdown, got %+v", tw)
+	}
+	if h := provider.GetHealth(); !h["first"].Fresh || !h["second"].Fresh {
+		t.Errorf("Expected health for each sentiment source, got %+v", h)
+	}
+}
+
+// mockFearGreed is a FearGreedGateway returning a fixed index
+type mockFearGreed struct {
+	value int
+	err   error
+}
+
+func (m *mockFearGreed) GetIndex(ctx context.Context) (*entity.FearGreedIndex, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &entity.FearGreedIndex{Value: m.value, Timestamp: time.Now()}, nil
+}
+
+func TestProvider_GetMarketSignal_FearGreed(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	fg := &mockFearGreed{value: 10}
+	provider.fearGreed = fg
+
+	// Extreme fear alone is a contrarian bullish signal
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+	if signal.FearGreed == nil || signal.FearGreed.Value != 10 {
+		t.Fatalf("Expected fear & greed index in the signal, got %+v", signal.FearGreed)
+	}
+	if signal.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected bullish bias in extreme fear, got %s", signal.Bias)
+	}
+
+	// A failed fetch falls back to the cached index
+	fg.err = errors.New("unavailable")
+	signal, _ = provider.GetMarketSignal(context.Background(), "BTC")
+	if signal.FearGreed == nil || signal.Bias != entity.SignalBiasBullish {
+		t.Errorf("Expected cached index after a failed fetch, got %+v (%s)", signal.FearGreed, signal.Bias)
+	}
+
+	// Extreme greed is bearish
+	fg.err, fg.value = nil, 90
+	signal, _ = provider.GetMarketSignal(context.Background(), "BTC")
+	if signal.Bias != entity.SignalBiasBearish {
+		t.Errorf("Expected bearish bias in extreme greed, got %s", signal.Bias)
+	}
+}
+
+func TestProvider_GetMarketSignal_ConcurrentFetch(t *testing.T) {
+	delay := 100 * time.Millisecond
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	provider.coinglass = &mockDerivatives{delay: delay}
+	provider.sentiment = []SentimentSource{{Name: SourceLunarCrush, Provider: &mockSentiment{delay: delay}}}
+
+	start := time.Now()
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+
+	// Four sequential fetches would take 4x the delay
+	if elapsed >= 2*delay {
+		t.Errorf("Expected fetches to run concurrently, took %v", elapsed)
+	}
+	if signal.OpenInterest == nil || signal.FundingRate == nil || signal.LongShortRatio == nil {
+		t.Error("Expected CoinGlass data to be included")
+	}
+	if signal.SocialSentiment == nil {
+		t.Error("Expected social sentiment to be included")
+	}
+
+	t.Logf("Concurrent fetch took %v", elapsed)
+}
+
+func TestProvider_GetMarketSignal_FetchTimeout(t *testing.T) {
+	provider := NewProvider(Config{
+		Symbols:      []string{"BTC"},
+		FetchTimeout: 50 * time.Millisecond,
+	}, nil)
+	provider.coinglass = &mockDerivatives{delay: time.Second}
+	provider.sentiment = []SentimentSource{{Name: SourceLunarCrush, Provider: &mockSentiment{delay: time.Second}}}
+
+	start := time.Now()
+	signal, err := provider.GetMarketSignal(context.Background(), "BTC")
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+
+	if elapsed >= 500*time.Millisecond {
+		t.Errorf("Expected fetch timeout to bound call duration, took %v", elapsed)
+	}
+	if signal.OpenInterest != nil || signal.FundingRate != nil || signal.LongShortRatio != nil {
+		t.Error("Expected timed out CoinGlass fetches to be skipped")
+	}
+	if signal.SocialSentiment != nil {
+		t.Error("Expected timed out sentiment fetch to be skipped")
+	}
+	if signal.Bias != entity.SignalBiasNeutral {
+		t.Errorf("Expected neutral bias without data, got %s", signal.Bias)
+	}
+}
+
 func TestMapBlockchainToSymbol(t *testing.T) {
 	tests := []struct {
 		blockchain string
@@ -237,6 +771,13 @@ func TestMapBlockchainToSymbol(t *testing.T) {
 	}
 }
 
+func TestBlockchainsFor(t *testing.T) {
+	got := blockchainsFor([]string{"BTC", "eth", "BTC", "LINK", "PEPE"})
+	if strings.Join(got, ",") != "bitcoin,ethereum" {
+		t.Errorf("Expected bitcoin and ethereum once each, got %v", got)
+	}
+}
+
 func TestGetSignalSummary(t *testing.T) {
 	signal := &entity.MarketSignal{
 		Symbol:     "BTC",
@@ -272,6 +813,62 @@ func TestGetSignalSummary(t *testing.T) {
 	t.Logf("Signal summary:\n%s", summary)
 }
 
+func TestGetSignalSummary_OpenInterestTrend(t *testing.T) {
+	signal := &entity.MarketSignal{
+		Symbol:         "BTC",
+		OpenInterest:   &entity.OpenInterest{OpenInterest: 12500000000, Change24h: 4.2},
+		PriceChange24h: -2.5,
+	}
+
+	summary := GetSignalSummary(signal)
+	if !strings.Contains(summary, "Open Interest: $12.50B (+4.2% 24h) - rising as price falls, new shorts") {
+		t.Errorf("Expected OI interpretation in summary, got:\n%s", summary)
+	}
+}
+
+func TestFormatLargeNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{50000000, "50.00M"},
+		{2500000000, "2.50B"},
+		{12500, "12.50K"},
+		{999, "999.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := formatLargeNumber(tt.value); got != tt.expected {
+				t.Errorf("formatLargeNumber(%f) = %s, want %s", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetSignalSummary_NumericFormatting(t *testing.T) {
+	signal := &entity.MarketSignal{
+		Symbol:     "BTC",
+		Bias:       entity.SignalBiasBullish,
+		Strength:   0.6,
+		Confidence: 0.7,
+		LongShortRatio: &entity.LongShortRatio{
+			LongShortRatio: 0.8,
+		},
+		RecentWhaleAlerts: []*entity.WhaleAlert{
+			{FromOwner: "binance", ToOwner: "unknown", AmountUSD: 50000000},
+		},
+	}
+
+	summary := GetSignalSummary(signal)
+
+	for _, want := range []string{"60.00%", "70.00%", "0.80", "$50.00M"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+}
+
 func TestGetSignalSummary_Nil(t *testing.T) {
 	summary := GetSignalSummary(nil)
 	if summary != "No signal available" {
@@ -283,7 +880,7 @@ func TestProvider_SubscribeSignals(t *testing.T) {
 	cfg := Config{
 		Symbols: []string{"BTC"},
 	}
-	provider := NewProvider(cfg)
+	provider := NewProvider(cfg, nil)
 	ctx := context.Background()
 
 	received := make(chan *entity.MarketSignal, 1)
@@ -0,0 +1,97 @@
+package signal
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// Sentiment history is pulled this often, as this many points of this
+// interval, to compute each symbol's sentiment trend
+const (
+	sentimentHistoryRefresh  = 15 * time.Minute
+	sentimentHistoryInterval = "1h"
+	sentimentHistoryPoints   = 24
+)
+
+// sentimentHistorian is implemented by sentiment sources that serve history
+type sentimentHistorian interface {
+	GetSentimentHistory(ctx context.Context, symbol string, interval string, limit int) ([]*entity.SocialSentiment, error)
+}
+
+// blendSentiment merges sentiment from several sources into one. Scores and
+// ratios are averaged, activity counts and platform breakdowns are summed, and
+// LunarCrush-only metrics are taken from the first source reporting them.
+// Nil entries are skipped; it returns nil when there is no sentiment.
+func blendSentiment(sentiments []*entity.SocialSentiment) *entity.SocialSentiment {
+	var present []*entity.SocialSentiment
+	for _, s := range sentiments {
+		if s != nil {
+			present = append(present, s)
+		}
+	}
+	switch len(present) {
+	case 0:
+		return nil
+	case 1:
+		return present[0]
+	}
+
+	blended := &entity.SocialSentiment{Symbol: present[0].Symbol}
+	sources := make([]string, 0, len(present))
+	for _, s := range present {
+		sources = append(sources, s.Source)
+		blended.Sentiment += s.Sentiment
+		blended.SentimentScore += s.SentimentScore
+		blended.PositiveRatio += s.PositiveRatio
+		blended.NegativeRatio += s.NegativeRatio
+		blended.NeutralRatio += s.NeutralRatio
+		blended.SocialVolume += s.SocialVolume
+		blended.Interactions += s.Interactions
+		blended.Contributors += s.Contributors
+		if blended.GalaxyScore == 0 {
+			blended.GalaxyScore = s.GalaxyScore
+		}
+		if blended.AltRank == 0 {
+			blended.AltRank = s.AltRank
+		}
+		if blended.PriceChange24h == 0 {
+			blended.PriceChange24h = s.PriceChange24h
+		}
+		for platform, m := range s.PlatformBreakdown {
+			if blended.PlatformBreakdown == nil {
+				blended.PlatformBreakdown = make(map[string]entity.PlatformMetrics)
+			}
+			total := blended.PlatformBreakdown[platform]
+			total.Positive += m.Positive
+			total.Neutral += m.Neutral
+			total.Negative += m.Negative
+			blended.PlatformBreakdown[platform] = total
+		}
+		if s.Timestamp.After(blended.Timestamp) {
+			blended.Timestamp = s.Timestamp
+		}
+	}
+
+	n := float64(len(present))
+	blended.Sentiment /= n
+	blended.SentimentScore /= n
+	blended.PositiveRatio /= n
+	blended.NegativeRatio /= n
+	blended.NeutralRatio /= n
+	blended.Source = strings.Join(sources, "+")
+	return blended
+}
+
+// sortedKeys returns the sources of cached sentiment in a stable order
+func sortedKeys(m map[string]*entity.SocialSentiment) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
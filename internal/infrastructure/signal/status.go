@@ -0,0 +1,94 @@
+package signal
+
+import (
+	"context"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+)
+
+// SourceStatus describes the state of a single data source
+type SourceStatus struct {
+	Enabled     bool      `json:"enabled"`   // API key configured
+	Connected   bool      `json:"connected"` // Last connect or fetch succeeded
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	Fresh       bool      `json:"fresh"`
+	Circuit     string    `json:"circuit,omitempty"` // Circuit breaker state (closed, open, half-open)
+}
+
+// ProviderStatus describes the state of all data sources and cached data
+type ProviderStatus struct {
+	Running      bool                    `json:"running"`
+	Sources      map[string]SourceStatus `json:"sources"`
+	WhaleAlerts  map[string]int          `json:"whale_alerts"` // Cached alerts per symbol
+	Liquidations map[string]int          `json:"liquidations"` // Cached liquidations per symbol
+}
+
+// breakerStater is implemented by clients guarded by a circuit breaker
+type breakerStater interface {
+	BreakerState() httputil.BreakerState
+}
+
+// connector is implemented by sources that hold a connection
+type connector interface {
+	Connect(ctx context.Context) error
+	Disconnect(ctx context.Context) error
+}
+
+// GetStatus returns per-source connection state and cached data counts
+func (p *Provider) GetStatus() ProviderStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	breakers := map[string]interface{}{
+		SourceCoinGlass:  p.coinglass,
+		SourceWhaleAlert: p.whalealert,
+		SourceMacro:      p.macroProvider,
+		SourceFearGreed:  p.fearGreed,
+	}
+
+	enabled := map[string]bool{
+		SourceCoinGlass:  p.coinglass != nil,
+		SourceWhaleAlert: p.whalealert != nil,
+		SourceLunarCrush: false,
+		SourceMacro:      p.macroProvider != nil,
+		SourceFearGreed:  p.fearGreed != nil,
+	}
+	for _, src := range p.sentiment {
+		breakers[src.Name] = src.Provider
+		enabled[src.Name] = true
+	}
+
+	status := ProviderStatus{
+		Running:      p.running,
+		Sources:      make(map[string]SourceStatus, len(enabled)),
+		WhaleAlerts:  make(map[string]int, len(p.recentWhaleAlerts)),
+		Liquidations: make(map[string]int, len(p.recentLiquidations)),
+	}
+
+	for source, on := range enabled {
+		s := SourceStatus{
+			Enabled:     on,
+			Connected:   on && p.connected[source],
+			LastSuccess: p.lastUpdate[source],
+			Fresh:       p.isFresh(source),
+		}
+		if err := p.lastError[source]; err != nil {
+			s.LastError = err.Error()
+		}
+		if b, ok := breakers[source].(breakerStater); ok && on {
+			s.Circuit = string(b.BreakerState())
+		}
+		status.Sources[source] = s
+	}
+
+	for symbol, alerts := range p.recentWhaleAlerts {
+		status.WhaleAlerts[symbol] = len(alerts)
+	}
+	for symbol, liqs := range p.recentLiquidations {
+		status.Liquidations[symbol] = len(liqs)
+	}
+
+	return status
+}
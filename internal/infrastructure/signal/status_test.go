@@ -0,0 +1,130 @@
+package signal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+func TestProvider_GetStatus_NoKeys(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+
+	status := provider.GetStatus()
+
+	if status.Running {
+		t.Error("Expected provider not to be running")
+	}
+	if len(status.Sources) != 5 {
+		t.Fatalf("Expected 5 sources, got %d", len(status.Sources))
+	}
+	for name, s := range status.Sources {
+		if s.Enabled || s.Connected {
+			t.Errorf("Expected %s to be disabled, got %+v", name, s)
+		}
+	}
+}
+
+func TestProvider_GetStatus_Counts(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+
+	provider.onWhaleAlert(&entity.WhaleAlert{Blockchain: "bitcoin", Timestamp: time.Now()})
+	provider.onWhaleAlert(&entity.WhaleAlert{Blockchain: "bitcoin", Timestamp: time.Now()})
+	provider.onLiquidation("ETH", &entity.Liquidation{Symbol: "ETH", Timestamp: time.Now()})
+
+	status := provider.GetStatus()
+
+	if status.WhaleAlerts["BTC"] != 2 {
+		t.Errorf("Expected 2 BTC whale alerts, got %d", status.WhaleAlerts["BTC"])
+	}
+	if status.Liquidations["ETH"] != 1 {
+		t.Errorf("Expected 1 ETH liquidation, got %d", status.Liquidations["ETH"])
+	}
+}
+
+func TestProvider_GetStatus_FetchError(t *testing.T) {
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, nil)
+	provider.coinglass = &mockDerivatives{delay: time.Millisecond}
+	provider.sentiment = []SentimentSource{{Name: SourceLunarCrush, Provider: &failingSentiment{err: errors.New("rate limited")}}}
+
+	if _, err := provider.GetMarketSignal(context.Background(), "BTC"); err != nil {
+		t.Fatalf("GetMarketSignal failed: %v", err)
+	}
+
+	status := provider.GetStatus()
+
+	cg := status.Sources[SourceCoinGlass]
+	if !cg.Enabled || !cg.Connected || cg.LastSuccess.IsZero() {
+		t.Errorf("Expected CoinGlass to be connected with a last success, got %+v", cg)
+	}
+	lc := status.Sources[SourceLunarCrush]
+	if lc.Connected || lc.LastError != "rate limited" {
+		t.Errorf("Expected LunarCrush error to be recorded, got %+v", lc)
+	}
+}
+
+func TestProvider_Start_RecordsConnectError(t *testing.T) {
+	var buf bytes.Buffer
+	provider := NewProvider(Config{Symbols: []string{"BTC"}}, logger.New(logger.LevelInfo, &buf))
+	provider.coinglass = &mockDerivatives{}
+	provider.sentiment = []SentimentSource{{Name: SourceLunarCrush, Provider: &failingSentiment{err: errors.New("invalid api key")}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("Start should continue despite connect errors: %v", err)
+	}
+	defer provider.Stop(ctx)
+
+	status := provider.GetStatus()
+	if !status.Sources[SourceCoinGlass].Connected {
+		t.Error("Expected CoinGlass to be connected")
+	}
+	lc := status.Sources[SourceLunarCrush]
+	if lc.Connected || lc.LastError != "invalid api key" {
+		t.Errorf("Expected LunarCrush connect error to be recorded, got %+v", lc)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `"level":"WARN"`) || !strings.Contains(output, "lunarcrush") {
+		t.Errorf("Expected WARN log naming lunarcrush, got: %s", output)
+	}
+}
+
+// failingSentiment is a SentimentGateway whose calls always fail
+type failingSentiment struct {
+	err error
+}
+
+func (m *failingSentiment) Connect(ctx context.Context) error    { return m.err }
+func (m *failingSentiment) Disconnect(ctx context.Context) error { return nil }
+
+func (m *failingSentiment) GetSentiment(ctx context.Context, symbol string) (*entity.SocialSentiment, error) {
+	return nil, m.err
+}
+
+func (m *failingSentiment) SubscribeSentiment(ctx context.Context, symbol string, handler func(*entity.SocialSentiment)) error {
+	return nil
+}
+
+func TestProvider_GetStatus_Circuit(t *testing.T) {
+	provider := NewProvider(Config{
+		Symbols:         []string{"BTC"},
+		CoinGlassAPIKey: "test",
+		Breaker:         &httputil.BreakerConfig{FailureThreshold: 1, Cooldown: time.Minute},
+	}, nil)
+
+	if got := provider.GetStatus().Sources[SourceCoinGlass].Circuit; got != string(httputil.BreakerClosed) {
+		t.Errorf("Expected closed circuit, got %q", got)
+	}
+	if got := provider.GetStatus().Sources[SourceWhaleAlert].Circuit; got != "" {
+		t.Errorf("Expected no circuit state for disabled source, got %q", got)
+	}
+}
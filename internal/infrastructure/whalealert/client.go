@@ -4,22 +4,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"sort"
 	"time"
 
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/httputil"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/pollutil"
 )
 
 const (
-	baseURL = "https://api.whale-alert.io/v1"
+	defaultBaseURL = "https://api.whale-alert.io/v1"
 )
 
+// DefaultBlockchains are polled when no blockchains are configured
+var DefaultBlockchains = []string{"bitcoin", "ethereum", "tron"}
+
+// Ensure Client implements DataSourceGateway
+var _ gateway.DataSourceGateway = (*Client)(nil)
+
 // Client is a Whale Alert API client
 type Client struct {
 	apiKey     string
+	baseURL    string
 	httpClient *http.Client
+	retry      httputil.RetryConfig
+	breaker    *httputil.Breaker
 	minValue   float64 // Minimum USD value to track
+
+	blockchains  []string           // Polled by SubscribeWhaleAlerts
+	chainMin     map[string]float64 // Per-blockchain minimum USD value, overriding minValue
+	pollInterval time.Duration
+
+	pollers pollutil.Group // Subscription goroutines, drained by Disconnect
 }
 
 // NewClient creates a new Whale Alert client
@@ -29,23 +47,70 @@ func NewClient(apiKey string, minValue float64) *Client {
 	}
 	return &Client{
 		apiKey:   apiKey,
+		baseURL:  defaultBaseURL,
 		minValue: minValue,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		retry:        httputil.DefaultRetryConfig(),
+		breaker:      httputil.NewBreaker(httputil.DefaultBreakerConfig()),
+		blockchains:  DefaultBlockchains,
+		chainMin:     make(map[string]float64),
+		pollInterval: 60 * time.Second, // Whale Alert has rate limits
+	}
+}
+
+// SetBlockchains sets the blockchains SubscribeWhaleAlerts polls (empty = DefaultBlockchains)
+func (c *Client) SetBlockchains(blockchains ...string) {
+	if len(blockchains) == 0 {
+		blockchains = DefaultBlockchains
 	}
+	c.blockchains = blockchains
+}
+
+// SetMinValue sets the minimum USD value tracked on a blockchain, overriding the client default
+func (c *Client) SetMinValue(blockchain string, usd float64) {
+	c.chainMin[blockchain] = usd
+}
+
+// SetPollInterval sets how often SubscribeWhaleAlerts polls each blockchain
+func (c *Client) SetPollInterval(d time.Duration) {
+	c.pollInterval = d
+}
+
+// minValueFor returns the minimum USD value tracked on a blockchain
+func (c *Client) minValueFor(blockchain string) float64 {
+	if v, ok := c.chainMin[blockchain]; ok && v > 0 {
+		return v
+	}
+	return c.minValue
+}
+
+// SetMaxAttempts sets the maximum number of attempts per request
+func (c *Client) SetMaxAttempts(n int) {
+	c.retry.MaxAttempts = n
+}
+
+// SetBreakerConfig sets the circuit breaker thresholds
+func (c *Client) SetBreakerConfig(cfg httputil.BreakerConfig) {
+	c.breaker = httputil.NewBreaker(cfg)
+}
+
+// BreakerState returns the circuit breaker state
+func (c *Client) BreakerState() httputil.BreakerState {
+	return c.breaker.State()
 }
 
 // Connect establishes connection (validates API key)
 func (c *Client) Connect(ctx context.Context) error {
 	// Test API connection with a simple status check
-	_, err := c.GetRecentTransactions(ctx, "bitcoin", time.Now().Add(-1*time.Hour))
+	_, err := c.GetRecentTransactions(ctx, c.blockchains[0], time.Now().Add(-1*time.Hour))
 	return err
 }
 
-// Disconnect closes connection
+// Disconnect stops subscriptions and waits for their goroutines to exit
 func (c *Client) Disconnect(ctx context.Context) error {
-	return nil
+	return c.pollers.Stop(ctx)
 }
 
 // TransactionResponse represents Whale Alert API response
@@ -79,30 +144,17 @@ type Owner struct {
 // GetRecentTransactions retrieves recent whale transactions
 func (c *Client) GetRecentTransactions(ctx context.Context, blockchain string, since time.Time) ([]*entity.WhaleAlert, error) {
 	url := fmt.Sprintf("%s/transactions?api_key=%s&min_value=%d&start=%d",
-		baseURL, c.apiKey, int(c.minValue), since.Unix())
+		c.baseURL, c.apiKey, int(c.minValueFor(blockchain)), since.Unix())
 
 	if blockchain != "" {
 		url += "&blockchain=" + blockchain
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.breaker.Do(func() ([]byte, error) {
+		return httputil.Get(ctx, c.httpClient, url, nil, c.retry)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: status=%d, body=%s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
 	var txResp TransactionResponse
@@ -134,29 +186,9 @@ func (c *Client) GetRecentTransactions(ctx context.Context, blockchain string, s
 	return alerts, nil
 }
 
-// normalizeOwner normalizes owner names to lowercase for comparison
+// normalizeOwner maps exchange owner aliases to their canonical lower-case name via the asset registry
 func normalizeOwner(owner string) string {
-	if owner == "" {
-		return "unknown"
-	}
-	// Map common variations
-	ownerMap := map[string]string{
-		"Binance":     "binance",
-		"Coinbase":    "coinbase",
-		"Kraken":      "kraken",
-		"Bitfinex":    "bitfinex",
-		"Bybit":       "bybit",
-		"OKX":         "okx",
-		"OKEx":        "okx",
-		"Huobi":       "huobi",
-		"KuCoin":      "kucoin",
-		"Gate.io":     "gate.io",
-		"unknown":     "unknown",
-	}
-	if normalized, ok := ownerMap[owner]; ok {
-		return normalized
-	}
-	return owner
+	return entity.Assets().NormalizeOwner(owner)
 }
 
 // GetLiquidations is not supported by Whale Alert
@@ -186,40 +218,80 @@ func (c *Client) SubscribeLiquidations(ctx context.Context, symbol string, handl
 
 // SubscribeWhaleAlerts subscribes to whale transaction alerts (polling implementation)
 func (c *Client) SubscribeWhaleAlerts(ctx context.Context, handler func(*entity.WhaleAlert)) error {
-	go func() {
-		ticker := time.NewTicker(60 * time.Second) // Whale Alert has rate limits
-		defer ticker.Stop()
-
-		lastCheck := time.Now().Add(-5 * time.Minute)
-		seenIDs := make(map[string]bool)
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				// Get transactions for major blockchains
-				blockchains := []string{"bitcoin", "ethereum", "tron"}
-				for _, bc := range blockchains {
-					alerts, err := c.GetRecentTransactions(ctx, bc, lastCheck)
-					if err != nil {
-						continue
-					}
-					for _, alert := range alerts {
-						if !seenIDs[alert.ID] {
-							seenIDs[alert.ID] = true
-							handler(alert)
-						}
-					}
-				}
-				lastCheck = time.Now().Add(-1 * time.Minute) // Overlap to avoid missing
-			}
+	since := time.Now().Add(-5 * time.Minute)
+	seen := make(seenIDs)
+	pollutil.Start(ctx, &c.pollers, c.pollInterval, func(ctx context.Context) ([]*entity.WhaleAlert, error) {
+		next := time.Now().Add(-1 * time.Minute) // Overlap to avoid missing
+		alerts, err := c.poll(ctx, since, seen)
+		if err != nil {
+			return nil, err // Keep the window so the next poll catches up
+		}
+		since = next
+		return alerts, nil
+	}, func(alerts []*entity.WhaleAlert) {
+		for _, alert := range alerts {
+			handler(alert)
 		}
-	}()
+	})
 
 	return nil
 }
 
+// poll fetches transactions since the given time on each configured blockchain
+// and returns those not seen before. IDs older than since are then forgotten,
+// since later polls start after them and cannot return them again; the set is
+// also capped at maxSeenIDs. It fails only if every blockchain failed.
+func (c *Client) poll(ctx context.Context, since time.Time, seen seenIDs) ([]*entity.WhaleAlert, error) {
+	var fresh []*entity.WhaleAlert
+	var lastErr error
+	failed := 0
+	for _, bc := range c.blockchains {
+		alerts, err := c.GetRecentTransactions(ctx, bc, since)
+		if err != nil {
+			lastErr = err
+			failed++
+			continue
+		}
+		for _, alert := range alerts {
+			if _, ok := seen[alert.ID]; !ok {
+				seen[alert.ID] = alert.Timestamp
+				fresh = append(fresh, alert)
+			}
+		}
+	}
+	if failed == len(c.blockchains) && lastErr != nil {
+		return nil, lastErr
+	}
+	seen.prune(since)
+	return fresh, nil
+}
+
+// maxSeenIDs caps the dedup set in case a burst outpaces time-based pruning
+const maxSeenIDs = 10000
+
+// seenIDs records delivered transaction IDs with their timestamps
+type seenIDs map[string]time.Time
+
+// prune forgets IDs of transactions before cutoff, then the oldest beyond maxSeenIDs
+func (s seenIDs) prune(cutoff time.Time) {
+	for id, ts := range s {
+		if ts.Before(cutoff) {
+			delete(s, id)
+		}
+	}
+	if len(s) <= maxSeenIDs {
+		return
+	}
+	ids := make([]string, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return s[ids[i]].Before(s[ids[j]]) })
+	for _, id := range ids[:len(ids)-maxSeenIDs] {
+		delete(s, id)
+	}
+}
+
 // FilterBySymbol filters alerts for specific crypto symbols
 func FilterBySymbol(alerts []*entity.WhaleAlert, symbols ...string) []*entity.WhaleAlert {
 	symbolMap := make(map[string]bool)
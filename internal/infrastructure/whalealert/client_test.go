@@ -0,0 +1,118 @@
+package whalealert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPI serves transactions per blockchain and records the query of each request
+type fakeAPI struct {
+	mu      sync.Mutex
+	queries []string
+	byChain map[string][]string // blockchain -> transaction JSON objects
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f.mu.Lock()
+	f.queries = append(f.queries, q.Get("blockchain")+"@"+q.Get("min_value"))
+	txs := f.byChain[q.Get("blockchain")]
+	f.mu.Unlock()
+	fmt.Fprintf(w, `{"result":"success","transactions":[%s]}`, strings.Join(txs, ","))
+}
+
+func tx(id, blockchain string, ts time.Time) string {
+	return fmt.Sprintf(`{"id":%q,"blockchain":%q,"symbol":"btc","timestamp":%d,"amount_usd":1000000,"from":{"owner":"unknown"},"to":{"owner":"Binance"}}`,
+		id, blockchain, ts.Unix())
+}
+
+func TestClient_PollsConfiguredBlockchains(t *testing.T) {
+	since := time.Unix(1700000000, 0)
+	api := &fakeAPI{byChain: map[string][]string{
+		"avalanche": {tx("a1", "avalanche", since.Add(time.Minute))},
+		"bitcoin":   {tx("b1", "bitcoin", since.Add(time.Minute))},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	c := NewClient("key", 500000)
+	c.baseURL = server.URL
+	c.SetBlockchains("avalanche", "bitcoin")
+	c.SetMinValue("avalanche", 2000000)
+
+	got, err := c.poll(context.Background(), since, make(seenIDs))
+	if err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+
+	want := []string{"avalanche@2000000", "bitcoin@500000"}
+	if strings.Join(api.queries, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected queries %v, got %v", want, api.queries)
+	}
+	if len(got) != 2 || got[0].ID != "a1" || got[1].ID != "b1" {
+		t.Fatalf("Expected alerts from both blockchains, got %+v", got)
+	}
+	if got[0].ToOwner != "binance" {
+		t.Errorf("Expected normalized owner, got %q", got[0].ToOwner)
+	}
+
+	// Clearing the list falls back to the defaults
+	api.queries = nil
+	c.SetBlockchains()
+	c.poll(context.Background(), since, make(seenIDs))
+	if len(api.queries) != len(DefaultBlockchains) {
+		t.Errorf("Expected the default blockchains to be polled, got %v", api.queries)
+	}
+}
+
+func TestClient_PollPrunesSeenIDs(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	api := &fakeAPI{byChain: map[string][]string{
+		"bitcoin": {tx("old", "bitcoin", start.Add(time.Minute)), tx("new", "bitcoin", start.Add(5*time.Minute))},
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	c := NewClient("key", 0)
+	c.baseURL = server.URL
+	c.SetBlockchains("bitcoin")
+
+	seen := make(seenIDs)
+	alerts, _ := c.poll(context.Background(), start, seen)
+	if len(alerts) != 2 || len(seen) != 2 {
+		t.Fatalf("Expected 2 alerts delivered and remembered, got %d / %d", len(alerts), len(seen))
+	}
+
+	// Overlapping poll: nothing is redelivered, and IDs before the window are forgotten
+	if alerts, _ := c.poll(context.Background(), start.Add(3*time.Minute), seen); len(alerts) != 0 {
+		t.Errorf("Expected no duplicate deliveries, got %d", len(alerts))
+	}
+	if _, ok := seen["old"]; ok || len(seen) != 1 {
+		t.Errorf("Expected only the recent ID to be kept, got %v", seen)
+	}
+}
+
+func TestSeenIDs_PruneCapsSize(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	seen := make(seenIDs)
+	for i := 0; i < maxSeenIDs+100; i++ {
+		seen[fmt.Sprint(i)] = start.Add(time.Duration(i) * time.Millisecond)
+	}
+
+	seen.prune(start)
+	if len(seen) != maxSeenIDs {
+		t.Fatalf("Expected %d IDs kept, got %d", maxSeenIDs, len(seen))
+	}
+	if _, ok := seen["99"]; ok {
+		t.Error("Expected the oldest IDs to be evicted")
+	}
+	if _, ok := seen[fmt.Sprint(maxSeenIDs+99)]; !ok {
+		t.Error("Expected the newest ID to be kept")
+	}
+}
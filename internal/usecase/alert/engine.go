@@ -0,0 +1,125 @@
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// DefaultDebounce is the minimum time between alerts of a rule per symbol
+const DefaultDebounce = 15 * time.Minute
+
+// Alert is a rule that started to hold for a symbol
+type Alert struct {
+	Rule    entity.AlertRule
+	Symbol  string
+	Value   float64
+	Message string
+	Time    time.Time
+}
+
+// ruleState tracks a rule for one symbol
+type ruleState struct {
+	met   bool      // Condition held at the last evaluation
+	fired time.Time // Last alert
+}
+
+// Engine evaluates alert rules against market signals. A rule fires when its
+// condition goes from false to true, at most once per debounce interval, so a
+// condition that stays true or flaps does not spam.
+type Engine struct {
+	rules    []entity.AlertRule
+	debounce time.Duration
+
+	mu    sync.Mutex
+	clock service.Clock
+	state map[stateKey]*ruleState
+}
+
+// stateKey identifies a rule (by index) for a symbol
+type stateKey struct {
+	rule   int
+	symbol string
+}
+
+// NewEngine creates an engine for rules; rules without their own debounce use
+// debounce (0 = DefaultDebounce)
+func NewEngine(rules []entity.AlertRule, debounce time.Duration) *Engine {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	return &Engine{
+		rules:    append([]entity.AlertRule(nil), rules...),
+		debounce: debounce,
+		clock:    service.RealClock,
+		state:    make(map[stateKey]*ruleState),
+	}
+}
+
+// SetClock sets the clock used for signals without a timestamp; nil restores the real clock
+func (e *Engine) SetClock(clock service.Clock) {
+	if clock == nil {
+		clock = service.RealClock
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.clock = clock
+}
+
+// Evaluate checks every rule covering the signal's symbol and returns the
+// alerts that fire. Fields the signal lacks leave the rule's state unchanged.
+func (e *Engine) Evaluate(signal *entity.MarketSignal) []Alert {
+	if signal == nil {
+		return nil
+	}
+	symbol := entity.Symbol(signal.Symbol).Normalize().String()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := signal.Timestamp
+	if now.IsZero() {
+		now = e.clock.Now()
+	}
+
+	var alerts []Alert
+	for i, rule := range e.rules {
+		if !rule.Applies(signal) {
+			continue
+		}
+		value, met, ok := rule.Evaluate(signal)
+		if !ok {
+			continue
+		}
+		key := stateKey{rule: i, symbol: symbol}
+		st := e.state[key]
+		if st == nil {
+			st = &ruleState{}
+			e.state[key] = st
+		}
+		crossed := met && !st.met
+		st.met = met
+		if !crossed {
+			continue
+		}
+
+		debounce := rule.Debounce
+		if debounce <= 0 {
+			debounce = e.debounce
+		}
+		if !st.fired.IsZero() && now.Sub(st.fired) < debounce {
+			continue
+		}
+		st.fired = now
+		alerts = append(alerts, Alert{
+			Rule:    rule,
+			Symbol:  symbol,
+			Value:   value,
+			Message: rule.Format(symbol, value),
+			Time:    now,
+		})
+	}
+	return alerts
+}
@@ -0,0 +1,99 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// fundingSignal returns a BTC signal with a funding rate at t
+func fundingSignal(rate float64, t time.Time) *entity.MarketSignal {
+	return &entity.MarketSignal{
+		Symbol:      "BTC",
+		Timestamp:   t,
+		FundingRate: &entity.FundingRate{Rate: rate},
+	}
+}
+
+func TestEngine_FiresOncePerCrossing(t *testing.T) {
+	engine := NewEngine([]entity.AlertRule{
+		{Name: "hot funding", Field: "funding_rate", Op: ">", Threshold: 0.0005, Message: "{symbol} funding {value}"},
+	}, time.Minute)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rates := []float64{0.0001, 0.0006, 0.0008, 0.0007, 0.0002, 0.0009}
+	var fired []int
+	for i, rate := range rates {
+		// Ticks are far apart, so only crossings limit the alerts
+		for _, a := range engine.Evaluate(fundingSignal(rate, start.Add(time.Duration(i)*time.Hour))) {
+			fired = append(fired, i)
+			if i == 1 && a.Message != "BTC funding 0.0006" {
+				t.Errorf("Expected formatted message, got %q", a.Message)
+			}
+		}
+	}
+	if len(fired) != 2 || fired[0] != 1 || fired[1] != 5 {
+		t.Errorf("Expected alerts on the two upward crossings (ticks 1 and 5), got %v", fired)
+	}
+}
+
+func TestEngine_Debounce(t *testing.T) {
+	engine := NewEngine([]entity.AlertRule{
+		{Field: "funding_rate", Op: ">", Threshold: 0.0005},
+	}, 10*time.Minute)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	evaluate := func(rate float64, after time.Duration) int {
+		return len(engine.Evaluate(fundingSignal(rate, start.Add(after))))
+	}
+	if n := evaluate(0.0006, 0); n != 1 {
+		t.Fatalf("Expected the first crossing to fire, got %d alerts", n)
+	}
+	evaluate(0.0001, time.Minute)
+	if n := evaluate(0.0006, 2*time.Minute); n != 0 {
+		t.Errorf("Expected a crossing within the debounce interval to be suppressed, got %d alerts", n)
+	}
+	evaluate(0.0001, 11*time.Minute)
+	if n := evaluate(0.0006, 12*time.Minute); n != 1 {
+		t.Errorf("Expected a crossing after the debounce interval to fire, got %d alerts", n)
+	}
+}
+
+func TestEngine_RuleDebounceOverridesDefault(t *testing.T) {
+	engine := NewEngine([]entity.AlertRule{
+		{Field: "bias", Op: "==", Threshold: -1, Debounce: time.Second},
+	}, time.Hour)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	biases := []entity.SignalBias{entity.SignalBiasBearish, entity.SignalBiasNeutral, entity.SignalBiasBearish}
+	fired := 0
+	for i, bias := range biases {
+		fired += len(engine.Evaluate(&entity.MarketSignal{Symbol: "BTC", Bias: bias, Timestamp: start.Add(time.Duration(i) * time.Minute)}))
+	}
+	if fired != 2 {
+		t.Errorf("Expected the rule's own debounce to allow both flips to bearish, got %d alerts", fired)
+	}
+}
+
+func TestEngine_MissingFieldAndSymbol(t *testing.T) {
+	engine := NewEngine([]entity.AlertRule{
+		{Symbol: "BTC-PERP", Field: "funding_rate", Op: ">", Threshold: 0.0005},
+	}, time.Minute)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if alerts := engine.Evaluate(fundingSignal(0.0006, start)); len(alerts) != 1 || alerts[0].Symbol != "BTC" {
+		t.Fatalf("Expected the rule to match BTC in any format, got %+v", alerts)
+	}
+	// A signal without funding data neither clears nor re-arms the rule
+	engine.Evaluate(&entity.MarketSignal{Symbol: "BTC", Timestamp: start.Add(time.Hour)})
+	if alerts := engine.Evaluate(fundingSignal(0.0007, start.Add(2*time.Hour))); len(alerts) != 0 {
+		t.Errorf("Expected missing data to leave the condition held, got %+v", alerts)
+	}
+
+	eth := fundingSignal(0.0009, start)
+	eth.Symbol = "ETH"
+	if alerts := engine.Evaluate(eth); len(alerts) != 0 {
+		t.Errorf("Expected a BTC rule to ignore ETH, got %+v", alerts)
+	}
+}
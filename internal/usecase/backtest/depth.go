@@ -0,0 +1,36 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// DepthModel supplies the liquidity a backtest fill walks through
+type DepthModel interface {
+	// Book returns the order book around the reference fill price
+	Book(symbol string, price float64, ts time.Time) *entity.OrderBook
+}
+
+// SyntheticDepth is a depth curve of evenly spaced levels around the fill
+// price: the top level on each side sits at the price itself and each further
+// level is StepBps worse and holds 1+Growth times the size of the one before.
+type SyntheticDepth struct {
+	Levels    int     // Levels per side
+	StepBps   float64 // Spacing between levels, in basis points of the price
+	LevelSize float64 // Size of the top level, in base currency
+	Growth    float64 // Relative size increase per level (0 = flat)
+}
+
+// Book builds the synthetic book around price
+func (d SyntheticDepth) Book(symbol string, price float64, ts time.Time) *entity.OrderBook {
+	book := &entity.OrderBook{Symbol: symbol, Timestamp: ts}
+	size := d.LevelSize
+	for i := 0; i < d.Levels; i++ {
+		step := price * float64(i) * d.StepBps / 10000
+		book.Bids = append(book.Bids, entity.OrderBookLevel{Price: price - step, Size: size})
+		book.Asks = append(book.Asks, entity.OrderBookLevel{Price: price + step, Size: size})
+		size *= 1 + d.Growth
+	}
+	return book
+}
@@ -0,0 +1,322 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// FillMode selects the price at which emitted signals are filled
+type FillMode string
+
+const (
+	FillAtSignal   FillMode = "signal"    // Fill immediately at the signal price
+	FillAtNextOpen FillMode = "next_open" // Fill at the open of the following candle
+)
+
+// Config holds backtest configuration
+type Config struct {
+	Symbol        string          // Symbol used when candles carry none
+	InitialEquity float64         // Starting account equity for drawdown and returns
+	FillMode      FillMode        // How signals are filled
+	Fees          entity.FeeModel // Fees charged on fills (all fills are taker)
+	RiskFreeRate  float64         // Annual risk-free rate for the Sharpe and Sortino ratios
+
+	VolatilityWindow int // Bars per rolling volatility window (0 = no rolling volatility)
+
+	Depth DepthModel // Liquidity fills walk through; nil fills any size at the price
+}
+
+// DefaultConfig returns default configuration
+func DefaultConfig() Config {
+	return Config{
+		InitialEquity:    10000,
+		FillMode:         FillAtNextOpen,
+		VolatilityWindow: 20,
+	}
+}
+
+// Trade represents a closed (or partially closed) round trip
+type Trade struct {
+	Symbol     string
+	Side       entity.Side // Side of the position that was closed
+	Quantity   float64
+	EntryPrice float64
+	ExitPrice  float64
+	PnL        float64 // Net of entry and exit fees on the closed quantity
+	Fees       float64
+	EntryTime  time.Time
+	ExitTime   time.Time
+}
+
+// Engine replays historical candles through a strategy with simulated fills
+type Engine struct {
+	strategy service.Strategy
+	config   Config
+
+	position  *entity.Position
+	entryTime time.Time
+	realized  float64
+	trades    []Trade
+	orderSeq  int
+}
+
+// NewEngine creates a new backtest engine; the strategy must already be initialized
+func NewEngine(strategy service.Strategy, cfg Config) *Engine {
+	if cfg.InitialEquity <= 0 {
+		cfg.InitialEquity = DefaultConfig().InitialEquity
+	}
+	if cfg.FillMode == "" {
+		cfg.FillMode = DefaultConfig().FillMode
+	}
+	return &Engine{
+		strategy: strategy,
+		config:   cfg,
+	}
+}
+
+// Run feeds each candle to the strategy and returns the backtest result
+func (e *Engine) Run(ctx context.Context, candles []entity.Candle) (*Result, error) {
+	e.position = nil
+	e.entryTime = time.Time{}
+	e.realized = 0
+	e.trades = nil
+	e.orderSeq = 0
+
+	equity := make([]float64, 0, len(candles))
+	var pending []*service.Signal
+
+	for i := range candles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		candle := candles[i]
+		if candle.Symbol == "" {
+			candle.Symbol = e.config.Symbol
+		}
+
+		// Signals from the previous bar fill at this bar's open
+		for _, sig := range pending {
+			if err := e.fill(ctx, sig, candle.Open, candle.Timestamp); err != nil {
+				return nil, err
+			}
+		}
+		pending = pending[:0]
+
+		e.mark(candle.Close, candle.Timestamp)
+
+		state := &service.MarketState{
+			Ticker:   candleTicker(&candle),
+			Position: e.positionSnapshot(),
+		}
+		signals, err := e.strategy.OnTick(ctx, state)
+		if err != nil {
+			return nil, fmt.Errorf("strategy error at %s: %w", candle.Timestamp.Format(time.RFC3339), err)
+		}
+
+		for _, sig := range signals {
+			if e.config.FillMode == FillAtNextOpen {
+				pending = append(pending, sig)
+				continue
+			}
+			price := sig.Price
+			if price <= 0 {
+				price = candle.Close
+			}
+			if err := e.fill(ctx, sig, price, candle.Timestamp); err != nil {
+				return nil, err
+			}
+		}
+
+		e.mark(candle.Close, candle.Timestamp)
+		equity = append(equity, e.config.InitialEquity+e.realized+e.unrealized())
+	}
+
+	return newResult(e.config, e.trades, equity, e.unrealized(), barsPerYear(candles)), nil
+}
+
+// fill simulates a fill of the signal and notifies the strategy. With a depth
+// model the order sweeps the book at its volume-weighted price and, like an
+// IOC order, any size beyond the available depth is canceled.
+func (e *Engine) fill(ctx context.Context, sig *service.Signal, price float64, ts time.Time) error {
+	if sig.Quantity <= 0 || price <= 0 {
+		return nil
+	}
+
+	filled, status := sig.Quantity, entity.OrderStatusFilled
+	if e.config.Depth != nil {
+		var avg float64
+		filled, avg = e.config.Depth.Book(sig.Symbol, price, ts).Sweep(sig.Side, sig.Quantity)
+		if filled > 0 {
+			price = avg
+		}
+		if filled < sig.Quantity {
+			status = entity.OrderStatusCanceled
+		}
+	}
+
+	e.orderSeq++
+	order := &entity.Order{
+		ID:        fmt.Sprintf("bt-%d", e.orderSeq),
+		Symbol:    sig.Symbol,
+		Side:      sig.Side,
+		Type:      entity.OrderTypeMarket,
+		Price:     price,
+		Quantity:  sig.Quantity,
+		FilledQty: filled,
+		Status:    status,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+
+	if filled > 0 {
+		e.applyFill(order)
+	}
+
+	if err := e.strategy.OnOrderUpdate(ctx, order); err != nil {
+		return fmt.Errorf("strategy order update failed: %w", err)
+	}
+	if err := e.strategy.OnPositionUpdate(ctx, e.positionSnapshot()); err != nil {
+		return fmt.Errorf("strategy position update failed: %w", err)
+	}
+	if filled > 0 && e.position == nil {
+		if err := e.strategy.Reset(ctx); err != nil {
+			return fmt.Errorf("strategy reset failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyFill updates the simulated position, realizing PnL on reductions
+func (e *Engine) applyFill(order *entity.Order) {
+	delta := order.FilledQty
+	if order.Side == entity.SideSell {
+		delta = -delta
+	}
+	liquidity := entity.OrderLiquidity(order)
+	e.realized -= e.config.Fees.Fee(order.Price, order.FilledQty, liquidity)
+
+	if e.position == nil || e.position.Size == 0 {
+		e.openPosition(order, delta)
+		return
+	}
+
+	size := e.position.Size
+	if (size > 0) == (delta > 0) {
+		// Adding to the position: average the entry price
+		newSize := size + delta
+		e.position.EntryPrice = (e.position.EntryPrice*math.Abs(size) + order.Price*math.Abs(delta)) / math.Abs(newSize)
+		e.position.Size = newSize
+		return
+	}
+
+	// Reducing, closing or flipping the position
+	closeQty := math.Min(math.Abs(delta), math.Abs(size))
+	side := entity.SideBuy
+	if size < 0 {
+		side = entity.SideSell
+	}
+	gross := entity.GrossPnL(side, e.position.EntryPrice, order.Price, closeQty)
+	net := e.config.Fees.NetPnL(side, e.position.EntryPrice, order.Price, closeQty, entity.LiquidityTaker, liquidity)
+	e.realized += gross
+	e.position.RealizedPnL += gross
+	e.trades = append(e.trades, Trade{
+		Symbol:     order.Symbol,
+		Side:       side,
+		Quantity:   closeQty,
+		EntryPrice: e.position.EntryPrice,
+		ExitPrice:  order.Price,
+		PnL:        net,
+		Fees:       gross - net,
+		EntryTime:  e.entryTime,
+		ExitTime:   order.UpdatedAt,
+	})
+
+	remaining := size + delta
+	if math.Abs(remaining) < 1e-12 {
+		e.position = nil
+		e.entryTime = time.Time{}
+		return
+	}
+	if (remaining > 0) != (size > 0) {
+		// Flipped: the excess opens a new position at the fill price
+		e.openPosition(order, remaining)
+		return
+	}
+	e.position.Size = remaining
+}
+
+// openPosition starts a new position from a fill
+func (e *Engine) openPosition(order *entity.Order, size float64) {
+	side := entity.SideBuy
+	if size < 0 {
+		side = entity.SideSell
+	}
+	e.position = &entity.Position{
+		Symbol:     order.Symbol,
+		Side:       side,
+		Size:       size,
+		EntryPrice: order.Price,
+		MarkPrice:  order.Price,
+		Leverage:   1,
+		UpdatedAt:  order.UpdatedAt,
+	}
+	e.entryTime = order.UpdatedAt
+}
+
+// mark updates the position mark price and unrealized PnL
+func (e *Engine) mark(price float64, ts time.Time) {
+	if e.position == nil {
+		return
+	}
+	e.position.MarkPrice = price
+	e.position.UnrealizedPnL = (price - e.position.EntryPrice) * e.position.Size
+	e.position.UpdatedAt = ts
+}
+
+// unrealized returns the open position's unrealized PnL
+func (e *Engine) unrealized() float64 {
+	if e.position == nil {
+		return 0
+	}
+	return e.position.UnrealizedPnL
+}
+
+// positionSnapshot returns a copy of the position so strategies cannot mutate engine state
+func (e *Engine) positionSnapshot() *entity.Position {
+	if e.position == nil {
+		return nil
+	}
+	pos := *e.position
+	return &pos
+}
+
+// candleTicker converts a candle into a ticker; bid/ask carry the bar low/high
+func candleTicker(c *entity.Candle) *entity.Ticker {
+	return &entity.Ticker{
+		Symbol:    c.Symbol,
+		BidPrice:  c.Low,
+		AskPrice:  c.High,
+		LastPrice: c.Close,
+		Volume24h: c.Volume,
+		Timestamp: c.Timestamp,
+	}
+}
+
+// barsPerYear estimates the number of bars per year from the average candle interval
+func barsPerYear(candles []entity.Candle) float64 {
+	if len(candles) < 2 {
+		return 0
+	}
+	span := candles[len(candles)-1].Timestamp.Sub(candles[0].Timestamp)
+	if span <= 0 {
+		return 0
+	}
+	interval := span / time.Duration(len(candles)-1)
+	return float64(365*24*time.Hour) / float64(interval)
+}
@@ -0,0 +1,301 @@
+package backtest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/usecase/strategy"
+)
+
+// sineCandles builds an oscillating hourly price series around 100
+func sineCandles(n int, period, amplitude float64) []entity.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]entity.Candle, n)
+	prev := 100.0
+	for i := 0; i < n; i++ {
+		price := 100 + amplitude*math.Sin(2*math.Pi*float64(i)/period)
+		candles[i] = entity.Candle{
+			Symbol:    "BTC",
+			Open:      prev,
+			High:      math.Max(prev, price) + 0.1,
+			Low:       math.Min(prev, price) - 0.1,
+			Close:     price,
+			Volume:    1,
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+		}
+		prev = price
+	}
+	return candles
+}
+
+// scriptedStrategy emits predefined signals at given bar indexes and records callbacks
+type scriptedStrategy struct {
+	signals   map[int]*service.Signal
+	bar       int
+	orders    []*entity.Order
+	positions []*entity.Position
+	resets    int
+}
+
+func (s *scriptedStrategy) Name() string { return "scripted" }
+
+func (s *scriptedStrategy) Init(ctx context.Context, config map[string]interface{}) error {
+	return nil
+}
+
+func (s *scriptedStrategy) OnTick(ctx context.Context, state *service.MarketState) ([]*service.Signal, error) {
+	defer func() { s.bar++ }()
+	if sig, ok := s.signals[s.bar]; ok {
+		return []*service.Signal{sig}, nil
+	}
+	return nil, nil
+}
+
+func (s *scriptedStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	s.orders = append(s.orders, order)
+	return nil
+}
+
+func (s *scriptedStrategy) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	s.positions = append(s.positions, position)
+	return nil
+}
+
+func (s *scriptedStrategy) Reset(ctx context.Context) error {
+	s.resets++
+	return nil
+}
+
+func (s *scriptedStrategy) Stop(ctx context.Context) error { return nil }
+
+func flatCandles(prices ...float64) []entity.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]entity.Candle, len(prices))
+	for i, p := range prices {
+		candles[i] = entity.Candle{Open: p, High: p, Low: p, Close: p, Timestamp: start.Add(time.Duration(i) * time.Minute)}
+	}
+	return candles
+}
+
+func TestEngine_MeanReversionSynthetic(t *testing.T) {
+	s := strategy.NewMeanReversionStrategy()
+	err := s.Init(context.Background(), map[string]interface{}{
+		"window_size":     40,
+		"entry_deviation": 1.2,
+		"exit_deviation":  0.2,
+		"position_size":   1.0,
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	engine := NewEngine(s, Config{Symbol: "BTC", FillMode: FillAtNextOpen})
+	result, err := engine.Run(context.Background(), sineCandles(400, 40, 5))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.TradeCount == 0 {
+		t.Fatal("Expected trades on an oscillating series")
+	}
+	if result.TotalPnL <= 0 {
+		t.Errorf("Expected mean reversion to profit on a sine wave, got PnL=%.4f", result.TotalPnL)
+	}
+	if result.WinRate <= 0 || result.WinRate > 1 {
+		t.Errorf("Win rate out of range: %.2f", result.WinRate)
+	}
+	if result.MaxDrawdown < 0 || result.MaxDrawdown >= 1 {
+		t.Errorf("Max drawdown out of range: %.4f", result.MaxDrawdown)
+	}
+	if len(result.EquityCurve) != 400 {
+		t.Errorf("Expected equity point per bar, got %d", len(result.EquityCurve))
+	}
+}
+
+func TestEngine_FillAtNextOpen(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 2},
+		2: {Symbol: "BTC", Side: entity.SideSell, Price: 120, Quantity: 2},
+	}}
+	candles := flatCandles(100, 110, 120, 130)
+
+	result, err := NewEngine(s, Config{FillMode: FillAtNextOpen}).Run(context.Background(), candles)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Bought at bar 1 open (110), sold at bar 3 open (130)
+	if result.TradeCount != 1 {
+		t.Fatalf("Expected 1 trade, got %d", result.TradeCount)
+	}
+	trade := result.Trades[0]
+	if trade.EntryPrice != 110 || trade.ExitPrice != 130 {
+		t.Errorf("Expected fills at 110/130, got %.2f/%.2f", trade.EntryPrice, trade.ExitPrice)
+	}
+	if result.TotalPnL != 40 {
+		t.Errorf("Expected PnL 40, got %.2f", result.TotalPnL)
+	}
+	if result.WinRate != 1 {
+		t.Errorf("Expected win rate 1, got %.2f", result.WinRate)
+	}
+
+	if len(s.orders) != 2 || s.orders[0].Status != entity.OrderStatusFilled {
+		t.Errorf("Expected 2 filled order updates, got %d", len(s.orders))
+	}
+	if len(s.positions) != 2 || s.positions[0].Size != 2 || s.positions[1] != nil {
+		t.Errorf("Expected open then flat position updates, got %+v", s.positions)
+	}
+	if s.resets != 1 {
+		t.Errorf("Expected the strategy reset once on the close, got %d", s.resets)
+	}
+}
+
+func TestEngine_FillAtSignalAndFlip(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1},
+		1: {Symbol: "BTC", Side: entity.SideSell, Price: 90, Quantity: 3},
+	}}
+	candles := flatCandles(100, 90, 80)
+
+	result, err := NewEngine(s, Config{FillMode: FillAtSignal}).Run(context.Background(), candles)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Long 1 closed at 90 (-10), then short 2 from 90 marked at 80 (+20)
+	if result.TradeCount != 1 || result.RealizedPnL != -10 {
+		t.Errorf("Expected one losing trade of -10, got count=%d pnl=%.2f", result.TradeCount, result.RealizedPnL)
+	}
+	if result.UnrealizedPnL != 20 {
+		t.Errorf("Expected unrealized 20, got %.2f", result.UnrealizedPnL)
+	}
+	if result.TotalPnL != 10 {
+		t.Errorf("Expected total PnL 10, got %.2f", result.TotalPnL)
+	}
+	last := s.positions[len(s.positions)-1]
+	if last == nil || last.Size != -2 || last.Side != entity.SideSell {
+		t.Errorf("Expected flipped short of 2, got %+v", last)
+	}
+}
+
+func TestEngine_MaxDrawdown(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 10},
+	}}
+	candles := flatCandles(100, 200, 100)
+
+	result, err := NewEngine(s, Config{InitialEquity: 1000, FillMode: FillAtSignal}).Run(context.Background(), candles)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Equity 1000 -> 2000 -> 1000
+	if math.Abs(result.MaxDrawdown-0.5) > 1e-9 {
+		t.Errorf("Expected max drawdown 0.5, got %.4f", result.MaxDrawdown)
+	}
+}
+
+func TestEngine_RollingVolatility(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 10},
+	}}
+	candles := flatCandles(100, 110, 100, 100, 100)
+
+	cfg := Config{InitialEquity: 1000, FillMode: FillAtSignal, VolatilityWindow: 3}
+	result, err := NewEngine(s, cfg).Run(context.Background(), candles)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if want := len(candles) - cfg.VolatilityWindow + 1; len(result.RollingVolatility) != want {
+		t.Fatalf("Expected %d volatility windows, got %v", want, result.RollingVolatility)
+	}
+	// Returns 0, +10%, -9.1%, 0, 0: the swing widens the early windows and the flat tail is calm
+	if result.RollingVolatility[0] <= 0 || !(result.RollingVolatility[len(result.RollingVolatility)-1] < result.RollingVolatility[0]) {
+		t.Errorf("Expected volatility to fall once the price settles, got %v", result.RollingVolatility)
+	}
+
+	cfg.VolatilityWindow = 0
+	s.bar = 0
+	if result, _ := NewEngine(s, cfg).Run(context.Background(), candles); result.RollingVolatility != nil {
+		t.Errorf("Expected no rolling volatility when disabled, got %v", result.RollingVolatility)
+	}
+}
+
+func TestEngine_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewEngine(&scriptedStrategy{}, DefaultConfig()).Run(ctx, flatCandles(100)); err == nil {
+		t.Error("Expected error for canceled context")
+	}
+}
+
+func TestEngine_BreakEvenAfterFeesIsLoss(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1},
+		1: {Symbol: "BTC", Side: entity.SideSell, Price: 100, Quantity: 1},
+	}}
+	candles := flatCandles(100, 100, 100)
+
+	cfg := Config{FillMode: FillAtSignal, Fees: entity.FeeModel{TakerBps: 10}}
+	result, err := NewEngine(s, cfg).Run(context.Background(), candles)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.TradeCount != 1 {
+		t.Fatalf("Expected 1 trade, got %d", result.TradeCount)
+	}
+	trade := result.Trades[0]
+	if math.Abs(trade.PnL+0.2) > 1e-9 || math.Abs(trade.Fees-0.2) > 1e-9 {
+		t.Errorf("Expected net loss of 0.2 in fees, got pnl=%.4f fees=%.4f", trade.PnL, trade.Fees)
+	}
+	if result.WinRate != 0 {
+		t.Errorf("Expected break-even trade to count as a loss, got win rate %.2f", result.WinRate)
+	}
+	final := result.EquityCurve[len(result.EquityCurve)-1]
+	if math.Abs(final-(DefaultConfig().InitialEquity-0.2)) > 1e-9 {
+		t.Errorf("Expected equity to drop by fees, got %.4f", final)
+	}
+}
+
+func TestEngine_DepthLimitsFill(t *testing.T) {
+	s := &scriptedStrategy{signals: map[int]*service.Signal{
+		0: {Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 5},
+	}}
+	candles := flatCandles(100, 100)
+
+	// Three levels of 1 at 100, 101 and 102
+	cfg := Config{FillMode: FillAtSignal, Depth: SyntheticDepth{Levels: 3, StepBps: 100, LevelSize: 1}}
+	if _, err := NewEngine(s, cfg).Run(context.Background(), candles); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(s.orders) != 1 {
+		t.Fatalf("Expected one order update, got %d", len(s.orders))
+	}
+	order := s.orders[0]
+	if order.FilledQty != 3 || order.Status != entity.OrderStatusCanceled {
+		t.Errorf("Expected 3 of 5 filled and the rest canceled, got %f (%s)", order.FilledQty, order.Status)
+	}
+	if math.Abs(order.Price-101) > 1e-9 {
+		t.Errorf("Expected average fill price 101 beyond the top of book, got %f", order.Price)
+	}
+	if pos := s.positions[0]; pos == nil || pos.Size != 3 || math.Abs(pos.EntryPrice-101) > 1e-9 {
+		t.Errorf("Expected long 3 at 101, got %+v", pos)
+	}
+}
+
+func TestSyntheticDepth_Growth(t *testing.T) {
+	book := SyntheticDepth{Levels: 3, StepBps: 10, LevelSize: 1, Growth: 1}.Book("BTC", 1000, time.Time{})
+	if len(book.Asks) != 3 || book.Asks[2].Price != 1002 || book.Asks[2].Size != 4 {
+		t.Errorf("Unexpected asks: %+v", book.Asks)
+	}
+	if book.Bids[1].Price != 999 || book.Bids[1].Size != 2 {
+		t.Errorf("Unexpected bids: %+v", book.Bids)
+	}
+}
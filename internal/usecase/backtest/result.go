@@ -0,0 +1,54 @@
+package backtest
+
+import (
+	"github.com/zono819/hyperliquid-bot/internal/analytics"
+)
+
+// Result holds backtest performance statistics
+type Result struct {
+	TotalPnL      float64   // Realized plus unrealized PnL at the end of the run
+	RealizedPnL   float64   // PnL from closed trades
+	UnrealizedPnL float64   // PnL of the position still open at the end
+	TradeCount    int       // Number of closing fills
+	WinRate       float64   // Fraction of trades with positive PnL (0-1)
+	MaxDrawdown   float64   // Largest peak-to-trough equity decline (0-1)
+	SharpeRatio   float64   // Annualized Sharpe ratio of per-bar returns
+	SortinoRatio  float64   // Annualized Sortino ratio of per-bar returns
+	CalmarRatio   float64   // Annualized return over max drawdown
+	Trades        []Trade   // Closed trades in order
+	EquityCurve   []float64 // Equity after each bar
+
+	RollingVolatility []float64 // Annualized volatility of per-bar returns over each window of VolatilityWindow bars
+}
+
+// newResult computes statistics from trades and the equity curve
+func newResult(cfg Config, trades []Trade, equity []float64, unrealized, periodsPerYear float64) *Result {
+	r := &Result{
+		UnrealizedPnL: unrealized,
+		TradeCount:    len(trades),
+		Trades:        trades,
+		EquityCurve:   equity,
+	}
+
+	wins := 0
+	for _, t := range trades {
+		r.RealizedPnL += t.PnL
+		if t.PnL > 0 {
+			wins++
+		}
+	}
+	r.TotalPnL = r.RealizedPnL + unrealized
+	if len(trades) > 0 {
+		r.WinRate = float64(wins) / float64(len(trades))
+	}
+
+	returns := analytics.Returns(cfg.InitialEquity, equity)
+	r.MaxDrawdown = analytics.MaxDrawdown(cfg.InitialEquity, equity)
+	r.SharpeRatio = analytics.Sharpe(returns, cfg.RiskFreeRate, periodsPerYear)
+	r.SortinoRatio = analytics.Sortino(returns, cfg.RiskFreeRate, periodsPerYear)
+	r.CalmarRatio = analytics.Calmar(cfg.InitialEquity, equity, periodsPerYear)
+	if cfg.VolatilityWindow > 0 {
+		r.RollingVolatility = analytics.RollingVolatility(returns, cfg.VolatilityWindow, periodsPerYear)
+	}
+	return r
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
@@ -19,7 +20,7 @@ type BotUseCase struct {
 	mu       sync.RWMutex
 	running  bool
 	position *entity.Position
-	orders   []*entity.Order
+	orders   *OrderManager
 }
 
 // NewBotUseCase creates a new bot use case
@@ -28,6 +29,7 @@ func NewBotUseCase(exchange gateway.ExchangeGateway, strategy service.Strategy,
 		exchange: exchange,
 		strategy: strategy,
 		symbol:   symbol,
+		orders:   NewOrderManager(),
 	}
 }
 
@@ -112,7 +114,6 @@ func (b *BotUseCase) onTicker(ticker *entity.Ticker) {
 		return
 	}
 	position := b.position
-	orders := b.orders
 	b.mu.RUnlock()
 
 	ctx := context.Background()
@@ -121,7 +122,7 @@ func (b *BotUseCase) onTicker(ticker *entity.Ticker) {
 	state := &service.MarketState{
 		Ticker:   ticker,
 		Position: position,
-		Orders:   orders,
+		Orders:   b.orders.OpenOrders(),
 	}
 
 	// Get signals from strategy
@@ -139,20 +140,9 @@ func (b *BotUseCase) onTicker(ticker *entity.Ticker) {
 
 // onOrderUpdate handles order updates
 func (b *BotUseCase) onOrderUpdate(order *entity.Order) {
-	b.mu.Lock()
-	// Update orders list
-	found := false
-	for i, o := range b.orders {
-		if o.ID == order.ID {
-			b.orders[i] = order
-			found = true
-			break
-		}
-	}
-	if !found && order.Status == entity.OrderStatusOpen {
-		b.orders = append(b.orders, order)
+	if _, ok := b.orders.Update(order, time.Now()); !ok {
+		return
 	}
-	b.mu.Unlock()
 
 	// Notify strategy
 	ctx := context.Background()
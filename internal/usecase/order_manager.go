@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// maxClosedOrders bounds how many finished orders are kept for Snapshot
+const maxClosedOrders = 100
+
+// OrderManager owns the lifecycle state of the bot's orders.
+// It dedups updates by order ID and tracks open/closed transitions.
+type OrderManager struct {
+	mu       sync.RWMutex
+	open     map[string]*entity.Order
+	openIDs  []string             // Open order IDs in the order they were first seen
+	openedAt map[string]time.Time // When each open order was first seen
+	closed   []*entity.Order      // Most recent finished orders, oldest first
+	closedID map[string]bool
+}
+
+// NewOrderManager creates an empty order manager
+func NewOrderManager() *OrderManager {
+	return &OrderManager{
+		open:     make(map[string]*entity.Order),
+		openedAt: make(map[string]time.Time),
+		closedID: make(map[string]bool),
+	}
+}
+
+// isTerminal reports whether an order status is final
+func isTerminal(status entity.OrderStatus) bool {
+	return status == entity.OrderStatusFilled ||
+		status == entity.OrderStatusCanceled ||
+		status == entity.OrderStatusRejected
+}
+
+// Update records an order update observed at the given time and returns the
+// quantity filled since the previous update for the order. It returns false
+// when the update is a duplicate or arrives after the order already finished,
+// in which case it should not be processed again.
+func (m *OrderManager) Update(order *entity.Order, at time.Time) (float64, bool) {
+	filled, _, ok := m.UpdateFill(order, at)
+	return filled, ok
+}
+
+// UpdateFill is Update that also returns the average price of the quantity
+// filled since the previous update, taking each update's Price as the
+// average price of its FilledQty
+func (m *OrderManager) UpdateFill(order *entity.Order, at time.Time) (float64, float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closedID[order.ID] {
+		return 0, 0, false
+	}
+
+	filled, price := order.FilledQty, order.Price
+	prev, tracked := m.open[order.ID]
+	if tracked {
+		if prev.Status == order.Status && prev.FilledQty == order.FilledQty {
+			return 0, 0, false
+		}
+		filled -= prev.FilledQty
+		if filled > 0 && prev.FilledQty > 0 {
+			if p := (order.Price*order.FilledQty - prev.Price*prev.FilledQty) / filled; p > 0 {
+				price = p
+			}
+		}
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	if isTerminal(order.Status) {
+		if tracked {
+			m.removeOpenLocked(order.ID)
+		}
+		m.closeLocked(order)
+		return filled, price, true
+	}
+
+	if !tracked {
+		m.openIDs = append(m.openIDs, order.ID)
+		m.openedAt[order.ID] = at
+	}
+	m.open[order.ID] = order
+	return filled, price, true
+}
+
+// removeOpenLocked drops an order from the open set
+func (m *OrderManager) removeOpenLocked(id string) {
+	delete(m.open, id)
+	delete(m.openedAt, id)
+	for i, openID := range m.openIDs {
+		if openID == id {
+			m.openIDs = append(m.openIDs[:i], m.openIDs[i+1:]...)
+			break
+		}
+	}
+}
+
+// closeLocked appends a finished order, evicting the oldest beyond maxClosedOrders
+func (m *OrderManager) closeLocked(order *entity.Order) {
+	m.closed = append(m.closed, order)
+	m.closedID[order.ID] = true
+	if excess := len(m.closed) - maxClosedOrders; excess > 0 {
+		for _, o := range m.closed[:excess] {
+			delete(m.closedID, o.ID)
+		}
+		m.closed = append([]*entity.Order(nil), m.closed[excess:]...)
+	}
+}
+
+// IsOpen reports whether the order is tracked as open
+func (m *OrderManager) IsOpen(id string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.open[id]
+	return ok
+}
+
+// OpenOrders returns the open orders in the order they were first seen
+func (m *OrderManager) OpenOrders() []*entity.Order {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	orders := make([]*entity.Order, 0, len(m.openIDs))
+	for _, id := range m.openIDs {
+		orders = append(orders, m.open[id])
+	}
+	return orders
+}
+
+// Snapshot returns recently finished orders followed by open orders
+func (m *OrderManager) Snapshot() []*entity.Order {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	orders := make([]*entity.Order, 0, len(m.closed)+len(m.openIDs))
+	orders = append(orders, m.closed...)
+	for _, id := range m.openIDs {
+		orders = append(orders, m.open[id])
+	}
+	return orders
+}
+
+// Stale returns open orders first seen at least timeout before now
+func (m *OrderManager) Stale(now time.Time, timeout time.Duration) []*entity.Order {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var stale []*entity.Order
+	for _, id := range m.openIDs {
+		if now.Sub(m.openedAt[id]) >= timeout {
+			stale = append(stale, m.open[id])
+		}
+	}
+	return stale
+}
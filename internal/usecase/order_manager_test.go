@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func testOrder(id string, status entity.OrderStatus, filled float64) *entity.Order {
+	return &entity.Order{ID: id, Symbol: "BTC", Side: entity.SideBuy, Quantity: 1, FilledQty: filled, Status: status}
+}
+
+func TestOrderManager_Transitions(t *testing.T) {
+	m := NewOrderManager()
+	now := time.Now()
+
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusOpen, 0), now); !ok {
+		t.Fatal("Expected new open order to be recorded")
+	}
+	if !m.IsOpen("a") || len(m.OpenOrders()) != 1 {
+		t.Fatal("Expected order a to be open")
+	}
+
+	// Partial fill keeps the order open but is a change
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusOpen, 0.5), now); !ok {
+		t.Error("Expected partial fill to be recorded")
+	}
+	if got := m.OpenOrders()[0].FilledQty; got != 0.5 {
+		t.Errorf("Expected filled qty 0.5, got %f", got)
+	}
+
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusFilled, 1), now); !ok {
+		t.Error("Expected fill to be recorded")
+	}
+	if m.IsOpen("a") || len(m.OpenOrders()) != 0 {
+		t.Error("Expected filled order to leave the open set")
+	}
+	snapshot := m.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].Status != entity.OrderStatusFilled {
+		t.Errorf("Expected filled order in snapshot, got %+v", snapshot)
+	}
+}
+
+func TestOrderManager_DedupsByID(t *testing.T) {
+	m := NewOrderManager()
+	now := time.Now()
+
+	m.Update(testOrder("a", entity.OrderStatusOpen, 0), now)
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusOpen, 0), now); ok {
+		t.Error("Expected identical update to be a duplicate")
+	}
+	if len(m.Snapshot()) != 1 {
+		t.Errorf("Expected one tracked order, got %d", len(m.Snapshot()))
+	}
+
+	m.Update(testOrder("a", entity.OrderStatusFilled, 1), now)
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusFilled, 1), now); ok {
+		t.Error("Expected repeated fill to be a duplicate")
+	}
+	// A late open update must not resurrect a finished order
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusOpen, 0), now); ok || m.IsOpen("a") {
+		t.Error("Expected late open update after fill to be ignored")
+	}
+}
+
+func TestOrderManager_FilledDelta(t *testing.T) {
+	m := NewOrderManager()
+	now := time.Now()
+
+	steps := []struct {
+		status entity.OrderStatus
+		filled float64
+		want   float64
+	}{
+		{entity.OrderStatusOpen, 0, 0},
+		{entity.OrderStatusOpen, 0.3, 0.3},
+		{entity.OrderStatusOpen, 0.5, 0.2},
+		{entity.OrderStatusFilled, 1, 0.5},
+	}
+	for i, step := range steps {
+		got, ok := m.Update(testOrder("a", step.status, step.filled), now)
+		if !ok || got != step.want {
+			t.Errorf("step %d: expected delta %f, got %f (ok=%v)", i, step.want, got, ok)
+		}
+	}
+
+	// An order first seen filled reports its whole quantity
+	if got, _ := m.Update(testOrder("b", entity.OrderStatusFilled, 0.7), now); got != 0.7 {
+		t.Errorf("Expected delta 0.7 for immediately filled order, got %f", got)
+	}
+}
+
+func TestOrderManager_FillPrice(t *testing.T) {
+	m := NewOrderManager()
+	now := time.Now()
+
+	update := func(status entity.OrderStatus, filled, avg float64) (float64, float64) {
+		order := testOrder("a", status, filled)
+		order.Price = avg
+		qty, price, _ := m.UpdateFill(order, now)
+		return qty, price
+	}
+	if qty, price := update(entity.OrderStatusOpen, 0.4, 100); qty != 0.4 || price != 100 {
+		t.Errorf("Expected 0.4 at 100, got %f at %f", qty, price)
+	}
+	// 1 at an average of 101 after 0.4 at 100 leaves 0.6 at 101.67
+	if qty, price := update(entity.OrderStatusFilled, 1, 101); math.Abs(qty-0.6) > 1e-9 || math.Abs(price-(101-40)/0.6) > 1e-9 {
+		t.Errorf("Expected 0.6 at %f, got %f at %f", (101-40)/0.6, qty, price)
+	}
+}
+
+func TestOrderManager_TerminalWithoutOpen(t *testing.T) {
+	m := NewOrderManager()
+
+	// Immediately filled or rejected orders never pass through open
+	if _, ok := m.Update(testOrder("a", entity.OrderStatusRejected, 0), time.Now()); !ok {
+		t.Error("Expected rejected order to be recorded")
+	}
+	if len(m.OpenOrders()) != 0 || len(m.Snapshot()) != 1 {
+		t.Errorf("Expected rejected order closed only, got open=%d snapshot=%d", len(m.OpenOrders()), len(m.Snapshot()))
+	}
+}
+
+func TestOrderManager_Stale(t *testing.T) {
+	m := NewOrderManager()
+	start := time.Now()
+
+	m.Update(testOrder("old", entity.OrderStatusOpen, 0), start)
+	m.Update(testOrder("new", entity.OrderStatusOpen, 0), start.Add(40*time.Second))
+	// Updates keep the first-seen time
+	m.Update(testOrder("old", entity.OrderStatusOpen, 0.2), start.Add(50*time.Second))
+
+	stale := m.Stale(start.Add(time.Minute), time.Minute)
+	if len(stale) != 1 || stale[0].ID != "old" {
+		t.Errorf("Expected only the old order to be stale, got %+v", stale)
+	}
+}
+
+func TestOrderManager_BoundsClosedHistory(t *testing.T) {
+	m := NewOrderManager()
+	for i := 0; i < maxClosedOrders+10; i++ {
+		m.Update(testOrder(fmt.Sprintf("o%d", i), entity.OrderStatusFilled, 1), time.Now())
+	}
+
+	snapshot := m.Snapshot()
+	if len(snapshot) != maxClosedOrders {
+		t.Fatalf("Expected %d closed orders kept, got %d", maxClosedOrders, len(snapshot))
+	}
+	if snapshot[0].ID != "o10" {
+		t.Errorf("Expected oldest orders evicted first, got %s", snapshot[0].ID)
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvHeader lists the CSV columns in order
+var csvHeader = []string{
+	"symbol", "realized_pnl", "fees", "net_pnl", "trades", "wins", "losses",
+	"win_rate", "avg_win", "avg_loss", "max_drawdown", "open_size", "sharpe", "sortino",
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes one row per symbol followed by a TOTAL row
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	rows := append(append([]SymbolReport{}, r.Symbols...), r.Total)
+	for _, s := range rows {
+		if err := cw.Write(csvRow(s)); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvRow formats a symbol report as CSV fields
+func csvRow(s SymbolReport) []string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return []string{
+		s.Symbol, f(s.RealizedPnL), f(s.Fees), f(s.NetPnL),
+		strconv.Itoa(s.Trades), strconv.Itoa(s.Wins), strconv.Itoa(s.Losses),
+		f(s.WinRate), f(s.AvgWin), f(s.AvgLoss), f(s.MaxDrawdown), f(s.OpenSize),
+		f(s.Sharpe), f(s.Sortino),
+	}
+}
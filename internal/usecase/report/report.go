@@ -0,0 +1,244 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/analytics"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+// Config holds report configuration
+type Config struct {
+	Fees entity.FeeModel // Maker/taker fees estimated by fill liquidity (zero = no fees)
+}
+
+// fee returns the fee charged for a fill: the fee paid when known, else the configured estimate
+func (c Config) fee(f Fill) float64 {
+	if f.Fee != 0 {
+		return f.Fee
+	}
+	return c.Fees.Fee(f.Price, f.Quantity, f.Liquidity)
+}
+
+// Fill represents an executed quantity of an order
+type Fill struct {
+	Symbol    string
+	Side      entity.Side
+	Price     float64
+	Quantity  float64
+	Liquidity entity.Liquidity
+	Time      time.Time
+
+	Fee float64 // Fee paid in USD; 0 is estimated from Config
+}
+
+// SymbolReport holds trade statistics for a single symbol (or all symbols)
+type SymbolReport struct {
+	Symbol      string  `json:"symbol"`
+	RealizedPnL float64 `json:"realized_pnl"` // Gross PnL from closed quantity
+	Fees        float64 `json:"fees"`
+	NetPnL      float64 `json:"net_pnl"` // RealizedPnL minus fees
+	Trades      int     `json:"trades"`  // Fills that closed some quantity
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	WinRate     float64 `json:"win_rate"`
+	AvgWin      float64 `json:"avg_win"`
+	AvgLoss     float64 `json:"avg_loss"`     // Negative average of losing trades
+	MaxDrawdown float64 `json:"max_drawdown"` // Largest decline of cumulative net PnL from its peak
+	OpenSize    float64 `json:"open_size"`    // Signed quantity still open (positive = long)
+	Sharpe      float64 `json:"sharpe"`       // Mean over standard deviation of net trade PnL (per trade, not annualized)
+	Sortino     float64 `json:"sortino"`      // Mean over downside deviation of net trade PnL
+}
+
+// Report summarizes realized trading performance
+type Report struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Symbols     []SymbolReport `json:"symbols"`
+	Total       SymbolReport   `json:"total"`
+}
+
+// lot is an open quantity awaiting a closing fill
+type lot struct {
+	side     entity.Side
+	price    float64
+	quantity float64
+}
+
+// accumulator builds a SymbolReport from closing trades
+type accumulator struct {
+	report   SymbolReport
+	winSum   float64
+	lossSum  float64
+	cumPnL   float64
+	peakPnL  float64
+	openLots []lot
+	netPnLs  []float64 // Net PnL of each closing trade
+}
+
+// FromRepository builds a report from all orders with filled quantity
+func FromRepository(ctx context.Context, repo repository.OrderRepository, cfg Config) (*Report, error) {
+	orders, err := repo.List(ctx, repository.OrderFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return Build(FillsFromOrders(orders), cfg), nil
+}
+
+// FillsFromOrders extracts fills from orders in execution order
+func FillsFromOrders(orders []*entity.Order) []Fill {
+	fills := make([]Fill, 0, len(orders))
+	for _, o := range orders {
+		if o.FilledQty <= 0 {
+			continue
+		}
+		ts := o.UpdatedAt
+		if ts.IsZero() {
+			ts = o.CreatedAt
+		}
+		fills = append(fills, Fill{
+			Symbol:    o.Symbol,
+			Side:      o.Side,
+			Price:     o.Price,
+			Quantity:  o.FilledQty,
+			Liquidity: entity.OrderLiquidity(o),
+			Time:      ts,
+			Fee:       o.Fee,
+		})
+	}
+	sort.SliceStable(fills, func(i, j int) bool {
+		return fills[i].Time.Before(fills[j].Time)
+	})
+	return fills
+}
+
+// Build computes the report, pairing entry and exit fills FIFO per symbol
+func Build(fills []Fill, cfg Config) *Report {
+	bySymbol := make(map[string]*accumulator)
+	total := &accumulator{report: SymbolReport{Symbol: "TOTAL"}}
+
+	for _, f := range fills {
+		acc, ok := bySymbol[f.Symbol]
+		if !ok {
+			acc = &accumulator{report: SymbolReport{Symbol: f.Symbol}}
+			bySymbol[f.Symbol] = acc
+		}
+
+		fee := cfg.fee(f)
+		acc.report.Fees += fee
+		total.report.Fees += fee
+
+		pnl, closed := acc.match(f)
+		if closed {
+			acc.record(pnl, fee)
+			total.record(pnl, fee)
+		} else {
+			acc.addFee(fee)
+			total.addFee(fee)
+		}
+	}
+
+	r := &Report{
+		GeneratedAt: time.Now(),
+		Symbols:     make([]SymbolReport, 0, len(bySymbol)),
+	}
+	for _, acc := range bySymbol {
+		acc.report.OpenSize = acc.openSize()
+		r.Symbols = append(r.Symbols, acc.finish())
+	}
+	sort.Slice(r.Symbols, func(i, j int) bool {
+		return r.Symbols[i].Symbol < r.Symbols[j].Symbol
+	})
+	r.Total = total.finish()
+	return r
+}
+
+// match closes open lots FIFO against the fill; any excess opens a new lot
+func (a *accumulator) match(f Fill) (float64, bool) {
+	remaining := f.Quantity
+	pnl := 0.0
+	closed := false
+
+	for remaining > 0 && len(a.openLots) > 0 && a.openLots[0].side != f.Side {
+		l := &a.openLots[0]
+		qty := math.Min(remaining, l.quantity)
+
+		pnl += entity.GrossPnL(l.side, l.price, f.Price, qty)
+		closed = true
+
+		l.quantity -= qty
+		remaining -= qty
+		if l.quantity <= 1e-12 {
+			a.openLots = a.openLots[1:]
+		}
+	}
+
+	if remaining > 1e-12 {
+		a.openLots = append(a.openLots, lot{side: f.Side, price: f.Price, quantity: remaining})
+	}
+	return pnl, closed
+}
+
+// record adds a closing trade; the fill's fee is charged against it
+func (a *accumulator) record(pnl, fee float64) {
+	a.report.RealizedPnL += pnl
+	a.report.Trades++
+
+	net := pnl - fee
+	a.netPnLs = append(a.netPnLs, net)
+	if net > 0 {
+		a.report.Wins++
+		a.winSum += net
+	} else {
+		a.report.Losses++
+		a.lossSum += net
+	}
+	a.updateDrawdown(net)
+}
+
+// addFee charges an opening fill's fee against cumulative PnL
+func (a *accumulator) addFee(fee float64) {
+	a.updateDrawdown(-fee)
+}
+
+// updateDrawdown tracks the largest decline of cumulative net PnL
+func (a *accumulator) updateDrawdown(delta float64) {
+	a.cumPnL += delta
+	a.peakPnL = math.Max(a.peakPnL, a.cumPnL)
+	a.report.MaxDrawdown = math.Max(a.report.MaxDrawdown, a.peakPnL-a.cumPnL)
+}
+
+// openSize returns the signed quantity of open lots
+func (a *accumulator) openSize() float64 {
+	size := 0.0
+	for _, l := range a.openLots {
+		if l.side == entity.SideBuy {
+			size += l.quantity
+		} else {
+			size -= l.quantity
+		}
+	}
+	return size
+}
+
+// finish computes derived statistics
+func (a *accumulator) finish() SymbolReport {
+	r := a.report
+	r.NetPnL = r.RealizedPnL - r.Fees
+	if r.Trades > 0 {
+		r.WinRate = float64(r.Wins) / float64(r.Trades)
+	}
+	if r.Wins > 0 {
+		r.AvgWin = a.winSum / float64(r.Wins)
+	}
+	if r.Losses > 0 {
+		r.AvgLoss = a.lossSum / float64(r.Losses)
+	}
+	r.Sharpe = analytics.Sharpe(a.netPnLs, 0, 0)
+	r.Sortino = analytics.Sortino(a.netPnLs, 0, 0)
+	return r
+}
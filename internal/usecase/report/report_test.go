@@ -0,0 +1,184 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/repository"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// craftedFills returns a BTC sequence with partial FIFO closes and a losing ETH short
+func craftedFills() []Fill {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+	return []Fill{
+		{Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1, Time: at(0)},
+		{Symbol: "BTC", Side: entity.SideBuy, Price: 110, Quantity: 1, Time: at(1)},
+		// Closes lot @100 (+20) and half of lot @110 (+5)
+		{Symbol: "BTC", Side: entity.SideSell, Price: 120, Quantity: 1.5, Time: at(2)},
+		// Closes remaining 0.5 @110 (-5) and opens a 0.5 short @100
+		{Symbol: "BTC", Side: entity.SideSell, Price: 100, Quantity: 1, Time: at(3)},
+		{Symbol: "ETH", Side: entity.SideSell, Price: 50, Quantity: 2, Time: at(4)},
+		// Short closed @55 (-10)
+		{Symbol: "ETH", Side: entity.SideBuy, Price: 55, Quantity: 2, Time: at(5)},
+	}
+}
+
+func TestBuild_FIFO(t *testing.T) {
+	r := Build(craftedFills(), Config{})
+
+	if len(r.Symbols) != 2 {
+		t.Fatalf("Expected 2 symbols, got %d", len(r.Symbols))
+	}
+
+	btc := r.Symbols[0]
+	if btc.Symbol != "BTC" {
+		t.Fatalf("Expected symbols sorted, got %s first", btc.Symbol)
+	}
+	if !approx(btc.RealizedPnL, 20) {
+		t.Errorf("Expected BTC PnL 20, got %.4f", btc.RealizedPnL)
+	}
+	if btc.Trades != 2 || btc.Wins != 1 || btc.Losses != 1 {
+		t.Errorf("Expected 2 trades (1W/1L), got %d (%dW/%dL)", btc.Trades, btc.Wins, btc.Losses)
+	}
+	if !approx(btc.AvgWin, 25) || !approx(btc.AvgLoss, -5) {
+		t.Errorf("Expected avg win 25 / loss -5, got %.4f / %.4f", btc.AvgWin, btc.AvgLoss)
+	}
+	if !approx(btc.OpenSize, -0.5) {
+		t.Errorf("Expected open short 0.5, got %.4f", btc.OpenSize)
+	}
+	if !approx(btc.MaxDrawdown, 5) {
+		t.Errorf("Expected BTC drawdown 5, got %.4f", btc.MaxDrawdown)
+	}
+	// Trades +25 and -5: mean 10, standard deviation 15, downside deviation 5/sqrt(2)
+	if !approx(btc.Sharpe, 10.0/15) || !approx(btc.Sortino, 2*math.Sqrt2) {
+		t.Errorf("Expected BTC Sharpe 0.667 / Sortino 2.828, got %.4f / %.4f", btc.Sharpe, btc.Sortino)
+	}
+
+	eth := r.Symbols[1]
+	if !approx(eth.RealizedPnL, -10) || eth.WinRate != 0 {
+		t.Errorf("Expected ETH PnL -10 and win rate 0, got %.4f / %.2f", eth.RealizedPnL, eth.WinRate)
+	}
+	if eth.Sharpe != 0 || eth.Sortino != 0 {
+		t.Errorf("Expected no ratios from a single trade, got %.4f / %.4f", eth.Sharpe, eth.Sortino)
+	}
+
+	total := r.Total
+	if !approx(total.RealizedPnL, 10) || total.Trades != 3 {
+		t.Errorf("Expected total PnL 10 over 3 trades, got %.4f over %d", total.RealizedPnL, total.Trades)
+	}
+	if !approx(total.WinRate, 1.0/3) {
+		t.Errorf("Expected total win rate 1/3, got %.4f", total.WinRate)
+	}
+	// Cumulative: +25, +20, +10 -> drawdown 15 from the +25 peak
+	if !approx(total.MaxDrawdown, 15) {
+		t.Errorf("Expected total drawdown 15, got %.4f", total.MaxDrawdown)
+	}
+}
+
+func TestBuild_Fees(t *testing.T) {
+	fills := []Fill{
+		{Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1},
+		{Symbol: "BTC", Side: entity.SideSell, Price: 100.1, Quantity: 1},
+	}
+	r := Build(fills, Config{Fees: entity.FeeModel{MakerBps: 10, TakerBps: 10}})
+
+	btc := r.Symbols[0]
+	if !approx(btc.Fees, 0.2001) {
+		t.Errorf("Expected fees 0.2001, got %.6f", btc.Fees)
+	}
+	if !approx(btc.NetPnL, 0.1-0.2001) {
+		t.Errorf("Expected net PnL %.6f, got %.6f", 0.1-0.2001, btc.NetPnL)
+	}
+	if btc.Losses != 1 {
+		t.Errorf("Expected the fee-adjusted trade to be a loss")
+	}
+}
+
+func TestBuild_FeeModelByLiquidity(t *testing.T) {
+	fills := []Fill{
+		{Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1, Liquidity: entity.LiquidityTaker},
+		{Symbol: "BTC", Side: entity.SideSell, Price: 100, Quantity: 1, Liquidity: entity.LiquidityMaker},
+	}
+	r := Build(fills, Config{Fees: entity.FeeModel{MakerBps: 2, TakerBps: 5}})
+
+	btc := r.Symbols[0]
+	if !approx(btc.Fees, 0.07) {
+		t.Errorf("Expected taker entry and maker exit fees of 0.07, got %.6f", btc.Fees)
+	}
+	if !approx(btc.NetPnL, -0.07) || btc.Losses != 1 {
+		t.Errorf("Expected break-even trade to be a net loss, got %.6f", btc.NetPnL)
+	}
+}
+
+// listRepo is a minimal OrderRepository serving a fixed order list
+type listRepo struct {
+	repository.OrderRepository
+	orders []*entity.Order
+}
+
+func (r *listRepo) List(ctx context.Context, filter repository.OrderFilter) ([]*entity.Order, error) {
+	return r.orders, nil
+}
+
+func TestFromRepository(t *testing.T) {
+	t0 := time.Now()
+	// Repository returns most recent first; unfilled orders are ignored
+	repo := &listRepo{orders: []*entity.Order{
+		{ID: "3", Symbol: "BTC", Side: entity.SideBuy, Price: 90, Quantity: 1, Status: entity.OrderStatusOpen, UpdatedAt: t0.Add(2 * time.Second)},
+		{ID: "2", Symbol: "BTC", Side: entity.SideSell, Price: 110, Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled, UpdatedAt: t0.Add(time.Second)},
+		{ID: "1", Symbol: "BTC", Side: entity.SideBuy, Price: 100, Quantity: 1, FilledQty: 1, Status: entity.OrderStatusFilled, UpdatedAt: t0},
+	}}
+
+	r, err := FromRepository(context.Background(), repo, Config{})
+	if err != nil {
+		t.Fatalf("FromRepository failed: %v", err)
+	}
+	if !approx(r.Total.RealizedPnL, 10) || r.Total.Trades != 1 {
+		t.Errorf("Expected one +10 trade, got %.4f over %d", r.Total.RealizedPnL, r.Total.Trades)
+	}
+}
+
+func TestReport_Export(t *testing.T) {
+	r := Build(craftedFills(), Config{})
+
+	var jsonBuf bytes.Buffer
+	if err := r.WriteJSON(&jsonBuf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(jsonBuf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(decoded.Symbols) != 2 || decoded.Total.Trades != 3 {
+		t.Errorf("Unexpected decoded report: %+v", decoded)
+	}
+
+	var csvBuf bytes.Buffer
+	if err := r.WriteCSV(&csvBuf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&csvBuf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Expected header + 2 symbols + total, got %d rows", len(rows))
+	}
+	if rows[0][0] != "symbol" || rows[1][0] != "BTC" || rows[3][0] != "TOTAL" {
+		t.Errorf("Unexpected CSV rows: %v", rows)
+	}
+	if rows[1][1] != "20" {
+		t.Errorf("Expected BTC realized_pnl 20, got %s", rows[1][1])
+	}
+}
@@ -0,0 +1,91 @@
+package risk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// EventBlackout blocks new entries in a window around high-importance
+// economic events (CPI, FOMC, payrolls...), when releases cause price spikes
+type EventBlackout struct {
+	mu        sync.Mutex
+	before    time.Duration
+	after     time.Duration
+	events    []*entity.EconomicEvent
+	flattened map[string]bool // Events FlattenDue has reported
+}
+
+// NewEventBlackout creates a blackout starting before each event and ending after it
+func NewEventBlackout(before, after time.Duration) *EventBlackout {
+	return &EventBlackout{
+		before:    before,
+		after:     after,
+		flattened: make(map[string]bool),
+	}
+}
+
+// SetWindow changes how long before and after each event entries are blocked
+func (b *EventBlackout) SetWindow(before, after time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.before, b.after = before, after
+}
+
+// SetEvents replaces the scheduled events; only high-importance ones are kept
+func (b *EventBlackout) SetEvents(events []*entity.EconomicEvent) {
+	high := make([]*entity.EconomicEvent, 0, len(events))
+	for _, e := range events {
+		if e != nil && e.Importance == "high" && !e.Date.IsZero() {
+			high = append(high, e)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = high
+}
+
+// Active returns the event whose blackout window contains now, or nil
+func (b *EventBlackout) Active(now time.Time) *entity.EconomicEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active(now)
+}
+
+// FlattenDue returns the event whose window has opened but which has not been
+// released yet, once per event, so positions can be closed ahead of it
+func (b *EventBlackout) FlattenDue(now time.Time) *entity.EconomicEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	event := b.active(now)
+	if event == nil || !now.Before(event.Date) {
+		return nil
+	}
+	key := eventKey(event)
+	if b.flattened[key] {
+		return nil
+	}
+	b.flattened[key] = true
+	return event
+}
+
+// active returns the first event whose window contains now. Caller must hold b.mu.
+func (b *EventBlackout) active(now time.Time) *entity.EconomicEvent {
+	for _, e := range b.events {
+		if !now.Before(e.Date.Add(-b.before)) && !now.After(e.Date.Add(b.after)) {
+			return e
+		}
+	}
+	return nil
+}
+
+// eventKey identifies an event across refreshes of the calendar
+func eventKey(e *entity.EconomicEvent) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Event + "@" + e.Date.UTC().Format(time.RFC3339)
+}
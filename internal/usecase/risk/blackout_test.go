@@ -0,0 +1,58 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+func TestEventBlackout_Active(t *testing.T) {
+	now := time.Date(2025, 1, 15, 13, 20, 0, 0, time.UTC)
+	cpi := &entity.EconomicEvent{ID: "cpi", Event: "CPI", Date: now.Add(10 * time.Minute), Importance: "high"}
+	b := NewEventBlackout(30*time.Minute, 15*time.Minute)
+	b.SetEvents([]*entity.EconomicEvent{
+		{ID: "claims", Event: "Jobless Claims", Date: now.Add(5 * time.Minute), Importance: "medium"},
+		cpi,
+	})
+
+	if got := b.Active(now); got != cpi {
+		t.Errorf("Expected CPI blackout 10 minutes before the release, got %+v", got)
+	}
+	if got := b.Active(now.Add(-25 * time.Minute)); got != nil {
+		t.Errorf("Expected no blackout 35 minutes before the release, got %+v", got)
+	}
+	if got := b.Active(now.Add(25 * time.Minute)); got != cpi {
+		t.Errorf("Expected blackout 15 minutes after the release, got %+v", got)
+	}
+	if got := b.Active(now.Add(26 * time.Minute)); got != nil {
+		t.Errorf("Expected blackout to end after the window, got %+v", got)
+	}
+
+	b.SetWindow(5*time.Minute, 30*time.Minute)
+	if got := b.Active(now); got != nil {
+		t.Errorf("Expected no blackout 10 minutes before the release after narrowing, got %+v", got)
+	}
+	if got := b.Active(now.Add(26 * time.Minute)); got != cpi {
+		t.Errorf("Expected the widened window to cover 16 minutes after the release, got %+v", got)
+	}
+}
+
+func TestEventBlackout_FlattenDue(t *testing.T) {
+	now := time.Date(2025, 1, 15, 13, 20, 0, 0, time.UTC)
+	b := NewEventBlackout(30*time.Minute, 30*time.Minute)
+	b.SetEvents([]*entity.EconomicEvent{{Event: "FOMC", Date: now.Add(10 * time.Minute), Importance: "high"}})
+
+	if b.FlattenDue(now) == nil {
+		t.Fatal("Expected flatten to be due inside the pre-event window")
+	}
+	if b.FlattenDue(now.Add(time.Minute)) != nil {
+		t.Error("Expected flatten to be reported once per event")
+	}
+
+	// Refreshed calendars keep the event's state
+	b.SetEvents([]*entity.EconomicEvent{{Event: "FOMC", Date: now.Add(10 * time.Minute), Importance: "high"}})
+	if b.FlattenDue(now.Add(2*time.Minute)) != nil {
+		t.Error("Expected flatten not to repeat after a calendar refresh")
+	}
+}
@@ -1,16 +1,31 @@
 package risk
 
 import (
+	"fmt"
+	"math"
 	"sync"
 	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
 )
 
 // Config holds risk management configuration
 type Config struct {
 	MaxPositionSize     float64
-	MaxDailyLoss        float64
+	MaxDailyLoss        float64 // Max daily loss in USD (0 = disabled)
 	MaxConsecutiveLoss  int
 	CooldownDuration    time.Duration
+	SymbolLimits        map[string]float64 // Max open size per symbol (falls back to MaxPositionSize)
+	MaxLeverage         float64            // Max total notional / equity (0 = unlimited)
+	DailyResetHour      int                // UTC hour at which daily PnL resets (0-23)
+	StopATRMultiple     float64            // Stop distance in ATR multiples used by SizePosition
+	ResetStatsDaily     bool               // Reset trade statistics with daily PnL (otherwise cumulative)
+	MaxSpreadBps        float64            // Reject orders when the bid/ask spread exceeds this (0 = disabled)
+	MinTopSize          float64            // Reject orders when the top-of-book size they take is below this (0 = disabled)
+	MaxPositions        int                // Max symbols with an open position at once (0 = unlimited)
+
+	MaxDailyLossPct float64 // Max daily loss as a fraction of the day's starting equity (0 = disabled; needs UpdateEquity)
 }
 
 // DefaultConfig returns default risk configuration
@@ -20,6 +35,7 @@ func DefaultConfig() *Config {
 		MaxDailyLoss:       0.05, // 5%
 		MaxConsecutiveLoss: 3,
 		CooldownDuration:   5 * time.Minute,
+		StopATRMultiple:    2.0,
 	}
 }
 
@@ -39,6 +55,15 @@ type Checker struct {
 	cooldownUntil    time.Time
 	halted           bool
 	haltReason       string
+	haltHandlers     []func(reason string)
+	positions        map[string]float64 // Signed open size per symbol
+	marks            map[string]float64 // Latest price per symbol for exposure checks
+	tradingDay       time.Time          // Start of the current trading day
+	stats            tradeStats
+	clock            service.Clock
+
+	equity    float64 // Latest account equity (0 = unknown)
+	dayEquity float64 // Equity at the start of the trading day
 }
 
 // NewChecker creates a new risk checker
@@ -46,34 +71,64 @@ func NewChecker(cfg *Config) *Checker {
 	if cfg == nil {
 		cfg = DefaultConfig()
 	}
-	return &Checker{
-		config: cfg,
+	c := &Checker{
+		config:    cfg,
+		positions: make(map[string]float64),
+		marks:     make(map[string]float64),
+		clock:     service.RealClock,
 	}
+	c.tradingDay = c.tradingDayStart(c.clock.Now())
+	return c
 }
 
 // CanTrade checks if trading is allowed
 func (c *Checker) CanTrade() CheckResult {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rolloverDay()
 
 	if c.halted {
 		return CheckResult{Allowed: false, Reason: "trading halted: " + c.haltReason}
 	}
 
-	if time.Now().Before(c.cooldownUntil) {
+	if c.clock.Now().Before(c.cooldownUntil) {
 		return CheckResult{Allowed: false, Reason: "in cooldown until " + c.cooldownUntil.Format(time.RFC3339)}
 	}
 
-	if c.dailyPnL < -c.config.MaxDailyLoss {
+	if c.config.MaxDailyLoss > 0 && c.dailyPnL < -c.config.MaxDailyLoss {
 		return CheckResult{Allowed: false, Reason: "daily loss limit exceeded"}
 	}
+	if limit := c.config.MaxDailyLossPct * c.dayEquity; limit > 0 && c.dailyPnL < -limit {
+		return CheckResult{Allowed: false, Reason: fmt.Sprintf("daily loss %.2f exceeds %.1f%% of equity %.2f",
+			-c.dailyPnL, c.config.MaxDailyLossPct*100, c.dayEquity)}
+	}
 
 	return CheckResult{Allowed: true}
 }
 
+// Reconfigure replaces the risk limits; tracked state is kept
+func (c *Checker) Reconfigure(cfg *Config) {
+	if cfg == nil {
+		return
+	}
+	next := *cfg
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config = &next
+}
+
+// settings returns the current configuration
+func (c *Checker) settings() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.config
+}
+
 // CheckPositionSize validates position size
 func (c *Checker) CheckPositionSize(size float64) CheckResult {
-	if size > c.config.MaxPositionSize {
+	if size > c.settings().MaxPositionSize {
 		return CheckResult{
 			Allowed: false,
 			Reason:  "position size exceeds maximum",
@@ -82,30 +137,280 @@ func (c *Checker) CheckPositionSize(size float64) CheckResult {
 	return CheckResult{Allowed: true}
 }
 
+// CheckSymbolPositionSize validates that an order keeps the symbol's total open size within its limit.
+// Orders that reduce the open position are always allowed.
+func (c *Checker) CheckSymbolPositionSize(symbol string, side entity.Side, size float64) CheckResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	current := c.positions[symbol]
+	next := current + signedSize(side, size)
+	limit := c.symbolLimit(symbol)
+
+	reducing := math.Abs(next) <= math.Abs(current) && next*current >= 0
+	if math.Abs(next) > limit && !reducing {
+		return CheckResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("%s position %.4f would exceed limit %.4f", symbol, math.Abs(next), limit),
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// CheckOpenPositions rejects an order that would open a position in a new
+// symbol while MaxPositions symbols are already in a position
+func (c *Checker) CheckOpenPositions(symbol string) CheckResult {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	limit := c.config.MaxPositions
+	if _, open := c.positions[symbol]; open || limit <= 0 {
+		return CheckResult{Allowed: true}
+	}
+	if len(c.positions) >= limit {
+		return CheckResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("%d positions open, limit %d", len(c.positions), limit),
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// RecordFill updates the tracked open size of a symbol after a fill
+func (c *Checker) RecordFill(symbol string, side entity.Side, qty float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.positions[symbol] += signedSize(side, qty)
+	if math.Abs(c.positions[symbol]) < 1e-12 {
+		delete(c.positions, symbol)
+	}
+}
+
+// SetClock sets the clock used for cooldowns and the trading day; nil restores the real clock
+func (c *Checker) SetClock(clock service.Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if clock == nil {
+		clock = service.RealClock
+	}
+	c.clock = clock
+}
+
+// SetPosition overwrites the tracked open size of a symbol (positive = long)
+func (c *Checker) SetPosition(symbol string, size float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if size == 0 {
+		delete(c.positions, symbol)
+		return
+	}
+	c.positions[symbol] = size
+}
+
+// UpdatePrice records the latest price of a symbol used to value its open position
+func (c *Checker) UpdatePrice(symbol string, price float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.marks[symbol] = price
+}
+
+// UpdateEquity records the latest account equity; the first value of each
+// trading day is the base for MaxDailyLossPct
+func (c *Checker) UpdateEquity(equity float64) {
+	if equity <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rolloverDay()
+	c.equity = equity
+	if c.dayEquity == 0 {
+		c.dayEquity = equity
+	}
+}
+
+// Equity returns the latest account equity, 0 before UpdateEquity
+func (c *Checker) Equity() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.equity
+}
+
+// CheckLeverage validates that notional exposure stays within MaxLeverage of equity
+func (c *Checker) CheckLeverage(notional, equity float64) CheckResult {
+	cfg := c.settings()
+	if cfg.MaxLeverage <= 0 {
+		return CheckResult{Allowed: true}
+	}
+	if equity <= 0 {
+		return CheckResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("no equity available (%.2f)", equity),
+		}
+	}
+
+	leverage := math.Abs(notional) / equity
+	if leverage > cfg.MaxLeverage {
+		return CheckResult{
+			Allowed: false,
+			Reason:  fmt.Sprintf("leverage %.2fx exceeds maximum %.2fx", leverage, cfg.MaxLeverage),
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// CheckExposure validates the aggregate leverage of all tracked positions after the order fills.
+// Positions in other symbols are valued at their latest price from UpdatePrice.
+// Orders that reduce the open position are always allowed.
+func (c *Checker) CheckExposure(symbol string, side entity.Side, size, price, equity float64) CheckResult {
+	c.mu.RLock()
+	current := c.positions[symbol]
+	next := current + signedSize(side, size)
+	if math.Abs(next) <= math.Abs(current) && next*current >= 0 {
+		c.mu.RUnlock()
+		return CheckResult{Allowed: true}
+	}
+
+	notional := math.Abs(next) * price
+	for s, pos := range c.positions {
+		if s != symbol {
+			notional += math.Abs(pos) * c.marks[s]
+		}
+	}
+	c.mu.RUnlock()
+
+	return c.CheckLeverage(notional, equity)
+}
+
+// CheckLiquidity rejects orders in thin markets: a spread wider than MaxSpreadBps,
+// or less than MinTopSize on the side the order takes (asks for a buy, bids for a sell)
+func (c *Checker) CheckLiquidity(ticker *entity.Ticker, side entity.Side) CheckResult {
+	cfg := c.settings()
+	if cfg.MaxSpreadBps <= 0 && cfg.MinTopSize <= 0 {
+		return CheckResult{Allowed: true}
+	}
+	if ticker == nil || ticker.BidPrice <= 0 || ticker.AskPrice <= 0 {
+		return CheckResult{Allowed: false, Reason: "no bid/ask quotes"}
+	}
+
+	if cfg.MaxSpreadBps > 0 {
+		if spread := ticker.SpreadBps(); spread > cfg.MaxSpreadBps {
+			return CheckResult{
+				Allowed: false,
+				Reason:  fmt.Sprintf("spread %.2f bps exceeds maximum %.2f bps", spread, cfg.MaxSpreadBps),
+			}
+		}
+	}
+
+	if cfg.MinTopSize > 0 {
+		size := ticker.AskSize
+		if side == entity.SideSell {
+			size = ticker.BidSize
+		}
+		if size < cfg.MinTopSize {
+			return CheckResult{
+				Allowed: false,
+				Reason:  fmt.Sprintf("top-of-book size %.4f below minimum %.4f", size, cfg.MinTopSize),
+			}
+		}
+	}
+	return CheckResult{Allowed: true}
+}
+
+// Ensure Checker can size strategy entries
+var _ service.PositionSizer = (*Checker)(nil)
+
+// SizePosition returns the size at which a stop StopATRMultiple ATRs away
+// loses riskPerTrade of equity. The size is capped by MaxLeverage when set.
+// It returns 0 if any input is not positive.
+func (c *Checker) SizePosition(equity, price, atr, riskPerTrade float64) float64 {
+	cfg := c.settings()
+	multiple := cfg.StopATRMultiple
+	if multiple <= 0 {
+		multiple = DefaultConfig().StopATRMultiple
+	}
+	if equity <= 0 || price <= 0 || atr <= 0 || riskPerTrade <= 0 {
+		return 0
+	}
+
+	size := equity * riskPerTrade / (atr * multiple)
+	if cfg.MaxLeverage > 0 {
+		size = math.Min(size, equity*cfg.MaxLeverage/price)
+	}
+	return size
+}
+
+// symbolLimit returns the max open size for a symbol; caller must hold the lock
+func (c *Checker) symbolLimit(symbol string) float64 {
+	if limit, ok := c.config.SymbolLimits[symbol]; ok {
+		return limit
+	}
+	return c.config.MaxPositionSize
+}
+
+// signedSize returns size as positive for buys and negative for sells
+func signedSize(side entity.Side, size float64) float64 {
+	if side == entity.SideSell {
+		return -size
+	}
+	return size
+}
+
 // RecordTrade records a trade result
 func (c *Checker) RecordTrade(pnl float64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.rolloverDay()
 	c.dailyPnL += pnl
+	c.stats.record(pnl)
 
 	if pnl < 0 {
-		c.consecutiveLoss++
-		if c.consecutiveLoss >= c.config.MaxConsecutiveLoss {
-			c.cooldownUntil = time.Now().Add(c.config.CooldownDuration)
-			c.consecutiveLoss = 0
-		}
+		c.addConsecutiveLoss()
 	} else {
 		c.consecutiveLoss = 0
 	}
 }
 
+// RecordFailure counts a failed order placement towards the consecutive loss
+// cooldown without touching daily PnL or the trade statistics
+func (c *Checker) RecordFailure() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addConsecutiveLoss()
+}
+
+// addConsecutiveLoss starts the cooldown once the losses in a row reach the
+// limit. Callers must hold c.mu.
+func (c *Checker) addConsecutiveLoss() {
+	c.consecutiveLoss++
+	if c.consecutiveLoss >= c.config.MaxConsecutiveLoss {
+		c.cooldownUntil = c.clock.Now().Add(c.config.CooldownDuration)
+		c.consecutiveLoss = 0
+	}
+}
+
 // Halt stops trading
 func (c *Checker) Halt(reason string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.halted = true
 	c.haltReason = reason
+	handlers := make([]func(string), len(c.haltHandlers))
+	copy(handlers, c.haltHandlers)
+	c.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(reason)
+	}
+}
+
+// OnHalt registers a handler called whenever trading is halted
+func (c *Checker) OnHalt(handler func(reason string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.haltHandlers = append(c.haltHandlers, handler)
 }
 
 // Resume resumes trading
@@ -121,7 +426,33 @@ func (c *Checker) Resume() {
 func (c *Checker) ResetDaily() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.resetDaily()
+}
+
+// resetDaily resets daily statistics; caller must hold the lock
+func (c *Checker) resetDaily() {
 	c.dailyPnL = 0
+	c.dayEquity = c.equity
+	if c.config.ResetStatsDaily {
+		c.stats = tradeStats{}
+	}
+}
+
+// rolloverDay resets daily statistics once the trading day has changed; caller must hold the lock
+func (c *Checker) rolloverDay() {
+	day := c.tradingDayStart(c.clock.Now())
+	if day.After(c.tradingDay) {
+		c.tradingDay = day
+		c.resetDaily()
+	}
+}
+
+// tradingDayStart returns the start of the trading day containing t
+func (c *Checker) tradingDayStart(t time.Time) time.Time {
+	offset := time.Duration(c.config.DailyResetHour) * time.Hour
+	shifted := t.UTC().Add(-offset)
+	day := time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, time.UTC)
+	return day.Add(offset)
 }
 
 // Status returns current risk status
@@ -129,12 +460,70 @@ func (c *Checker) Status() map[string]interface{} {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	positions := make(map[string]float64, len(c.positions))
+	for symbol, size := range c.positions {
+		positions[symbol] = size
+	}
+
 	return map[string]interface{}{
 		"halted":           c.halted,
 		"halt_reason":      c.haltReason,
 		"daily_pnl":        c.dailyPnL,
 		"consecutive_loss": c.consecutiveLoss,
-		"in_cooldown":      time.Now().Before(c.cooldownUntil),
+		"in_cooldown":      c.clock.Now().Before(c.cooldownUntil),
 		"cooldown_until":   c.cooldownUntil,
+		"positions":        positions,
+		"trading_day":      c.tradingDay,
+		"wins":             c.stats.wins,
+		"losses":           c.stats.losses,
+		"gross_profit":     c.stats.grossProfit,
+		"gross_loss":       c.stats.grossLoss,
+		"win_rate":         c.stats.winRate(),
+		"profit_factor":    c.stats.profitFactor(),
+		"avg_trade":        c.stats.avgTrade(),
+		"equity":           c.equity,
+	}
+}
+
+// tradeStats accumulates closed trade results
+type tradeStats struct {
+	wins        int
+	losses      int
+	grossProfit float64
+	grossLoss   float64 // Sum of losing trades as a positive amount
+}
+
+// record adds a trade result; break-even trades count as wins
+func (s *tradeStats) record(pnl float64) {
+	if pnl < 0 {
+		s.losses++
+		s.grossLoss -= pnl
+	} else {
+		s.wins++
+		s.grossProfit += pnl
+	}
+}
+
+// winRate returns the fraction of winning trades
+func (s *tradeStats) winRate() float64 {
+	if total := s.wins + s.losses; total > 0 {
+		return float64(s.wins) / float64(total)
+	}
+	return 0
+}
+
+// profitFactor returns gross profit divided by gross loss (0 without losses)
+func (s *tradeStats) profitFactor() float64 {
+	if s.grossLoss > 0 {
+		return s.grossProfit / s.grossLoss
+	}
+	return 0
+}
+
+// avgTrade returns the average PnL per trade
+func (s *tradeStats) avgTrade() float64 {
+	if total := s.wins + s.losses; total > 0 {
+		return (s.grossProfit - s.grossLoss) / float64(total)
 	}
+	return 0
 }
@@ -0,0 +1,377 @@
+package risk
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+func TestChecker_CheckSymbolPositionSize(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxPositionSize = 1.0
+	cfg.SymbolLimits = map[string]float64{"BTC": 0.5}
+	c := NewChecker(cfg)
+
+	if r := c.CheckSymbolPositionSize("BTC", entity.SideBuy, 0.4); !r.Allowed {
+		t.Errorf("Expected order within limit to be allowed: %s", r.Reason)
+	}
+	c.RecordFill("BTC", entity.SideBuy, 0.4)
+
+	// Total BTC exposure would be 0.6 > 0.5
+	if r := c.CheckSymbolPositionSize("BTC", entity.SideBuy, 0.2); r.Allowed {
+		t.Error("Expected order exceeding BTC cap to be rejected")
+	}
+
+	// A different symbol uses the global default
+	if r := c.CheckSymbolPositionSize("ETH", entity.SideBuy, 0.8); !r.Allowed {
+		t.Errorf("Expected ETH order under default limit to be allowed: %s", r.Reason)
+	}
+	if r := c.CheckSymbolPositionSize("ETH", entity.SideBuy, 1.2); r.Allowed {
+		t.Error("Expected ETH order over default limit to be rejected")
+	}
+}
+
+func TestChecker_CheckSymbolPositionSize_Reducing(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SymbolLimits = map[string]float64{"BTC": 0.5}
+	c := NewChecker(cfg)
+
+	// Tracked position already above the cap (e.g. limit lowered after entry)
+	c.SetPosition("BTC", 0.8)
+
+	if r := c.CheckSymbolPositionSize("BTC", entity.SideSell, 0.2); !r.Allowed {
+		t.Errorf("Expected reducing order to be allowed: %s", r.Reason)
+	}
+	if r := c.CheckSymbolPositionSize("BTC", entity.SideBuy, 0.1); r.Allowed {
+		t.Error("Expected increasing order to be rejected")
+	}
+	// Flipping to a short larger than the cap is rejected
+	if r := c.CheckSymbolPositionSize("BTC", entity.SideSell, 1.5); r.Allowed {
+		t.Error("Expected flip beyond the cap to be rejected")
+	}
+}
+
+func TestChecker_CheckOpenPositions(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxPositions = 2
+	c := NewChecker(cfg)
+
+	for _, symbol := range []string{"BTC", "ETH"} {
+		if r := c.CheckOpenPositions(symbol); !r.Allowed {
+			t.Fatalf("Expected %s entry under the cap to be allowed: %s", symbol, r.Reason)
+		}
+		c.RecordFill(symbol, entity.SideBuy, 0.1)
+	}
+
+	// The 3rd concurrent entry is blocked
+	if r := c.CheckOpenPositions("SOL"); r.Allowed {
+		t.Error("Expected 3rd concurrent position to be rejected")
+	}
+	// Symbols already in a position can still trade
+	if r := c.CheckOpenPositions("BTC"); !r.Allowed {
+		t.Errorf("Expected order in an open symbol to be allowed: %s", r.Reason)
+	}
+
+	// Closing a position frees a slot
+	c.RecordFill("ETH", entity.SideSell, 0.1)
+	if r := c.CheckOpenPositions("SOL"); !r.Allowed {
+		t.Errorf("Expected entry allowed after a position closed: %s", r.Reason)
+	}
+}
+
+func TestChecker_RecordFill(t *testing.T) {
+	c := NewChecker(nil)
+
+	c.RecordFill("BTC", entity.SideBuy, 0.3)
+	c.RecordFill("BTC", entity.SideSell, 0.1)
+
+	positions := c.Status()["positions"].(map[string]float64)
+	if got := positions["BTC"]; got < 0.2-1e-9 || got > 0.2+1e-9 {
+		t.Errorf("Expected tracked BTC size 0.2, got %.4f", got)
+	}
+
+	c.RecordFill("BTC", entity.SideSell, 0.2)
+	positions = c.Status()["positions"].(map[string]float64)
+	if _, ok := positions["BTC"]; ok {
+		t.Error("Expected flat symbol to be removed")
+	}
+}
+
+func TestChecker_CheckPositionSize(t *testing.T) {
+	c := NewChecker(&Config{MaxPositionSize: 1.0})
+
+	if r := c.CheckPositionSize(0.5); !r.Allowed {
+		t.Error("Expected size under max to be allowed")
+	}
+	if r := c.CheckPositionSize(1.5); r.Allowed {
+		t.Error("Expected size over max to be rejected")
+	}
+}
+
+func TestChecker_CheckLeverage(t *testing.T) {
+	c := NewChecker(&Config{MaxLeverage: 3.0})
+
+	if r := c.CheckLeverage(25000, 10000); !r.Allowed {
+		t.Errorf("Expected 2.5x to be allowed: %s", r.Reason)
+	}
+	if r := c.CheckLeverage(35000, 10000); r.Allowed {
+		t.Error("Expected 3.5x to be rejected")
+	}
+	if r := c.CheckLeverage(100, 0); r.Allowed {
+		t.Error("Expected zero equity to be rejected")
+	}
+
+	unlimited := NewChecker(&Config{})
+	if r := unlimited.CheckLeverage(1e9, 1); !r.Allowed {
+		t.Error("Expected leverage check to be disabled without MaxLeverage")
+	}
+}
+
+func TestChecker_CheckLiquidity(t *testing.T) {
+	c := NewChecker(&Config{MaxSpreadBps: 10, MinTopSize: 0.5})
+	normal := &entity.Ticker{BidPrice: 49995, AskPrice: 50005, BidSize: 1, AskSize: 2}
+
+	if r := c.CheckLiquidity(normal, entity.SideBuy); !r.Allowed {
+		t.Errorf("Expected 2 bps spread to be allowed: %s", r.Reason)
+	}
+
+	wide := &entity.Ticker{BidPrice: 49900, AskPrice: 50100, BidSize: 1, AskSize: 1}
+	if r := c.CheckLiquidity(wide, entity.SideBuy); r.Allowed {
+		t.Error("Expected 40 bps spread to be rejected")
+	} else if !strings.Contains(r.Reason, "spread") {
+		t.Errorf("Expected spread rejection reason, got %q", r.Reason)
+	}
+
+	thinBids := &entity.Ticker{BidPrice: 49995, AskPrice: 50005, BidSize: 0.1, AskSize: 2}
+	if r := c.CheckLiquidity(thinBids, entity.SideBuy); !r.Allowed {
+		t.Errorf("Expected buy against deep asks to be allowed: %s", r.Reason)
+	}
+	if r := c.CheckLiquidity(thinBids, entity.SideSell); r.Allowed {
+		t.Error("Expected sell into thin bids to be rejected")
+	}
+
+	if r := c.CheckLiquidity(&entity.Ticker{LastPrice: 50000}, entity.SideBuy); r.Allowed {
+		t.Error("Expected empty book to be rejected")
+	}
+
+	disabled := NewChecker(&Config{})
+	if r := disabled.CheckLiquidity(wide, entity.SideBuy); !r.Allowed {
+		t.Error("Expected liquidity check to be disabled without limits")
+	}
+}
+
+func TestChecker_CheckExposure(t *testing.T) {
+	c := NewChecker(&Config{MaxLeverage: 2.0})
+	c.RecordFill("BTC", entity.SideBuy, 0.2)
+	c.UpdatePrice("BTC", 50000) // 10000 notional
+
+	// ETH 2 @ 4000 = 8000 → 18000 total on 10000 equity = 1.8x
+	if r := c.CheckExposure("ETH", entity.SideBuy, 2, 4000, 10000); !r.Allowed {
+		t.Errorf("Expected aggregate 1.8x to be allowed: %s", r.Reason)
+	}
+	// ETH 3 @ 4000 = 12000 → 22000 total = 2.2x
+	if r := c.CheckExposure("ETH", entity.SideBuy, 3, 4000, 10000); r.Allowed {
+		t.Error("Expected aggregate 2.2x to be rejected")
+	}
+	// Closing BTC is allowed even without equity
+	if r := c.CheckExposure("BTC", entity.SideSell, 0.2, 50000, 0); !r.Allowed {
+		t.Errorf("Expected closing order to be allowed: %s", r.Reason)
+	}
+}
+
+func TestChecker_DailyReset(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DailyResetHour = 8
+	c := NewChecker(cfg)
+
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC))
+	c.SetClock(clock)
+	c.tradingDay = c.tradingDayStart(clock.Now())
+
+	c.RecordTrade(-0.1)
+	if r := c.CanTrade(); r.Allowed {
+		t.Fatal("Expected daily loss limit to block trading")
+	}
+
+	// Still the same trading day before the reset hour
+	clock.Advance(20 * time.Minute)
+	if r := c.CanTrade(); r.Allowed {
+		t.Error("Expected loss to persist before the reset hour")
+	}
+
+	// Crossing 08:00 UTC starts a new trading day
+	clock.Set(time.Date(2024, 3, 1, 8, 0, 1, 0, time.UTC))
+	if r := c.CanTrade(); !r.Allowed {
+		t.Errorf("Expected trading allowed after reset: %s", r.Reason)
+	}
+	if pnl := c.Status()["daily_pnl"].(float64); pnl != 0 {
+		t.Errorf("Expected daily PnL reset to 0, got %.4f", pnl)
+	}
+}
+
+func TestChecker_DailyLossPct(t *testing.T) {
+	c := NewChecker(&Config{MaxDailyLossPct: 0.05, MaxConsecutiveLoss: 10})
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	c.SetClock(clock)
+	c.tradingDay = c.tradingDayStart(clock.Now())
+
+	// Without equity the limit cannot be applied
+	c.RecordTrade(-100)
+	if r := c.CanTrade(); !r.Allowed {
+		t.Fatalf("Expected no limit before equity is known: %s", r.Reason)
+	}
+
+	// 5% of the day's starting 10000 is 500; later equity does not move the base
+	c.UpdateEquity(10000)
+	c.UpdateEquity(9600)
+	c.RecordTrade(-350)
+	if r := c.CanTrade(); !r.Allowed {
+		t.Fatalf("Expected a 450 loss within the limit: %s", r.Reason)
+	}
+	c.RecordTrade(-100)
+	if r := c.CanTrade(); r.Allowed || !strings.Contains(r.Reason, "equity") {
+		t.Fatalf("Expected a 550 loss to exceed 5%% of equity, got %+v", r)
+	}
+	if equity := c.Equity(); equity != 9600 {
+		t.Errorf("Expected latest equity 9600, got %v", equity)
+	}
+
+	// A new trading day starts from the latest equity
+	clock.Advance(24 * time.Hour)
+	if r := c.CanTrade(); !r.Allowed {
+		t.Errorf("Expected trading allowed on a new day: %s", r.Reason)
+	}
+	c.RecordTrade(-470)
+	if r := c.CanTrade(); !r.Allowed {
+		t.Errorf("Expected a 470 loss within 5%% of 9600: %s", r.Reason)
+	}
+	c.RecordTrade(-20)
+	if r := c.CanTrade(); r.Allowed {
+		t.Error("Expected a 490 loss to exceed 5% of 9600")
+	}
+}
+
+func TestChecker_CooldownAfterConsecutiveLosses(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxConsecutiveLoss = 2
+	cfg.CooldownDuration = 30 * time.Minute
+	c := NewChecker(cfg)
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	c.SetClock(clock)
+	c.tradingDay = c.tradingDayStart(clock.Now())
+
+	c.RecordTrade(-0.001)
+	c.RecordTrade(-0.001)
+	if r := c.CanTrade(); r.Allowed || !strings.Contains(r.Reason, "cooldown") {
+		t.Fatalf("Expected cooldown after consecutive losses, got %+v", r)
+	}
+
+	clock.Advance(29 * time.Minute)
+	if r := c.CanTrade(); r.Allowed {
+		t.Error("Expected cooldown to last its full duration")
+	}
+
+	clock.Advance(time.Minute)
+	if r := c.CanTrade(); !r.Allowed {
+		t.Errorf("Expected trading allowed once the cooldown elapsed: %s", r.Reason)
+	}
+}
+
+func TestChecker_RecordFailure(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxConsecutiveLoss = 2
+	cfg.CooldownDuration = 30 * time.Minute
+	c := NewChecker(cfg)
+	c.RecordTrade(10)
+
+	c.RecordFailure()
+	c.RecordFailure()
+	if r := c.CanTrade(); r.Allowed || !strings.Contains(r.Reason, "cooldown") {
+		t.Fatalf("Expected cooldown after consecutive failures, got %+v", r)
+	}
+	status := c.Status()
+	if status["daily_pnl"].(float64) != 10 || status["losses"].(int) != 0 || status["win_rate"].(float64) != 1 {
+		t.Errorf("Expected failures to leave PnL and trade stats alone, got %+v", status)
+	}
+}
+
+func TestChecker_SizePosition(t *testing.T) {
+	c := NewChecker(&Config{StopATRMultiple: 2.0})
+
+	equity, price, atr, risk := 10000.0, 50000.0, 500.0, 0.01
+	size := c.SizePosition(equity, price, atr, risk)
+
+	// Loss at a 2 ATR stop equals 1% of equity
+	loss := size * atr * 2.0
+	if math.Abs(loss-equity*risk) > 1e-9 {
+		t.Errorf("Expected stop loss %.2f, got %.2f (size %.6f)", equity*risk, loss, size)
+	}
+
+	for _, tc := range []struct {
+		name                     string
+		equity, price, atr, risk float64
+	}{
+		{"zero equity", 0, price, atr, risk},
+		{"zero atr", equity, price, 0, risk},
+		{"zero price", equity, 0, atr, risk},
+		{"negative risk", equity, price, atr, -0.01},
+	} {
+		if got := c.SizePosition(tc.equity, tc.price, tc.atr, tc.risk); got != 0 {
+			t.Errorf("%s: expected 0, got %.6f", tc.name, got)
+		}
+	}
+}
+
+func TestChecker_SizePosition_LeverageCap(t *testing.T) {
+	c := NewChecker(&Config{StopATRMultiple: 1.0, MaxLeverage: 2.0})
+
+	// Tiny ATR would imply a huge size; capped at 2x equity notional
+	size := c.SizePosition(10000, 100, 0.01, 0.01)
+	if math.Abs(size-200) > 1e-9 {
+		t.Errorf("Expected size capped at 200, got %.4f", size)
+	}
+}
+
+func TestChecker_TradeStats(t *testing.T) {
+	c := NewChecker(&Config{MaxDailyLoss: 1000, MaxConsecutiveLoss: 10})
+
+	for _, pnl := range []float64{30, -10, 20, -10, 10} {
+		c.RecordTrade(pnl)
+	}
+
+	status := c.Status()
+	if status["wins"] != 3 || status["losses"] != 2 {
+		t.Errorf("Expected 3 wins / 2 losses, got %v / %v", status["wins"], status["losses"])
+	}
+	if got := status["win_rate"].(float64); math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("Expected win rate 0.6, got %.4f", got)
+	}
+	if got := status["profit_factor"].(float64); math.Abs(got-3.0) > 1e-9 {
+		t.Errorf("Expected profit factor 3.0, got %.4f", got)
+	}
+	if got := status["avg_trade"].(float64); math.Abs(got-8.0) > 1e-9 {
+		t.Errorf("Expected average trade 8.0, got %.4f", got)
+	}
+
+	// Stats are cumulative by default
+	c.ResetDaily()
+	if status := c.Status(); status["wins"] != 3 {
+		t.Errorf("Expected cumulative stats to survive ResetDaily, got %v wins", status["wins"])
+	}
+}
+
+func TestChecker_TradeStats_ResetDaily(t *testing.T) {
+	c := NewChecker(&Config{MaxDailyLoss: 1000, MaxConsecutiveLoss: 10, ResetStatsDaily: true})
+	c.RecordTrade(10)
+	c.RecordTrade(-5)
+
+	c.ResetDaily()
+	status := c.Status()
+	if status["wins"] != 0 || status["losses"] != 0 || status["profit_factor"].(float64) != 0 {
+		t.Errorf("Expected stats reset, got %v", status)
+	}
+}
@@ -0,0 +1,60 @@
+package risk
+
+import "sync"
+
+// DrawdownMonitor tracks equity against its running peak and trips once the
+// decline from the peak exceeds a fraction of it. It stays tripped until Reset.
+type DrawdownMonitor struct {
+	mu          sync.Mutex
+	maxDrawdown float64
+	peak        float64
+	tripped     bool
+}
+
+// NewDrawdownMonitor creates a monitor tripping beyond maxDrawdown (0-1) from peak equity
+func NewDrawdownMonitor(maxDrawdown float64) *DrawdownMonitor {
+	return &DrawdownMonitor{maxDrawdown: maxDrawdown}
+}
+
+// Update records the latest equity and returns the drawdown from peak (0-1).
+// trip is true only on the update that trips the monitor.
+func (m *DrawdownMonitor) Update(equity float64) (drawdown float64, trip bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if equity > m.peak {
+		m.peak = equity
+	}
+	if m.peak <= 0 {
+		return 0, false
+	}
+	drawdown = (m.peak - equity) / m.peak
+	if !m.tripped && m.maxDrawdown > 0 && drawdown > m.maxDrawdown {
+		m.tripped = true
+		return drawdown, true
+	}
+	return drawdown, false
+}
+
+// SetMaxDrawdown changes the trip threshold (0-1), keeping the peak and
+// tripped state
+func (m *DrawdownMonitor) SetMaxDrawdown(maxDrawdown float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxDrawdown = maxDrawdown
+}
+
+// Tripped reports whether the monitor has tripped since the last Reset
+func (m *DrawdownMonitor) Tripped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tripped
+}
+
+// Reset re-arms the monitor; the next Update starts a new peak
+func (m *DrawdownMonitor) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.peak = 0
+	m.tripped = false
+}
@@ -0,0 +1,33 @@
+package risk
+
+import "testing"
+
+func TestDrawdownMonitor(t *testing.T) {
+	m := NewDrawdownMonitor(0.1)
+
+	for _, equity := range []float64{1000, 1100, 1050, 1000} {
+		if _, trip := m.Update(equity); trip {
+			t.Fatalf("Expected no trip at equity %.0f", equity)
+		}
+	}
+	dd, trip := m.Update(980)
+	if !trip || dd < 0.109 || dd > 0.110 {
+		t.Fatalf("Expected trip at ~10.9%% from the 1100 peak, got %.4f trip=%v", dd, trip)
+	}
+	// Latched: further losses do not trip again
+	if _, trip := m.Update(900); trip || !m.Tripped() {
+		t.Error("Expected monitor to stay tripped without tripping again")
+	}
+
+	m.Reset()
+	if m.Tripped() {
+		t.Error("Expected reset to re-arm the monitor")
+	}
+	// The peak restarts from the current equity
+	if _, trip := m.Update(900); trip {
+		t.Error("Expected no trip right after reset")
+	}
+	if _, trip := m.Update(800); !trip {
+		t.Error("Expected trip beyond 10% of the new peak")
+	}
+}
@@ -0,0 +1,354 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// BreakoutStrategy implements a Donchian-style trend-following breakout strategy.
+// The channel is built from ticks, or from closed candles once the bot hands
+// it candles (strategy.candle_interval); ticks then only check exits.
+type BreakoutStrategy struct {
+	mu       sync.RWMutex
+	running  bool
+	config   BreakoutConfig
+	prices   ring // Price history, capped at historySize
+	highs    ring
+	lows     ring
+	position *entity.Position
+	candles  bool                // History is built from closed candles
+	last     service.MarketState // Latest tick state, used to price candle entries
+
+	// Open trade state
+	stopPrice float64
+	entryTime time.Time
+}
+
+// BreakoutConfig holds strategy configuration
+type BreakoutConfig struct {
+	Lookback          int           // Number of periods for the breakout channel
+	ATRPeriod         int           // Number of periods for ATR calculation
+	ATRStopMultiplier float64       // Stop distance in ATR multiples
+	PositionSize      float64       // Position size in base currency
+	MaxHoldTime       time.Duration // Close position after this duration (0 = disabled)
+	EntryPricing      EntryPricing  // How entry orders are priced
+}
+
+// DefaultBreakoutConfig returns default configuration
+func DefaultBreakoutConfig() BreakoutConfig {
+	return BreakoutConfig{
+		Lookback:          20,
+		ATRPeriod:         14,
+		ATRStopMultiplier: 2.0,
+		PositionSize:      0.01,
+		MaxHoldTime:       0,
+		EntryPricing:      EntryPricing{Mode: EntryPricingLast},
+	}
+}
+
+// NewBreakoutStrategy creates a new breakout strategy
+func NewBreakoutStrategy() *BreakoutStrategy {
+	return &BreakoutStrategy{
+		config: DefaultBreakoutConfig(),
+	}
+}
+
+// Name returns strategy name
+func (s *BreakoutStrategy) Name() string {
+	return "breakout"
+}
+
+// Init initializes strategy with config
+func (s *BreakoutStrategy) Init(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.applyConfig(config); err != nil {
+		return err
+	}
+	s.running = true
+	return nil
+}
+
+// Reconfigure applies new parameters without resetting price history or position state
+func (s *BreakoutStrategy) Reconfigure(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.applyConfig(config)
+}
+
+// applyConfig parses config over the current settings; nothing changes on error
+func (s *BreakoutStrategy) applyConfig(config map[string]interface{}) error {
+	cfg := s.config
+
+	if v, ok := config["lookback"].(int); ok {
+		cfg.Lookback = v
+	}
+	if v, ok := config["atr_period"].(int); ok {
+		cfg.ATRPeriod = v
+	}
+	if v, ok := config["atr_stop_multiplier"].(float64); ok {
+		cfg.ATRStopMultiplier = v
+	}
+	if v, ok := config["position_size"].(float64); ok {
+		cfg.PositionSize = v
+	}
+	if v, ok := config["max_hold_time"].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid max_hold_time: %w", err)
+		}
+		cfg.MaxHoldTime = d
+	}
+	if err := parseEntryPricing(config, &cfg.EntryPricing); err != nil {
+		return err
+	}
+
+	s.config = cfg
+	return nil
+}
+
+// OnTick is called on each market tick
+func (s *BreakoutStrategy) OnTick(ctx context.Context, state *service.MarketState) ([]*service.Signal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running || state.Ticker == nil {
+		return nil, nil
+	}
+
+	currentPrice := state.Ticker.LastPrice
+	s.last = *state
+
+	var ready bool
+	var channelHigh, channelLow, atr float64
+	if s.candles {
+		atr = ATR(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.ATRPeriod)
+	} else {
+		high, low := tickHighLow(state.Ticker)
+		ready, channelHigh, channelLow, atr = s.push(high, low, currentPrice)
+	}
+
+	hasPosition := state.Position != nil && state.Position.Size != 0
+	s.position = state.Position
+
+	if hasPosition {
+		if signal := s.checkExitConditions(state, currentPrice, atr); signal != nil {
+			return []*service.Signal{signal}, nil
+		}
+		return nil, nil
+	}
+
+	// Flat: clear any state left over from a previous trade
+	s.resetTradeState()
+
+	if s.candles || !ready {
+		return nil, nil
+	}
+	return s.entrySignals(state, currentPrice, channelHigh, channelLow), nil
+}
+
+// OnCandle records a closed candle and enters when it closes outside the
+// channel of the prior candles. The first candle replaces the tick history.
+func (s *BreakoutStrategy) OnCandle(ctx context.Context, candle entity.Candle) ([]*service.Signal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return nil, nil
+	}
+	if !s.candles {
+		s.candles = true
+		s.prices, s.highs, s.lows = ring{}, ring{}, ring{}
+	}
+
+	ready, channelHigh, channelLow, _ := s.push(candle.High, candle.Low, candle.Close)
+	if !ready || (s.position != nil && s.position.Size != 0) {
+		return nil, nil
+	}
+
+	state := s.last
+	if state.Ticker == nil || state.Ticker.Symbol != candle.Symbol {
+		state = service.MarketState{Ticker: &entity.Ticker{Symbol: candle.Symbol, LastPrice: candle.Close, Timestamp: candle.Timestamp}}
+	}
+	return s.entrySignals(&state, candle.Close, channelHigh, channelLow), nil
+}
+
+// push records a bar and returns whether the history was full before it,
+// with the channel and ATR of the prior bars
+func (s *BreakoutStrategy) push(high, low, close float64) (bool, float64, float64, float64) {
+	// Channel is computed from prior bars, so evaluate before recording the bar
+	size := s.historySize()
+	s.prices.Resize(size)
+	s.highs.Resize(size)
+	s.lows.Resize(size)
+	ready := s.prices.Len() >= size
+	channelHigh, channelLow := s.channel()
+	atr := ATR(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.ATRPeriod)
+
+	s.prices.Push(close)
+	s.highs.Push(high)
+	s.lows.Push(low)
+	return ready, channelHigh, channelLow, atr
+}
+
+// entrySignals returns an entry when price breaks out of the channel
+func (s *BreakoutStrategy) entrySignals(state *service.MarketState, price, channelHigh, channelLow float64) []*service.Signal {
+	if price > channelHigh {
+		return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideBuy, s.config.PositionSize, price,
+			fmt.Sprintf("Breakout: price above %d-period high %.2f (enter long)", s.config.Lookback, channelHigh))}
+	}
+	if price < channelLow {
+		return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideSell, s.config.PositionSize, price,
+			fmt.Sprintf("Breakout: price below %d-period low %.2f (enter short)", s.config.Lookback, channelLow))}
+	}
+	return nil
+}
+
+// checkExitConditions checks ATR trailing stop and max hold time
+func (s *BreakoutStrategy) checkExitConditions(state *service.MarketState, currentPrice, atr float64) *service.Signal {
+	isLong := s.position.Size > 0
+	now := state.Ticker.Timestamp
+
+	// Initialize trade state on first tick with the position
+	if s.entryTime.IsZero() {
+		s.entryTime = now
+	}
+
+	// Trail the stop in the direction of the trade
+	if atr > 0 {
+		distance := atr * s.config.ATRStopMultiplier
+		switch {
+		case s.stopPrice == 0 && isLong:
+			s.stopPrice = s.position.EntryPrice - distance
+		case s.stopPrice == 0:
+			s.stopPrice = s.position.EntryPrice + distance
+		case isLong:
+			s.stopPrice = math.Max(s.stopPrice, currentPrice-distance)
+		default:
+			s.stopPrice = math.Min(s.stopPrice, currentPrice+distance)
+		}
+	}
+
+	if s.stopPrice > 0 {
+		if isLong && currentPrice <= s.stopPrice {
+			return s.exitSignal(state, currentPrice, fmt.Sprintf("Breakout: ATR stop hit at %.2f (close long)", s.stopPrice))
+		}
+		if !isLong && currentPrice >= s.stopPrice {
+			return s.exitSignal(state, currentPrice, fmt.Sprintf("Breakout: ATR stop hit at %.2f (close short)", s.stopPrice))
+		}
+	}
+
+	if s.config.MaxHoldTime > 0 && now.Sub(s.entryTime) >= s.config.MaxHoldTime {
+		return s.exitSignal(state, currentPrice, "Breakout: max hold time reached")
+	}
+
+	return nil
+}
+
+// exitSignal creates a signal closing the current position
+func (s *BreakoutStrategy) exitSignal(state *service.MarketState, price float64, reason string) *service.Signal {
+	side := entity.SideSell
+	if s.position.Size < 0 {
+		side = entity.SideBuy
+	}
+	return &service.Signal{
+		Symbol:   state.Ticker.Symbol,
+		Side:     side,
+		Price:    price,
+		Quantity: math.Abs(s.position.Size),
+		Reason:   reason,
+	}
+}
+
+// historySize returns the number of ticks to keep in history
+func (s *BreakoutStrategy) historySize() int {
+	size := s.config.Lookback
+	if s.config.ATRPeriod+1 > size {
+		size = s.config.ATRPeriod + 1
+	}
+	return size
+}
+
+// channel returns the highest high and lowest low over the lookback period
+func (s *BreakoutStrategy) channel() (float64, float64) {
+	highs, lows := s.highs.Values(), s.lows.Values()
+	if len(highs) == 0 {
+		return 0, 0
+	}
+
+	start := len(highs) - s.config.Lookback
+	if start < 0 {
+		start = 0
+	}
+
+	high, low := highs[start], lows[start]
+	for i := start + 1; i < len(highs); i++ {
+		high = math.Max(high, highs[i])
+		low = math.Min(low, lows[i])
+	}
+	return high, low
+}
+
+// OnOrderUpdate is called when order status changes
+func (s *BreakoutStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	return nil
+}
+
+// OnPositionUpdate is called when position changes
+func (s *BreakoutStrategy) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.position = position
+	if position == nil || position.Size == 0 {
+		s.resetTradeState()
+	}
+	return nil
+}
+
+// Reset clears the trailing stop and entry time of the last trade
+func (s *BreakoutStrategy) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetTradeState()
+	return nil
+}
+
+// resetTradeState clears per-trade state. s.mu must be held.
+func (s *BreakoutStrategy) resetTradeState() {
+	s.stopPrice = 0
+	s.entryTime = time.Time{}
+}
+
+// GetState returns a snapshot of the strategy state
+func (s *BreakoutStrategy) GetState() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := map[string]interface{}{
+		"running":    s.running,
+		"history":    s.prices.Len(),
+		"stop_price": s.stopPrice,
+	}
+	if !s.entryTime.IsZero() {
+		state["entry_time"] = s.entryTime
+	}
+	if s.prices.Len() > 0 {
+		state["last_price"] = s.prices.Last()
+	}
+	return state
+}
+
+// Stop stops the strategy
+func (s *BreakoutStrategy) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	return nil
+}
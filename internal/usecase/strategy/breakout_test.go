@@ -0,0 +1,226 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// feedBreakout feeds prices one second apart and returns signals from the last tick
+func feedBreakout(t *testing.T, s *BreakoutStrategy, start time.Time, prices []float64, position *entity.Position) []*service.Signal {
+	t.Helper()
+	ctx := context.Background()
+
+	var signals []*service.Signal
+	for i, p := range prices {
+		state := tickState("BTC", p, position)
+		state.Ticker.Timestamp = start.Add(time.Duration(i) * time.Second)
+
+		var err error
+		signals, err = s.OnTick(ctx, state)
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+	}
+	return signals
+}
+
+func newTestBreakout(t *testing.T, extra map[string]interface{}) *BreakoutStrategy {
+	t.Helper()
+	config := map[string]interface{}{
+		"lookback":   5,
+		"atr_period": 3,
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+
+	s := NewBreakoutStrategy()
+	if err := s.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	return s
+}
+
+func TestBreakoutStrategy_Name(t *testing.T) {
+	s := NewBreakoutStrategy()
+	if s.Name() != "breakout" {
+		t.Errorf("Expected name 'breakout', got '%s'", s.Name())
+	}
+}
+
+func TestBreakoutStrategy_Init(t *testing.T) {
+	s := newTestBreakout(t, map[string]interface{}{
+		"atr_stop_multiplier": 3.0,
+		"position_size":       0.05,
+		"max_hold_time":       "30m",
+	})
+
+	if s.config.Lookback != 5 {
+		t.Errorf("Lookback not set correctly: %d", s.config.Lookback)
+	}
+	if s.config.ATRStopMultiplier != 3.0 {
+		t.Errorf("ATRStopMultiplier not set correctly: %f", s.config.ATRStopMultiplier)
+	}
+	if s.config.MaxHoldTime != 30*time.Minute {
+		t.Errorf("MaxHoldTime not set correctly: %v", s.config.MaxHoldTime)
+	}
+}
+
+func TestBreakoutStrategy_Init_InvalidHoldTime(t *testing.T) {
+	s := NewBreakoutStrategy()
+	err := s.Init(context.Background(), map[string]interface{}{"max_hold_time": "soon"})
+	if err == nil {
+		t.Error("Expected error for invalid max_hold_time")
+	}
+}
+
+func TestBreakoutStrategy_NoEntryInsideChannel(t *testing.T) {
+	s := newTestBreakout(t, nil)
+
+	signals := feedBreakout(t, s, time.Now(), []float64{100, 101, 100, 101, 100, 100.5}, nil)
+	if len(signals) != 0 {
+		t.Errorf("Expected no signal inside channel, got %d", len(signals))
+	}
+}
+
+func TestBreakoutStrategy_EntryLong(t *testing.T) {
+	s := newTestBreakout(t, nil)
+
+	signals := feedBreakout(t, s, time.Now(), []float64{100, 101, 100, 101, 100, 105}, nil)
+	if len(signals) == 0 {
+		t.Fatal("Expected long entry on breakout above channel high")
+	}
+	if signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected BUY side, got %s", signals[0].Side)
+	}
+
+	t.Logf("Entry signal: %s @ %.2f - %s", signals[0].Side, signals[0].Price, signals[0].Reason)
+}
+
+func TestBreakoutStrategy_EntryShort(t *testing.T) {
+	s := newTestBreakout(t, nil)
+
+	signals := feedBreakout(t, s, time.Now(), []float64{100, 101, 100, 101, 100, 95}, nil)
+	if len(signals) == 0 {
+		t.Fatal("Expected short entry on breakout below channel low")
+	}
+	if signals[0].Side != entity.SideSell {
+		t.Errorf("Expected SELL side, got %s", signals[0].Side)
+	}
+}
+
+func TestBreakoutStrategy_ATRStopExit(t *testing.T) {
+	s := newTestBreakout(t, nil)
+	start := time.Now()
+
+	feedBreakout(t, s, start, []float64{100, 101, 100, 101, 100}, nil)
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideBuy,
+		Size:       0.01,
+		EntryPrice: 101,
+	}
+
+	// ATR ~1, stop ~2 below entry; holding above stop keeps position open
+	if signals := feedBreakout(t, s, start, []float64{101}, position); len(signals) != 0 {
+		t.Fatalf("Expected no exit above stop, got %d signals", len(signals))
+	}
+
+	signals := feedBreakout(t, s, start, []float64{97}, position)
+	if len(signals) == 0 {
+		t.Fatal("Expected exit signal when ATR stop is hit")
+	}
+	if signals[0].Side != entity.SideSell {
+		t.Errorf("Expected SELL to close long, got %s", signals[0].Side)
+	}
+	if signals[0].Quantity != 0.01 {
+		t.Errorf("Expected exit quantity 0.01, got %f", signals[0].Quantity)
+	}
+
+	t.Logf("Exit signal: %s @ %.2f - %s", signals[0].Side, signals[0].Price, signals[0].Reason)
+}
+
+func TestBreakoutStrategy_OnCandle(t *testing.T) {
+	s := newTestBreakout(t, nil)
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candle := func(i int, low, high, close float64) entity.Candle {
+		return entity.Candle{Symbol: "BTC", Open: close, High: high, Low: low, Close: close, Timestamp: start.Add(time.Duration(i) * time.Minute)}
+	}
+
+	// Tick history is replaced by candles, and ticks no longer enter
+	feedBreakout(t, s, start, []float64{100, 100, 100, 100, 100}, nil)
+	for i := 0; i < 5; i++ {
+		if signals, err := s.OnCandle(ctx, candle(i, 99, 101, 100)); err != nil || len(signals) != 0 {
+			t.Fatalf("Expected no entry while the channel fills, got %v (%v)", signals, err)
+		}
+	}
+	if signals := feedBreakout(t, s, start, []float64{110}, nil); len(signals) != 0 {
+		t.Errorf("Expected ticks not to enter on candles, got %+v", signals)
+	}
+
+	// A close inside the channel does not enter even if the bar wicked out
+	if signals, _ := s.OnCandle(ctx, candle(5, 99, 103, 100.5)); len(signals) != 0 {
+		t.Errorf("Expected no entry for a close inside the channel, got %+v", signals)
+	}
+	signals, _ := s.OnCandle(ctx, candle(6, 100, 105, 104))
+	if len(signals) != 1 || signals[0].Side != entity.SideBuy || signals[0].Price != 104 {
+		t.Fatalf("Expected a long entry at the close, got %+v", signals)
+	}
+
+	// Ticks still run the ATR stop on the candle history
+	position := &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.01, EntryPrice: 104}
+	if signals := feedBreakout(t, s, start, []float64{103.5}, position); len(signals) != 0 {
+		t.Errorf("Expected the position held above the stop, got %+v", signals)
+	}
+	if signals := feedBreakout(t, s, start, []float64{95}, position); len(signals) != 1 || signals[0].Side != entity.SideSell {
+		t.Errorf("Expected the ATR stop to close the long, got %+v", signals)
+	}
+}
+
+func TestBreakoutStrategy_TimeoutExit(t *testing.T) {
+	s := newTestBreakout(t, map[string]interface{}{"max_hold_time": "1m"})
+	start := time.Now()
+
+	feedBreakout(t, s, start, []float64{100, 101, 100, 101, 100}, nil)
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideSell,
+		Size:       -0.01,
+		EntryPrice: 100,
+	}
+
+	if signals := feedBreakout(t, s, start, []float64{100}, position); len(signals) != 0 {
+		t.Fatalf("Expected no exit before max hold time, got %d signals", len(signals))
+	}
+
+	signals := feedBreakout(t, s, start.Add(2*time.Minute), []float64{100}, position)
+	if len(signals) == 0 {
+		t.Fatal("Expected exit signal after max hold time")
+	}
+	if signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected BUY to close short, got %s", signals[0].Side)
+	}
+}
+
+func TestBreakoutStrategy_GetState(t *testing.T) {
+	s := newTestBreakout(t, nil)
+	feedBreakout(t, s, time.Now(), []float64{100, 101, 102}, nil)
+
+	state := s.GetState()
+	if state["history"] != 3 {
+		t.Errorf("Expected history 3, got %v", state["history"])
+	}
+	if state["last_price"] != 102.0 {
+		t.Errorf("Expected last_price 102, got %v", state["last_price"])
+	}
+	if _, ok := state["entry_time"]; ok {
+		t.Error("Expected no entry_time without an open trade")
+	}
+}
@@ -0,0 +1,478 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// CombineMode selects how an ensemble combines its children's entry signals
+type CombineMode string
+
+const (
+	CombineUnanimous CombineMode = "unanimous" // Every child signals the same side
+	CombineMajority  CombineMode = "majority"  // More than half of the children signal the same side
+	CombineWeighted  CombineMode = "weighted"  // The weight signaling a side reaches Threshold of the total
+)
+
+// EnsembleConfig holds ensemble configuration
+type EnsembleConfig struct {
+	Mode      CombineMode // How entry signals are combined
+	Threshold float64     // Weighted mode: share of the total weight needed for a side (0-1)
+}
+
+// DefaultEnsembleConfig returns default configuration
+func DefaultEnsembleConfig() EnsembleConfig {
+	return EnsembleConfig{
+		Mode:      CombineUnanimous,
+		Threshold: 0.5,
+	}
+}
+
+// ensembleMember is a child strategy and its vote weight
+type ensembleMember struct {
+	name     string
+	weight   float64
+	strategy service.Strategy
+}
+
+// EnsembleStrategy runs several strategies on the same market and trades only
+// when their entry signals agree under the configured combine mode. Exit
+// signals (opposite to the open position) from any child pass through, so one
+// child's stop is never outvoted.
+type EnsembleStrategy struct {
+	factory service.StrategyFactory
+
+	mu      sync.RWMutex
+	running bool
+	config  EnsembleConfig
+	members []ensembleMember
+	log     service.Logger
+	sizer   service.PositionSizer // Passed to children that size by volatility
+}
+
+// NewEnsembleStrategy creates an ensemble whose children are built by factory
+func NewEnsembleStrategy(factory service.StrategyFactory) *EnsembleStrategy {
+	return &EnsembleStrategy{
+		factory: factory,
+		config:  DefaultEnsembleConfig(),
+		log:     service.NopLogger,
+	}
+}
+
+// Name returns strategy name
+func (s *EnsembleStrategy) Name() string {
+	return "ensemble"
+}
+
+// SetLogger sets the logger passed to children that accept one
+func (s *EnsembleStrategy) SetLogger(log service.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if log == nil {
+		log = service.NopLogger
+	}
+	s.log = log
+	for _, m := range s.members {
+		setChildLogger(m, log)
+	}
+}
+
+// SetPositionSizer sets the sizer passed to children that accept one
+func (s *EnsembleStrategy) SetPositionSizer(sizer service.PositionSizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sizer = sizer
+	for _, m := range s.members {
+		setChildSizer(m, sizer)
+	}
+}
+
+// Init creates and initializes the children listed under "strategies"; each
+// entry has a name, an optional weight (default 1) and optional params
+func (s *EnsembleStrategy) Init(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := parseEnsembleConfig(s.config, config)
+	if err != nil {
+		return err
+	}
+	specs, err := parseEnsembleMembers(config["strategies"])
+	if err != nil {
+		return err
+	}
+
+	members := make([]ensembleMember, 0, len(specs))
+	for i, spec := range specs {
+		child, err := s.factory.Create(spec.name)
+		if err != nil {
+			return fmt.Errorf("strategies[%d]: %w", i, err)
+		}
+		m := ensembleMember{name: spec.name, weight: spec.weight, strategy: child}
+		setChildLogger(m, s.log)
+		setChildSizer(m, s.sizer)
+		if err := child.Init(ctx, spec.params); err != nil {
+			return fmt.Errorf("strategies[%d] (%s): %w", i, spec.name, err)
+		}
+		members = append(members, m)
+	}
+
+	s.config = cfg
+	s.members = members
+	s.running = true
+	return nil
+}
+
+// Reconfigure applies new combine settings, weights and child params. The
+// children must be listed in the same order as at Init.
+func (s *EnsembleStrategy) Reconfigure(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := parseEnsembleConfig(s.config, config)
+	if err != nil {
+		return err
+	}
+	specs, err := parseEnsembleMembers(config["strategies"])
+	if err != nil {
+		return err
+	}
+	if len(specs) != len(s.members) {
+		return fmt.Errorf("ensemble children cannot change while running: have %d, got %d", len(s.members), len(specs))
+	}
+	for i, spec := range specs {
+		if spec.name != s.members[i].name {
+			return fmt.Errorf("strategies[%d]: cannot replace %s with %s while running", i, s.members[i].name, spec.name)
+		}
+	}
+
+	for i, spec := range specs {
+		if r, ok := s.members[i].strategy.(service.ReconfigurableStrategy); ok {
+			if err := r.Reconfigure(ctx, spec.params); err != nil {
+				return fmt.Errorf("strategies[%d] (%s): %w", i, spec.name, err)
+			}
+		}
+		s.members[i].weight = spec.weight
+	}
+	s.config = cfg
+	return nil
+}
+
+// OnTick forwards the tick to every child and combines their signals
+func (s *EnsembleStrategy) OnTick(ctx context.Context, state *service.MarketState) ([]*service.Signal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.running {
+		return nil, nil
+	}
+
+	votes := make([][]*service.Signal, len(s.members))
+	for i, m := range s.members {
+		signals, err := m.strategy.OnTick(ctx, state)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.name, err)
+		}
+		votes[i] = signals
+	}
+
+	if exit := s.exitSignal(state.Position, votes); exit != nil {
+		return []*service.Signal{exit}, nil
+	}
+	if entry := s.combine(votes); entry != nil {
+		return []*service.Signal{entry}, nil
+	}
+	return nil, nil
+}
+
+// exitSignal returns the first child signal that reduces the open position,
+// as a reduce-only order capped at the position size so it cannot flip it
+func (s *EnsembleStrategy) exitSignal(pos *entity.Position, votes [][]*service.Signal) *service.Signal {
+	if pos == nil || pos.Size == 0 {
+		return nil
+	}
+	closing := entity.SideSell
+	if pos.Size < 0 {
+		closing = entity.SideBuy
+	}
+	for i, signals := range votes {
+		for _, sig := range signals {
+			if sig.Side == closing {
+				exit := *sig
+				exit.Reason = fmt.Sprintf("%s: %s", s.members[i].name, sig.Reason)
+				exit.Quantity = math.Min(exit.Quantity, math.Abs(pos.Size))
+				exit.ReduceOnly = true
+				return &exit
+			}
+		}
+	}
+	return nil
+}
+
+// combine applies the combine mode to the children's entry signals (the first
+// signal of each child). On agreement it returns the first agreeing child's
+// signal with its quantity replaced by the weighted mean of the agreeing
+// children's quantities.
+func (s *EnsembleStrategy) combine(votes [][]*service.Signal) *service.Signal {
+	var totalWeight float64
+	weights := make(map[entity.Side]float64, 2)
+	counts := make(map[entity.Side]int, 2)
+	for i, signals := range votes {
+		totalWeight += s.members[i].weight
+		if len(signals) == 0 {
+			continue
+		}
+		weights[signals[0].Side] += s.members[i].weight
+		counts[signals[0].Side]++
+	}
+
+	var side entity.Side
+	for _, candidate := range []entity.Side{entity.SideBuy, entity.SideSell} {
+		other := entity.SideSell
+		if candidate == entity.SideSell {
+			other = entity.SideBuy
+		}
+		var agreed bool
+		switch s.config.Mode {
+		case CombineUnanimous:
+			agreed = counts[candidate] == len(votes)
+		case CombineMajority:
+			agreed = counts[candidate]*2 > len(votes)
+		case CombineWeighted:
+			agreed = totalWeight > 0 && weights[candidate] > weights[other] &&
+				weights[candidate] >= s.config.Threshold*totalWeight
+		}
+		if agreed {
+			side = candidate
+			break
+		}
+	}
+	if side == "" || len(votes) == 0 {
+		return nil
+	}
+
+	var combined *service.Signal
+	var qtyWeight, weightSum float64
+	names := make([]string, 0, len(votes))
+	for i, signals := range votes {
+		if len(signals) == 0 || signals[0].Side != side {
+			continue
+		}
+		if combined == nil {
+			first := *signals[0]
+			combined = &first
+		}
+		w := s.members[i].weight
+		qtyWeight += w * signals[0].Quantity
+		weightSum += w
+		names = append(names, s.members[i].name)
+	}
+	if weightSum > 0 {
+		combined.Quantity = qtyWeight / weightSum
+	}
+	combined.Reason = fmt.Sprintf("ensemble %s (%s): %s", s.config.Mode, strings.Join(names, ", "), combined.Reason)
+	return combined
+}
+
+// OnOrderUpdate forwards the order update to every child
+func (s *EnsembleStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var errs []error
+	for _, m := range s.members {
+		if err := m.strategy.OnOrderUpdate(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OnPositionUpdate forwards the position update to every child
+func (s *EnsembleStrategy) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var errs []error
+	for _, m := range s.members {
+		if err := m.strategy.OnPositionUpdate(ctx, position); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reset forwards the reset to every child
+func (s *EnsembleStrategy) Reset(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var errs []error
+	for _, m := range s.members {
+		if err := m.strategy.Reset(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetState returns the combine settings and each child's state
+func (s *EnsembleStrategy) GetState() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	children := make([]map[string]interface{}, 0, len(s.members))
+	for _, m := range s.members {
+		child := map[string]interface{}{
+			"name":   m.name,
+			"weight": m.weight,
+		}
+		if stateful, ok := m.strategy.(service.StatefulStrategy); ok {
+			child["state"] = stateful.GetState()
+		}
+		children = append(children, child)
+	}
+	return map[string]interface{}{
+		"running":    s.running,
+		"mode":       string(s.config.Mode),
+		"threshold":  s.config.Threshold,
+		"strategies": children,
+	}
+}
+
+// Stop stops every child
+func (s *EnsembleStrategy) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+
+	var errs []error
+	for _, m := range s.members {
+		if err := m.strategy.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setChildLogger passes log to a child that accepts one, tagged with its name
+func setChildLogger(m ensembleMember, log service.Logger) {
+	if loggable, ok := m.strategy.(service.LoggableStrategy); ok {
+		loggable.SetLogger(prefixLogger{prefix: m.name + ": ", log: log})
+	}
+}
+
+// setChildSizer passes sizer to a child that accepts one
+func setChildSizer(m ensembleMember, sizer service.PositionSizer) {
+	if sized, ok := m.strategy.(service.SizedStrategy); ok {
+		sized.SetPositionSizer(sizer)
+	}
+}
+
+// prefixLogger prefixes each message with a child strategy name
+type prefixLogger struct {
+	prefix string
+	log    service.Logger
+}
+
+func (l prefixLogger) Debug(msg string, args ...interface{}) {
+	l.log.Debug(l.prefix+msg, args...)
+}
+
+// ensembleMemberSpec is a parsed "strategies" entry
+type ensembleMemberSpec struct {
+	name   string
+	weight float64
+	params map[string]interface{}
+}
+
+// parseEnsembleConfig parses the combine settings over cfg
+func parseEnsembleConfig(cfg EnsembleConfig, config map[string]interface{}) (EnsembleConfig, error) {
+	if v, ok := config["mode"].(string); ok {
+		switch mode := CombineMode(v); mode {
+		case CombineUnanimous, CombineMajority, CombineWeighted:
+			cfg.Mode = mode
+		default:
+			return cfg, fmt.Errorf("invalid mode %q (expected %s, %s or %s)", v, CombineUnanimous, CombineMajority, CombineWeighted)
+		}
+	}
+	if v, ok := numberParam(config["threshold"]); ok {
+		if v <= 0 || v > 1 {
+			return cfg, fmt.Errorf("threshold must be in (0, 1], got %f", v)
+		}
+		cfg.Threshold = v
+	}
+	return cfg, nil
+}
+
+// StrategyNames returns the name of a configured strategy and, for an
+// ensemble, the names of its children, nested ensembles included
+func StrategyNames(name string, params map[string]interface{}) []string {
+	names := []string{name}
+	if name != "ensemble" {
+		return names
+	}
+	specs, err := parseEnsembleMembers(params["strategies"])
+	if err != nil {
+		return names
+	}
+	for _, spec := range specs {
+		names = append(names, StrategyNames(spec.name, spec.params)...)
+	}
+	return names
+}
+
+// parseEnsembleMembers parses the list of child strategies
+func parseEnsembleMembers(v interface{}) ([]ensembleMemberSpec, error) {
+	items, ok := v.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("strategies: expected a non-empty list, got %T", v)
+	}
+
+	specs := make([]ensembleMemberSpec, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("strategies[%d]: expected map, got %T", i, item)
+		}
+		name, _ := m["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("strategies[%d]: missing name", i)
+		}
+		spec := ensembleMemberSpec{name: name, weight: 1}
+		if w, ok := numberParam(m["weight"]); ok {
+			if w <= 0 {
+				return nil, fmt.Errorf("strategies[%d]: weight must be positive, got %f", i, w)
+			}
+			spec.weight = w
+		}
+		if params, ok := m["params"].(map[string]interface{}); ok {
+			spec.params = params
+		} else if m["params"] != nil {
+			return nil, fmt.Errorf("strategies[%d]: params: expected map, got %T", i, m["params"])
+		}
+		if spec.params == nil {
+			spec.params = map[string]interface{}{}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// numberParam reads an int or float64 parameter
+func numberParam(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
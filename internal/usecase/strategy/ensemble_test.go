@@ -0,0 +1,194 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// voterStrategy emits its configured signal on every tick and records callbacks
+type voterStrategy struct {
+	name      string
+	signal    *service.Signal
+	orders    int
+	positions int
+	resets    int
+	stopped   bool
+}
+
+func (v *voterStrategy) Name() string { return v.name }
+
+func (v *voterStrategy) Init(ctx context.Context, config map[string]interface{}) error {
+	if side, ok := config["side"].(string); ok {
+		qty, _ := config["qty"].(float64)
+		v.signal = &service.Signal{Symbol: "BTC", Side: entity.Side(side), Price: 100, Quantity: qty, Reason: v.name}
+	}
+	return nil
+}
+
+func (v *voterStrategy) OnTick(ctx context.Context, state *service.MarketState) ([]*service.Signal, error) {
+	if v.signal == nil {
+		return nil, nil
+	}
+	sig := *v.signal
+	return []*service.Signal{&sig}, nil
+}
+
+func (v *voterStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	v.orders++
+	return nil
+}
+
+func (v *voterStrategy) OnPositionUpdate(ctx context.Context, position *entity.Position) error {
+	v.positions++
+	return nil
+}
+
+func (v *voterStrategy) Reset(ctx context.Context) error {
+	v.resets++
+	return nil
+}
+
+func (v *voterStrategy) Stop(ctx context.Context) error {
+	v.stopped = true
+	return nil
+}
+
+// newTestEnsemble builds an ensemble over voter children, one per vote
+// ("buy", "sell" or "" to abstain), each with the given quantity and weight
+func newTestEnsemble(t *testing.T, mode string, votes []string, qtys, weights []float64) (*EnsembleStrategy, []*voterStrategy) {
+	t.Helper()
+	f := NewFactory()
+	var voters []*voterStrategy
+	f.Register("voter", func() service.Strategy {
+		v := &voterStrategy{name: "voter"}
+		voters = append(voters, v)
+		return v
+	})
+
+	children := make([]interface{}, len(votes))
+	for i, vote := range votes {
+		params := map[string]interface{}{"qty": qtys[i]}
+		if vote != "" {
+			params["side"] = vote
+		}
+		children[i] = map[string]interface{}{"name": "voter", "weight": weights[i], "params": params}
+	}
+
+	s := NewEnsembleStrategy(f)
+	if err := s.Init(context.Background(), map[string]interface{}{"mode": mode, "strategies": children}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	return s, voters
+}
+
+func ensembleTick(t *testing.T, s *EnsembleStrategy, pos *entity.Position) []*service.Signal {
+	t.Helper()
+	signals, err := s.OnTick(context.Background(), &service.MarketState{
+		Ticker:   &entity.Ticker{Symbol: "BTC", LastPrice: 100},
+		Position: pos,
+	})
+	if err != nil {
+		t.Fatalf("OnTick failed: %v", err)
+	}
+	return signals
+}
+
+func TestEnsemble_UnanimousAgreement(t *testing.T) {
+	s, voters := newTestEnsemble(t, "unanimous", []string{"buy", "buy"}, []float64{1, 3}, []float64{1, 1})
+
+	signals := ensembleTick(t, s, nil)
+	if len(signals) != 1 || signals[0].Side != entity.SideBuy {
+		t.Fatalf("Expected one buy when all children agree, got %+v", signals)
+	}
+	if signals[0].Quantity != 2 {
+		t.Errorf("Expected the mean child quantity 2, got %f", signals[0].Quantity)
+	}
+
+	// Callbacks reach every child
+	s.OnOrderUpdate(context.Background(), &entity.Order{})
+	s.OnPositionUpdate(context.Background(), nil)
+	s.Reset(context.Background())
+	s.Stop(context.Background())
+	for i, v := range voters {
+		if v.orders != 1 || v.positions != 1 || v.resets != 1 || !v.stopped {
+			t.Errorf("Child %d missed callbacks: %+v", i, v)
+		}
+	}
+}
+
+func TestEnsemble_ConflictingSignals(t *testing.T) {
+	votes := []string{"buy", "buy", "sell"}
+	qtys := []float64{1, 1, 1}
+
+	tests := []struct {
+		name    string
+		mode    string
+		weights []float64
+		want    entity.Side // "" = no trade
+	}{
+		{"unanimous blocks on conflict", "unanimous", []float64{1, 1, 1}, ""},
+		{"majority follows two of three", "majority", []float64{1, 1, 1}, entity.SideBuy},
+		{"weighted follows the heavier side", "weighted", []float64{1, 1, 4}, entity.SideSell},
+		{"weighted tie does not trade", "weighted", []float64{1, 1, 2}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestEnsemble(t, tt.mode, votes, qtys, tt.weights)
+			signals := ensembleTick(t, s, nil)
+			switch {
+			case tt.want == "" && len(signals) != 0:
+				t.Errorf("Expected no trade, got %+v", signals)
+			case tt.want != "" && (len(signals) != 1 || signals[0].Side != tt.want):
+				t.Errorf("Expected one %s, got %+v", tt.want, signals)
+			}
+		})
+	}
+
+	// An abstaining child blocks a unanimous entry
+	s, _ := newTestEnsemble(t, "unanimous", []string{"buy", ""}, []float64{1, 1}, []float64{1, 1})
+	if signals := ensembleTick(t, s, nil); len(signals) != 0 {
+		t.Errorf("Expected abstention to block a unanimous entry, got %+v", signals)
+	}
+}
+
+func TestEnsemble_ExitFromAnyChild(t *testing.T) {
+	s, _ := newTestEnsemble(t, "unanimous", []string{"buy", "sell"}, []float64{1, 0.5}, []float64{1, 1})
+
+	long := &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.5}
+	signals := ensembleTick(t, s, long)
+	if len(signals) != 1 || signals[0].Side != entity.SideSell || signals[0].Quantity != 0.5 || !signals[0].ReduceOnly {
+		t.Fatalf("Expected the child's exit to pass through reduce-only, got %+v", signals)
+	}
+
+	// A child's larger sell is capped so it closes the position without flipping it
+	small := &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.2}
+	if signals := ensembleTick(t, s, small); len(signals) != 1 || signals[0].Quantity != 0.2 || !signals[0].ReduceOnly {
+		t.Errorf("Expected a reduce-only exit of 0.2, got %+v", signals)
+	}
+}
+
+func TestEnsemble_InitErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"no children", map[string]interface{}{}},
+		{"unknown child", map[string]interface{}{"strategies": []interface{}{map[string]interface{}{"name": "nope"}}}},
+		{"bad mode", map[string]interface{}{"mode": "dictator", "strategies": []interface{}{map[string]interface{}{"name": "breakout"}}}},
+		{"bad weight", map[string]interface{}{"strategies": []interface{}{map[string]interface{}{"name": "breakout", "weight": -1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewFactory().Create("ensemble")
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if err := s.Init(context.Background(), tt.config); err == nil {
+				t.Error("Expected Init to fail")
+			}
+		})
+	}
+}
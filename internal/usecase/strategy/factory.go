@@ -0,0 +1,86 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	domainstrategy "github.com/zono819/hyperliquid-bot/internal/domain/service/strategy"
+)
+
+// Ensure Factory implements StrategyFactory
+var _ service.StrategyFactory = (*Factory)(nil)
+
+// Ensure built-in strategies report their state
+var (
+	_ service.StatefulStrategy = (*MeanReversionStrategy)(nil)
+	_ service.StatefulStrategy = (*BreakoutStrategy)(nil)
+	_ service.StatefulStrategy = (*domainstrategy.AISignalStrategy)(nil)
+	_ service.StatefulStrategy = (*EnsembleStrategy)(nil)
+)
+
+// Ensure built-in strategies can be reconfigured while running
+var (
+	_ service.ReconfigurableStrategy = (*MeanReversionStrategy)(nil)
+	_ service.ReconfigurableStrategy = (*BreakoutStrategy)(nil)
+	_ service.ReconfigurableStrategy = (*domainstrategy.AISignalStrategy)(nil)
+	_ service.ReconfigurableStrategy = (*EnsembleStrategy)(nil)
+)
+
+// Ensure strategies with per-tick diagnostics accept a logger
+var (
+	_ service.LoggableStrategy = (*MeanReversionStrategy)(nil)
+	_ service.LoggableStrategy = (*domainstrategy.AISignalStrategy)(nil)
+	_ service.LoggableStrategy = (*EnsembleStrategy)(nil)
+)
+
+// Factory creates built-in strategy instances by name
+type Factory struct {
+	mu           sync.RWMutex
+	constructors map[string]func() service.Strategy
+}
+
+// NewFactory creates a factory with all built-in strategies registered
+func NewFactory() *Factory {
+	f := &Factory{
+		constructors: make(map[string]func() service.Strategy),
+	}
+	f.Register("mean_reversion", func() service.Strategy { return NewMeanReversionStrategy() })
+	f.Register("breakout", func() service.Strategy { return NewBreakoutStrategy() })
+	f.Register("ai_signal", func() service.Strategy { return domainstrategy.NewAISignalStrategy() })
+	f.Register("ensemble", func() service.Strategy { return NewEnsembleStrategy(f) })
+	return f
+}
+
+// Register registers a strategy constructor under a name
+func (f *Factory) Register(name string, constructor func() service.Strategy) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.constructors[name] = constructor
+}
+
+// Create creates a new strategy instance by name
+func (f *Factory) Create(name string) (service.Strategy, error) {
+	f.mu.RLock()
+	constructor, ok := f.constructors[name]
+	f.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s", name)
+	}
+	return constructor(), nil
+}
+
+// List returns available strategy names
+func (f *Factory) List() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	names := make([]string, 0, len(f.constructors))
+	for name := range f.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,26 @@
+package strategy
+
+import (
+	"testing"
+)
+
+func TestFactory_Create(t *testing.T) {
+	f := NewFactory()
+
+	for _, name := range []string{"mean_reversion", "breakout", "ai_signal", "ensemble"} {
+		s, err := f.Create(name)
+		if err != nil {
+			t.Fatalf("Create(%s) failed: %v", name, err)
+		}
+		if s.Name() != name {
+			t.Errorf("Expected strategy %s, got %s", name, s.Name())
+		}
+	}
+
+	if _, err := f.Create("unknown"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+	if len(f.List()) != 4 {
+		t.Errorf("Expected 4 strategies, got %v", f.List())
+	}
+}
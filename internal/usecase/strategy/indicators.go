@@ -0,0 +1,417 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// tickHighLow approximates a bar's high/low from a single tick.
+// Ticks carry no OHLC data, so bid/ask bound the traded price.
+func tickHighLow(ticker *entity.Ticker) (float64, float64) {
+	price := ticker.LastPrice
+	high := math.Max(price, ticker.AskPrice)
+	low := price
+	if ticker.BidPrice > 0 {
+		low = math.Min(price, ticker.BidPrice)
+	}
+	return high, low
+}
+
+// trueRange calculates the true range of a bar given the previous close
+func trueRange(high, low, prevClose float64) float64 {
+	return math.Max(high-low, math.Max(math.Abs(high-prevClose), math.Abs(low-prevClose)))
+}
+
+// ATR calculates the Average True Range using Wilder's smoothing.
+// Returns 0 if there is not enough data.
+func ATR(highs, lows, closes []float64, period int) float64 {
+	atr := atrSeries(highs, lows, closes, period)
+	if len(atr) == 0 {
+		return 0
+	}
+	return atr[len(atr)-1]
+}
+
+// atrSeries calculates the ATR series using Wilder's smoothing.
+// The first value corresponds to bar index `period`.
+func atrSeries(highs, lows, closes []float64, period int) []float64 {
+	n := len(closes)
+	if period <= 0 || n <= period || len(highs) != n || len(lows) != n {
+		return nil
+	}
+
+	atr := 0.0
+	for i := 1; i <= period; i++ {
+		atr += trueRange(highs[i], lows[i], closes[i-1])
+	}
+	atr /= float64(period)
+
+	series := make([]float64, 0, n-period)
+	series = append(series, atr)
+	for i := period + 1; i < n; i++ {
+		tr := trueRange(highs[i], lows[i], closes[i-1])
+		atr = (atr*float64(period-1) + tr) / float64(period)
+		series = append(series, atr)
+	}
+
+	return series
+}
+
+// dmiSeries calculates the +DI/-DI series using Wilder's smoothing.
+// The first value corresponds to bar index `period`.
+func dmiSeries(highs, lows, closes []float64, period int) ([]float64, []float64) {
+	n := len(closes)
+	if period <= 0 || n <= period || len(highs) != n || len(lows) != n {
+		return nil, nil
+	}
+
+	plusDI := make([]float64, 0, n-period)
+	minusDI := make([]float64, 0, n-period)
+
+	var smoothTR, smoothPlusDM, smoothMinusDM float64
+	for i := 1; i < n; i++ {
+		upMove := highs[i] - highs[i-1]
+		downMove := lows[i-1] - lows[i]
+
+		plusDM, minusDM := 0.0, 0.0
+		if upMove > downMove && upMove > 0 {
+			plusDM = upMove
+		}
+		if downMove > upMove && downMove > 0 {
+			minusDM = downMove
+		}
+		tr := trueRange(highs[i], lows[i], closes[i-1])
+
+		if i <= period {
+			// Seed with simple sums over the first period
+			smoothTR += tr
+			smoothPlusDM += plusDM
+			smoothMinusDM += minusDM
+			if i < period {
+				continue
+			}
+		} else {
+			smoothTR = smoothTR - smoothTR/float64(period) + tr
+			smoothPlusDM = smoothPlusDM - smoothPlusDM/float64(period) + plusDM
+			smoothMinusDM = smoothMinusDM - smoothMinusDM/float64(period) + minusDM
+		}
+
+		if smoothTR == 0 {
+			plusDI = append(plusDI, 0)
+			minusDI = append(minusDI, 0)
+			continue
+		}
+		plusDI = append(plusDI, 100*smoothPlusDM/smoothTR)
+		minusDI = append(minusDI, 100*smoothMinusDM/smoothTR)
+	}
+
+	return plusDI, minusDI
+}
+
+// DMI returns the latest +DI and -DI values.
+// Returns 0, 0 if there is not enough data.
+func DMI(highs, lows, closes []float64, period int) (float64, float64) {
+	plusDI, minusDI := dmiSeries(highs, lows, closes, period)
+	if len(plusDI) == 0 {
+		return 0, 0
+	}
+	return plusDI[len(plusDI)-1], minusDI[len(minusDI)-1]
+}
+
+// ADX calculates the Average Directional Index (0-100).
+// High values indicate a trending market, low values a ranging one.
+// Requires at least 2*period bars; returns 0 otherwise.
+func ADX(highs, lows, closes []float64, period int) float64 {
+	plusDI, minusDI := dmiSeries(highs, lows, closes, period)
+	if len(plusDI) < period {
+		return 0
+	}
+
+	dx := make([]float64, len(plusDI))
+	for i := range plusDI {
+		sum := plusDI[i] + minusDI[i]
+		if sum == 0 {
+			continue
+		}
+		dx[i] = 100 * math.Abs(plusDI[i]-minusDI[i]) / sum
+	}
+
+	// First ADX is the average of the first period DX values
+	adx := 0.0
+	for i := 0; i < period; i++ {
+		adx += dx[i]
+	}
+	adx /= float64(period)
+
+	for i := period; i < len(dx); i++ {
+		adx = (adx*float64(period-1) + dx[i]) / float64(period)
+	}
+
+	return adx
+}
+
+// RSI smoothing methods
+const (
+	RSISmoothingWilder = "wilder" // Wilder's smoothing across the full series, as charting platforms use
+	RSISmoothingSMA    = "sma"    // Simple average of the last period changes (Cutler's RSI)
+)
+
+// rsiSeries calculates the RSI series (0-100) with the given smoothing; an
+// unknown smoothing uses Wilder's. The first value corresponds to price index `period`.
+func rsiSeries(prices []float64, period int, smoothing string) []float64 {
+	if smoothing == RSISmoothingSMA {
+		return simpleRSISeries(prices, period)
+	}
+	return wilderRSISeries(prices, period)
+}
+
+// wilderRSISeries calculates the RSI series using Wilder's smoothing, seeded
+// with the simple average of the first period changes
+func wilderRSISeries(prices []float64, period int) []float64 {
+	n := len(prices)
+	if period <= 0 || n <= period {
+		return nil
+	}
+
+	avgGain, avgLoss := 0.0, 0.0
+	for i := 1; i <= period; i++ {
+		change := prices[i] - prices[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	rsi := make([]float64, 0, n-period)
+	rsi = append(rsi, rsiValue(avgGain, avgLoss))
+	for i := period + 1; i < n; i++ {
+		gain, loss := 0.0, 0.0
+		if change := prices[i] - prices[i-1]; change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		rsi = append(rsi, rsiValue(avgGain, avgLoss))
+	}
+	return rsi
+}
+
+// simpleRSISeries calculates the RSI series from the simple average of the
+// gains and losses of the last period changes at each point
+func simpleRSISeries(prices []float64, period int) []float64 {
+	n := len(prices)
+	if period <= 0 || n <= period {
+		return nil
+	}
+
+	gains := make([]float64, n)
+	losses := make([]float64, n)
+	for i := 1; i < n; i++ {
+		if change := prices[i] - prices[i-1]; change > 0 {
+			gains[i] = change
+		} else {
+			losses[i] = -change
+		}
+	}
+
+	rsi := make([]float64, 0, n-period)
+	for i := period; i < n; i++ {
+		sumGain, sumLoss := 0.0, 0.0
+		for j := i - period + 1; j <= i; j++ {
+			sumGain += gains[j]
+			sumLoss += losses[j]
+		}
+		rsi = append(rsi, rsiValue(sumGain/float64(period), sumLoss/float64(period)))
+	}
+	return rsi
+}
+
+// rsiValue converts average gain/loss to RSI
+func rsiValue(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// RSI calculates the latest Relative Strength Index (0-100) using Wilder's smoothing.
+// Returns 0 if there is not enough data.
+func RSI(prices []float64, period int) float64 {
+	return RSIWith(prices, period, RSISmoothingWilder)
+}
+
+// RSIWith calculates the latest RSI with the given smoothing (RSISmoothingWilder
+// or RSISmoothingSMA). Returns 0 if there is not enough data.
+func RSIWith(prices []float64, period int, smoothing string) float64 {
+	rsi := rsiSeries(prices, period, smoothing)
+	if len(rsi) == 0 {
+		return 0
+	}
+	return rsi[len(rsi)-1]
+}
+
+// pivotStrength is the number of bars on each side a swing point must exceed
+const pivotStrength = 2
+
+// pivots returns the indexes of swing lows (or highs): values strictly below
+// (above) the strength values on each side
+func pivots(values []float64, strength int, lows bool) []int {
+	var result []int
+	for i := strength; i < len(values)-strength; i++ {
+		pivot := true
+		for j := i - strength; j <= i+strength && pivot; j++ {
+			if j == i {
+				continue
+			}
+			if lows {
+				pivot = values[i] < values[j]
+			} else {
+				pivot = values[i] > values[j]
+			}
+		}
+		if pivot {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// RSIDivergence compares the last two price swing points against RSI with
+// the given smoothing.
+// Bullish: price makes a lower low while RSI makes a higher low.
+// Bearish: price makes a higher high while RSI makes a lower high.
+func RSIDivergence(prices []float64, period int, smoothing string) (bullish, bearish bool) {
+	rsi := rsiSeries(prices, period, smoothing)
+	if len(rsi) == 0 {
+		return false, false
+	}
+	// Align prices with the RSI series
+	prices = prices[len(prices)-len(rsi):]
+
+	if lows := pivots(prices, pivotStrength, true); len(lows) >= 2 {
+		a, b := lows[len(lows)-2], lows[len(lows)-1]
+		bullish = prices[b] < prices[a] && rsi[b] > rsi[a]
+	}
+	if highs := pivots(prices, pivotStrength, false); len(highs) >= 2 {
+		a, b := highs[len(highs)-2], highs[len(highs)-1]
+		bearish = prices[b] > prices[a] && rsi[b] < rsi[a]
+	}
+	return bullish, bearish
+}
+
+// EMA calculates the latest exponential moving average, seeded with the
+// simple average of the first period values. Returns 0 if there is not enough data.
+func EMA(values []float64, period int) float64 {
+	if period <= 0 || len(values) < period {
+		return 0
+	}
+
+	ema := 0.0
+	for _, v := range values[:period] {
+		ema += v
+	}
+	ema /= float64(period)
+
+	k := 2 / float64(period+1)
+	for _, v := range values[period:] {
+		ema = v*k + ema*(1-k)
+	}
+	return ema
+}
+
+// BollingerBands calculates the SMA of the last period closes and bands
+// mult population standard deviations away. Returns zeros if there is not enough data.
+func BollingerBands(closes []float64, period int, mult float64) (upper, middle, lower float64) {
+	if period <= 0 || len(closes) < period {
+		return 0, 0, 0
+	}
+	window := closes[len(closes)-period:]
+
+	for _, c := range window {
+		middle += c
+	}
+	middle /= float64(period)
+
+	variance := 0.0
+	for _, c := range window {
+		variance += (c - middle) * (c - middle)
+	}
+	stdDev := math.Sqrt(variance / float64(period))
+
+	return middle + mult*stdDev, middle, middle - mult*stdDev
+}
+
+// KeltnerChannels calculates the EMA of closes and bands mult ATRs away.
+// Returns zeros if there is not enough data.
+func KeltnerChannels(highs, lows, closes []float64, period int, mult float64) (upper, middle, lower float64) {
+	atr := ATR(highs, lows, closes, period)
+	if atr == 0 {
+		return 0, 0, 0
+	}
+	middle = EMA(closes, period)
+	return middle + mult*atr, middle, middle - mult*atr
+}
+
+// BollingerSqueeze reports whether the Bollinger Bands sit inside the Keltner
+// Channels, i.e. volatility has contracted and a breakout is likely.
+// Returns false if there is not enough data.
+func BollingerSqueeze(highs, lows, closes []float64, period int, bbMult, kcMult float64) bool {
+	kcUpper, _, kcLower := KeltnerChannels(highs, lows, closes, period, kcMult)
+	if kcUpper == 0 {
+		return false
+	}
+	bbUpper, _, bbLower := BollingerBands(closes, period, bbMult)
+	return bbUpper < kcUpper && bbLower > kcLower
+}
+
+// Supertrend calculates the Supertrend line: ATR bands mult ATRs around the
+// bar midpoint that ratchet toward price and flip when closed through.
+// In an uptrend the line is the lower band (below price), in a downtrend the
+// upper band. Returns 0, false if there is not enough data.
+func Supertrend(highs, lows, closes []float64, period int, mult float64) (value float64, isUptrend bool) {
+	atr := atrSeries(highs, lows, closes, period)
+	if len(atr) == 0 {
+		return 0, false
+	}
+
+	var upper, lower float64
+	for j, a := range atr {
+		i := period + j
+		mid := (highs[i] + lows[i]) / 2
+		basicUpper, basicLower := mid+mult*a, mid-mult*a
+
+		if j == 0 {
+			upper, lower = basicUpper, basicLower
+			isUptrend = closes[i] >= mid
+			continue
+		}
+
+		prevClose := closes[i-1]
+		if basicUpper < upper || prevClose > upper {
+			upper = basicUpper
+		}
+		if basicLower > lower || prevClose < lower {
+			lower = basicLower
+		}
+
+		if isUptrend && closes[i] < lower {
+			isUptrend = false
+		} else if !isUptrend && closes[i] > upper {
+			isUptrend = true
+		}
+	}
+
+	if isUptrend {
+		return lower, true
+	}
+	return upper, false
+}
@@ -0,0 +1,269 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+)
+
+// trendingSeries builds a steadily rising OHLC series
+func trendingSeries(n int) ([]float64, []float64, []float64) {
+	highs := make([]float64, n)
+	lows := make([]float64, n)
+	closes := make([]float64, n)
+	for i := 0; i < n; i++ {
+		closes[i] = 100 + float64(i)
+		highs[i] = closes[i] + 0.5
+		lows[i] = closes[i] - 0.5
+	}
+	return highs, lows, closes
+}
+
+// choppySeries builds a series oscillating around a fixed level
+func choppySeries(n int) ([]float64, []float64, []float64) {
+	highs := make([]float64, n)
+	lows := make([]float64, n)
+	closes := make([]float64, n)
+	for i := 0; i < n; i++ {
+		closes[i] = 100
+		if i%2 == 1 {
+			closes[i] = 101
+		}
+		highs[i] = closes[i] + 0.5
+		lows[i] = closes[i] - 0.5
+	}
+	return highs, lows, closes
+}
+
+func TestADX_Trending(t *testing.T) {
+	highs, lows, closes := trendingSeries(40)
+
+	adx := ADX(highs, lows, closes, 14)
+	if adx < 50 {
+		t.Errorf("Expected high ADX for trending series, got %.2f", adx)
+	}
+
+	plusDI, minusDI := DMI(highs, lows, closes, 14)
+	if plusDI <= minusDI {
+		t.Errorf("Expected +DI > -DI for uptrend, got +DI=%.2f -DI=%.2f", plusDI, minusDI)
+	}
+
+	t.Logf("Trending: ADX=%.2f, +DI=%.2f, -DI=%.2f", adx, plusDI, minusDI)
+}
+
+func TestADX_Choppy(t *testing.T) {
+	highs, lows, closes := choppySeries(40)
+
+	adx := ADX(highs, lows, closes, 14)
+	if adx > 20 {
+		t.Errorf("Expected low ADX for choppy series, got %.2f", adx)
+	}
+
+	t.Logf("Choppy: ADX=%.2f", adx)
+}
+
+func TestADX_InsufficientData(t *testing.T) {
+	highs, lows, closes := trendingSeries(20)
+
+	if adx := ADX(highs, lows, closes, 14); adx != 0 {
+		t.Errorf("Expected 0 ADX with insufficient data, got %.2f", adx)
+	}
+	if adx := ADX(highs[:10], lows, closes, 5); adx != 0 {
+		t.Errorf("Expected 0 ADX with mismatched lengths, got %.2f", adx)
+	}
+}
+
+func TestATR(t *testing.T) {
+	highs, lows, closes := trendingSeries(30)
+
+	// Each bar: high-low = 1, |high-prevClose| = 1.5
+	atr := ATR(highs, lows, closes, 14)
+	if atr < 1.49 || atr > 1.51 {
+		t.Errorf("Expected ATR ~1.5, got %.4f", atr)
+	}
+
+	if atr := ATR(highs[:5], lows[:5], closes[:5], 14); atr != 0 {
+		t.Errorf("Expected 0 ATR with insufficient data, got %.4f", atr)
+	}
+}
+
+// mirror reflects a price series so lows become highs
+func mirror(prices []float64) []float64 {
+	result := make([]float64, len(prices))
+	for i, p := range prices {
+		result[i] = 200 - p
+	}
+	return result
+}
+
+func TestRSI(t *testing.T) {
+	_, _, closes := trendingSeries(30)
+	if rsi := RSI(closes, 14); rsi != 100 {
+		t.Errorf("Expected RSI 100 with only gains, got %.2f", rsi)
+	}
+	_, _, closes = choppySeries(30)
+	if rsi := RSI(closes, 14); rsi < 40 || rsi > 60 {
+		t.Errorf("Expected RSI near 50 in a range, got %.2f", rsi)
+	}
+	if rsi := RSI(closes[:10], 14); rsi != 0 {
+		t.Errorf("Expected 0 RSI with insufficient data, got %.2f", rsi)
+	}
+}
+
+// rsiReference is Wilder's 14-period worked example series
+var rsiReference = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89,
+	46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25,
+	45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13,
+}
+
+func TestRSISmoothing(t *testing.T) {
+	tests := []struct {
+		smoothing string
+		want      []float64
+	}{
+		{RSISmoothingWilder, []float64{70.46, 66.25, 66.48, 69.35, 66.29, 57.92, 62.88, 63.21, 56.01, 62.34,
+			54.67, 50.39, 40.02, 41.49, 41.90, 45.50, 37.32, 33.09, 37.79}},
+		{RSISmoothingSMA, []float64{70.46, 70.02, 69.83, 80.57, 73.33, 59.81, 62.53, 60.00, 48.48, 53.88,
+			48.95, 43.86, 37.73, 32.26, 32.72, 38.14, 31.75, 25.10, 30.22}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.smoothing, func(t *testing.T) {
+			got := rsiSeries(rsiReference, 14, tt.smoothing)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d RSI values, got %d", len(tt.want), len(got))
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 0.01 {
+					t.Errorf("RSI[%d] = %.2f, want %.2f", i, got[i], tt.want[i])
+				}
+			}
+			if last := RSIWith(rsiReference, 14, tt.smoothing); last != got[len(got)-1] {
+				t.Errorf("Expected RSIWith to return the last value %.2f, got %.2f", got[len(got)-1], last)
+			}
+		})
+	}
+
+	if RSI(rsiReference, 14) != RSIWith(rsiReference, 14, RSISmoothingWilder) {
+		t.Error("Expected RSI to default to Wilder's smoothing")
+	}
+}
+
+func TestRSIDivergence(t *testing.T) {
+	// Sharp drop to 85, then a slow grind to a lower low at 84.5 with higher RSI
+	divergent := []float64{100, 101, 100, 101, 95, 90, 85, 88, 91, 93, 91, 89, 87, 86, 84.5, 86, 88, 89}
+	// Slow drift to 98, then a crash to a lower low at 85 with lower RSI
+	confirming := []float64{100, 101, 100, 101, 100, 99.5, 99, 98.5, 98, 99, 100, 101, 95, 90, 85, 88, 91}
+
+	tests := []struct {
+		name        string
+		prices      []float64
+		wantBullish bool
+		wantBearish bool
+	}{
+		{"bullish divergence", divergent, true, false},
+		{"bearish divergence", mirror(divergent), false, true},
+		{"lower low confirmed by RSI", confirming, false, false},
+		{"higher high confirmed by RSI", mirror(confirming), false, false},
+		{"insufficient data", divergent[:3], false, false},
+	}
+	for _, tt := range tests {
+		bullish, bearish := RSIDivergence(tt.prices, 3, RSISmoothingWilder)
+		if bullish != tt.wantBullish || bearish != tt.wantBearish {
+			t.Errorf("%s: expected bullish=%v bearish=%v, got %v %v",
+				tt.name, tt.wantBullish, tt.wantBearish, bullish, bearish)
+		}
+	}
+}
+
+func TestPivots(t *testing.T) {
+	values := []float64{5, 4, 3, 4, 5, 4, 2, 4, 5, 3}
+	if got := pivots(values, 2, true); len(got) != 2 || got[0] != 2 || got[1] != 6 {
+		t.Errorf("Expected swing lows at 2 and 6, got %v", got)
+	}
+	// The last value has no bars after it to confirm a swing
+	if got := pivots(values, 2, false); len(got) != 1 || got[0] != 4 {
+		t.Errorf("Expected swing high at 4, got %v", got)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	if ema := EMA([]float64{1, 2, 3}, 3); ema != 2 {
+		t.Errorf("Expected EMA seeded with SMA 2, got %.4f", ema)
+	}
+	// k = 0.5 for period 3
+	if ema := EMA([]float64{1, 2, 3, 4}, 3); ema != 3 {
+		t.Errorf("Expected EMA 3, got %.4f", ema)
+	}
+	if ema := EMA([]float64{1, 2}, 3); ema != 0 {
+		t.Errorf("Expected 0 EMA with insufficient data, got %.4f", ema)
+	}
+}
+
+func TestKeltnerChannels(t *testing.T) {
+	highs, lows, closes := trendingSeries(30)
+	upper, middle, lower := KeltnerChannels(highs, lows, closes, 14, 2)
+	// ATR ~1.5 around an EMA lagging the last close
+	if middle <= 100 || middle >= closes[len(closes)-1] {
+		t.Errorf("Expected EMA middle below the last close, got %.4f", middle)
+	}
+	if w := upper - lower; w < 5.9 || w > 6.1 {
+		t.Errorf("Expected channel width ~6 (2 x 2 ATR), got %.4f", w)
+	}
+	if upper, _, _ := KeltnerChannels(highs[:5], lows[:5], closes[:5], 14, 2); upper != 0 {
+		t.Errorf("Expected zero channels with insufficient data, got %.4f", upper)
+	}
+}
+
+func TestBollingerSqueeze(t *testing.T) {
+	// Closes barely move but bars are wide: BB inside KC
+	n := 30
+	highs, lows, closes := make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		closes[i] = 100 + 0.1*float64(i%2)
+		highs[i] = closes[i] + 2
+		lows[i] = closes[i] - 2
+	}
+	if !BollingerSqueeze(highs, lows, closes, 20, 2, 1.5) {
+		t.Error("Expected squeeze when closes are tight relative to ATR")
+	}
+
+	// Steady trend with narrow bars: BB wider than KC
+	highs, lows, closes = trendingSeries(n)
+	if BollingerSqueeze(highs, lows, closes, 20, 2, 1.5) {
+		t.Error("Expected no squeeze in a trending series")
+	}
+
+	if BollingerSqueeze(highs[:10], lows[:10], closes[:10], 20, 2, 1.5) {
+		t.Error("Expected no squeeze with insufficient data")
+	}
+}
+
+func TestSupertrend(t *testing.T) {
+	highs, lows, closes := trendingSeries(40)
+	prev := 0.0
+	for n := 11; n <= len(closes); n++ {
+		value, up := Supertrend(highs[:n], lows[:n], closes[:n], 10, 3)
+		if !up {
+			t.Fatalf("bar %d: expected uptrend for a rising series", n)
+		}
+		if value <= prev || value >= closes[n-1] {
+			t.Errorf("bar %d: expected rising band below price %.2f, got %.2f (prev %.2f)", n, closes[n-1], value, prev)
+		}
+		prev = value
+	}
+
+	down := make([]float64, len(closes))
+	downHighs, downLows := make([]float64, len(closes)), make([]float64, len(closes))
+	for i := range closes {
+		down[i] = 200 - closes[i]
+		downHighs[i], downLows[i] = down[i]+0.5, down[i]-0.5
+	}
+	value, up := Supertrend(downHighs, downLows, down, 10, 3)
+	if up || value <= down[len(down)-1] {
+		t.Errorf("Expected downtrend with band above price, got %.2f up=%v", value, up)
+	}
+
+	if value, up := Supertrend(highs[:5], lows[:5], closes[:5], 10, 3); value != 0 || up {
+		t.Errorf("Expected 0, false with insufficient data, got %.2f %v", value, up)
+	}
+}
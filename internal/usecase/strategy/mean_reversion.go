@@ -2,8 +2,10 @@ package strategy
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
 	"github.com/zono819/hyperliquid-bot/internal/domain/service"
@@ -14,17 +16,89 @@ type MeanReversionStrategy struct {
 	mu       sync.RWMutex
 	running  bool
 	config   MeanReversionConfig
-	prices   []float64
+	symbols  map[string]bool // Supported base symbols
+	log      service.Logger
+	debug    bool                  // Whether a real logger is set; guards Debug calls so quiet ticks do not allocate
+	sizer    service.PositionSizer // nil unless set; sizes RiskPerTrade entries
+	clock    service.Clock
+	prices   ring // Price history, capped at historySize
+	highs    ring
+	lows     ring
 	position *entity.Position
+
+	// Open trade state
+	bestPrice     float64 // Most favorable price since entry
+	initialSize   float64 // Absolute position size at entry
+	remainingSize float64 // Size not yet scaled out
+	levelsFired   int     // Number of take-profit levels already hit
+
+	lastTick      time.Time // Timestamp of the latest tick
+	cooldownUntil time.Time // No entries before this after a losing exit
+
+	uptrend    bool // Latest Supertrend direction
+	trendKnown bool // Whether uptrend has been computed yet
+
+	htfCloses ring      // Closes of completed higher-timeframe bars
+	htfBar    time.Time // Start of the higher-timeframe bar in progress
+	htfLast   float64   // Latest price of the bar in progress
 }
 
+// Supertrend modes
+const (
+	SupertrendOff     = ""        // Supertrend is not used
+	SupertrendCounter = "counter" // Only fade the trend: longs in a downtrend, shorts in an uptrend
+	SupertrendFollow  = "follow"  // Enter on Supertrend flips in the new direction, exit on the next flip
+)
+
 // MeanReversionConfig holds strategy configuration
 type MeanReversionConfig struct {
-	WindowSize      int     // Number of periods for MA calculation
-	EntryDeviation  float64 // Entry threshold (standard deviations)
-	ExitDeviation   float64 // Exit threshold (standard deviations)
-	PositionSize    float64 // Position size in base currency
-	MaxPositionSize float64 // Maximum position size
+	WindowSize      int               // Number of periods for MA calculation
+	EntryDeviation  float64           // Entry threshold (standard deviations)
+	MinStdDevPct    float64           // Floor on the band standard deviation as a fraction of the mean (0 = none)
+	ExitDeviation   float64           // Exit threshold (standard deviations)
+	PositionSize    float64           // Position size in base currency
+	MaxPositionSize float64           // Maximum position size
+	MaxADX          float64           // Suppress entries when ADX exceeds this (0 = disabled)
+	ADXPeriod       int               // Number of periods for ADX calculation
+	TakeProfitPct   float64           // Close when profit reaches this % (0 = disabled)
+	StopLossPct     float64           // Close when loss reaches this % (0 = disabled)
+	TrailingStop    bool              // Enable trailing stop
+	TrailingPct     float64           // Trailing stop % from best price since entry
+	TPLevels        []TakeProfitLevel // Scaled take-profit levels, ordered by Pct
+	MinImbalance    float64           // Require book imbalance of at least this in the entry direction (0 = disabled)
+	ImbalanceLevels int               // Order book levels per side used for imbalance
+	EntryPricing    EntryPricing      // How entry orders are priced
+	Cooldown        time.Duration     // Suppress entries this long after a losing exit (0 = disabled)
+
+	RequireDivergence bool   // Require RSI divergence in the entry direction
+	RSIPeriod         int    // Number of periods for RSI divergence
+	RSISmoothing      string // RSISmoothingWilder or RSISmoothingSMA
+
+	SqueezeFilter bool    // Suppress entries while Bollinger Bands are inside Keltner Channels
+	SqueezePeriod int     // Number of periods for the squeeze bands
+	SqueezeBBMult float64 // Bollinger Band width in standard deviations
+	SqueezeKCMult float64 // Keltner Channel width in ATRs
+
+	SupertrendMode   string  // SupertrendOff, SupertrendCounter or SupertrendFollow
+	SupertrendPeriod int     // Number of periods for the Supertrend ATR
+	SupertrendMult   float64 // Supertrend band distance in ATRs
+
+	HTFFilter    bool          // Skip entries against the higher-timeframe trend
+	HTFInterval  time.Duration // Higher-timeframe bar length
+	HTFPeriod    int           // Number of higher-timeframe bars in the trend SMA
+	HTFThreshold float64       // Fraction price may sit against the SMA before the trend blocks entries
+
+	PositionSizeUSD float64 // Position size in USD notional, converted at the entry price; overrides PositionSize when set
+	PositionSizePct float64 // Position size as a fraction of account equity in USD notional; overrides both while equity is known
+
+	RiskPerTrade float64 // Fraction of equity a stop some ATRs away may lose; overrides the sizes above while equity and ATR are known (0 = disabled)
+	ATRPeriod    int     // Number of periods for the RiskPerTrade ATR
+}
+
+// TakeProfitLevel defines a partial exit target
+type TakeProfitLevel struct {
+	Pct     float64 // Profit % from entry that triggers this level
+	Portion float64 // Portion of the initial position to close (0-1)
 }
 
 // DefaultMeanReversionConfig returns default configuration
@@ -35,6 +109,27 @@ func DefaultMeanReversionConfig() MeanReversionConfig {
 		ExitDeviation:   0.5,
 		PositionSize:    0.01,
 		MaxPositionSize: 0.1,
+		MaxADX:          0,
+		ADXPeriod:       14,
+		TakeProfitPct:   0,
+		StopLossPct:     0,
+		TrailingStop:    false,
+		TrailingPct:     0.005,
+		MinImbalance:    0,
+		ImbalanceLevels: 5,
+		EntryPricing:    EntryPricing{Mode: EntryPricingLast},
+		RSIPeriod:       14,
+		RSISmoothing:    RSISmoothingWilder,
+		SqueezePeriod:   20,
+		SqueezeBBMult:   2.0,
+		SqueezeKCMult:   1.5,
+		HTFInterval:     time.Hour,
+		HTFPeriod:       50,
+
+		SupertrendPeriod: 10,
+		SupertrendMult:   3.0,
+
+		ATRPeriod: 14,
 	}
 }
 
@@ -42,8 +137,42 @@ func DefaultMeanReversionConfig() MeanReversionConfig {
 func NewMeanReversionStrategy() *MeanReversionStrategy {
 	return &MeanReversionStrategy{
 		config: DefaultMeanReversionConfig(),
-		prices: make([]float64, 0),
+		symbols: map[string]bool{
+			"BTC": true,
+			"ETH": true,
+			"XRP": true,
+		},
+		log:   service.NopLogger,
+		clock: service.RealClock,
+	}
+}
+
+// SetLogger sets the logger used for per-tick diagnostics
+func (s *MeanReversionStrategy) SetLogger(log service.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if log == nil {
+		log = service.NopLogger
+	}
+	s.log = log
+	s.debug = log != service.NopLogger
+}
+
+// SetPositionSizer sets the sizer used for risk_per_trade entries
+func (s *MeanReversionStrategy) SetPositionSizer(sizer service.PositionSizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sizer = sizer
+}
+
+// SetClock sets the clock timing the cooldown of ticks without a timestamp; nil restores the real clock
+func (s *MeanReversionStrategy) SetClock(clock service.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clock == nil {
+		clock = service.RealClock
 	}
+	s.clock = clock
 }
 
 // Name returns strategy name
@@ -56,23 +185,188 @@ func (s *MeanReversionStrategy) Init(ctx context.Context, config map[string]inte
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := s.applyConfig(config); err != nil {
+		return err
+	}
+	s.running = true
+	return nil
+}
+
+// Reconfigure applies new parameters without resetting price history or position state
+func (s *MeanReversionStrategy) Reconfigure(ctx context.Context, config map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.applyConfig(config)
+}
+
+// applyConfig parses config over the current settings; nothing changes on error
+func (s *MeanReversionStrategy) applyConfig(config map[string]interface{}) error {
+	cfg := s.config
+	supported := s.symbols
+
 	if v, ok := config["window_size"].(int); ok {
-		s.config.WindowSize = v
+		cfg.WindowSize = v
 	}
 	if v, ok := config["entry_deviation"].(float64); ok {
-		s.config.EntryDeviation = v
+		cfg.EntryDeviation = v
 	}
 	if v, ok := config["exit_deviation"].(float64); ok {
-		s.config.ExitDeviation = v
+		cfg.ExitDeviation = v
+	}
+	if v, ok := config["min_stddev_pct"].(float64); ok {
+		if v < 0 {
+			return fmt.Errorf("min_stddev_pct must be non-negative")
+		}
+		cfg.MinStdDevPct = v
 	}
 	if v, ok := config["position_size"].(float64); ok {
-		s.config.PositionSize = v
+		cfg.PositionSize = v
 	}
 	if v, ok := config["max_position_size"].(float64); ok {
-		s.config.MaxPositionSize = v
+		cfg.MaxPositionSize = v
+	}
+	switch v := config["position_size_usd"].(type) {
+	case int:
+		cfg.PositionSizeUSD = float64(v)
+	case float64:
+		cfg.PositionSizeUSD = v
+	}
+	if cfg.PositionSizeUSD < 0 {
+		return fmt.Errorf("position_size_usd must be non-negative")
+	}
+	if v, ok := config["position_size_pct"].(float64); ok {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("position_size_pct must be between 0 and 1")
+		}
+		cfg.PositionSizePct = v
+	}
+	if v, ok := config["risk_per_trade"].(float64); ok {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("risk_per_trade must be between 0 and 1")
+		}
+		cfg.RiskPerTrade = v
+	}
+	if v, ok := config["atr_period"].(int); ok {
+		if v < 1 {
+			return fmt.Errorf("atr_period must be positive")
+		}
+		cfg.ATRPeriod = v
+	}
+	if v, ok := config["max_adx"].(float64); ok {
+		cfg.MaxADX = v
+	}
+	if v, ok := config["adx_period"].(int); ok {
+		cfg.ADXPeriod = v
+	}
+	if v, ok := config["take_profit_pct"].(float64); ok {
+		cfg.TakeProfitPct = v
+	}
+	if v, ok := config["stop_loss_pct"].(float64); ok {
+		cfg.StopLossPct = v
+	}
+	if v, ok := config["trailing_stop"].(bool); ok {
+		cfg.TrailingStop = v
+	}
+	if v, ok := config["trailing_pct"].(float64); ok {
+		cfg.TrailingPct = v
+	}
+	if v, ok := config["min_imbalance"].(float64); ok {
+		cfg.MinImbalance = v
+	}
+	if v, ok := config["imbalance_levels"].(int); ok {
+		cfg.ImbalanceLevels = v
+	}
+	if v, ok := config["require_divergence"].(bool); ok {
+		cfg.RequireDivergence = v
+	}
+	if v, ok := config["rsi_period"].(int); ok {
+		cfg.RSIPeriod = v
+	}
+	if v, ok := config["rsi_smoothing"].(string); ok {
+		switch v {
+		case RSISmoothingWilder, RSISmoothingSMA:
+			cfg.RSISmoothing = v
+		default:
+			return fmt.Errorf("invalid rsi_smoothing %q (expected %s or %s)", v, RSISmoothingWilder, RSISmoothingSMA)
+		}
+	}
+	if v, ok := config["squeeze_filter"].(bool); ok {
+		cfg.SqueezeFilter = v
+	}
+	if v, ok := config["squeeze_period"].(int); ok {
+		cfg.SqueezePeriod = v
+	}
+	if v, ok := config["squeeze_bb_mult"].(float64); ok {
+		cfg.SqueezeBBMult = v
+	}
+	if v, ok := config["squeeze_kc_mult"].(float64); ok {
+		cfg.SqueezeKCMult = v
+	}
+	if v, ok := config["supertrend_mode"].(string); ok {
+		switch v {
+		case SupertrendOff, SupertrendCounter, SupertrendFollow:
+			cfg.SupertrendMode = v
+		default:
+			return fmt.Errorf("invalid supertrend_mode %q (expected %s or %s)", v, SupertrendCounter, SupertrendFollow)
+		}
+	}
+	if v, ok := config["supertrend_period"].(int); ok {
+		cfg.SupertrendPeriod = v
+	}
+	if v, ok := config["supertrend_mult"].(float64); ok {
+		cfg.SupertrendMult = v
+	}
+	if err := parseEntryPricing(config, &cfg.EntryPricing); err != nil {
+		return err
+	}
+	if v, ok := config["htf_filter"].(bool); ok {
+		cfg.HTFFilter = v
+	}
+	if v, ok := config["htf_interval"].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid htf_interval: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("htf_interval must be positive")
+		}
+		cfg.HTFInterval = d
+	}
+	if v, ok := config["htf_period"].(int); ok {
+		if v <= 0 {
+			return fmt.Errorf("htf_period must be positive")
+		}
+		cfg.HTFPeriod = v
+	}
+	if v, ok := config["htf_threshold"].(float64); ok {
+		if v < 0 {
+			return fmt.Errorf("htf_threshold must be non-negative")
+		}
+		cfg.HTFThreshold = v
+	}
+	switch v := config["cooldown_seconds"].(type) {
+	case int:
+		cfg.Cooldown = time.Duration(v) * time.Second
+	case float64:
+		cfg.Cooldown = time.Duration(v * float64(time.Second))
+	}
+	if v, ok := config["symbols"]; ok {
+		symbols, err := parseSymbols(v)
+		if err != nil {
+			return err
+		}
+		supported = symbols
+	}
+	if v, ok := config["tp_levels"]; ok {
+		levels, err := parseTPLevels(v)
+		if err != nil {
+			return err
+		}
+		cfg.TPLevels = levels
 	}
 
-	s.running = true
+	s.config = cfg
+	s.symbols = supported
 	return nil
 }
 
@@ -81,116 +375,624 @@ func (s *MeanReversionStrategy) OnTick(ctx context.Context, state *service.Marke
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if !s.running || state.Ticker == nil {
+	if !s.running || state.Ticker == nil || !s.isSymbolSupported(state.Ticker.Symbol) {
 		return nil, nil
 	}
 
-	signals := make([]*service.Signal, 0)
 	currentPrice := state.Ticker.LastPrice
 
 	// Add price to history
-	s.prices = append(s.prices, currentPrice)
-	if len(s.prices) > s.config.WindowSize {
-		s.prices = s.prices[1:]
+	s.recordTick(state.Ticker)
+	s.lastTick = s.tickTime(state.Ticker)
+	s.recordHTF(currentPrice, s.lastTick)
+
+	hasPosition := state.Position != nil && state.Position.Size != 0
+	s.position = state.Position
+	flipped := s.updateTrend()
+
+	if hasPosition {
+		return s.checkExitConditions(state, currentPrice), nil
 	}
 
-	// Need enough data for calculation
-	if len(s.prices) < s.config.WindowSize {
+	// Flat: reset trade tracking
+	s.resetTradeState()
+
+	symbol := state.Ticker.Symbol
+	if remaining := s.cooldownRemaining(); remaining > 0 {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no entry, in cooldown after loss for %s", symbol, remaining)
+		}
+		return nil, nil
+	}
+	if s.config.SupertrendMode == SupertrendFollow {
+		return s.followTrend(state, currentPrice, flipped), nil
+	}
+	zScore, ok := s.zScore(currentPrice)
+	if !ok {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no z-score yet (%d/%d prices or zero variance)",
+				symbol, s.prices.Len(), s.historySize())
+		}
+		return nil, nil
+	}
+	if adx, trending := s.trend(); trending {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no entry, ADX %.2f > max %.2f (trending)", symbol, adx, s.config.MaxADX)
+		}
+		return nil, nil
+	}
+	if s.config.SqueezeFilter && s.squeezed() {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no entry, Bollinger squeeze (breakout likely)", symbol)
+		}
+		return nil, nil
+	}
+
+	// Check entry conditions
+	if zScore <= -s.config.EntryDeviation && !s.imbalanceConfirms(state, entity.SideBuy) {
+		return nil, nil
+	}
+	if zScore >= s.config.EntryDeviation && !s.imbalanceConfirms(state, entity.SideSell) {
 		return nil, nil
 	}
+	if zScore <= -s.config.EntryDeviation && !s.counterTrend(symbol, entity.SideBuy) {
+		return nil, nil
+	}
+	if zScore >= s.config.EntryDeviation && !s.counterTrend(symbol, entity.SideSell) {
+		return nil, nil
+	}
+	if zScore <= -s.config.EntryDeviation && !s.divergenceConfirms(symbol, entity.SideBuy) {
+		return nil, nil
+	}
+	if zScore >= s.config.EntryDeviation && !s.divergenceConfirms(symbol, entity.SideSell) {
+		return nil, nil
+	}
+	if zScore <= -s.config.EntryDeviation && !s.htfConfirms(symbol, entity.SideBuy, currentPrice) {
+		return nil, nil
+	}
+	if zScore >= s.config.EntryDeviation && !s.htfConfirms(symbol, entity.SideSell, currentPrice) {
+		return nil, nil
+	}
+	if zScore <= -s.config.EntryDeviation {
+		// Price below mean - buy expecting reversion up
+		return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideBuy, s.entrySize(state, currentPrice), currentPrice,
+			"Mean reversion: price below lower band (enter long)")}, nil
+	}
+	if zScore >= s.config.EntryDeviation {
+		// Price above mean - sell expecting reversion down
+		return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideSell, s.entrySize(state, currentPrice), currentPrice,
+			"Mean reversion: price above upper band (enter short)")}, nil
+	}
+	if s.debug {
+		s.log.Debug("mean_reversion %s: no entry, price %.2f z-score %.2f within ±%.2f",
+			symbol, currentPrice, zScore, s.config.EntryDeviation)
+	}
+	return nil, nil
+}
+
+// entrySize returns the entry quantity in base units: the volatility-scaled
+// size for RiskPerTrade, else PositionSizePct of the account equity, else
+// PositionSizeUSD converted at price, else PositionSize
+func (s *MeanReversionStrategy) entrySize(state *service.MarketState, price float64) float64 {
+	if s.config.RiskPerTrade > 0 && s.sizer != nil && state.Equity > 0 {
+		atr := ATR(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.ATRPeriod)
+		if size := s.sizer.SizePosition(state.Equity, price, atr, s.config.RiskPerTrade); size > 0 {
+			return size
+		}
+	}
+	if s.config.PositionSizePct > 0 && state.Equity > 0 {
+		return service.QuantityForNotional(state.Equity*s.config.PositionSizePct, price)
+	}
+	if s.config.PositionSizeUSD > 0 {
+		return service.QuantityForNotional(s.config.PositionSizeUSD, price)
+	}
+	return s.config.PositionSize
+}
+
+// imbalanceConfirms reports whether the order book supports an entry on side:
+// bids must outweigh asks by MinImbalance for a long, asks for a short.
+// Entries are allowed when the filter is disabled or no book is available.
+func (s *MeanReversionStrategy) imbalanceConfirms(state *service.MarketState, side entity.Side) bool {
+	if s.config.MinImbalance <= 0 || state.OrderBook == nil {
+		return true
+	}
+	imbalance := state.OrderBook.Imbalance(s.config.ImbalanceLevels)
+	if side == entity.SideSell {
+		imbalance = -imbalance
+	}
+	if imbalance < s.config.MinImbalance {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no %s entry, book imbalance %.2f below min %.2f",
+				state.Ticker.Symbol, side, imbalance, s.config.MinImbalance)
+		}
+		return false
+	}
+	return true
+}
+
+// updateTrend recomputes the Supertrend direction and reports whether it flipped
+func (s *MeanReversionStrategy) updateTrend() bool {
+	if s.config.SupertrendMode == SupertrendOff {
+		return false
+	}
+	value, up := Supertrend(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.SupertrendPeriod, s.config.SupertrendMult)
+	if value == 0 {
+		return false
+	}
+	flipped := s.trendKnown && up != s.uptrend
+	s.uptrend, s.trendKnown = up, true
+	return flipped
+}
+
+// counterTrend reports whether an entry on side fades the Supertrend:
+// longs only in a downtrend, shorts only in an uptrend. Always true outside counter mode.
+func (s *MeanReversionStrategy) counterTrend(symbol string, side entity.Side) bool {
+	if s.config.SupertrendMode != SupertrendCounter {
+		return true
+	}
+	if s.trendKnown && (side == entity.SideBuy) != s.uptrend {
+		return true
+	}
+	if s.debug {
+		s.log.Debug("mean_reversion %s: no %s entry, not against the Supertrend", symbol, side)
+	}
+	return false
+}
+
+// followTrend enters in the new direction when the Supertrend flips
+func (s *MeanReversionStrategy) followTrend(state *service.MarketState, price float64, flipped bool) []*service.Signal {
+	if !flipped {
+		return nil
+	}
+	if s.uptrend {
+		return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideBuy, s.entrySize(state, price), price,
+			"Supertrend: flipped up (enter long)")}
+	}
+	return []*service.Signal{s.config.EntryPricing.entrySignal(state, entity.SideSell, s.entrySize(state, price), price,
+		"Supertrend: flipped down (enter short)")}
+}
+
+// divergenceConfirms reports whether RSI divergence supports an entry on side:
+// bullish for a long, bearish for a short. Always true when not required.
+func (s *MeanReversionStrategy) divergenceConfirms(symbol string, side entity.Side) bool {
+	if !s.config.RequireDivergence {
+		return true
+	}
+	bullish, bearish := RSIDivergence(s.prices.Values(), s.config.RSIPeriod, s.config.RSISmoothing)
+	if (side == entity.SideBuy && bullish) || (side == entity.SideSell && bearish) {
+		return true
+	}
+	if s.debug {
+		s.log.Debug("mean_reversion %s: no %s entry, no RSI divergence", symbol, side)
+	}
+	return false
+}
+
+// recordHTF tracks higher-timeframe bars from ticks: the last price of each
+// HTFInterval bar becomes its close once the next bar starts
+func (s *MeanReversionStrategy) recordHTF(price float64, at time.Time) {
+	if !s.config.HTFFilter {
+		return
+	}
+	s.htfCloses.Resize(s.config.HTFPeriod)
+	bar := at.Truncate(s.config.HTFInterval)
+	if !s.htfBar.IsZero() && bar.After(s.htfBar) {
+		s.htfCloses.Push(s.htfLast)
+	}
+	if s.htfBar.IsZero() || bar.After(s.htfBar) {
+		s.htfBar = bar
+	}
+	s.htfLast = price
+}
+
+// htfSMA returns the SMA of the higher-timeframe closes, or false until
+// HTFPeriod bars have completed
+func (s *MeanReversionStrategy) htfSMA() (float64, bool) {
+	closes := s.htfCloses.Values()
+	if len(closes) < s.config.HTFPeriod {
+		return 0, false
+	}
+	sum := 0.0
+	for _, c := range closes {
+		sum += c
+	}
+	return sum / float64(len(closes)), true
+}
+
+// htfConfirms reports whether the higher-timeframe trend allows an entry on
+// side: longs unless price is more than HTFThreshold below the SMA, shorts
+// unless it is more than HTFThreshold above. Always true when the filter is
+// off; false until the SMA is known.
+func (s *MeanReversionStrategy) htfConfirms(symbol string, side entity.Side, price float64) bool {
+	if !s.config.HTFFilter {
+		return true
+	}
+	sma, ok := s.htfSMA()
+	if !ok {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no %s entry, higher timeframe trend not known yet (%d/%d bars)",
+				symbol, side, s.htfCloses.Len(), s.config.HTFPeriod)
+		}
+		return false
+	}
+	deviation := (price - sma) / sma
+	if side == entity.SideSell {
+		deviation = -deviation
+	}
+	if deviation < -s.config.HTFThreshold {
+		if s.debug {
+			s.log.Debug("mean_reversion %s: no %s entry, price %.2f against the higher timeframe SMA %.2f",
+				symbol, side, price, sma)
+		}
+		return false
+	}
+	return true
+}
+
+// checkExitConditions checks stop loss, take profit, trailing stop and mean reversion exits
+func (s *MeanReversionStrategy) checkExitConditions(state *service.MarketState, currentPrice float64) []*service.Signal {
+	isLong := s.position.Size > 0
+	entryPrice := s.position.EntryPrice
+
+	// Track the most favorable price since entry
+	if s.bestPrice == 0 {
+		s.bestPrice = entryPrice
+	}
+	if s.initialSize == 0 {
+		s.initialSize = math.Abs(s.position.Size)
+		s.remainingSize = s.initialSize
+	}
+	if isLong {
+		s.bestPrice = math.Max(s.bestPrice, currentPrice)
+	} else {
+		s.bestPrice = math.Min(s.bestPrice, currentPrice)
+	}
+
+	if entryPrice > 0 {
+		pnlPct := (currentPrice - entryPrice) / entryPrice
+		if !isLong {
+			pnlPct = -pnlPct
+		}
+
+		if s.config.StopLossPct > 0 && pnlPct <= -s.config.StopLossPct {
+			return s.exitSignals(state, currentPrice, fmt.Sprintf("Mean reversion: stop loss %.2f%%", pnlPct*100))
+		}
+		if s.config.TakeProfitPct > 0 && pnlPct >= s.config.TakeProfitPct {
+			return s.exitSignals(state, currentPrice, fmt.Sprintf("Mean reversion: take profit %.2f%%", pnlPct*100))
+		}
+	}
+
+	if s.config.TrailingStop && s.config.TrailingPct > 0 && s.bestPrice > 0 {
+		retrace := (s.bestPrice - currentPrice) / s.bestPrice
+		if !isLong {
+			retrace = -retrace
+		}
+		// Only trail once the trade has moved in our favor
+		inProfit := (isLong && s.bestPrice > entryPrice) || (!isLong && s.bestPrice < entryPrice)
+		if inProfit && retrace >= s.config.TrailingPct {
+			return s.exitSignals(state, currentPrice, fmt.Sprintf("Mean reversion: trailing stop %.2f%% from best %.2f", retrace*100, s.bestPrice))
+		}
+	}
+
+	if signals := s.checkTakeProfitLevels(state, currentPrice); len(signals) > 0 {
+		return signals
+	}
+
+	// Trend-following trades ride the trend instead of exiting at the mean
+	if s.config.SupertrendMode == SupertrendFollow {
+		if s.trendKnown && isLong != s.uptrend {
+			return s.exitSignals(state, currentPrice, "Supertrend: trend flipped against position")
+		}
+		return nil
+	}
+
+	zScore, ok := s.zScore(currentPrice)
+	if !ok {
+		return nil
+	}
+
+	if isLong && zScore >= -s.config.ExitDeviation {
+		// Close long position (price returned to mean)
+		return s.exitSignals(state, currentPrice, "Mean reversion: price returned to mean (close long)")
+	}
+	if !isLong && zScore <= s.config.ExitDeviation {
+		// Close short position
+		return s.exitSignals(state, currentPrice, "Mean reversion: price returned to mean (close short)")
+	}
+
+	if s.debug {
+		s.log.Debug("mean_reversion %s: holding, price %.2f z-score %.2f not past exit ±%.2f",
+			state.Ticker.Symbol, currentPrice, zScore, s.config.ExitDeviation)
+	}
+	return nil
+}
+
+// checkTakeProfitLevels emits partial exits for each newly reached take-profit level
+func (s *MeanReversionStrategy) checkTakeProfitLevels(state *service.MarketState, currentPrice float64) []*service.Signal {
+	entryPrice := s.position.EntryPrice
+	if len(s.config.TPLevels) == 0 || entryPrice <= 0 {
+		return nil
+	}
+
+	isLong := s.position.Size > 0
+	pnlPct := (currentPrice - entryPrice) / entryPrice
+	if !isLong {
+		pnlPct = -pnlPct
+	}
+
+	side := entity.SideSell
+	if !isLong {
+		side = entity.SideBuy
+	}
+
+	var signals []*service.Signal
+	for s.levelsFired < len(s.config.TPLevels) && s.remainingSize > 0 {
+		level := s.config.TPLevels[s.levelsFired]
+		if pnlPct < level.Pct {
+			break
+		}
+
+		quantity := s.initialSize * level.Portion
+		// Final level closes whatever is left
+		if s.levelsFired == len(s.config.TPLevels)-1 || quantity > s.remainingSize {
+			quantity = s.remainingSize
+		}
+		quantity = math.Min(quantity, math.Abs(s.position.Size))
+
+		s.levelsFired++
+		s.remainingSize -= quantity
+
+		signals = append(signals, &service.Signal{
+			Symbol:   state.Ticker.Symbol,
+			Side:     side,
+			Price:    currentPrice,
+			Quantity: quantity,
+			Reason:   fmt.Sprintf("Mean reversion: take profit level %d (%.2f%%) partial exit", s.levelsFired, level.Pct*100),
+		})
+	}
+
+	return signals
+}
+
+// resetTradeState clears per-trade tracking after the position is closed
+func (s *MeanReversionStrategy) resetTradeState() {
+	s.bestPrice = 0
+	s.initialSize = 0
+	s.remainingSize = 0
+	s.levelsFired = 0
+}
+
+// isSymbolSupported checks if the symbol's base asset is in the supported set.
+// Accepts every entity.Symbol format ("BTC", "BTC/USDC", "BTC-PERP", "BTCUSDC").
+func (s *MeanReversionStrategy) isSymbolSupported(symbol string) bool {
+	return s.symbols[entity.Symbol(symbol).Base()]
+}
+
+// parseSymbols parses the supported symbol list from Init config
+func parseSymbols(v interface{}) (map[string]bool, error) {
+	var list []string
+	switch symbols := v.(type) {
+	case []string:
+		list = symbols
+	case []interface{}:
+		for i, item := range symbols {
+			sym, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("symbols[%d]: expected string, got %T", i, item)
+			}
+			list = append(list, sym)
+		}
+	default:
+		return nil, fmt.Errorf("symbols: unsupported type %T", v)
+	}
+
+	result := make(map[string]bool, len(list))
+	for _, sym := range list {
+		result[entity.Symbol(sym).Base()] = true
+	}
+	return result, nil
+}
+
+// parseTPLevels parses take-profit levels from Init config
+func parseTPLevels(v interface{}) ([]TakeProfitLevel, error) {
+	switch levels := v.(type) {
+	case []TakeProfitLevel:
+		return levels, nil
+	case []interface{}:
+		result := make([]TakeProfitLevel, 0, len(levels))
+		for i, item := range levels {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("tp_levels[%d]: expected map, got %T", i, item)
+			}
+			pct, ok := m["pct"].(float64)
+			if !ok {
+				return nil, fmt.Errorf("tp_levels[%d]: missing pct", i)
+			}
+			portion, ok := m["portion"].(float64)
+			if !ok {
+				return nil, fmt.Errorf("tp_levels[%d]: missing portion", i)
+			}
+			result = append(result, TakeProfitLevel{Pct: pct, Portion: portion})
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("tp_levels: unsupported type %T", v)
+	}
+}
+
+// exitSignals creates a signal closing the whole position
+func (s *MeanReversionStrategy) exitSignals(state *service.MarketState, price float64, reason string) []*service.Signal {
+	side := entity.SideSell
+	if s.position.Size < 0 {
+		side = entity.SideBuy
+	}
+	return []*service.Signal{{
+		Symbol:   state.Ticker.Symbol,
+		Side:     side,
+		Price:    price,
+		Quantity: math.Abs(s.position.Size),
+		Reason:   reason,
+	}}
+}
+
+// zScore returns the z-score of price against the window, with the standard
+// deviation floored at MinStdDevPct of the mean.
+// Returns false if there is not enough data, no variance or invalid prices.
+func (s *MeanReversionStrategy) zScore(price float64) (float64, bool) {
+	// Need enough data for calculation
+	if s.prices.Len() < s.historySize() {
+		return 0, false
+	}
 
 	// Calculate mean and standard deviation
 	mean := s.calculateMean()
 	stdDev := s.calculateStdDev(mean)
+	if math.IsNaN(stdDev) || math.IsInf(stdDev, 0) {
+		return 0, false
+	}
 
+	// Keep flat markets from collapsing the bands onto the mean
+	stdDev = math.Max(stdDev, math.Abs(mean)*s.config.MinStdDevPct)
 	if stdDev == 0 {
-		return nil, nil
+		return 0, false
 	}
 
-	// Calculate z-score
-	zScore := (currentPrice - mean) / stdDev
+	return (price - mean) / stdDev, true
+}
 
-	hasPosition := state.Position != nil && state.Position.Size != 0
-	s.position = state.Position
+// recordTick appends the tick to price history
+func (s *MeanReversionStrategy) recordTick(ticker *entity.Ticker) {
+	price := ticker.LastPrice
+	high, low := tickHighLow(ticker)
 
-	if hasPosition {
-		// Check exit conditions
-		if s.position.Size > 0 && zScore >= -s.config.ExitDeviation {
-			// Close long position (price returned to mean)
-			signals = append(signals, &service.Signal{
-				Symbol:   state.Ticker.Symbol,
-				Side:     entity.SideSell,
-				Price:    currentPrice,
-				Quantity: math.Abs(s.position.Size),
-				Reason:   "Mean reversion: price returned to mean (close long)",
-			})
-		} else if s.position.Size < 0 && zScore <= s.config.ExitDeviation {
-			// Close short position
-			signals = append(signals, &service.Signal{
-				Symbol:   state.Ticker.Symbol,
-				Side:     entity.SideBuy,
-				Price:    currentPrice,
-				Quantity: math.Abs(s.position.Size),
-				Reason:   "Mean reversion: price returned to mean (close short)",
-			})
-		}
-	} else {
-		// Check entry conditions
-		if zScore <= -s.config.EntryDeviation {
-			// Price below mean - buy expecting reversion up
-			signals = append(signals, &service.Signal{
-				Symbol:   state.Ticker.Symbol,
-				Side:     entity.SideBuy,
-				Price:    currentPrice,
-				Quantity: s.config.PositionSize,
-				Reason:   "Mean reversion: price below lower band (enter long)",
-			})
-		} else if zScore >= s.config.EntryDeviation {
-			// Price above mean - sell expecting reversion down
-			signals = append(signals, &service.Signal{
-				Symbol:   state.Ticker.Symbol,
-				Side:     entity.SideSell,
-				Price:    currentPrice,
-				Quantity: s.config.PositionSize,
-				Reason:   "Mean reversion: price above upper band (enter short)",
-			})
-		}
-	}
-
-	return signals, nil
+	size := s.historySize()
+	s.prices.Resize(size)
+	s.highs.Resize(size)
+	s.lows.Resize(size)
+
+	s.prices.Push(price)
+	s.highs.Push(high)
+	s.lows.Push(low)
+}
+
+// historySize returns the number of ticks to keep in history
+func (s *MeanReversionStrategy) historySize() int {
+	size := s.config.WindowSize
+	if s.config.MaxADX > 0 && 2*s.config.ADXPeriod > size {
+		size = 2 * s.config.ADXPeriod
+	}
+	if s.config.SupertrendMode != SupertrendOff && 2*s.config.SupertrendPeriod > size {
+		size = 2 * s.config.SupertrendPeriod
+	}
+	if s.config.RiskPerTrade > 0 && s.config.ATRPeriod+1 > size {
+		size = s.config.ATRPeriod + 1
+	}
+	if s.config.SqueezeFilter && s.config.SqueezePeriod+1 > size {
+		size = s.config.SqueezePeriod + 1
+	}
+	// RSI warm-up plus room for two swing points
+	if s.config.RequireDivergence && 3*s.config.RSIPeriod > size {
+		size = 3 * s.config.RSIPeriod
+	}
+	return size
+}
+
+// window returns the most recent WindowSize prices
+func (s *MeanReversionStrategy) window() []float64 {
+	prices := s.prices.Values()
+	if len(prices) <= s.config.WindowSize {
+		return prices
+	}
+	return prices[len(prices)-s.config.WindowSize:]
+}
+
+// trend returns the ADX and whether it indicates a trending market
+func (s *MeanReversionStrategy) trend() (float64, bool) {
+	if s.config.MaxADX <= 0 {
+		return 0, false
+	}
+	adx := ADX(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.ADXPeriod)
+	return adx, adx > s.config.MaxADX
+}
+
+// squeezed reports whether the Bollinger Bands are inside the Keltner Channels.
+// Needs SqueezePeriod+1 ticks of history, which is kept when the filter is on.
+func (s *MeanReversionStrategy) squeezed() bool {
+	return BollingerSqueeze(s.highs.Values(), s.lows.Values(), s.prices.Values(), s.config.SqueezePeriod, s.config.SqueezeBBMult, s.config.SqueezeKCMult)
 }
 
 // calculateMean calculates the simple moving average
 func (s *MeanReversionStrategy) calculateMean() float64 {
-	if len(s.prices) == 0 {
+	prices := s.window()
+	if len(prices) == 0 {
 		return 0
 	}
 
 	sum := 0.0
-	for _, p := range s.prices {
+	for _, p := range prices {
 		sum += p
 	}
-	return sum / float64(len(s.prices))
+	return sum / float64(len(prices))
 }
 
 // calculateStdDev calculates standard deviation
 func (s *MeanReversionStrategy) calculateStdDev(mean float64) float64 {
-	if len(s.prices) == 0 {
+	prices := s.window()
+	if len(prices) == 0 {
 		return 0
 	}
 
 	variance := 0.0
-	for _, p := range s.prices {
+	for _, p := range prices {
 		diff := p - mean
 		variance += diff * diff
 	}
-	variance /= float64(len(s.prices))
+	variance /= float64(len(prices))
 
 	return math.Sqrt(variance)
 }
 
-// OnOrderUpdate is called when order status changes
+// tickTime returns the tick's timestamp, so backtests replay cooldowns on
+// market time, or the clock's time when the tick carries none
+func (s *MeanReversionStrategy) tickTime(ticker *entity.Ticker) time.Time {
+	if ticker.Timestamp.IsZero() {
+		return s.clock.Now()
+	}
+	return ticker.Timestamp
+}
+
+// cooldownRemaining returns how long entries stay suppressed after a losing exit
+func (s *MeanReversionStrategy) cooldownRemaining() time.Duration {
+	if remaining := s.cooldownUntil.Sub(s.lastTick); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// OnOrderUpdate starts the cooldown when a fill closes the position at a loss
 func (s *MeanReversionStrategy) OnOrderUpdate(ctx context.Context, order *entity.Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Cooldown <= 0 || order.Status != entity.OrderStatusFilled ||
+		s.position == nil || s.position.Size == 0 || s.position.EntryPrice <= 0 {
+		return nil
+	}
+
+	isLong := s.position.Size > 0
+	closing := (isLong && order.Side == entity.SideSell) || (!isLong && order.Side == entity.SideBuy)
+	if !closing {
+		return nil
+	}
+	loss := (isLong && order.Price < s.position.EntryPrice) || (!isLong && order.Price > s.position.EntryPrice)
+	if loss {
+		s.cooldownUntil = s.lastTick.Add(s.config.Cooldown)
+		if s.debug {
+			s.log.Debug("mean_reversion %s: losing exit at %.2f (entry %.2f), cooldown until %s",
+				order.Symbol, order.Price, s.position.EntryPrice, s.cooldownUntil.Format(time.RFC3339))
+		}
+	}
 	return nil
 }
 
@@ -199,9 +1001,47 @@ func (s *MeanReversionStrategy) OnPositionUpdate(ctx context.Context, position *
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.position = position
+	if position == nil || position.Size == 0 {
+		s.resetTradeState()
+	}
+	return nil
+}
+
+// Reset clears the trailing-stop and take-profit tracking of the last trade
+func (s *MeanReversionStrategy) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetTradeState()
 	return nil
 }
 
+// GetState returns a snapshot of the strategy state
+func (s *MeanReversionStrategy) GetState() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := map[string]interface{}{
+		"running":            s.running,
+		"history":            s.prices.Len(),
+		"best_price":         s.bestPrice,
+		"initial_size":       s.initialSize,
+		"remaining_size":     s.remainingSize,
+		"levels_fired":       s.levelsFired,
+		"cooldown_remaining": s.cooldownRemaining().Seconds(),
+		"squeeze":            s.squeezed(),
+	}
+	if s.trendKnown {
+		state["supertrend_up"] = s.uptrend
+	}
+	if sma, ok := s.htfSMA(); ok && s.config.HTFFilter {
+		state["htf_sma"] = sma
+	}
+	if s.prices.Len() > 0 {
+		state["last_price"] = s.prices.Last()
+	}
+	return state
+}
+
 // Stop stops the strategy
 func (s *MeanReversionStrategy) Stop(ctx context.Context) error {
 	s.mu.Lock()
@@ -0,0 +1,918 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/logger"
+)
+
+// tickState builds a market state for a single price tick
+func tickState(symbol string, price float64, position *entity.Position) *service.MarketState {
+	return &service.MarketState{
+		Ticker: &entity.Ticker{
+			Symbol:    symbol,
+			LastPrice: price,
+			Timestamp: time.Now(),
+		},
+		Position: position,
+	}
+}
+
+// feedPrices feeds prices into the strategy and returns signals from the last tick
+func feedPrices(t *testing.T, s *MeanReversionStrategy, prices []float64, position *entity.Position) []*service.Signal {
+	t.Helper()
+	ctx := context.Background()
+
+	var signals []*service.Signal
+	for _, p := range prices {
+		var err error
+		signals, err = s.OnTick(ctx, tickState("BTC", p, position))
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+	}
+	return signals
+}
+
+func TestMeanReversionStrategy_Name(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	if s.Name() != "mean_reversion" {
+		t.Errorf("Expected name 'mean_reversion', got '%s'", s.Name())
+	}
+}
+
+func TestMeanReversionStrategy_Init(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	ctx := context.Background()
+
+	config := map[string]interface{}{
+		"window_size":     10,
+		"entry_deviation": 1.5,
+		"max_adx":         25.0,
+		"adx_period":      7,
+	}
+
+	if err := s.Init(ctx, config); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if s.config.WindowSize != 10 {
+		t.Errorf("WindowSize not set correctly: %d", s.config.WindowSize)
+	}
+	if s.config.EntryDeviation != 1.5 {
+		t.Errorf("EntryDeviation not set correctly: %f", s.config.EntryDeviation)
+	}
+	if s.config.MaxADX != 25.0 {
+		t.Errorf("MaxADX not set correctly: %f", s.config.MaxADX)
+	}
+	if s.config.ADXPeriod != 7 {
+		t.Errorf("ADXPeriod not set correctly: %d", s.config.ADXPeriod)
+	}
+}
+
+func TestMeanReversionStrategy_Init_RSISmoothing(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	if s.config.RSISmoothing != RSISmoothingWilder {
+		t.Errorf("Expected Wilder's RSI smoothing by default, got %q", s.config.RSISmoothing)
+	}
+	if err := s.Init(context.Background(), map[string]interface{}{"rsi_smoothing": "sma"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if s.config.RSISmoothing != RSISmoothingSMA {
+		t.Errorf("RSISmoothing not set correctly: %q", s.config.RSISmoothing)
+	}
+	if err := NewMeanReversionStrategy().Init(context.Background(), map[string]interface{}{"rsi_smoothing": "ema"}); err == nil {
+		t.Error("Expected error for unknown rsi_smoothing")
+	}
+}
+
+func TestMeanReversionStrategy_EntryLong(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10})
+
+	prices := []float64{100, 101, 100, 101, 100, 101, 100, 101, 100, 95}
+	signals := feedPrices(t, s, prices, nil)
+
+	if len(signals) == 0 {
+		t.Fatal("Expected entry signal for price below lower band")
+	}
+	if signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected BUY side, got %s", signals[0].Side)
+	}
+}
+
+func TestMeanReversionStrategy_PositionSizeUSD(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	if err := s.Init(context.Background(), map[string]interface{}{"window_size": 10, "position_size_usd": 100}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	prices := []float64{51000, 51200, 51000, 51200, 51000, 51200, 51000, 51200, 51000, 50000}
+	signals := feedPrices(t, s, prices, nil)
+
+	if len(signals) == 0 {
+		t.Fatal("Expected entry signal for price below lower band")
+	}
+	if math.Abs(signals[0].Quantity-0.002) > 1e-12 {
+		t.Errorf("Expected $100 at 50000 to size 0.002, got %v", signals[0].Quantity)
+	}
+
+	if err := NewMeanReversionStrategy().Init(context.Background(), map[string]interface{}{"position_size_usd": -1.0}); err == nil {
+		t.Error("Expected error for negative position_size_usd")
+	}
+}
+
+func TestMeanReversionStrategy_HTFFilter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// run feeds one tick per one-minute higher-timeframe bar, then a long
+	// setup within the next bar, and returns the signals of the last tick
+	run := func(config map[string]interface{}, htfCloses []float64) []*service.Signal {
+		t.Helper()
+		ctx := context.Background()
+		s := NewMeanReversionStrategy()
+		if err := s.Init(ctx, config); err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+		tick := func(price float64, at time.Time) []*service.Signal {
+			signals, err := s.OnTick(ctx, &service.MarketState{
+				Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: price, Timestamp: at},
+			})
+			if err != nil {
+				t.Fatalf("OnTick failed: %v", err)
+			}
+			return signals
+		}
+		for i, c := range htfCloses {
+			tick(c, start.Add(time.Duration(i)*time.Minute))
+		}
+		bar := start.Add(time.Duration(len(htfCloses)) * time.Minute)
+		var signals []*service.Signal
+		for i, p := range []float64{100, 101, 100, 101, 100, 101, 100, 101, 100, 95} {
+			signals = tick(p, bar.Add(time.Duration(i)*time.Second))
+		}
+		return signals
+	}
+	filtered := map[string]interface{}{"window_size": 10, "htf_filter": true, "htf_interval": "1m", "htf_period": 3}
+	downtrend := []float64{120, 115, 110}
+
+	if signals := run(map[string]interface{}{"window_size": 10}, downtrend); len(signals) != 1 || signals[0].Side != entity.SideBuy {
+		t.Fatalf("Expected the long setup to enter without the filter, got %+v", signals)
+	}
+	if signals := run(filtered, downtrend); len(signals) != 0 {
+		t.Errorf("Expected the higher-timeframe downtrend to block the long, got %+v", signals)
+	}
+	if signals := run(filtered, []float64{80, 85, 90}); len(signals) != 1 || signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected the long in a higher-timeframe uptrend, got %+v", signals)
+	}
+	if signals := run(filtered, []float64{80, 85}); len(signals) != 0 {
+		t.Errorf("Expected no entry before the higher-timeframe SMA is known, got %+v", signals)
+	}
+
+	// A threshold tolerates price a little below the SMA
+	filtered["htf_threshold"] = 0.2
+	if signals := run(filtered, downtrend); len(signals) != 1 {
+		t.Errorf("Expected the long within htf_threshold of the SMA, got %+v", signals)
+	}
+
+	for _, config := range []map[string]interface{}{{"htf_interval": "0s"}, {"htf_period": 0}, {"htf_threshold": -0.1}} {
+		if err := NewMeanReversionStrategy().Init(context.Background(), config); err == nil {
+			t.Errorf("Expected error for %v", config)
+		}
+	}
+}
+
+// stubSizer returns a fixed size and records the ATR it was asked to size for
+type stubSizer struct {
+	size float64
+	atr  float64
+}
+
+func (z *stubSizer) SizePosition(equity, price, atr, riskPerTrade float64) float64 {
+	z.atr = atr
+	if atr <= 0 {
+		return 0
+	}
+	return z.size
+}
+
+func TestMeanReversionStrategy_RiskPerTrade(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	sizer := &stubSizer{size: 0.25}
+	s.SetPositionSizer(sizer)
+	if err := s.Init(context.Background(), map[string]interface{}{
+		"window_size": 10, "position_size_pct": 0.1, "risk_per_trade": 0.01, "atr_period": 5,
+	}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	feedPrices(t, s, []float64{51000, 51200, 51000, 51200, 51000, 51200, 51000, 51200, 51000}, nil)
+	state := tickState("BTC", 50000, nil)
+	state.Equity = 20000
+	signals, err := s.OnTick(context.Background(), state)
+	if err != nil || len(signals) == 0 {
+		t.Fatalf("Expected entry signal, got %v (%v)", signals, err)
+	}
+	if sizer.atr <= 0 {
+		t.Errorf("Expected the sizer to get the tick ATR, got %v", sizer.atr)
+	}
+	if signals[0].Quantity != 0.25 {
+		t.Errorf("Expected 0.25 from volatility sizing, got %v", signals[0].Quantity)
+	}
+
+	// The equity fraction applies when the sizer cannot size the entry
+	s = NewMeanReversionStrategy()
+	s.SetPositionSizer(&stubSizer{})
+	if err := s.Init(context.Background(), map[string]interface{}{
+		"window_size": 10, "position_size_pct": 0.1, "risk_per_trade": 0.01, "atr_period": 5,
+	}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	feedPrices(t, s, []float64{51000, 51200, 51000, 51200, 51000, 51200, 51000, 51200, 51000}, nil)
+	signals, _ = s.OnTick(context.Background(), state)
+	if len(signals) == 0 || math.Abs(signals[0].Quantity-0.04) > 1e-12 {
+		t.Errorf("Expected 0.04 from equity sizing when the sizer gives 0, got %v", signals)
+	}
+
+	for _, config := range []map[string]interface{}{{"risk_per_trade": 1.5}, {"risk_per_trade": -0.1}, {"atr_period": 0}} {
+		if err := NewMeanReversionStrategy().Init(context.Background(), config); err == nil {
+			t.Errorf("Expected error for %v", config)
+		}
+	}
+}
+
+func TestMeanReversionStrategy_PositionSizePct(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	if err := s.Init(context.Background(), map[string]interface{}{
+		"window_size": 10, "position_size_usd": 100, "position_size_pct": 0.1,
+	}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	feedPrices(t, s, []float64{51000, 51200, 51000, 51200, 51000, 51200, 51000, 51200, 51000}, nil)
+	state := tickState("BTC", 50000, nil)
+	state.Equity = 20000
+	signals, err := s.OnTick(context.Background(), state)
+	if err != nil || len(signals) == 0 {
+		t.Fatalf("Expected entry signal, got %v (%v)", signals, err)
+	}
+	// 10% of $20000 is $2000, 0.04 at 50000
+	if math.Abs(signals[0].Quantity-0.04) > 1e-12 {
+		t.Errorf("Expected 0.04 from equity sizing, got %v", signals[0].Quantity)
+	}
+
+	if err := NewMeanReversionStrategy().Init(context.Background(), map[string]interface{}{"position_size_pct": 1.5}); err == nil {
+		t.Error("Expected error for position_size_pct above 1")
+	}
+}
+
+func TestMeanReversionStrategy_ImbalanceFiltersEntry(t *testing.T) {
+	prices := []float64{100, 101, 100, 101, 100, 101, 100, 101, 100}
+	book := func(bidSize, askSize float64) *entity.OrderBook {
+		return &entity.OrderBook{
+			Symbol: "BTC",
+			Bids:   []entity.OrderBookLevel{{Price: 94.9, Size: bidSize}},
+			Asks:   []entity.OrderBookLevel{{Price: 95.1, Size: askSize}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		book    *entity.OrderBook
+		entered bool
+	}{
+		{"bid heavy confirms long", book(3, 1), true},
+		{"ask heavy blocks long", book(1, 3), false},
+		{"balanced blocks long", book(1, 1), false},
+		{"no book allows entry", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMeanReversionStrategy()
+			s.Init(context.Background(), map[string]interface{}{
+				"window_size":   10,
+				"min_imbalance": 0.3,
+			})
+			feedPrices(t, s, prices, nil)
+
+			state := tickState("BTC", 95, nil)
+			state.OrderBook = tt.book
+			signals, err := s.OnTick(context.Background(), state)
+			if err != nil {
+				t.Fatalf("OnTick failed: %v", err)
+			}
+			if entered := len(signals) > 0; entered != tt.entered {
+				t.Errorf("Expected entry=%v, got %d signals", tt.entered, len(signals))
+			}
+		})
+	}
+}
+
+func TestMeanReversionStrategy_ExitLong(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10})
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideBuy,
+		Size:       0.01,
+		EntryPrice: 95,
+	}
+	prices := []float64{100, 101, 100, 101, 100, 101, 100, 101, 100, 100.5}
+	signals := feedPrices(t, s, prices, position)
+
+	if len(signals) == 0 {
+		t.Fatal("Expected exit signal when price returned to mean")
+	}
+	if signals[0].Side != entity.SideSell {
+		t.Errorf("Expected SELL side to close long, got %s", signals[0].Side)
+	}
+	if signals[0].Quantity != 0.01 {
+		t.Errorf("Expected exit quantity 0.01, got %f", signals[0].Quantity)
+	}
+}
+
+func TestMeanReversionStrategy_ADXSuppressesEntryInTrend(t *testing.T) {
+	// Steadily rising prices push z-score above the entry band
+	prices := make([]float64, 30)
+	for i := range prices {
+		prices[i] = 100 + float64(i)
+	}
+
+	withoutFilter := NewMeanReversionStrategy()
+	withoutFilter.Init(context.Background(), map[string]interface{}{
+		"window_size":     20,
+		"entry_deviation": 1.5,
+	})
+	if signals := feedPrices(t, withoutFilter, prices, nil); len(signals) == 0 {
+		t.Fatal("Expected short entry without ADX filter")
+	}
+
+	withFilter := NewMeanReversionStrategy()
+	withFilter.Init(context.Background(), map[string]interface{}{
+		"window_size":     20,
+		"entry_deviation": 1.5,
+		"max_adx":         25.0,
+	})
+	if signals := feedPrices(t, withFilter, prices, nil); len(signals) != 0 {
+		t.Errorf("Expected no entry in trending market, got %d signals", len(signals))
+	}
+}
+
+func TestMeanReversionStrategy_ADXAllowsEntryInRange(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{
+		"window_size": 10,
+		"max_adx":     25.0,
+	})
+
+	prices := make([]float64, 0, 30)
+	for i := 0; i < 29; i++ {
+		prices = append(prices, 100+float64(i%2))
+	}
+	prices = append(prices, 98)
+
+	signals := feedPrices(t, s, prices, nil)
+	if len(signals) == 0 {
+		t.Fatal("Expected entry signal in ranging market")
+	}
+	if signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected BUY side, got %s", signals[0].Side)
+	}
+}
+
+func TestMeanReversionStrategy_TrailingStopLong(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{
+		"window_size":     50, // Keep z-score exits out of the way
+		"trailing_stop":   true,
+		"trailing_pct":    0.01,
+		"take_profit_pct": 0.05,
+		"stop_loss_pct":   0.02,
+	})
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideBuy,
+		Size:       0.01,
+		EntryPrice: 100,
+	}
+
+	// Ratchet up without retracing enough to trigger the stop
+	if signals := feedPrices(t, s, []float64{100, 101, 102, 103, 102.5}, position); len(signals) != 0 {
+		t.Fatalf("Expected no exit while trailing, got %d signals", len(signals))
+	}
+	if s.bestPrice != 103 {
+		t.Errorf("Expected best price 103, got %f", s.bestPrice)
+	}
+
+	// Give back more than 1% from the high
+	signals := feedPrices(t, s, []float64{101.9}, position)
+	if len(signals) == 0 {
+		t.Fatal("Expected trailing stop exit")
+	}
+	if signals[0].Side != entity.SideSell {
+		t.Errorf("Expected SELL to close long, got %s", signals[0].Side)
+	}
+
+	t.Logf("Trailing stop signal: %s @ %.2f - %s", signals[0].Side, signals[0].Price, signals[0].Reason)
+}
+
+func TestMeanReversionStrategy_TrailingStopShort(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{
+		"window_size":   50,
+		"trailing_stop": true,
+		"trailing_pct":  0.01,
+	})
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideSell,
+		Size:       -0.01,
+		EntryPrice: 100,
+	}
+
+	if signals := feedPrices(t, s, []float64{99, 98, 97.5}, position); len(signals) != 0 {
+		t.Fatalf("Expected no exit while trailing, got %d signals", len(signals))
+	}
+
+	signals := feedPrices(t, s, []float64{98.6}, position)
+	if len(signals) == 0 {
+		t.Fatal("Expected trailing stop exit")
+	}
+	if signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected BUY to close short, got %s", signals[0].Side)
+	}
+}
+
+func TestMeanReversionStrategy_TakeProfitAndStopLossBounds(t *testing.T) {
+	config := map[string]interface{}{
+		"window_size":     50,
+		"trailing_stop":   true,
+		"trailing_pct":    0.1,
+		"take_profit_pct": 0.02,
+		"stop_loss_pct":   0.01,
+	}
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideBuy,
+		Size:       0.01,
+		EntryPrice: 100,
+	}
+
+	tp := NewMeanReversionStrategy()
+	tp.Init(context.Background(), config)
+	if signals := feedPrices(t, tp, []float64{101, 102.5}, position); len(signals) == 0 {
+		t.Error("Expected take profit exit")
+	}
+
+	sl := NewMeanReversionStrategy()
+	sl.Init(context.Background(), config)
+	if signals := feedPrices(t, sl, []float64{99.5, 98.9}, position); len(signals) == 0 {
+		t.Error("Expected stop loss exit")
+	}
+}
+
+func TestMeanReversionStrategy_ScaledTakeProfit(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	err := s.Init(context.Background(), map[string]interface{}{
+		"window_size": 50,
+		"tp_levels": []interface{}{
+			map[string]interface{}{"pct": 0.004, "portion": 0.5},
+			map[string]interface{}{"pct": 0.008, "portion": 0.5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	position := &entity.Position{
+		Symbol:     "BTC",
+		Side:       entity.SideBuy,
+		Size:       0.02,
+		EntryPrice: 100,
+	}
+
+	if signals := feedPrices(t, s, []float64{100.2}, position); len(signals) != 0 {
+		t.Fatalf("Expected no exit below first level, got %d signals", len(signals))
+	}
+
+	first := feedPrices(t, s, []float64{100.5}, position)
+	if len(first) != 1 {
+		t.Fatalf("Expected 1 partial exit at first level, got %d", len(first))
+	}
+	if first[0].Side != entity.SideSell || first[0].Quantity != 0.01 {
+		t.Errorf("Expected SELL 0.01, got %s %f", first[0].Side, first[0].Quantity)
+	}
+
+	// Position shrinks after the partial fill
+	position.Size = 0.01
+	if signals := feedPrices(t, s, []float64{100.6}, position); len(signals) != 0 {
+		t.Fatalf("Expected first level not to fire twice, got %d signals", len(signals))
+	}
+
+	second := feedPrices(t, s, []float64{100.9}, position)
+	if len(second) != 1 {
+		t.Fatalf("Expected 1 partial exit at second level, got %d", len(second))
+	}
+	if second[0].Quantity != 0.01 {
+		t.Errorf("Expected remaining 0.01 closed, got %f", second[0].Quantity)
+	}
+
+	// Closing the position resets level tracking
+	s.OnPositionUpdate(context.Background(), &entity.Position{Symbol: "BTC"})
+	if s.levelsFired != 0 || s.remainingSize != 0 {
+		t.Errorf("Expected trade state reset, got levels=%d remaining=%f", s.levelsFired, s.remainingSize)
+	}
+}
+
+func TestMeanReversionStrategy_Init_InvalidTPLevels(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	err := s.Init(context.Background(), map[string]interface{}{
+		"tp_levels": []interface{}{map[string]interface{}{"pct": 0.004}},
+	})
+	if err == nil {
+		t.Error("Expected error for tp level without portion")
+	}
+}
+
+func TestMeanReversionStrategy_IsSymbolSupported(t *testing.T) {
+	s := NewMeanReversionStrategy()
+
+	tests := []struct {
+		symbol   string
+		expected bool
+	}{
+		{"BTC", true},
+		{"BTC/USDC", true},
+		{"ETH-PERP", true},
+		{"XRPUSDC", true},
+		{"SOL", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			if got := s.isSymbolSupported(tt.symbol); got != tt.expected {
+				t.Errorf("isSymbolSupported(%s) = %v, want %v", tt.symbol, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMeanReversionStrategy_Init_Symbols(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	err := s.Init(context.Background(), map[string]interface{}{
+		"symbols": []interface{}{"SOL", "AVAX"},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	if !s.isSymbolSupported("SOL/USDC") {
+		t.Error("Expected SOL/USDC to be supported")
+	}
+	if !s.isSymbolSupported("AVAX-PERP") {
+		t.Error("Expected AVAX-PERP to be supported")
+	}
+	if s.isSymbolSupported("BTC") {
+		t.Error("Expected BTC to be unsupported after replacing defaults")
+	}
+}
+
+func TestMeanReversionStrategy_UnsupportedSymbolIgnored(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 3})
+
+	ctx := context.Background()
+	for _, p := range []float64{100, 101, 100, 90} {
+		signals, err := s.OnTick(ctx, tickState("DOGE", p, nil))
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+		if len(signals) != 0 {
+			t.Fatalf("Expected no signals for unsupported symbol, got %d", len(signals))
+		}
+	}
+}
+
+func TestMeanReversionStrategy_DebugLogging(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 5})
+	s.SetLogger(logger.New(logger.LevelDebug, &buf))
+
+	feedPrices(t, s, []float64{100, 101, 99, 100, 100.5}, nil)
+
+	out := buf.String()
+	if !strings.Contains(out, "no z-score yet (1/5 prices") {
+		t.Errorf("Expected warm-up debug output, got %q", out)
+	}
+	if !strings.Contains(out, "within ±2.00") {
+		t.Errorf("Expected threshold explanation in debug output, got %q", out)
+	}
+
+	// Silent at INFO
+	buf.Reset()
+	s.SetLogger(logger.New(logger.LevelInfo, &buf))
+	feedPrices(t, s, []float64{100}, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output at INFO, got %q", buf.String())
+	}
+}
+
+func TestMeanReversionStrategy_CooldownAfterLoss(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	tick := func(s *MeanReversionStrategy, i int, price float64, position *entity.Position) []*service.Signal {
+		state := tickState("BTC", price, position)
+		state.Ticker.Timestamp = start.Add(time.Duration(i) * time.Second)
+		signals, err := s.OnTick(ctx, state)
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+		return signals
+	}
+	// closeAt holds a long from 100 for one tick, then exits at price
+	closeAt := func(s *MeanReversionStrategy, price float64) {
+		position := &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.01, EntryPrice: 100}
+		tick(s, 9, 100, position)
+		s.OnOrderUpdate(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Price: price,
+			Quantity: 0.01, FilledQty: 0.01, Status: entity.OrderStatusFilled})
+		s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC"})
+	}
+	warmUp := func(s *MeanReversionStrategy) {
+		for i, p := range []float64{100, 101, 100, 101, 100, 101, 100, 101, 100} {
+			tick(s, i, p, nil)
+		}
+	}
+
+	s := NewMeanReversionStrategy()
+	s.Init(ctx, map[string]interface{}{"window_size": 10, "cooldown_seconds": 60})
+	warmUp(s)
+	closeAt(s, 98)
+
+	if got := s.GetState()["cooldown_remaining"]; got != 60.0 {
+		t.Errorf("Expected 60s cooldown remaining, got %v", got)
+	}
+	if signals := tick(s, 30, 95, nil); len(signals) != 0 {
+		t.Fatalf("Expected entry blocked during cooldown, got %+v", signals)
+	}
+	if signals := tick(s, 70, 94, nil); len(signals) == 0 || signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected long entry after cooldown, got %+v", signals)
+	}
+
+	// A winning exit does not trigger the cooldown
+	s = NewMeanReversionStrategy()
+	s.Init(ctx, map[string]interface{}{"window_size": 10, "cooldown_seconds": 60})
+	warmUp(s)
+	closeAt(s, 102)
+	if signals := tick(s, 10, 95, nil); len(signals) == 0 {
+		t.Error("Expected entry right after a winning exit")
+	}
+}
+
+func TestMeanReversionStrategy_CooldownOnClock(t *testing.T) {
+	ctx := context.Background()
+	clock := service.NewFakeClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	s := NewMeanReversionStrategy()
+	s.Init(ctx, map[string]interface{}{"window_size": 10, "cooldown_seconds": 60})
+	s.SetClock(clock)
+
+	// Ticks without a timestamp are timed by the clock
+	tick := func(price float64, position *entity.Position) []*service.Signal {
+		state := tickState("BTC", price, position)
+		state.Ticker.Timestamp = time.Time{}
+		signals, err := s.OnTick(ctx, state)
+		if err != nil {
+			t.Fatalf("OnTick failed: %v", err)
+		}
+		clock.Advance(time.Second)
+		return signals
+	}
+	for _, p := range []float64{100, 101, 100, 101, 100, 101, 100, 101, 100} {
+		tick(p, nil)
+	}
+	tick(100, &entity.Position{Symbol: "BTC", Side: entity.SideBuy, Size: 0.01, EntryPrice: 100})
+	s.OnOrderUpdate(ctx, &entity.Order{Symbol: "BTC", Side: entity.SideSell, Price: 98,
+		Quantity: 0.01, FilledQty: 0.01, Status: entity.OrderStatusFilled})
+	s.OnPositionUpdate(ctx, &entity.Position{Symbol: "BTC"})
+
+	clock.Advance(30 * time.Second)
+	if signals := tick(95, nil); len(signals) != 0 {
+		t.Fatalf("Expected entry blocked during cooldown, got %+v", signals)
+	}
+	clock.Advance(30 * time.Second)
+	if signals := tick(94, nil); len(signals) == 0 || signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected long entry after the clock passed the cooldown, got %+v", signals)
+	}
+}
+
+func TestMeanReversionStrategy_RequireDivergence(t *testing.T) {
+	var warmUp []float64
+	for i := 0; i < 20; i++ {
+		warmUp = append(warmUp, 100+float64(i%2))
+	}
+	// Lower low at 84.5 on weaker momentum than the low at 85
+	divergent := append(append([]float64{}, warmUp...), 95, 90, 85, 88, 91, 93, 91, 89, 87, 86, 84.5, 85, 85.2)
+	// Straight crash with no second swing low
+	crash := append(append([]float64{}, warmUp...), 95, 90, 85, 80, 75)
+
+	newStrategy := func(require bool) *MeanReversionStrategy {
+		s := NewMeanReversionStrategy()
+		s.Init(context.Background(), map[string]interface{}{
+			"window_size": 20, "entry_deviation": 1.0, "rsi_period": 3, "require_divergence": require,
+		})
+		return s
+	}
+
+	if signals := feedPrices(t, newStrategy(false), crash, nil); len(signals) == 0 {
+		t.Fatal("Expected long entry on the crash without divergence required")
+	}
+	if signals := feedPrices(t, newStrategy(true), crash, nil); len(signals) != 0 {
+		t.Errorf("Expected no entry without divergence, got %+v", signals)
+	}
+
+	s := newStrategy(true)
+	if signals := feedPrices(t, s, divergent[:len(divergent)-1], nil); len(signals) != 0 {
+		t.Errorf("Expected no entry before the second low is confirmed, got %+v", signals)
+	}
+	signals := feedPrices(t, s, divergent[len(divergent)-1:], nil)
+	if len(signals) != 1 || signals[0].Side != entity.SideBuy {
+		t.Errorf("Expected long entry on bullish divergence, got %+v", signals)
+	}
+}
+
+func TestMeanReversionStrategy_SqueezeFilter(t *testing.T) {
+	ctx := context.Background()
+	// Closes barely move while quotes are wide: Bollinger Bands sit inside Keltner Channels
+	feed := func(s *MeanReversionStrategy) []*service.Signal {
+		var signals []*service.Signal
+		prices := make([]float64, 0, 26)
+		for i := 0; i < 25; i++ {
+			prices = append(prices, 100+0.1*float64(i%2))
+		}
+		prices = append(prices, 99.9)
+		for _, p := range prices {
+			state := tickState("BTC", p, nil)
+			state.Ticker.BidPrice = p - 2
+			state.Ticker.AskPrice = p + 2
+			signals, _ = s.OnTick(ctx, state)
+		}
+		return signals
+	}
+
+	withoutFilter := NewMeanReversionStrategy()
+	withoutFilter.Init(ctx, map[string]interface{}{"window_size": 20})
+	if signals := feed(withoutFilter); len(signals) == 0 {
+		t.Fatal("Expected long entry without squeeze filter")
+	}
+
+	withFilter := NewMeanReversionStrategy()
+	withFilter.Init(ctx, map[string]interface{}{"window_size": 20, "squeeze_filter": true})
+	if signals := feed(withFilter); len(signals) != 0 {
+		t.Errorf("Expected no entry during a squeeze, got %+v", signals)
+	}
+	if squeeze, _ := withFilter.GetState()["squeeze"].(bool); !squeeze {
+		t.Error("Expected squeeze reported in state")
+	}
+}
+
+func TestMeanReversionStrategy_SupertrendCounter(t *testing.T) {
+	ctx := context.Background()
+	// Wide quotes keep the Supertrend bands far away, so the trend stays up
+	feed := func(s *MeanReversionStrategy, last float64) []*service.Signal {
+		var signals []*service.Signal
+		for i := 0; i < 21; i++ {
+			price := 100 + float64(i%2)
+			if i == 20 {
+				price = last
+			}
+			state := tickState("BTC", price, nil)
+			state.Ticker.BidPrice = price - 5
+			state.Ticker.AskPrice = price + 5
+			signals, _ = s.OnTick(ctx, state)
+		}
+		return signals
+	}
+	newStrategy := func() *MeanReversionStrategy {
+		s := NewMeanReversionStrategy()
+		s.Init(ctx, map[string]interface{}{"window_size": 20, "supertrend_mode": "counter"})
+		return s
+	}
+
+	s := newStrategy()
+	if signals := feed(s, 97); len(signals) != 0 {
+		t.Errorf("Expected no long entry in an uptrend, got %+v", signals)
+	}
+	if up, _ := s.GetState()["supertrend_up"].(bool); !up {
+		t.Error("Expected uptrend reported in state")
+	}
+	if signals := feed(newStrategy(), 104); len(signals) != 1 || signals[0].Side != entity.SideSell {
+		t.Errorf("Expected short entry fading the uptrend, got %+v", signals)
+	}
+}
+
+func TestMeanReversionStrategy_SupertrendFollow(t *testing.T) {
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 20, "supertrend_mode": "follow"})
+
+	var prices []float64
+	for i := 0; i < 20; i++ {
+		prices = append(prices, 100+float64(i%2))
+	}
+	// Range entries are not taken in follow mode
+	if signals := feedPrices(t, s, append(prices, 98), nil); len(signals) != 0 {
+		t.Fatalf("Expected no entry before a flip, got %+v", signals)
+	}
+	signals := feedPrices(t, s, []float64{96}, nil)
+	if len(signals) != 1 || signals[0].Side != entity.SideSell {
+		t.Fatalf("Expected short entry on the flip down, got %+v", signals)
+	}
+
+	// The short rides the trend past the mean and exits on the flip back up
+	short := &entity.Position{Symbol: "BTC", Side: entity.SideSell, Size: -0.01, EntryPrice: 96}
+	if signals := feedPrices(t, s, []float64{94, 92, 90}, short); len(signals) != 0 {
+		t.Fatalf("Expected short held while trending down, got %+v", signals)
+	}
+	var exit []*service.Signal
+	for _, p := range []float64{93, 96, 99, 102} {
+		if exit = feedPrices(t, s, []float64{p}, short); len(exit) > 0 {
+			break
+		}
+	}
+	if len(exit) != 1 || exit[0].Side != entity.SideBuy || exit[0].Quantity != 0.01 {
+		t.Errorf("Expected short closed on the flip up, got %+v", exit)
+	}
+}
+
+// BenchmarkOnTick measures a steady-state tick that stays inside the bands
+func BenchmarkOnTick(b *testing.B) {
+	s := NewMeanReversionStrategy()
+	ctx := context.Background()
+	if err := s.Init(ctx, map[string]interface{}{"window_size": 20}); err != nil {
+		b.Fatalf("Init failed: %v", err)
+	}
+	ticker := &entity.Ticker{Symbol: "BTC", Timestamp: time.Now()}
+	state := &service.MarketState{Ticker: ticker}
+	for i := 0; i < 20; i++ {
+		ticker.LastPrice = 50000 + float64(i%5)*10
+		s.OnTick(ctx, state)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ticker.LastPrice = 50000 + float64(i%5)*10
+		if _, err := s.OnTick(ctx, state); err != nil {
+			b.Fatalf("OnTick failed: %v", err)
+		}
+	}
+}
+
+func TestMeanReversionStrategy_MinStdDev(t *testing.T) {
+	// Constant prices have no variance: no z-score without a floor, z = 0 with one
+	constant := []float64{100, 100, 100, 100, 100, 100, 100, 100, 100, 100}
+	s := NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10})
+	feedPrices(t, s, constant, nil)
+	if _, ok := s.zScore(100); ok {
+		t.Error("Expected no z-score for constant prices without a floor")
+	}
+
+	s = NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10, "min_stddev_pct": 0.001})
+	if signals := feedPrices(t, s, constant, nil); len(signals) != 0 {
+		t.Errorf("Expected no entry at the mean of constant prices, got %+v", signals)
+	}
+	if z, ok := s.zScore(100); !ok || z != 0 {
+		t.Errorf("Expected z-score 0 for constant prices, got %v (ok=%v)", z, ok)
+	}
+	if z, ok := s.zScore(99.7); !ok || math.Abs(z+3) > 1e-9 {
+		t.Errorf("Expected a 0.3%% drop to score -3 against the 0.1%% floor, got %v", z)
+	}
+
+	// A near-flat series scores a microscopic move as many deviations
+	nearFlat := []float64{100, 100.0001, 100, 100.0001, 100, 100.0001, 100, 100.0001, 100, 99.9995}
+	s = NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10})
+	if signals := feedPrices(t, s, nearFlat, nil); len(signals) != 1 {
+		t.Fatalf("Expected the unfloored bands to enter on a tiny move, got %+v", signals)
+	}
+	s = NewMeanReversionStrategy()
+	s.Init(context.Background(), map[string]interface{}{"window_size": 10, "min_stddev_pct": 0.001})
+	if signals := feedPrices(t, s, nearFlat, nil); len(signals) != 0 {
+		t.Errorf("Expected the floored bands to ignore a tiny move, got %+v", signals)
+	}
+
+	if err := NewMeanReversionStrategy().Init(context.Background(), map[string]interface{}{"min_stddev_pct": -0.1}); err == nil {
+		t.Error("Expected error for negative min_stddev_pct")
+	}
+}
@@ -0,0 +1,81 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+// Entry pricing modes
+const (
+	EntryPricingLast       = "last"       // Enter at the last traded price
+	EntryPricingMicroprice = "microprice" // Quote passively at microprice ± offset
+)
+
+// EntryPricing controls how entry orders are priced
+type EntryPricing struct {
+	Mode      string  // EntryPricingLast or EntryPricingMicroprice
+	OffsetBps float64 // Distance from microprice away from the market (buys below, sells above)
+}
+
+// parseEntryPricing parses entry pricing keys from Init config over p
+func parseEntryPricing(config map[string]interface{}, p *EntryPricing) error {
+	if v, ok := config["entry_pricing"].(string); ok {
+		switch v {
+		case EntryPricingLast, EntryPricingMicroprice:
+			p.Mode = v
+		default:
+			return fmt.Errorf("invalid entry_pricing %q (expected %s or %s)", v, EntryPricingLast, EntryPricingMicroprice)
+		}
+	}
+	if v, ok := config["quote_offset_bps"].(float64); ok {
+		if v < 0 {
+			return fmt.Errorf("quote_offset_bps must be non-negative, got %f", v)
+		}
+		p.OffsetBps = v
+	}
+	return nil
+}
+
+// microprice returns the microprice from the order book, or the ticker when the
+// book is missing or one-sided. Returns 0 without bid/ask quotes.
+func microprice(state *service.MarketState) float64 {
+	if state.OrderBook != nil {
+		if mp := state.OrderBook.Microprice(); mp > 0 {
+			return mp
+		}
+	}
+	if state.Ticker.BidPrice > 0 && state.Ticker.AskPrice > 0 {
+		return state.Ticker.Microprice()
+	}
+	return 0
+}
+
+// entrySignal builds an entry signal, quoting a post-only limit at microprice ± offset
+// in microprice mode and falling back to lastPrice when no quotes are available
+func (p EntryPricing) entrySignal(state *service.MarketState, side entity.Side, quantity, lastPrice float64, reason string) *service.Signal {
+	sig := &service.Signal{
+		Symbol:   state.Ticker.Symbol,
+		Side:     side,
+		Price:    lastPrice,
+		Quantity: quantity,
+		Reason:   reason,
+	}
+	if p.Mode != EntryPricingMicroprice {
+		return sig
+	}
+
+	mp := microprice(state)
+	if mp <= 0 {
+		return sig
+	}
+	offset := mp * p.OffsetBps / 10000
+	if side == entity.SideBuy {
+		sig.Price = mp - offset
+	} else {
+		sig.Price = mp + offset
+	}
+	sig.PostOnly = true
+	return sig
+}
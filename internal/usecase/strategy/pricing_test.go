@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/domain/service"
+)
+
+func TestEntryPricing_Microprice(t *testing.T) {
+	state := &service.MarketState{
+		Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: 101, BidPrice: 99, AskPrice: 101, BidSize: 1, AskSize: 1},
+		OrderBook: &entity.OrderBook{
+			Bids: []entity.OrderBookLevel{{Price: 99, Size: 3}},
+			Asks: []entity.OrderBookLevel{{Price: 101, Size: 1}},
+		},
+	}
+	p := EntryPricing{Mode: EntryPricingMicroprice, OffsetBps: 10}
+
+	buy := p.entrySignal(state, entity.SideBuy, 0.1, 101, "test")
+	// Book microprice 100.5 is preferred over the ticker's 100
+	if math.Abs(buy.Price-100.5*(1-0.001)) > 1e-9 {
+		t.Errorf("Expected buy quoted below microprice, got %f", buy.Price)
+	}
+	if !buy.PostOnly {
+		t.Error("Expected passive quote to be post-only")
+	}
+	sell := p.entrySignal(state, entity.SideSell, 0.1, 101, "test")
+	if math.Abs(sell.Price-100.5*(1+0.001)) > 1e-9 {
+		t.Errorf("Expected sell quoted above microprice, got %f", sell.Price)
+	}
+
+	// No quotes: fall back to the last price as a taker
+	bare := &service.MarketState{Ticker: &entity.Ticker{Symbol: "BTC", LastPrice: 101}}
+	if sig := p.entrySignal(bare, entity.SideBuy, 0.1, 101, "test"); sig.Price != 101 || sig.PostOnly {
+		t.Errorf("Expected fallback to last price, got %+v", sig)
+	}
+
+	last := EntryPricing{Mode: EntryPricingLast}
+	if sig := last.entrySignal(state, entity.SideBuy, 0.1, 101, "test"); sig.Price != 101 || sig.PostOnly {
+		t.Errorf("Expected last-price entry, got %+v", sig)
+	}
+}
+
+func TestParseEntryPricing(t *testing.T) {
+	p := EntryPricing{Mode: EntryPricingLast}
+	if err := parseEntryPricing(map[string]interface{}{"entry_pricing": "microprice", "quote_offset_bps": 2.0}, &p); err != nil {
+		t.Fatalf("parseEntryPricing failed: %v", err)
+	}
+	if p.Mode != EntryPricingMicroprice || p.OffsetBps != 2 {
+		t.Errorf("Unexpected entry pricing: %+v", p)
+	}
+	if err := parseEntryPricing(map[string]interface{}{"entry_pricing": "mid"}, &p); err == nil {
+		t.Error("Expected unknown entry_pricing to be rejected")
+	}
+	if err := parseEntryPricing(map[string]interface{}{"quote_offset_bps": -1.0}, &p); err == nil {
+		t.Error("Expected negative quote_offset_bps to be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package strategy
+
+// ring keeps the latest values up to a fixed capacity. Each value is stored
+// twice, capacity apart, so the contents are always one contiguous slice of
+// the backing array: pushing never copies the history or allocates.
+type ring struct {
+	buf   []float64 // 2*capacity slots; buf[start:start+n] holds the values, oldest first
+	start int
+	n     int
+}
+
+// newRing returns an empty ring holding up to capacity values
+func newRing(capacity int) *ring {
+	r := &ring{}
+	r.Resize(capacity)
+	return r
+}
+
+// Cap returns the number of values the ring holds
+func (r *ring) Cap() int {
+	return len(r.buf) / 2
+}
+
+// Len returns the number of values held
+func (r *ring) Len() int {
+	return r.n
+}
+
+// Push appends v, dropping the oldest value when full
+func (r *ring) Push(v float64) {
+	c := r.Cap()
+	if c == 0 {
+		return
+	}
+	if r.n < c {
+		i := (r.start + r.n) % c
+		r.buf[i], r.buf[i+c] = v, v
+		r.n++
+		return
+	}
+	r.buf[r.start], r.buf[r.start+c] = v, v
+	r.start = (r.start + 1) % c
+}
+
+// Values returns the held values, oldest first. The slice aliases the ring
+// and is only valid until the next Push or Resize.
+func (r *ring) Values() []float64 {
+	return r.buf[r.start : r.start+r.n : r.start+r.n]
+}
+
+// Last returns the newest value, or 0 when empty
+func (r *ring) Last() float64 {
+	if r.n == 0 {
+		return 0
+	}
+	return r.buf[r.start+r.n-1]
+}
+
+// Resize changes the capacity, keeping the newest values that fit
+func (r *ring) Resize(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+	if capacity == r.Cap() {
+		return
+	}
+	values := r.Values()
+	if len(values) > capacity {
+		values = values[len(values)-capacity:]
+	}
+	next := ring{buf: make([]float64, 2*capacity)}
+	for _, v := range values {
+		next.Push(v)
+	}
+	*r = next
+}
@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRing_KeepsLatestValues(t *testing.T) {
+	r := newRing(3)
+	if len(r.Values()) != 0 || r.Last() != 0 {
+		t.Fatalf("Expected empty ring, got %v", r.Values())
+	}
+
+	for i := 1; i <= 7; i++ {
+		r.Push(float64(i))
+	}
+	if got := r.Values(); !reflect.DeepEqual(got, []float64{5, 6, 7}) {
+		t.Errorf("Expected latest 3 values, got %v", got)
+	}
+	if r.Len() != 3 || r.Last() != 7 {
+		t.Errorf("Expected len 3 and last 7, got %d and %v", r.Len(), r.Last())
+	}
+
+	// Appending to the view must not overwrite the ring
+	_ = append(r.Values(), 99)
+	if got := r.Values(); !reflect.DeepEqual(got, []float64{5, 6, 7}) {
+		t.Errorf("Expected view append to leave ring intact, got %v", got)
+	}
+}
+
+func TestRing_Resize(t *testing.T) {
+	r := newRing(4)
+	for i := 1; i <= 6; i++ {
+		r.Push(float64(i))
+	}
+
+	r.Resize(2)
+	if got := r.Values(); !reflect.DeepEqual(got, []float64{5, 6}) {
+		t.Errorf("Expected shrink to keep newest, got %v", got)
+	}
+
+	r.Resize(3)
+	r.Push(7)
+	r.Push(8)
+	if got := r.Values(); !reflect.DeepEqual(got, []float64{6, 7, 8}) {
+		t.Errorf("Expected grow then push to keep order, got %v", got)
+	}
+}
+
+func TestRing_PushDoesNotAllocate(t *testing.T) {
+	r := newRing(16)
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Push(1)
+		_ = r.Values()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations per push, got %v", allocs)
+	}
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+)
+
+// ErrTWAPStopped is returned when slicing stops because the executor was stopped
+var ErrTWAPStopped = errors.New("twap executor stopped")
+
+// TWAPExecutor slices large orders into equal child orders spread evenly over a duration
+type TWAPExecutor struct {
+	exchange gateway.ExchangeGateway
+	slices   int
+	duration time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// TWAPResult summarizes a TWAP execution
+type TWAPResult struct {
+	Target   float64         // Quantity requested
+	Placed   float64         // Quantity sent in child orders
+	Filled   float64         // Quantity filled across child orders
+	AvgPrice float64         // Average fill price
+	Orders   []*entity.Order // Latest state of each child order
+}
+
+// Remaining returns the quantity not yet filled
+func (r *TWAPResult) Remaining() float64 {
+	return r.Target - r.Filled
+}
+
+// NewTWAPExecutor creates a TWAP executor placing slices child orders over duration
+func NewTWAPExecutor(exchange gateway.ExchangeGateway, slices int, duration time.Duration) *TWAPExecutor {
+	if slices < 1 {
+		slices = 1
+	}
+	return &TWAPExecutor{
+		exchange: exchange,
+		slices:   slices,
+		duration: duration,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Stop stops all running executions before their next slice
+func (t *TWAPExecutor) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+// Execute places order.Quantity as child orders, one every duration/slices,
+// and returns the cumulative fills. When ctx is done, the executor is stopped
+// or a slice fails, it returns the partial result along with the cause.
+func (t *TWAPExecutor) Execute(ctx context.Context, order *entity.Order) (*TWAPResult, error) {
+	result := &TWAPResult{Target: order.Quantity}
+	interval := t.duration / time.Duration(t.slices)
+	sliceQty := order.Quantity / float64(t.slices)
+	// Fills are still refreshed after the context is canceled
+	refreshCtx := context.WithoutCancel(ctx)
+
+	for i := 0; i < t.slices; i++ {
+		if i > 0 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				t.refresh(refreshCtx, result)
+				return result, ctx.Err()
+			case <-t.stop:
+				timer.Stop()
+				t.refresh(refreshCtx, result)
+				return result, ErrTWAPStopped
+			case <-timer.C:
+			}
+		}
+
+		qty := sliceQty
+		if i == t.slices-1 {
+			// Last slice absorbs rounding
+			qty = order.Quantity - result.Placed
+		}
+
+		child := *order
+		child.ID = ""
+		child.Quantity = qty
+		child.FilledQty = 0
+		if order.ClientOrderID != "" {
+			child.ClientOrderID = childClientOrderID(order.ClientOrderID, i+1)
+		}
+
+		placed, err := t.exchange.PlaceOrder(ctx, &child)
+		if err != nil {
+			t.refresh(refreshCtx, result)
+			return result, fmt.Errorf("place slice %d/%d: %w", i+1, t.slices, err)
+		}
+		result.Orders = append(result.Orders, placed)
+		result.Placed += qty
+		t.refresh(refreshCtx, result)
+	}
+
+	return result, nil
+}
+
+// refresh re-reads resting child orders and recomputes the cumulative fills
+func (t *TWAPExecutor) refresh(ctx context.Context, result *TWAPResult) {
+	filled, notional := 0.0, 0.0
+	for i, o := range result.Orders {
+		if !isTerminal(o.Status) {
+			if latest, err := t.exchange.GetOrder(ctx, o.ID); err == nil && latest != nil {
+				result.Orders[i] = latest
+				o = latest
+			}
+		}
+		filled += o.FilledQty
+		notional += o.FilledQty * o.Price
+	}
+
+	result.Filled = filled
+	if filled > 0 {
+		result.AvgPrice = notional / filled
+	}
+}
+
+// childClientOrderID derives the client order ID of a parent's slice. It is a
+// valid Hyperliquid cloid (0x followed by 16 hex-encoded bytes) and the same
+// for the same parent and slice, so a retried slice is recognized as placed.
+func childClientOrderID(parent string, slice int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", parent, slice)))
+	return "0x" + hex.EncodeToString(sum[:16])
+}
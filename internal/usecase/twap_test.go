@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zono819/hyperliquid-bot/internal/adapter/gateway"
+	"github.com/zono819/hyperliquid-bot/internal/domain/entity"
+	"github.com/zono819/hyperliquid-bot/internal/infrastructure/paper"
+)
+
+// stubMarket lets the paper exchange subscribe to prices pushed by the test
+type stubMarket struct {
+	gateway.ExchangeGateway
+	handler func(*entity.Ticker)
+}
+
+func (m *stubMarket) SubscribeTicker(ctx context.Context, symbol string, handler func(*entity.Ticker)) error {
+	m.handler = handler
+	return nil
+}
+
+func newTWAPExchange(t *testing.T, price float64) *paper.Exchange {
+	t.Helper()
+	market := &stubMarket{}
+	ex := paper.NewExchange(market, paper.Config{InitialBalance: 1000000})
+	ex.SubscribeTicker(context.Background(), "BTC", func(*entity.Ticker) {})
+	market.handler(&entity.Ticker{Symbol: "BTC", LastPrice: price})
+	return ex
+}
+
+func TestTWAPExecutor_FillsTargetAcrossSlices(t *testing.T) {
+	ex := newTWAPExchange(t, 50000)
+	twap := NewTWAPExecutor(ex, 3, 30*time.Millisecond)
+
+	result, err := twap.Execute(context.Background(), &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 1,
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(result.Orders) != 3 {
+		t.Fatalf("Expected 3 child orders, got %d", len(result.Orders))
+	}
+	for _, o := range result.Orders {
+		if math.Abs(o.Quantity-1.0/3) > 1e-9 {
+			t.Errorf("Expected equal slices, got %f", o.Quantity)
+		}
+	}
+	if math.Abs(result.Filled-1) > 1e-9 || math.Abs(result.Remaining()) > 1e-9 {
+		t.Errorf("Expected total filled to equal the target, got %f", result.Filled)
+	}
+	if result.AvgPrice != 50000 {
+		t.Errorf("Expected average price 50000, got %f", result.AvgPrice)
+	}
+
+	pos, _ := ex.GetPosition(context.Background(), "BTC")
+	if pos == nil || math.Abs(pos.Size-1) > 1e-9 {
+		t.Errorf("Expected 1 BTC position, got %+v", pos)
+	}
+}
+
+func TestTWAPExecutor_ChildClientOrderIDs(t *testing.T) {
+	ex := newTWAPExchange(t, 50000)
+	twap := NewTWAPExecutor(ex, 3, 0)
+
+	parent := "0x0123456789abcdef0123456789abcdef"
+	result, err := twap.Execute(context.Background(), &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 1, ClientOrderID: parent,
+	})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	valid := regexp.MustCompile(`^0x[0-9a-f]{32}$`)
+	seen := map[string]bool{parent: true}
+	for i, o := range result.Orders {
+		if !valid.MatchString(o.ClientOrderID) {
+			t.Errorf("Expected slice %d to have a valid cloid, got %q", i+1, o.ClientOrderID)
+		}
+		if seen[o.ClientOrderID] {
+			t.Errorf("Expected slice %d to have a unique cloid, got %q", i+1, o.ClientOrderID)
+		}
+		seen[o.ClientOrderID] = true
+		if want := childClientOrderID(parent, i+1); o.ClientOrderID != want {
+			t.Errorf("Expected slice %d cloid to be derived from the parent, got %q want %q", i+1, o.ClientOrderID, want)
+		}
+	}
+}
+
+func TestTWAPExecutor_StopsOnCancel(t *testing.T) {
+	ex := newTWAPExchange(t, 50000)
+	twap := NewTWAPExecutor(ex, 4, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var result *TWAPResult
+	var err error
+	go func() {
+		defer close(done)
+		result, err = twap.Execute(ctx, &entity.Order{
+			Symbol: "BTC", Side: entity.SideSell, Type: entity.OrderTypeMarket, Quantity: 2,
+		})
+	}()
+
+	// The first slice goes out immediately; the rest wait on the interval
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-done
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+	if len(result.Orders) != 1 || result.Filled != 0.5 || result.Remaining() != 1.5 {
+		t.Errorf("Expected one 0.5 slice filled before cancel, got %d orders, filled %f", len(result.Orders), result.Filled)
+	}
+}
+
+func TestTWAPExecutor_Stop(t *testing.T) {
+	ex := newTWAPExchange(t, 50000)
+	twap := NewTWAPExecutor(ex, 2, time.Hour)
+	twap.Stop()
+
+	result, err := twap.Execute(context.Background(), &entity.Order{
+		Symbol: "BTC", Side: entity.SideBuy, Type: entity.OrderTypeMarket, Quantity: 1,
+	})
+	if !errors.Is(err, ErrTWAPStopped) {
+		t.Fatalf("Expected ErrTWAPStopped, got %v", err)
+	}
+	if result.Placed != 0.5 {
+		t.Errorf("Expected only the first slice placed, got %f", result.Placed)
+	}
+}